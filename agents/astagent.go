@@ -21,6 +21,7 @@ package agents
 import (
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -42,6 +43,7 @@ const (
 	ARIStasisStart           = "StasisStart"
 	ARIChannelStateChange    = "ChannelStateChange"
 	ARIChannelDestroyed      = "ChannelDestroyed"
+	ARITextMessageReceived   = "TextMessageReceived"
 	eventType                = "eventType"
 	channelID                = "channelID"
 	channelState             = "channelState"
@@ -49,7 +51,10 @@ const (
 	timestamp                = "timestamp"
 	SMAAuthorization         = "SMA_AUTHORIZATION"
 	SMASessionStart          = "SMA_SESSION_START"
+	SMASessionUpdate         = "SMA_SESSION_UPDATE"
 	SMASessionTerminate      = "SMA_SESSION_TERMINATE"
+	SMAMessage               = "SMA_MESSAGE"
+	ARITimeoutAbsolute       = "TIMEOUT(absolute)"
 	ARICGRResourceAllocation = "CGRResourceAllocation"
 )
 
@@ -61,6 +66,7 @@ func NewAsteriskAgent(cgrCfg *config.CGRConfig, astConnIdx int,
 		astConnIdx:  astConnIdx,
 		connMgr:     connMgr,
 		eventsCache: make(map[string]*utils.CGREventWithOpts),
+		updtStops:   make(map[string]chan struct{}),
 	}
 	return sma, nil
 }
@@ -74,17 +80,28 @@ type AsteriskAgent struct {
 	astEvChan   chan map[string]interface{}
 	astErrChan  chan error
 	eventsCache map[string]*utils.CGREventWithOpts // used to gather information about events during various phases
-	evCacheMux  sync.RWMutex                       // Protect eventsCache
+	updtStops   map[string]chan struct{}           // stop the periodic session updates, indexed on channelID
+	evCacheMux  sync.RWMutex                       // Protect eventsCache and updtStops
 }
 
 func (sma *AsteriskAgent) connectAsterisk() (err error) {
 	connCfg := sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx]
 	sma.astEvChan = make(chan map[string]interface{})
 	sma.astErrChan = make(chan error)
-	sma.astConn, err = aringo.NewARInGO(fmt.Sprintf("ws://%s/ari/events?api_key=%s:%s&app=%s",
+	if sma.astConn, err = aringo.NewARInGO(fmt.Sprintf("ws://%s/ari/events?api_key=%s:%s&app=%s",
 		connCfg.Address, connCfg.User, connCfg.Password, CGRAuthAPP), "http://cgrates.org",
 		connCfg.User, connCfg.Password, fmt.Sprintf("%s@%s", utils.CGRateS, utils.VERSION),
-		sma.astEvChan, sma.astErrChan, connCfg.ConnectAttempts, connCfg.Reconnects)
+		sma.astEvChan, sma.astErrChan, connCfg.ConnectAttempts, connCfg.Reconnects); err != nil {
+		return
+	}
+	// Subscribe for the text messages sent by the endpoints
+	if _, err := sma.astConn.Call(aringo.HTTP_POST,
+		fmt.Sprintf("http://%s/ari/applications/%s/subscription?eventSource=endpoint:PJSIP",
+			connCfg.Address, CGRAuthAPP), nil); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s subscribing for endpoint messages",
+				utils.AsteriskAgent, err.Error()))
+	}
 	return
 }
 
@@ -109,6 +126,8 @@ func (sma *AsteriskAgent) ListenAndServe() (err error) {
 				go sma.handleChannelStateChange(smAsteriskEvent)
 			case ARIChannelDestroyed:
 				go sma.handleChannelDestroyed(smAsteriskEvent)
+			case ARITextMessageReceived:
+				go sma.handleTextMessage(smAsteriskEvent)
 			}
 		}
 	}
@@ -277,6 +296,66 @@ func (sma *AsteriskAgent) handleChannelStateChange(ev *SMAsteriskEvent) {
 		sma.hangupChannel(ev.ChannelID(), "")
 		return
 	}
+	if initSessionArgs.InitSession &&
+		sma.cgrCfg.AsteriskAgentCfg().UpdateInterval > 0 {
+		stop := make(chan struct{})
+		sma.evCacheMux.Lock()
+		sma.updtStops[ev.ChannelID()] = stop
+		sma.evCacheMux.Unlock()
+		go sma.updateSessionLoop(ev.ChannelID(), stop)
+	}
+}
+
+// updateSessionLoop debits the session periodically, limiting the channel duration to the remaining credit
+func (sma *AsteriskAgent) updateSessionLoop(chanID string, stop chan struct{}) {
+	updtItvl := sma.cgrCfg.AsteriskAgentCfg().UpdateInterval
+	for {
+		select {
+		case <-stop:
+			return
+		case <-time.After(updtItvl):
+		}
+		sma.evCacheMux.RLock()
+		cgrEvDisp, hasIt := sma.eventsCache[chanID]
+		var updtArgs *sessions.V1UpdateSessionArgs
+		if hasIt {
+			updtArgs = &sessions.V1UpdateSessionArgs{
+				UpdateSession: true,
+				CGREvent:      cgrEvDisp.CGREvent.Clone(),
+				ArgDispatcher: cgrEvDisp.ArgDispatcher,
+				Opts:          cgrEvDisp.Opts,
+			}
+		}
+		sma.evCacheMux.RUnlock()
+		if !hasIt { // channel destroyed meanwhile
+			return
+		}
+		updtArgs.CGREvent.Event[utils.EVENT_NAME] = SMASessionUpdate
+		updtArgs.CGREvent.Event[utils.Usage] = updtItvl
+		updtArgs.CGREvent.Event[utils.LastUsed] = updtItvl
+		var updtReply sessions.V1UpdateSessionReply
+		if err := sma.connMgr.Call(sma.cgrCfg.AsteriskAgentCfg().SessionSConns, sma,
+			utils.SessionSv1UpdateSession, updtArgs, &updtReply); err != nil {
+			sma.hangupChannel(chanID,
+				fmt.Sprintf("<%s> error: %s when attempting to update session for channelID: %s",
+					utils.AsteriskAgent, err.Error(), chanID))
+			return
+		}
+		if updtReply.MaxUsage == nil || *updtReply.MaxUsage == time.Duration(0) {
+			sma.hangupChannel(chanID, "")
+			return
+		}
+		if *updtReply.MaxUsage < updtItvl { // not enough credit till the next update
+			sma.setMaxSessionTime(chanID, *updtReply.MaxUsage)
+			return
+		}
+	}
+}
+
+// setMaxSessionTime will limit the duration of an answered channel starting from now
+func (sma *AsteriskAgent) setMaxSessionTime(chanID string, maxUsage time.Duration) bool {
+	return sma.setChannelVar(chanID, ARITimeoutAbsolute,
+		strconv.FormatFloat(maxUsage.Seconds(), 'f', -1, 64))
 }
 
 // Channel disconnect
@@ -292,6 +371,11 @@ func (sma *AsteriskAgent) handleChannelDestroyed(ev *SMAsteriskEvent) {
 	for k, v := range ev.opts {
 		cgrEvDisp.Opts[k] = v
 	}
+	delete(sma.eventsCache, ev.ChannelID()) // channel is gone, no need to keep it further
+	if stop, has := sma.updtStops[ev.ChannelID()]; has {
+		close(stop)
+		delete(sma.updtStops, ev.ChannelID())
+	}
 	sma.evCacheMux.Unlock()
 	if err != nil {
 		utils.Logger.Warning(
@@ -328,6 +412,57 @@ func (sma *AsteriskAgent) handleChannelDestroyed(ev *SMAsteriskEvent) {
 
 }
 
+func (sma *AsteriskAgent) handleTextMessage(ev *SMAsteriskEvent) {
+	procArgs := ev.V1ProcessMessageArgs()
+	if procArgs == nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> message from: %s cannot generate process message arguments",
+			utils.AsteriskAgent, ev.MessageFrom()))
+		return
+	}
+	var procReply sessions.V1ProcessMessageReply
+	if err := sma.connMgr.Call(sma.cgrCfg.AsteriskAgentCfg().SessionSConns, sma,
+		utils.SessionSv1ProcessMessage, procArgs, &procReply); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s processing message from: %s to: %s, dropping it",
+				utils.AsteriskAgent, err.Error(), ev.MessageFrom(), ev.MessageTo()))
+		return
+	}
+	if procArgs.Debit && (procReply.MaxUsage == nil || *procReply.MaxUsage == time.Duration(0)) {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> message from: %s to: %s not authorized, dropping it",
+				utils.AsteriskAgent, ev.MessageFrom(), ev.MessageTo()))
+		return
+	}
+	if err := sma.sendMessage(ev.MessageFrom(), ev.MessageTo(), ev.MessageBody()); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s sending message from: %s to: %s",
+				utils.AsteriskAgent, err.Error(), ev.MessageFrom(), ev.MessageTo()))
+	}
+}
+
+// sendMessage delivers the authorized message to its destination
+// aringo does not support PUT so we build the request here
+func (sma *AsteriskAgent) sendMessage(from, to, body string) (err error) {
+	connCfg := sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx]
+	var req *http.Request
+	if req, err = http.NewRequest(http.MethodPut,
+		fmt.Sprintf("http://%s/ari/endpoints/sendMessage?%s", connCfg.Address,
+			url.Values{"from": {from}, "to": {to}, "body": {body}}.Encode()), nil); err != nil {
+		return
+	}
+	req.Header.Set("User-Agent", fmt.Sprintf("%s@%s", utils.CGRateS, utils.VERSION))
+	req.SetBasicAuth(connCfg.User, connCfg.Password)
+	var resp *http.Response
+	if resp, err = http.DefaultClient.Do(req); err != nil {
+		return
+	}
+	resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected reply code: %d", resp.StatusCode)
+	}
+	return
+}
+
 // ServiceShutdown is called to shutdown the service
 func (sma *AsteriskAgent) ServiceShutdown() error {
 	return nil
@@ -360,20 +495,41 @@ func (sma *AsteriskAgent) V1GetActiveSessionIDs(ignParam string,
 		return err
 	}
 	var sIDs []*sessions.SessionID
+	sma.evCacheMux.RLock()
 	for _, mpIface := range slMpIface {
+		chID, _ := mpIface["id"].(string)
+		cgrEvDisp, hasIt := sma.eventsCache[chID]
+		if !hasIt { // channel not handled by us
+			continue
+		}
 		sIDs = append(sIDs, &sessions.SessionID{
-			OriginHost: strings.Split(sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx].Address, ":")[0],
-			OriginID:   mpIface["id"].(string)},
+			OriginHost: utils.IfaceAsString(cgrEvDisp.CGREvent.Event[utils.OriginHost]),
+			OriginID:   chID},
 		)
 	}
+	sma.evCacheMux.RUnlock()
 	*sessionIDs = sIDs
 	return nil
 
 }
 
-// V1ReAuthorize is used to implement the sessions.BiRPClient interface
-func (*AsteriskAgent) V1ReAuthorize(originID string, reply *string) (err error) {
-	return utils.ErrNotImplemented
+// V1ReAuthorize authorizes the session again in order to recompute the MaxUsage and limits the channel duration to it
+func (sma *AsteriskAgent) V1ReAuthorize(originID string, reply *string) (err error) {
+	var maxUsage time.Duration
+	if _, maxUsage, err = reAuthorizeSession(sma.connMgr,
+		sma.cgrCfg.AsteriskAgentCfg().SessionSConns, sma, originID); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: <%s> when attempting to reauthorize channelID: <%s>",
+				utils.AsteriskAgent, err.Error(), originID))
+		return
+	}
+	if maxUsage == 0 {
+		sma.hangupChannel(originID, "")
+	} else if !sma.setMaxSessionTime(originID, maxUsage) {
+		return utils.ErrServerError
+	}
+	*reply = utils.OK
+	return
 }
 
 // V1DisconnectPeer is used to implement the sessions.BiRPClient interface
@@ -381,7 +537,24 @@ func (*AsteriskAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err
 	return utils.ErrNotImplemented
 }
 
-// DisconnectWarning is used to implement the sessions.BiRPClient interface
-func (*AsteriskAgent) DisconnectWarning(args map[string]interface{}, reply *string) (err error) {
-	return utils.ErrNotImplemented
+// DisconnectWarning is called when call goes under the minimum duration threshold, so Asterisk can play an announcement message
+func (sma *AsteriskAgent) DisconnectWarning(args map[string]interface{}, reply *string) (err error) {
+	annFile := sma.cgrCfg.AsteriskAgentCfg().LowBalanceAnnFile
+	if annFile == utils.EmptyString {
+		*reply = utils.OK
+		return
+	}
+	channelID := engine.NewMapEvent(args).GetStringIgnoreErrors(utils.OriginID)
+	if _, err = sma.astConn.Call(aringo.HTTP_POST,
+		fmt.Sprintf("http://%s/ari/channels/%s/play?%s",
+			sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx].Address,
+			channelID, url.Values{"media": {"sound:" + annFile}}.Encode()),
+		nil); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: %s playing announcement for channelID: %s",
+				utils.AsteriskAgent, err.Error(), channelID))
+		return
+	}
+	*reply = utils.OK
+	return
 }
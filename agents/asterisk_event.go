@@ -28,8 +28,12 @@ import (
 )
 
 func NewSMAsteriskEvent(ariEv map[string]interface{}, asteriskIP, asteriskAlias string) *SMAsteriskEvent {
-	smsmaEv := &SMAsteriskEvent{ariEv: ariEv, asteriskIP: asteriskIP, cachedFields: make(map[string]string)}
+	smsmaEv := &SMAsteriskEvent{ariEv: ariEv, asteriskIP: asteriskIP, asteriskAlias: asteriskAlias,
+		cachedFields: make(map[string]string), opts: make(map[string]interface{})}
 	smsmaEv.parseStasisArgs() // Populate appArgs
+	if evType, _ := ariEv["type"].(string); evType == ARITextMessageReceived {
+		smsmaEv.parseTextMessage()
+	}
 	return smsmaEv
 }
 
@@ -56,6 +60,59 @@ func (smaEv *SMAsteriskEvent) parseStasisArgs() {
 	}
 }
 
+// parseTextMessage will populate the cachedFields out of a TextMessageReceived event
+// the message variables are considered in the same way as the Stasis args
+func (smaEv *SMAsteriskEvent) parseTextMessage() {
+	msgData, _ := smaEv.ariEv["message"].(map[string]interface{})
+	vars, _ := msgData["variables"].(map[string]interface{})
+	for k, v := range vars {
+		if !utils.CGROptionsSet.Has(k) {
+			smaEv.cachedFields[k] = utils.IfaceAsString(v)
+		} else {
+			smaEv.opts[k] = v
+		}
+	}
+	smaEv.cachedFields[channelID] = utils.UUIDSha1Prefix() // messages have no ID of their own
+	smaEv.cachedFields[utils.CGR_ACCOUNT] = sipURIUser(smaEv.MessageFrom())
+	smaEv.cachedFields[utils.CGR_DESTINATION] = sipURIUser(smaEv.MessageTo())
+	smaEv.cachedFields[utils.SetupTime] = smaEv.Timestamp()
+	smaEv.cachedFields[utils.AnswerTime] = smaEv.Timestamp()
+	smaEv.cachedFields[utils.ToR] = utils.SMS
+	smaEv.cachedFields[utils.Usage] = "1"
+}
+
+// sipURIUser returns the user part out of an URI in the form of "Name" <sip:user@host>
+func sipURIUser(uri string) string {
+	if idx := strings.LastIndex(uri, "<"); idx != -1 {
+		uri = strings.TrimSuffix(uri[idx+1:], ">")
+	}
+	if idx := strings.Index(uri, ":"); idx != -1 {
+		uri = uri[idx+1:]
+	}
+	if idx := strings.Index(uri, "@"); idx != -1 {
+		uri = uri[:idx]
+	}
+	return uri
+}
+
+func (smaEv *SMAsteriskEvent) MessageFrom() string {
+	msgData, _ := smaEv.ariEv["message"].(map[string]interface{})
+	from, _ := msgData["from"].(string)
+	return from
+}
+
+func (smaEv *SMAsteriskEvent) MessageTo() string {
+	msgData, _ := smaEv.ariEv["message"].(map[string]interface{})
+	to, _ := msgData["to"].(string)
+	return to
+}
+
+func (smaEv *SMAsteriskEvent) MessageBody() string {
+	msgData, _ := smaEv.ariEv["message"].(map[string]interface{})
+	body, _ := msgData["body"].(string)
+	return body
+}
+
 func (smaEv *SMAsteriskEvent) OriginatorIP() string {
 	return smaEv.asteriskIP
 }
@@ -230,6 +287,8 @@ func (smaEv *SMAsteriskEvent) AsMapStringInterface() (mp map[string]interface{})
 		mp[utils.EVENT_NAME] = SMASessionStart
 	case ARIChannelDestroyed:
 		mp[utils.EVENT_NAME] = SMASessionTerminate
+	case ARITextMessageReceived:
+		mp[utils.EVENT_NAME] = SMAMessage
 	}
 	mp[utils.OriginID] = smaEv.ChannelID()
 	if smaEv.RequestType() != "" {
@@ -325,3 +384,21 @@ func (smaEv *SMAsteriskEvent) V1TerminateSessionArgs(cgrEvDisp utils.CGREventWit
 	args.ParseFlags(subsystems)
 	return
 }
+
+// V1ProcessMessageArgs returns the arguments used in SessionSv1.ProcessMessage
+func (smaEv *SMAsteriskEvent) V1ProcessMessageArgs() (args *sessions.V1ProcessMessageArgs) {
+	cgrEv, err := smaEv.AsCGREvent(config.CgrConfig().GeneralCfg().DefaultTimezone)
+	if err != nil {
+		return
+	}
+	args = &sessions.V1ProcessMessageArgs{
+		CGREvent: cgrEv,
+		Opts:     smaEv.opts,
+	}
+	if smaEv.Subsystems() == utils.EmptyString {
+		args.Debit = true
+		return
+	}
+	args.ParseFlags(smaEv.Subsystems())
+	return
+}
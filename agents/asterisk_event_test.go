@@ -448,3 +448,51 @@ func TestSMAEventV1TerminateSessionArgs(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(exp2), utils.ToJSON(rcv))
 	}
 }
+
+func TestSMAEventTextMessage(t *testing.T) {
+	textMessage := `{"type":"TextMessageReceived","timestamp":"2020-05-11T13:53:48.919+0200","message":{"from":"\"1001\" <pjsip:1001@127.0.0.1>","to":"pjsip:1002@127.0.0.1","body":"Hello","variables":{"cgr_reqtype":"*prepaid","cgr_flags":"*accounts"}},"endpoint":{"technology":"PJSIP","resource":"1001","state":"online","channel_ids":[]},"application":"cgrates_auth"}`
+	var ev map[string]interface{}
+	if err := json.Unmarshal([]byte(textMessage), &ev); err != nil {
+		t.Error(err)
+	}
+	smaEv := NewSMAsteriskEvent(ev, "127.0.0.1", "AST1")
+	if rcv := smaEv.Account(); rcv != "1001" {
+		t.Errorf("Expecting: 1001, received: %s", rcv)
+	}
+	if rcv := smaEv.Destination(); rcv != "1002" {
+		t.Errorf("Expecting: 1002, received: %s", rcv)
+	}
+	if rcv := smaEv.MessageBody(); rcv != "Hello" {
+		t.Errorf("Expecting: Hello, received: %s", rcv)
+	}
+	if rcv := smaEv.ChannelID(); rcv == utils.EmptyString {
+		t.Error("Expecting generated OriginID")
+	}
+	args := smaEv.V1ProcessMessageArgs()
+	if args == nil {
+		t.Fatal("Expecting process message arguments")
+	}
+	if !args.Debit {
+		t.Errorf("Expecting Debit flag, received: %s", utils.ToJSON(args))
+	}
+	if args.CGREvent.Event[utils.EVENT_NAME] != SMAMessage ||
+		args.CGREvent.Event[utils.RequestType] != utils.META_PREPAID ||
+		args.CGREvent.Event[utils.ToR] != utils.SMS ||
+		args.CGREvent.Event[utils.Usage] != "1" ||
+		args.CGREvent.Event[utils.OriginHost] != "AST1" {
+		t.Errorf("Unexpected event: %s", utils.ToJSON(args.CGREvent.Event))
+	}
+}
+
+func TestSipURIUser(t *testing.T) {
+	for uri, exp := range map[string]string{
+		`"1001" <pjsip:1001@127.0.0.1>`: "1001",
+		"sip:1002@127.0.0.1:5060":       "1002",
+		"pjsip:1003":                    "1003",
+		"1004":                          "1004",
+	} {
+		if rcv := sipURIUser(uri); rcv != exp {
+			t.Errorf("Expecting: %s, received: %s", exp, rcv)
+		}
+	}
+}
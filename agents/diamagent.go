@@ -47,12 +47,13 @@ const (
 func NewDiameterAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 	connMgr *engine.ConnManager) (*DiameterAgent, error) {
 	da := &DiameterAgent{
-		cgrCfg:  cgrCfg,
-		filterS: filterS,
-		connMgr: connMgr,
-		raa:     make(map[string]chan *diam.Message),
-		dpa:     make(map[string]chan *diam.Message),
-		peers:   make(map[string]diam.Conn),
+		cgrCfg:   cgrCfg,
+		filterS:  filterS,
+		connMgr:  connMgr,
+		raa:      make(map[string]chan *diam.Message),
+		dpa:      make(map[string]chan *diam.Message),
+		peers:    make(map[string]diam.Conn),
+		policies: make(map[string]*gxPolicy),
 	}
 	dictsPath := cgrCfg.DiameterAgentCfg().DictionariesPath
 	if len(dictsPath) != 0 {
@@ -60,6 +61,9 @@ func NewDiameterAgent(cgrCfg *config.CGRConfig, filterS *engine.FilterS,
 			return nil, err
 		}
 	}
+	if len(cgrCfg.DiameterAgentCfg().Peers) != 0 {
+		da.upstream = newDiamPeers(cgrCfg.DiameterAgentCfg().Peers, da.settings())
+	}
 	msgTemplates := da.cgrCfg.DiameterAgentCfg().Templates
 	// Inflate *template field types
 	for _, procsr := range da.cgrCfg.DiameterAgentCfg().RequestProcessors {
@@ -90,16 +94,22 @@ type DiameterAgent struct {
 	peers    map[string]diam.Conn // peer index by OriginHost;OriginRealm
 	dpa      map[string]chan *diam.Message
 	dpaLck   sync.RWMutex
+
+	policies  map[string]*gxPolicy // policy state of Gx sessions, indexed on Session-Id
+	policyLck sync.Mutex
+
+	upstream diamPeers // peers towards which we originate requests
 }
 
 // ListenAndServe is called when DiameterAgent is started, usually from within cmd/cgr-engine
 func (da *DiameterAgent) ListenAndServe() error {
+	da.upstream.serve()
 	utils.Logger.Info(fmt.Sprintf("<%s> Start listening on <%s>", utils.DiameterAgent, da.cgrCfg.DiameterAgentCfg().Listen))
 	return diam.ListenAndServeNetwork(da.cgrCfg.DiameterAgentCfg().ListenNet, da.cgrCfg.DiameterAgentCfg().Listen, da.handlers(), nil)
 }
 
-// Creates the message handlers
-func (da *DiameterAgent) handlers() diam.Handler {
+// settings returns the capabilities advertised by the agent
+func (da *DiameterAgent) settings() *sm.Settings {
 	settings := &sm.Settings{
 		OriginHost:       datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginHost),
 		OriginRealm:      datatype.DiameterIdentity(da.cgrCfg.DiameterAgentCfg().OriginRealm),
@@ -129,8 +139,12 @@ func (da *DiameterAgent) handlers() diam.Handler {
 	for i, host := range hosts {
 		settings.HostIPAddresses[i] = datatype.Address(host)
 	}
+	return settings
+}
 
-	dSM := sm.New(settings)
+// Creates the message handlers
+func (da *DiameterAgent) handlers() diam.Handler {
+	dSM := sm.New(da.settings())
 	if da.cgrCfg.DiameterAgentCfg().SyncedConnReqs {
 		dSM.HandleFunc(all, da.handleMessage)
 		dSM.HandleFunc(raa, da.handleRAA)
@@ -297,7 +311,7 @@ func (da *DiameterAgent) processRequest(reqProcessor *config.RequestProcessor,
 		utils.MetaDryRun, utils.MetaAuthorize,
 		utils.MetaInitiate, utils.MetaUpdate,
 		utils.MetaTerminate, utils.MetaMessage,
-		utils.MetaCDRs, utils.MetaEvent, utils.META_NONE} {
+		utils.MetaCDRs, utils.MetaEvent, utils.MetaProxy, utils.META_NONE} {
 		if reqProcessor.Flags.HasKey(typ) { // request type is identified through flags
 			reqType = typ
 			break
@@ -316,10 +330,24 @@ func (da *DiameterAgent) processRequest(reqProcessor *config.RequestProcessor,
 			fmt.Sprintf("<%s> LOG, processorID: %s, diameter message: %s",
 				utils.DiameterAgent, reqProcessor.ID, agReq.Request.String()))
 	}
+	if reqProcessor.Flags.HasKey(utils.MetaMSCC) &&
+		(reqType == utils.MetaInitiate ||
+			reqType == utils.MetaUpdate ||
+			reqType == utils.MetaTerminate) {
+		var handled bool
+		if handled, err = da.processMSCC(reqProcessor, reqType, agReq,
+			cgrEv, cgrArgs, opts); err != nil {
+			return
+		}
+		if handled {
+			reqType = utils.MetaMSCC
+		}
+	}
 	switch reqType {
 	default:
 		return false, fmt.Errorf("unknown request type: <%s>", reqType)
 	case utils.META_NONE: // do nothing on CGRateS side
+	case utils.MetaMSCC: // sessions were handled per MSCC
 	case utils.MetaDryRun:
 		utils.Logger.Info(
 			fmt.Sprintf("<%s> DRY_RUN, processorID: %s, DiameterMessage: %s",
@@ -446,11 +474,23 @@ func (da *DiameterAgent) processRequest(reqProcessor *config.RequestProcessor,
 		if err = agReq.setCGRReply(rply, err); err != nil {
 			return
 		}
+	case utils.MetaProxy:
+		ans, errProxy := da.proxyRequest(agReq)
+		if err = agReq.setCGRReply(ans, errProxy); err != nil {
+			return
+		}
 	case utils.MetaCDRs: // allow CDR processing
 	}
+	if reqProcessor.Flags.HasKey(utils.MetaPolicy) {
+		if err = da.processPolicy(reqProcessor, reqType, agReq,
+			cgrEv, cgrArgs, opts); err != nil {
+			return
+		}
+	}
 	// separate request so we can capture the Terminate/Event also here
 	if reqProcessor.Flags.HasKey(utils.MetaCDRs) &&
-		!reqProcessor.Flags.HasKey(utils.MetaDryRun) {
+		!reqProcessor.Flags.HasKey(utils.MetaDryRun) &&
+		reqType != utils.MetaMSCC { // MSCC CDRs are processed per child session
 		rplyCDRs := utils.StringPointer("")
 		if err = da.connMgr.Call(da.cgrCfg.DiameterAgentCfg().SessionSConns, da, utils.SessionSv1ProcessCDR,
 			&utils.CGREventWithArgDispatcher{CGREvent: cgrEv,
@@ -474,6 +514,337 @@ func (da *DiameterAgent) processRequest(reqProcessor *config.RequestProcessor,
 	return true, nil
 }
 
+// processMSCC charges each Multiple-Services-Credit-Control of the request
+// within its own child session, building the MSCC answers out of SessionS replies
+func (da *DiameterAgent) processMSCC(reqProcessor *config.RequestProcessor, reqType string,
+	agReq *AgentRequest, cgrEv *utils.CGREvent, cgrArgs utils.ExtractedArgs,
+	opts map[string]interface{}) (handled bool, err error) {
+	dDP, canCast := agReq.Request.(*diameterDP)
+	if !canCast {
+		return
+	}
+	var mscc []*msccData
+	if mscc, err = msccFromDiamMessage(dDP.m,
+		utils.IfaceAsString(cgrEv.Event[utils.ToR])); err != nil {
+		return
+	}
+	if len(mscc) == 0 {
+		if !isMSCCFinal(dDP.m, reqType) { // no MSCC, process the request as a single session
+			return
+		}
+		return da.terminateMSCCSessions(reqProcessor, agReq, cgrEv, cgrArgs, opts)
+	}
+	var errRply error
+	var succeeded bool
+	for _, ms := range mscc {
+		ev := ms.childEvent(cgrEv)
+		msReqType := reqType
+		if reqType == utils.MetaUpdate && !ms.hasRSU { // no more units requested for this service
+			msReqType = utils.MetaTerminate
+		}
+		var maxUsage *time.Duration
+		var errMS error
+		switch msReqType {
+		case utils.MetaInitiate:
+			rply := new(sessions.V1InitSessionReply)
+			errMS = da.connMgr.Call(da.cgrCfg.DiameterAgentCfg().SessionSConns, da, utils.SessionSv1InitiateSession,
+				sessions.NewV1InitSessionArgs(
+					reqProcessor.Flags.HasKey(utils.MetaAttributes),
+					reqProcessor.Flags.ParamsSlice(utils.MetaAttributes),
+					reqProcessor.Flags.HasKey(utils.MetaThresholds),
+					reqProcessor.Flags.ParamsSlice(utils.MetaThresholds),
+					reqProcessor.Flags.HasKey(utils.MetaStats),
+					reqProcessor.Flags.ParamsSlice(utils.MetaStats),
+					reqProcessor.Flags.HasKey(utils.MetaResources),
+					reqProcessor.Flags.HasKey(utils.MetaAccounts),
+					ev, cgrArgs.ArgDispatcher,
+					reqProcessor.Flags.HasKey(utils.MetaFD),
+					opts), rply)
+			maxUsage = rply.MaxUsage
+		case utils.MetaUpdate:
+			rply := new(sessions.V1UpdateSessionReply)
+			errMS = da.connMgr.Call(da.cgrCfg.DiameterAgentCfg().SessionSConns, da, utils.SessionSv1UpdateSession,
+				sessions.NewV1UpdateSessionArgs(
+					reqProcessor.Flags.HasKey(utils.MetaAttributes),
+					reqProcessor.Flags.ParamsSlice(utils.MetaAttributes),
+					reqProcessor.Flags.HasKey(utils.MetaAccounts),
+					ev, cgrArgs.ArgDispatcher,
+					reqProcessor.Flags.HasKey(utils.MetaFD),
+					opts), rply)
+			maxUsage = rply.MaxUsage
+		case utils.MetaTerminate:
+			errMS = da.terminateMSCCSession(reqProcessor, ev, cgrArgs, opts)
+		}
+		if errMS != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s processing MSCC with OriginID: %s",
+					utils.DiameterAgent, errMS.Error(), ev.Event[utils.OriginID]))
+			errRply = errMS
+		} else {
+			succeeded = true
+		}
+		if err = ms.setReply(agReq.Reply, msReqType, maxUsage, errMS,
+			da.cgrCfg.DiameterAgentCfg()); err != nil {
+			return
+		}
+	}
+	if succeeded { // the request is failed only if all of the MSCC failed
+		errRply = nil
+	}
+	return true, agReq.setCGRReply(nil, errRply)
+}
+
+// terminateMSCCSession terminates the child session of one MSCC, processing its CDR if requested
+func (da *DiameterAgent) terminateMSCCSession(reqProcessor *config.RequestProcessor,
+	ev *utils.CGREvent, cgrArgs utils.ExtractedArgs, opts map[string]interface{}) (err error) {
+	if err = da.connMgr.Call(da.cgrCfg.DiameterAgentCfg().SessionSConns, da, utils.SessionSv1TerminateSession,
+		sessions.NewV1TerminateSessionArgs(
+			reqProcessor.Flags.HasKey(utils.MetaAccounts),
+			reqProcessor.Flags.HasKey(utils.MetaResources),
+			reqProcessor.Flags.HasKey(utils.MetaThresholds),
+			reqProcessor.Flags.ParamsSlice(utils.MetaThresholds),
+			reqProcessor.Flags.HasKey(utils.MetaStats),
+			reqProcessor.Flags.ParamsSlice(utils.MetaStats),
+			ev, cgrArgs.ArgDispatcher,
+			reqProcessor.Flags.HasKey(utils.MetaFD),
+			opts), utils.StringPointer("")); err != nil ||
+		!reqProcessor.Flags.HasKey(utils.MetaCDRs) {
+		return
+	}
+	return da.connMgr.Call(da.cgrCfg.DiameterAgentCfg().SessionSConns, da, utils.SessionSv1ProcessCDR,
+		&utils.CGREventWithArgDispatcher{CGREvent: ev,
+			ArgDispatcher: cgrArgs.ArgDispatcher}, utils.StringPointer(""))
+}
+
+// terminateMSCCSessions terminates all the child sessions of the Diameter session
+// when the final request does not report any MSCC
+func (da *DiameterAgent) terminateMSCCSessions(reqProcessor *config.RequestProcessor,
+	agReq *AgentRequest, cgrEv *utils.CGREvent, cgrArgs utils.ExtractedArgs,
+	opts map[string]interface{}) (handled bool, err error) {
+	originID := utils.IfaceAsString(cgrEv.Event[utils.OriginID])
+	var aSs []*sessions.ExternalSession
+	if errSS := da.connMgr.Call(da.cgrCfg.DiameterAgentCfg().SessionSConns, da, utils.SessionSv1GetActiveSessions,
+		&utils.SessionFilter{
+			Filters: []string{utils.ConcatenatedKey(utils.MetaPrefix,
+				utils.DynamicDataPrefix+utils.MetaReq+utils.NestingSep+utils.OriginID,
+				originID+utils.CONCATENATED_KEY_SEP)},
+		}, &aSs); errSS != nil || len(aSs) == 0 { // no child sessions, process the request as a single session
+		return
+	}
+	var errRply error
+	var succeeded bool
+	childIDs := make(utils.StringSet)
+	for _, aS := range aSs {
+		if childIDs.Has(aS.OriginID) || // one for each run of the session
+			msccParentID(aS.OriginID) != originID {
+			continue
+		}
+		childIDs.Add(aS.OriginID)
+		ev := cgrEv.Clone()
+		ev.ID = utils.ConcatenatedKey(cgrEv.ID, aS.OriginID[len(originID)+1:])
+		ev.Event[utils.OriginID] = aS.OriginID
+		delete(ev.Event, utils.CGRID) // computed again by SessionS out of the new OriginID
+		delete(ev.Event, utils.Usage)
+		delete(ev.Event, utils.LastUsed)
+		if errMS := da.terminateMSCCSession(reqProcessor, ev, cgrArgs, opts); errMS != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s terminating MSCC session with OriginID: %s",
+					utils.DiameterAgent, errMS.Error(), aS.OriginID))
+			errRply = errMS
+		} else {
+			succeeded = true
+		}
+	}
+	if len(childIDs) == 0 {
+		return
+	}
+	if succeeded { // the request is failed only if all of the child sessions failed
+		errRply = nil
+	}
+	return true, agReq.setCGRReply(nil, errRply)
+}
+
+// processPolicy applies the policy decided by AttributeS on the Gx session or,
+// for Rx requests, on the Gx sessions of the same Account
+func (da *DiameterAgent) processPolicy(reqProcessor *config.RequestProcessor, reqType string,
+	agReq *AgentRequest, cgrEv *utils.CGREvent, cgrArgs utils.ExtractedArgs,
+	opts map[string]interface{}) (err error) {
+	dDP, canCast := agReq.Request.(*diameterDP)
+	if !canCast {
+		return
+	}
+	if errRply, _ := agReq.CGRReply.FieldAsString([]string{utils.Error}); errRply != utils.EmptyString {
+		return // keep the policy in place if we could not decide a new one
+	}
+	var sessID string
+	if sessID, err = dDP.FieldAsString([]string{"Session-Id"}); err != nil {
+		return
+	}
+	terminate := isPolicyTermination(dDP.m, reqType)
+	if dDP.m.Header.ApplicationID == rxAppID {
+		var rules []string
+		if !terminate {
+			rules = newPolicyDecision(*agReq.CGRReply).install
+		}
+		da.setAFRules(cgrEv, sessID, rules)
+		return
+	}
+	da.policyLck.Lock()
+	defer da.policyLck.Unlock()
+	if terminate {
+		delete(da.policies, sessID)
+		return
+	}
+	gp, has := da.policies[sessID]
+	if !has {
+		gp = &gxPolicy{
+			afRules: make(map[string][]string),
+			rules:   utils.NewStringSet(nil),
+		}
+		da.policies[sessID] = gp
+	}
+	gp.cgrEv = cgrEv
+	gp.opts = opts
+	gp.attrIDs = reqProcessor.Flags.ParamsSlice(utils.MetaAttributes)
+	gp.argDisp = cgrArgs.ArgDispatcher
+	gp.decision = newPolicyDecision(*agReq.CGRReply)
+	install, remove := gp.update()
+	return setPolicyAVPs(agReq.Reply, install, remove, gp.decision)
+}
+
+// setAFRules updates the rules requested by the AF session on the Gx sessions
+// of the same Account, pushing the changes towards the PCEF via RAR
+func (da *DiameterAgent) setAFRules(cgrEv *utils.CGREvent, afSessID string, rules []string) {
+	acnt := utils.IfaceAsString(cgrEv.Event[utils.Account])
+	if acnt == utils.EmptyString {
+		return
+	}
+	da.policyLck.Lock()
+	defer da.policyLck.Unlock()
+	for sessID, gp := range da.policies {
+		if gp.cgrEv.Tenant != cgrEv.Tenant ||
+			utils.IfaceAsString(gp.cgrEv.Event[utils.Account]) != acnt {
+			continue
+		}
+		if len(rules) == 0 {
+			delete(gp.afRules, afSessID)
+		} else {
+			gp.afRules[afSessID] = rules
+		}
+		install, remove := gp.update()
+		if len(install) == 0 && len(remove) == 0 {
+			continue
+		}
+		avps := utils.NewOrderedNavigableMap()
+		if err := setPolicyAVPs(avps, install, remove, nil); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> cannot build policy for session with OriginID: <%s>, err: %s",
+					utils.DiameterAgent, sessID, err.Error()))
+			continue
+		}
+		go func(sessID string) {
+			var rply string
+			if err := da.sendRAR(sessID, avps, &rply); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> cannot push policy for session with OriginID: <%s>, err: %s",
+						utils.DiameterAgent, sessID, err.Error()))
+			}
+		}(sessID)
+	}
+}
+
+// reAuthorizePolicy decides again the policy of a Gx session, returning the AVPs to be sent within RAR
+func (da *DiameterAgent) reAuthorizePolicy(originID string) (avps *utils.OrderedNavigableMap, err error) {
+	da.policyLck.Lock()
+	gp, has := da.policies[originID]
+	if !has {
+		da.policyLck.Unlock()
+		return
+	}
+	authArgs := sessions.NewV1AuthorizeArgs(true, gp.attrIDs,
+		false, nil, false, nil, false, false, false, false, false,
+		gp.cgrEv, gp.argDisp, utils.Paginator{}, false, gp.opts)
+	da.policyLck.Unlock()
+	rply := new(sessions.V1AuthorizeReply)
+	if err = da.connMgr.Call(da.cgrCfg.DiameterAgentCfg().SessionSConns, da, utils.SessionSv1AuthorizeEvent,
+		authArgs, rply); err != nil {
+		return
+	}
+	da.policyLck.Lock()
+	defer da.policyLck.Unlock()
+	if gp, has = da.policies[originID]; !has { // terminated in the meantime
+		return
+	}
+	gp.decision = newPolicyDecision(rply.AsNavigableMap())
+	install, remove := gp.update()
+	avps = utils.NewOrderedNavigableMap()
+	err = setPolicyAVPs(avps, install, remove, gp.decision)
+	return
+}
+
+// proxyRequest forwards the received request to the upstream peers, returning their answer
+func (da *DiameterAgent) proxyRequest(agReq *AgentRequest) (ans diamAnswerData, err error) {
+	dDP, canCast := agReq.Request.(*diameterDP)
+	if !canCast {
+		return nil, fmt.Errorf("cannot proxy request: %s", agReq.Request)
+	}
+	m := diam.NewRequest(dDP.m.Header.CommandCode,
+		dDP.m.Header.ApplicationID, dDP.m.Dictionary())
+	m.Header.CommandFlags = dDP.m.Header.CommandFlags
+	m.Header.EndToEndID = dDP.m.Header.EndToEndID
+	for _, a := range dDP.m.AVP {
+		m.AddAVP(a)
+	}
+	var a *diam.Message
+	if a, err = da.upstream.send(m, utils.EmptyString); err != nil {
+		return
+	}
+	return diamAVPsAsMap(a.AVP, a.Header.ApplicationID, a.Dictionary())
+}
+
+// V1SendRequest builds the request out of the template and sends it to the upstream peers,
+// returning the AVPs of the answer
+func (da *DiameterAgent) V1SendRequest(args *utils.DiamRequestArgs, reply *map[string]interface{}) (err error) {
+	tpl, has := da.cgrCfg.DiameterAgentCfg().Templates[args.Template]
+	if !has {
+		return utils.ErrPrefixNotFound(args.Template)
+	}
+	dApp, err := dict.Default.App(args.ApplicationID)
+	if err != nil {
+		return
+	}
+	reqVars := utils.NavigableMap2{
+		utils.OriginHost:  utils.NewNMData(da.cgrCfg.DiameterAgentCfg().OriginHost), // used in templates
+		utils.OriginRealm: utils.NewNMData(da.cgrCfg.DiameterAgentCfg().OriginRealm),
+		utils.ProductName: utils.NewNMData(da.cgrCfg.DiameterAgentCfg().ProductName),
+		utils.MetaApp:     utils.NewNMData(dApp.Name),
+		utils.MetaAppID:   utils.NewNMData(dApp.ID),
+	}
+	aReq := NewAgentRequest(
+		utils.MapStorage(args.Event),
+		reqVars, nil, nil, nil, nil,
+		da.cgrCfg.GeneralCfg().DefaultTenant,
+		da.cgrCfg.GeneralCfg().DefaultTimezone, da.filterS, nil, nil)
+	if err = aReq.SetFields(tpl); err != nil {
+		return
+	}
+	m := diam.NewRequest(args.CommandCode, args.ApplicationID, dict.Default)
+	if err = updateDiamMsgFromNavMap(m, aReq.diamreq,
+		da.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+		return
+	}
+	var a *diam.Message
+	if a, err = da.upstream.send(m, args.PeerID); err != nil {
+		return
+	}
+	var ans map[string]interface{}
+	if ans, err = diamAVPsAsMap(a.AVP, a.Header.ApplicationID, a.Dictionary()); err != nil {
+		return
+	}
+	*reply = ans
+	return
+}
+
 // Call implements rpcclient.ClientConnector interface
 func (da *DiameterAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
 	return utils.RPCCall(da, serviceMethod, args, reply)
@@ -489,6 +860,11 @@ func (da *DiameterAgent) V1DisconnectSession(args utils.AttrDisconnectSession, r
 		return utils.ErrMandatoryIeMissing
 	}
 	originID := ssID.(string)
+	if _, isMSCC := args.EventStart[utils.RatingGroup]; isMSCC {
+		originID = msccParentID(originID)
+	} else if _, isMSCC = args.EventStart[utils.ServiceIdentifier]; isMSCC {
+		originID = msccParentID(originID)
+	}
 	switch da.cgrCfg.DiameterAgentCfg().ForcedDisconnect {
 	case utils.META_NONE:
 		*reply = utils.OK
@@ -552,7 +928,23 @@ func (da *DiameterAgent) V1ReAuthorize(originID string, reply *string) (err erro
 				utils.DiameterAgent))
 		return utils.ErrMandatoryIeMissing
 	}
+	var avps *utils.OrderedNavigableMap
+	if avps, err = da.reAuthorizePolicy(originID); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> cannot decide policy for session with OriginID: <%s>, err: %s",
+				utils.DiameterAgent, originID, err.Error()))
+		return utils.ErrServerError
+	}
+	return da.sendRAR(originID, avps, reply)
+}
+
+// sendRAR builds the RAR out of rar_template, adding the extra avps if present
+func (da *DiameterAgent) sendRAR(originID string, avps *utils.OrderedNavigableMap, reply *string) (err error) {
 	msg, has := engine.Cache.Get(utils.CacheDiameterMessages, originID)
+	if !has { // OriginID of a MSCC child session
+		originID = msccParentID(originID)
+		msg, has = engine.Cache.Get(utils.CacheDiameterMessages, originID)
+	}
 	if !has {
 		utils.Logger.Warning(
 			fmt.Sprintf("<%s> cannot retrieve message from cache with OriginID: <%s>",
@@ -580,6 +972,15 @@ func (da *DiameterAgent) V1ReAuthorize(originID string, reply *string) (err erro
 				utils.DiameterAgent, originID, err.Error()))
 		return utils.ErrServerError
 	}
+	if avps != nil {
+		if err = updateDiamMsgFromNavMap(m, avps,
+			da.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> cannot send RAR with OriginID: <%s>, err: %s",
+					utils.DiameterAgent, originID, err.Error()))
+			return utils.ErrServerError
+		}
+	}
 	raaCh := make(chan *diam.Message, 1)
 	da.raaLck.Lock()
 	da.raa[originID] = raaCh
@@ -26,6 +26,9 @@ import (
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
+	"github.com/fiorix/go-diameter/diam"
+	"github.com/fiorix/go-diameter/diam/avp"
+	"github.com/fiorix/go-diameter/diam/datatype"
 
 	"github.com/cgrates/cgrates/sessions"
 )
@@ -538,3 +541,90 @@ func TestProcessRequest(t *testing.T) {
 	}
 
 }
+
+func TestProcessMSCCFinalWithoutMSCC(t *testing.T) {
+	var terminated []string
+	sS := &testMockSessionConn{calls: map[string]func(arg interface{}, rply interface{}) error{
+		utils.SessionSv1RegisterInternalBiJSONConn: func(arg interface{}, rply interface{}) error {
+			return nil
+		},
+		utils.SessionSv1GetActiveSessions: func(arg interface{}, rply interface{}) error {
+			expFltr := &utils.SessionFilter{
+				Filters: []string{"*prefix:~*req.OriginID:simuhuawei;1449573472;00002:"},
+			}
+			if !reflect.DeepEqual(expFltr, arg) {
+				t.Errorf("Expected: %s, received: %s", utils.ToJSON(expFltr), utils.ToJSON(arg))
+			}
+			*rply.(*[]*sessions.ExternalSession) = []*sessions.ExternalSession{
+				{OriginID: "simuhuawei;1449573472;00002:1", RunID: utils.MetaDefault},
+				{OriginID: "simuhuawei;1449573472;00002:1", RunID: "run2"},
+				{OriginID: "simuhuawei;1449573472;00002:2", RunID: utils.MetaDefault},
+			}
+			return nil
+		},
+		utils.SessionSv1TerminateSession: func(arg interface{}, rply interface{}) error {
+			ev := arg.(*sessions.V1TerminateSessionArgs).CGREvent.Event
+			if _, has := ev[utils.Usage]; has {
+				t.Errorf("unexpected usage in event: %s", utils.ToJSON(ev))
+			}
+			terminated = append(terminated, utils.IfaceAsString(ev[utils.OriginID]))
+			*rply.(*string) = utils.OK
+			return nil
+		},
+	}}
+	connID := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)
+	engine.Cache.Remove(utils.CacheRPCConnections, connID, true, utils.NonTransactional)
+	defer engine.Cache.Remove(utils.CacheRPCConnections, connID, true, utils.NonTransactional)
+	internalSessionSChan := make(chan rpcclient.ClientConnector, 1)
+	internalSessionSChan <- sS
+	da := &DiameterAgent{
+		cgrCfg: config.CgrConfig(),
+		connMgr: engine.NewConnManager(config.CgrConfig(), map[string]chan rpcclient.ClientConnector{
+			connID: internalSessionSChan,
+		}),
+	}
+	reqProcessor := &config.RequestProcessor{ID: "MSCC"}
+	reqProcessor.Flags, _ = utils.FlagsWithParamsFromSlice([]string{utils.MetaMSCC, utils.MetaAccounts})
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ccr",
+		Event: map[string]interface{}{
+			utils.OriginID: "simuhuawei;1449573472;00002",
+			utils.Usage:    time.Minute,
+		},
+	}
+	newAgReq := func(m *diam.Message) *AgentRequest {
+		return NewAgentRequest(newDADataProvider(nil, m), nil, &utils.NavigableMap2{},
+			utils.NewOrderedNavigableMap(), nil, nil, "cgrates.org", "", nil, nil, nil)
+	}
+	// CCR-T without MSCC terminates all the child sessions
+	m := diam.NewRequest(diam.CreditControl, 4, nil)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("simuhuawei;1449573472;00002"))
+	agReq := newAgReq(m)
+	if handled, err := da.processMSCC(reqProcessor, utils.MetaTerminate, agReq,
+		cgrEv, utils.ExtractedArgs{}, nil); err != nil {
+		t.Fatal(err)
+	} else if !handled {
+		t.Errorf("expecting the request to be handled")
+	}
+	eTerminated := []string{"simuhuawei;1449573472;00002:1", "simuhuawei;1449573472;00002:2"}
+	if !reflect.DeepEqual(eTerminated, terminated) {
+		t.Errorf("expecting: %v, received: %v", eTerminated, terminated)
+	}
+	// a CCR-U without MSCC is processed as a single session
+	terminated = nil
+	if handled, err := da.processMSCC(reqProcessor, utils.MetaUpdate, newAgReq(m),
+		cgrEv, utils.ExtractedArgs{}, nil); err != nil {
+		t.Fatal(err)
+	} else if handled || len(terminated) != 0 {
+		t.Errorf("unexpected handling, terminated: %v", terminated)
+	}
+	// unless it is the final one
+	m.NewAVP(avp.TerminationCause, avp.Mbit, 0, datatype.Enumerated(1))
+	if handled, err := da.processMSCC(reqProcessor, utils.MetaUpdate, newAgReq(m),
+		cgrEv, utils.ExtractedArgs{}, nil); err != nil {
+		t.Fatal(err)
+	} else if !handled || !reflect.DeepEqual(eTerminated, terminated) {
+		t.Errorf("expecting: %v, received: %v", eTerminated, terminated)
+	}
+}
@@ -0,0 +1,327 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/diam"
+	"github.com/fiorix/go-diameter/diam/avp"
+	"github.com/fiorix/go-diameter/diam/datatype"
+	"github.com/fiorix/go-diameter/diam/dict"
+	"github.com/fiorix/go-diameter/diam/sm"
+)
+
+// newDiamPeer constructs the outgoing connection towards one upstream peer
+func newDiamPeer(cfg *config.DiameterPeerCfg, settings *sm.Settings) (dp *diamPeer) {
+	dp = &diamPeer{
+		cfg:     cfg,
+		answers: make(map[uint32]chan *diam.Message),
+	}
+	dSM := sm.New(settings)
+	dSM.HandleFunc(all, dp.handleMessage)
+	go func() {
+		for err := range dSM.ErrorReports() {
+			utils.Logger.Err(fmt.Sprintf("<%s> peer <%s> sm error: %v",
+				utils.DiameterAgent, cfg.ID, err))
+		}
+	}()
+	dp.cli = &sm.Client{
+		Dict:               dict.Default,
+		Handler:            dSM,
+		MaxRetransmits:     3,
+		RetransmitInterval: time.Second,
+		EnableWatchdog:     true,
+		WatchdogInterval:   cfg.WatchdogInterval,
+	}
+	for _, appID := range cfg.AuthApplicationIDs {
+		dp.cli.AuthApplicationID = append(dp.cli.AuthApplicationID,
+			diam.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(appID)))
+	}
+	return
+}
+
+// diamPeer is an upstream peer towards which the agent originates requests
+type diamPeer struct {
+	cfg *config.DiameterPeerCfg
+	cli *sm.Client
+
+	connLck sync.RWMutex
+	conn    diam.Conn // nil while disconnected
+
+	ansLck  sync.Mutex
+	answers map[uint32]chan *diam.Message // requests waiting for answer, indexed on Hop-by-Hop-Id
+}
+
+// serve keeps the peer connected, reconnecting when the watchdog or the transport fails
+func (dp *diamPeer) serve() {
+	for {
+		conn, err := dp.dial()
+		if err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> giving up connecting to peer <%s> at <%s>, err: %s",
+				utils.DiameterAgent, dp.cfg.ID, dp.cfg.Address, err.Error()))
+			return
+		}
+		utils.Logger.Info(fmt.Sprintf("<%s> connected to peer <%s> at <%s>",
+			utils.DiameterAgent, dp.cfg.ID, dp.cfg.Address))
+		dp.setConn(conn)
+		<-conn.(diam.CloseNotifier).CloseNotify()
+		dp.setConn(nil)
+		utils.Logger.Warning(fmt.Sprintf("<%s> lost connection to peer <%s> at <%s>",
+			utils.DiameterAgent, dp.cfg.ID, dp.cfg.Address))
+	}
+}
+
+// dial connects to the peer, retrying based on the configured reconnects
+func (dp *diamPeer) dial() (conn diam.Conn, err error) {
+	for i := 0; ; i++ {
+		if conn, err = dp.cli.DialNetwork(dp.cfg.Transport, dp.cfg.Address); err == nil ||
+			(dp.cfg.Reconnects != -1 && i >= dp.cfg.Reconnects) {
+			return
+		}
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed connecting to peer <%s> at <%s>, err: %s",
+			utils.DiameterAgent, dp.cfg.ID, dp.cfg.Address, err.Error()))
+		time.Sleep(dp.cfg.ReconnectInterval)
+	}
+}
+
+func (dp *diamPeer) setConn(conn diam.Conn) {
+	dp.connLck.Lock()
+	dp.conn = conn
+	dp.connLck.Unlock()
+}
+
+func (dp *diamPeer) getConn() (conn diam.Conn) {
+	dp.connLck.RLock()
+	conn = dp.conn
+	dp.connLck.RUnlock()
+	return
+}
+
+// connected returns true if the peer finished the capabilities exchange
+func (dp *diamPeer) connected() bool {
+	return dp.getConn() != nil
+}
+
+// send writes the request to the peer and waits for its answer
+func (dp *diamPeer) send(m *diam.Message) (a *diam.Message, err error) {
+	conn := dp.getConn()
+	if conn == nil {
+		return nil, utils.ErrDisconnected
+	}
+	ansChan := make(chan *diam.Message, 1)
+	dp.ansLck.Lock()
+	dp.answers[m.Header.HopByHopID] = ansChan
+	dp.ansLck.Unlock()
+	defer func() {
+		dp.ansLck.Lock()
+		delete(dp.answers, m.Header.HopByHopID)
+		dp.ansLck.Unlock()
+	}()
+	if _, err = m.WriteTo(conn); err != nil {
+		return
+	}
+	select {
+	case a = <-ansChan:
+	case <-time.After(dp.cfg.ReplyTimeout):
+		err = utils.ErrTimedOut
+	}
+	return
+}
+
+// handleMessage passes the answers to the requests waiting for them
+func (dp *diamPeer) handleMessage(c diam.Conn, m *diam.Message) {
+	if m.Header.CommandFlags&diam.RequestFlag != 0 {
+		utils.Logger.Warning(fmt.Sprintf("<%s> ignoring request received from peer <%s>: %s",
+			utils.DiameterAgent, dp.cfg.ID, m))
+		return
+	}
+	dp.ansLck.Lock()
+	ansChan, has := dp.answers[m.Header.HopByHopID]
+	dp.ansLck.Unlock()
+	if !has {
+		utils.Logger.Warning(fmt.Sprintf("<%s> ignoring unexpected answer from peer <%s>: %s",
+			utils.DiameterAgent, dp.cfg.ID, m))
+		return
+	}
+	ansChan <- m
+}
+
+// newDiamPeers builds the peer table, ordered on weight
+func newDiamPeers(cfgs []*config.DiameterPeerCfg, settings *sm.Settings) (dps diamPeers) {
+	dps = make(diamPeers, len(cfgs))
+	for i, cfg := range cfgs {
+		dps[i] = newDiamPeer(cfg, settings)
+	}
+	sort.SliceStable(dps, func(i, j int) bool {
+		return dps[i].cfg.Weight > dps[j].cfg.Weight
+	})
+	return
+}
+
+// diamPeers is the table of upstream peers
+type diamPeers []*diamPeer
+
+// serve connects all the peers
+func (dps diamPeers) serve() {
+	for _, dp := range dps {
+		go dp.serve()
+	}
+}
+
+// route returns the peers able to receive the request, in the order they should be tried:
+// the ones matching Destination-Host first, then the ones serving Destination-Realm
+func (dps diamPeers) route(peerID, destHost, destRealm string) (peers []*diamPeer) {
+	var realmPeers []*diamPeer
+	for _, dp := range dps {
+		if peerID != utils.EmptyString {
+			if dp.cfg.ID == peerID {
+				return []*diamPeer{dp}
+			}
+			continue
+		}
+		if destRealm != utils.EmptyString &&
+			dp.cfg.OriginRealm != utils.EmptyString &&
+			dp.cfg.OriginRealm != destRealm {
+			continue
+		}
+		if destHost != utils.EmptyString &&
+			dp.cfg.OriginHost == destHost {
+			peers = append(peers, dp)
+			continue
+		}
+		realmPeers = append(realmPeers, dp)
+	}
+	return append(peers, realmPeers...)
+}
+
+// send routes the request to the peers, failing over to the next one
+// on transport errors, timeouts or when the peer is unable to deliver
+func (dps diamPeers) send(m *diam.Message, peerID string) (a *diam.Message, err error) {
+	var destHost, destRealm string
+	if destHost, err = diamMsgAVPAsString(m, avp.DestinationHost); err != nil {
+		return
+	}
+	if destRealm, err = diamMsgAVPAsString(m, avp.DestinationRealm); err != nil {
+		return
+	}
+	peers := dps.route(peerID, destHost, destRealm)
+	if len(peers) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	err = utils.ErrDisconnected
+	var sent bool
+	for _, dp := range peers {
+		if !dp.connected() {
+			continue
+		}
+		if sent { // failover, mark it as potentially duplicated
+			m.Header.CommandFlags |= diam.RetransmittedFlag
+		}
+		sent = true
+		if a, err = dp.send(m); err == nil {
+			var resCode string
+			if resCode, err = diamMsgAVPAsString(a, avp.ResultCode); err != nil {
+				return
+			}
+			if resCode != strconv.Itoa(diam.UnableToDeliver) &&
+				resCode != strconv.Itoa(diam.TooBusy) {
+				return
+			}
+			err = fmt.Errorf("Result-Code: %s", resCode)
+		}
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed sending request to peer <%s>, err: %s",
+			utils.DiameterAgent, dp.cfg.ID, err.Error()))
+	}
+	return nil, err
+}
+
+// diamMsgAVPAsString returns the value of the top level AVP with the given code or empty string if missing
+func diamMsgAVPAsString(m *diam.Message, code uint32) (s string, err error) {
+	for _, a := range m.AVP {
+		if a.Code == code {
+			return diamAVPAsString(a)
+		}
+	}
+	return
+}
+
+// diamAVPsAsMap converts the AVPs into a map indexed on their names,
+// grouped AVPs becoming maps and the repeated ones slices
+func diamAVPsAsMap(avps []*diam.AVP, appID uint32, dictionary *dict.Parser) (mp map[string]interface{}, err error) {
+	mp = make(map[string]interface{})
+	for _, a := range avps {
+		name := strconv.Itoa(int(a.Code))
+		if dAVP, errDict := dictionary.FindAVPWithVendor(appID, a.Code, a.VendorID); errDict == nil {
+			name = dAVP.Name
+		}
+		var val interface{}
+		if grp, isGrouped := a.Data.(*diam.GroupedAVP); isGrouped {
+			if val, err = diamAVPsAsMap(grp.AVP, appID, dictionary); err != nil {
+				return
+			}
+		} else if val, err = diamAVPAsIface(a); err != nil {
+			return
+		}
+		prev, has := mp[name]
+		if !has {
+			mp[name] = val
+			continue
+		}
+		if prevSls, isSlice := prev.([]interface{}); isSlice {
+			mp[name] = append(prevSls, val)
+		} else {
+			mp[name] = []interface{}{prev, val}
+		}
+	}
+	return
+}
+
+// diamAnswerData is the answer received from an upstream peer
+type diamAnswerData map[string]interface{}
+
+// AsNavigableMap is part of utils.NavigableMapper interface
+func (ad diamAnswerData) AsNavigableMap() (nm utils.NavigableMap2) {
+	nm = make(utils.NavigableMap2)
+	for k, v := range ad {
+		nm[k] = diamAnswerValueAsNM(v)
+	}
+	return
+}
+
+func diamAnswerValueAsNM(v interface{}) utils.NMInterface {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		return diamAnswerData(val).AsNavigableMap()
+	case []interface{}:
+		nms := make(utils.NMSlice, len(val))
+		for i, itm := range val {
+			nms[i] = diamAnswerValueAsNM(itm)
+		}
+		return &nms
+	default:
+		return utils.NewNMData(val)
+	}
+}
@@ -0,0 +1,191 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package agents
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/diam"
+	"github.com/fiorix/go-diameter/diam/avp"
+	"github.com/fiorix/go-diameter/diam/datatype"
+	"github.com/fiorix/go-diameter/diam/dict"
+	"github.com/fiorix/go-diameter/diam/sm"
+)
+
+func TestDiamPeersRoute(t *testing.T) {
+	dps := newDiamPeers([]*config.DiameterPeerCfg{
+		{ID: "OCS_BACKUP", OriginHost: "ocs2.legacy.org", OriginRealm: "legacy.org", Weight: 10},
+		{ID: "OCS_MAIN", OriginHost: "ocs1.legacy.org", OriginRealm: "legacy.org", Weight: 20},
+		{ID: "NEW_OCS", OriginHost: "ocs.cgrates.org", OriginRealm: "cgrates.org", Weight: 30},
+		{ID: "DEFAULT", Weight: 5},
+	}, &sm.Settings{})
+	ids := func(peers []*diamPeer) (rcv []string) {
+		for _, dp := range peers {
+			rcv = append(rcv, dp.cfg.ID)
+		}
+		return
+	}
+	if rcv, exp := ids(dps.route("", "", "")),
+		[]string{"NEW_OCS", "OCS_MAIN", "OCS_BACKUP", "DEFAULT"}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	if rcv, exp := ids(dps.route("", "", "legacy.org")),
+		[]string{"OCS_MAIN", "OCS_BACKUP", "DEFAULT"}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	if rcv, exp := ids(dps.route("", "ocs2.legacy.org", "legacy.org")),
+		[]string{"OCS_BACKUP", "OCS_MAIN", "DEFAULT"}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	if rcv, exp := ids(dps.route("OCS_BACKUP", "", "cgrates.org")),
+		[]string{"OCS_BACKUP"}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
+	}
+	if rcv := dps.route("UNKNOWN", "", ""); len(rcv) != 0 {
+		t.Errorf("Expecting no peers, received: %+v", ids(rcv))
+	}
+}
+
+func TestDiamPeersSendDisconnected(t *testing.T) {
+	dps := newDiamPeers([]*config.DiameterPeerCfg{{ID: "OCS1"}}, &sm.Settings{})
+	m := diam.NewRequest(diam.CreditControl, 4, dict.Default)
+	if _, err := dps.send(m, ""); err != utils.ErrDisconnected {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrDisconnected, err)
+	}
+	if _, err := dps.send(m, "OCS2"); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestDiamAVPsAsMap(t *testing.T) {
+	m := diam.NewRequest(diam.CreditControl, 4, dict.Default)
+	m.NewAVP(avp.ResultCode, avp.Mbit, 0, datatype.Unsigned32(2001))
+	m.NewAVP(avp.MultipleServicesCreditControl, avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.RatingGroup, avp.Mbit, 0, datatype.Unsigned32(1)),
+			diam.NewAVP(avp.GrantedServiceUnit, avp.Mbit, 0, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.CCTime, avp.Mbit, 0, datatype.Unsigned32(300)),
+				}}),
+		}})
+	m.NewAVP(avp.MultipleServicesCreditControl, avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.RatingGroup, avp.Mbit, 0, datatype.Unsigned32(2)),
+		}})
+	eMp := map[string]interface{}{
+		"Result-Code": uint32(2001),
+		"Multiple-Services-Credit-Control": []interface{}{
+			map[string]interface{}{
+				"Rating-Group": uint32(1),
+				"Granted-Service-Unit": map[string]interface{}{
+					"CC-Time": uint32(300),
+				},
+			},
+			map[string]interface{}{
+				"Rating-Group": uint32(2),
+			},
+		},
+	}
+	mp, err := diamAVPsAsMap(m.AVP, m.Header.ApplicationID, m.Dictionary())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(eMp, mp) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eMp), utils.ToJSON(mp))
+	}
+	nm := diamAnswerData(mp).AsNavigableMap()
+	if rcv, err := nm.FieldAsString([]string{"Result-Code"}); err != nil {
+		t.Error(err)
+	} else if rcv != "2001" {
+		t.Errorf("Expecting: 2001, received: %s", rcv)
+	}
+	if rcv, err := nm.Field(utils.PathItems{
+		{Field: "Multiple-Services-Credit-Control", Index: utils.StringPointer("0")},
+		{Field: "Granted-Service-Unit"}, {Field: "CC-Time"}}); err != nil {
+		t.Error(err)
+	} else if rcv.Interface() != uint32(300) {
+		t.Errorf("Expecting: 300, received: %v", rcv.Interface())
+	}
+}
+
+func TestDiamPeersSendFailover(t *testing.T) {
+	settings := &sm.Settings{
+		OriginHost:       datatype.DiameterIdentity("ocs.legacy.org"),
+		OriginRealm:      datatype.DiameterIdentity("legacy.org"),
+		VendorID:         datatype.Unsigned32(0),
+		ProductName:      datatype.UTF8String("LegacyOCS"),
+		FirmwareRevision: datatype.Unsigned32(1),
+		HostIPAddresses:  []datatype.Address{datatype.Address(net.ParseIP("127.0.0.1"))},
+	}
+	srvSM := sm.New(settings)
+	srvSM.HandleFunc("CCR", func(c diam.Conn, m *diam.Message) {
+		a := m.Answer(diam.Success)
+		a.NewAVP(avp.OriginHost, avp.Mbit, 0, settings.OriginHost)
+		a.NewAVP(avp.OriginRealm, avp.Mbit, 0, settings.OriginRealm)
+		a.WriteTo(c)
+	})
+	l, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go diam.Serve(l, srvSM)
+	dps := newDiamPeers([]*config.DiameterPeerCfg{
+		{ID: "DOWN", Address: "127.0.0.1:1", Transport: utils.TCP,
+			OriginRealm: "legacy.org", Weight: 20, AuthApplicationIDs: []uint32{4},
+			ReplyTimeout: time.Second},
+		{ID: "UP", Address: l.Addr().String(), Transport: utils.TCP,
+			OriginRealm: "legacy.org", Weight: 10, AuthApplicationIDs: []uint32{4},
+			ReplyTimeout: time.Second},
+	}, &sm.Settings{
+		OriginHost:       datatype.DiameterIdentity("CGR-DA"),
+		OriginRealm:      datatype.DiameterIdentity("cgrates.org"),
+		ProductName:      datatype.UTF8String("CGRateS"),
+		FirmwareRevision: datatype.Unsigned32(1),
+		HostIPAddresses:  []datatype.Address{datatype.Address(net.ParseIP("127.0.0.1"))},
+	})
+	dps.serve()
+	for i := 0; i < 50 && !dps[1].connected(); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if !dps[1].connected() {
+		t.Fatal("peer not connected")
+	}
+	m := diam.NewRequest(diam.CreditControl, 4, dict.Default)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("session1"))
+	m.NewAVP(avp.OriginHost, avp.Mbit, 0, datatype.DiameterIdentity("CGR-DA"))
+	m.NewAVP(avp.OriginRealm, avp.Mbit, 0, datatype.DiameterIdentity("cgrates.org"))
+	m.NewAVP(avp.DestinationRealm, avp.Mbit, 0, datatype.DiameterIdentity("legacy.org"))
+	m.NewAVP(avp.AuthApplicationID, avp.Mbit, 0, datatype.Unsigned32(4))
+	m.NewAVP(avp.CCRequestType, avp.Mbit, 0, datatype.Enumerated(1))
+	m.NewAVP(avp.CCRequestNumber, avp.Mbit, 0, datatype.Unsigned32(0))
+	a, err := dps.send(m, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resCode, err := diamMsgAVPAsString(a, avp.ResultCode); err != nil {
+		t.Error(err)
+	} else if resCode != "2001" {
+		t.Errorf("Expecting: 2001, received: %s", resCode)
+	}
+}
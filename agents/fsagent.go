@@ -21,6 +21,7 @@ package agents
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -114,6 +115,34 @@ func (fsa *FSsessions) setMaxCallDuration(uuid string, connIdx int,
 	return nil
 }
 
+// Reschedules the call timeout of an answered call
+func (fsa *FSsessions) resetMaxCallDuration(uuid string, connIdx int,
+	maxDur time.Duration, destNr string) (err error) {
+	// remove the tasks scheduled before so only the new timeout applies
+	if _, err = fsa.conns[connIdx].SendApiCmd(
+		fmt.Sprintf("sched_del %s\n\n", uuid)); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> Could not remove the scheduled call timeout, error: <%s>, connIdx: %v",
+				utils.FreeSWITCHAgent, err.Error(), connIdx))
+		return
+	}
+	cmd := fmt.Sprintf("sched_hangup +%d %s alloted_timeout\n\n",
+		int(maxDur.Seconds()), uuid)
+	if len(fsa.cfg.EmptyBalanceContext) != 0 {
+		cmd = fmt.Sprintf("sched_transfer +%d %s %s XML %s\n\n",
+			int(maxDur.Seconds()), uuid, destNr, fsa.cfg.EmptyBalanceContext)
+	} else if len(fsa.cfg.EmptyBalanceAnnFile) != 0 {
+		cmd = fmt.Sprintf("sched_broadcast +%d %s playback!manager_request::%s aleg\n\n",
+			int(maxDur.Seconds()), uuid, fsa.cfg.EmptyBalanceAnnFile)
+	}
+	if _, err = fsa.conns[connIdx].SendApiCmd(cmd); err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> Could not reschedule the call timeout, error: <%s>, connIdx: %v",
+				utils.FreeSWITCHAgent, err.Error(), connIdx))
+	}
+	return
+}
+
 // Sends the transfer command to unpark the call to freeswitch
 func (fsa *FSsessions) unparkCall(uuid string, connIdx int, callDestNb, notify string) (err error) {
 	_, err = fsa.conns[connIdx].SendApiCmd(
@@ -442,9 +471,38 @@ func (fsa *FSsessions) Reload() {
 	fsa.senderPools = make([]*fsock.FSockPool, len(fsa.cfg.EventSocketConns))
 }
 
-// V1ReAuthorize is used to implement the sessions.BiRPClient interface
-func (*FSsessions) V1ReAuthorize(originID string, reply *string) (err error) {
-	return utils.ErrNotImplemented
+// V1ReAuthorize authorizes the session again in order to recompute the MaxUsage and reschedules the hangup of the channel
+func (fsa *FSsessions) V1ReAuthorize(originID string, reply *string) (err error) {
+	aS, maxUsage, err := reAuthorizeSession(fsa.connMgr, fsa.cfg.SessionSConns, fsa, originID)
+	if err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: <%s> when attempting to reauthorize channelID: <%s>",
+				utils.FreeSWITCHAgent, err.Error(), originID))
+		return
+	}
+	connIdx, err := strconv.Atoi(aS.ExtraFields[FsConnID])
+	if err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: <%s:%s> when attempting to reauthorize channelID: <%s>",
+				utils.FreeSWITCHAgent, err.Error(), FsConnID, originID))
+		return
+	}
+	if connIdx >= len(fsa.conns) { // protection against index out of range panic
+		err = fmt.Errorf("Index out of range[0,%v): %v ", len(fsa.conns), connIdx)
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.FreeSWITCHAgent, err.Error()))
+		return
+	}
+	if maxUsage == 0 {
+		err = fsa.disconnectSession(connIdx, originID, aS.Destination,
+			utils.ErrInsufficientCredit.Error())
+	} else {
+		err = fsa.resetMaxCallDuration(originID, connIdx, maxUsage, aS.Destination)
+	}
+	if err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
 }
 
 // V1DisconnectPeer is used to implement the sessions.BiRPClient interface
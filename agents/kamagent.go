@@ -24,6 +24,7 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
@@ -423,9 +424,44 @@ func (ka *KamailioAgent) Reload() {
 	ka.conns = make([]*kamevapi.KamEvapi, len(ka.cfg.EvapiConns))
 }
 
-// V1ReAuthorize is used to implement the sessions.BiRPClient interface
-func (*KamailioAgent) V1ReAuthorize(originID string, reply *string) (err error) {
-	return utils.ErrNotImplemented
+// V1ReAuthorize authorizes the session again in order to recompute the MaxUsage and sends the new dialog timeout to Kamailio
+func (ka *KamailioAgent) V1ReAuthorize(originID string, reply *string) (err error) {
+	aS, maxUsage, err := reAuthorizeSession(ka.connMgr, ka.cfg.SessionSConns, ka, originID)
+	if err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: <%s> when attempting to reauthorize session with OriginID: <%s>",
+				utils.KamailioAgent, err.Error(), originID))
+		return
+	}
+	hEntry := aS.ExtraFields[KamHashEntry]
+	hID := aS.ExtraFields[KamHashID]
+	connIdx, err := strconv.Atoi(aS.ExtraFields[EvapiConnID])
+	if err != nil {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: <%s:%s> when attempting to reauthorize <%s:%s> and <%s:%s>",
+				utils.KamailioAgent, err.Error(), EvapiConnID,
+				KamHashEntry, hEntry, KamHashID, hID))
+		return
+	}
+	if connIdx >= len(ka.conns) { // protection against index out of range panic
+		err = fmt.Errorf("Index out of range[0,%v): %v ", len(ka.conns), connIdx)
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.KamailioAgent, err.Error()))
+		return
+	}
+	if maxUsage == 0 {
+		err = ka.disconnectSession(connIdx,
+			NewKamSessionDisconnect(hEntry, hID,
+				utils.ErrInsufficientCredit.Error()))
+	} else if err = ka.conns[connIdx].Send(
+		NewKamSessionUpdate(hEntry, hID, maxUsage).String()); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed sending session update for <%s:%s> and <%s:%s>, connection id: %v, error: %s",
+			utils.KamailioAgent, KamHashEntry, hEntry, KamHashID, hID, connIdx, err.Error()))
+	}
+	if err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
 }
 
 // V1DisconnectPeer is used to implement the sessions.BiRPClient interface
@@ -433,7 +469,32 @@ func (*KamailioAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err
 	return utils.ErrNotImplemented
 }
 
-// DisconnectWarning is used to implement the sessions.BiRPClient interface
-func (*KamailioAgent) DisconnectWarning(args map[string]interface{}, reply *string) (err error) {
-	return utils.ErrNotImplemented
+// DisconnectWarning is called when call goes under the minimum duration threshold, so Kamailio can play an announcement message
+func (ka *KamailioAgent) DisconnectWarning(args map[string]interface{}, reply *string) (err error) {
+	hEntry := utils.IfaceAsString(args[KamHashEntry])
+	hID := utils.IfaceAsString(args[KamHashID])
+	connIdxIface, has := args[EvapiConnID]
+	if !has {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: <%s:%s> when attempting to warn <%s:%s> and <%s:%s>",
+				utils.KamailioAgent, utils.ErrNotFound.Error(), EvapiConnID,
+				KamHashEntry, hEntry, KamHashID, hID))
+		return utils.ErrNotFound
+	}
+	connIdx, err := utils.IfaceAsTInt64(connIdxIface)
+	if err != nil {
+		return
+	}
+	if int(connIdx) >= len(ka.conns) { // protection against index out of range panic
+		err = fmt.Errorf("Index out of range[0,%v): %v ", len(ka.conns), connIdx)
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.KamailioAgent, err.Error()))
+		return
+	}
+	if err = ka.conns[connIdx].Send(NewKamSessionWarning(hEntry, hID).String()); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed sending session warning for <%s:%s> and <%s:%s>, connection id: %v, error: %s",
+			utils.KamailioAgent, KamHashEntry, hEntry, KamHashID, hID, connIdx, err.Error()))
+		return
+	}
+	*reply = utils.OK
+	return
 }
@@ -33,6 +33,8 @@ const (
 	CGR_AUTH_REQUEST       = "CGR_AUTH_REQUEST"
 	CGR_AUTH_REPLY         = "CGR_AUTH_REPLY"
 	CGR_SESSION_DISCONNECT = "CGR_SESSION_DISCONNECT"
+	CGR_SESSION_UPDATE     = "CGR_SESSION_UPDATE"
+	CGR_SESSION_WARNING    = "CGR_SESSION_WARNING"
 	CGR_CALL_START         = "CGR_CALL_START"
 	CGR_CALL_END           = "CGR_CALL_END"
 	CGR_PROCESS_MESSAGE    = "CGR_PROCESS_MESSAGE"
@@ -70,6 +72,44 @@ func (ksd *KamSessionDisconnect) String() string {
 	return utils.ToJSON(ksd)
 }
 
+// NewKamSessionUpdate builds the event sent to Kamailio in order to reset the dialog timeout
+func NewKamSessionUpdate(hEntry, hID string, maxUsage time.Duration) *KamSessionUpdate {
+	return &KamSessionUpdate{
+		Event:     CGR_SESSION_UPDATE,
+		HashEntry: hEntry,
+		HashId:    hID,
+		MaxUsage:  int(utils.Round(maxUsage.Seconds(), 0, utils.ROUNDING_MIDDLE))}
+}
+
+type KamSessionUpdate struct {
+	Event     string
+	HashEntry string
+	HashId    string
+	MaxUsage  int // Remaining session time in seconds
+}
+
+func (ksu *KamSessionUpdate) String() string {
+	return utils.ToJSON(ksu)
+}
+
+// NewKamSessionWarning builds the event sent to Kamailio in order to play the low balance announcement
+func NewKamSessionWarning(hEntry, hID string) *KamSessionWarning {
+	return &KamSessionWarning{
+		Event:     CGR_SESSION_WARNING,
+		HashEntry: hEntry,
+		HashId:    hID}
+}
+
+type KamSessionWarning struct {
+	Event     string
+	HashEntry string
+	HashId    string
+}
+
+func (ksw *KamSessionWarning) String() string {
+	return utils.ToJSON(ksw)
+}
+
 // NewKamEvent parses bytes received over the wire from Kamailio into KamEvent
 func NewKamEvent(kamEvData []byte, alias, adress string) (KamEvent, error) {
 	kev := make(map[string]string)
@@ -453,3 +453,27 @@ func TestKamEvAsKamProcessEventReply(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", expected, rcv)
 	}
 }
+
+func TestKamSessionUpdate(t *testing.T) {
+	ksu := NewKamSessionUpdate("3039", "1", 90400*time.Millisecond)
+	expected := &KamSessionUpdate{
+		Event:     CGR_SESSION_UPDATE,
+		HashEntry: "3039",
+		HashId:    "1",
+		MaxUsage:  90,
+	}
+	if !reflect.DeepEqual(expected, ksu) {
+		t.Errorf("Expecting: %+v, received: %+v", expected, ksu)
+	}
+	eStr := `{"Event":"CGR_SESSION_UPDATE","HashEntry":"3039","HashId":"1","MaxUsage":90}`
+	if rcv := ksu.String(); rcv != eStr {
+		t.Errorf("Expecting: %s, received: %s", eStr, rcv)
+	}
+}
+
+func TestKamSessionWarning(t *testing.T) {
+	eStr := `{"Event":"CGR_SESSION_WARNING","HashEntry":"3039","HashId":"1"}`
+	if rcv := NewKamSessionWarning("3039", "1").String(); rcv != eStr {
+		t.Errorf("Expecting: %s, received: %s", eStr, rcv)
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// reAuthorizeSession finds the active session with the given originID in SessionS
+// and authorizes its event again in order to obtain the new MaxUsage after a RAR,
+// without debiting anything out of the session
+func reAuthorizeSession(connMgr *engine.ConnManager, sSConns []string,
+	biRPCClient rpcclient.ClientConnector, originID string) (aS *sessions.ExternalSession,
+	maxUsage time.Duration, err error) {
+	var aSs []*sessions.ExternalSession
+	if err = connMgr.Call(sSConns, biRPCClient, utils.SessionSv1GetActiveSessions,
+		&utils.SessionFilter{
+			Filters: []string{utils.ConcatenatedKey(utils.MetaString,
+				utils.DynamicDataPrefix+utils.MetaReq+utils.NestingSep+utils.OriginID, originID)},
+		}, &aSs); err != nil {
+		return
+	}
+	if len(aSs) == 0 {
+		err = utils.ErrNotFound
+		return
+	}
+	aS = aSs[0]
+	ev := make(map[string]interface{}, len(aS.ExtraFields)+12)
+	for k, v := range aS.ExtraFields {
+		ev[k] = v
+	}
+	ev[utils.OriginID] = aS.OriginID
+	ev[utils.OriginHost] = aS.OriginHost
+	ev[utils.ToR] = aS.ToR
+	ev[utils.RequestType] = aS.RequestType
+	ev[utils.Tenant] = aS.Tenant
+	ev[utils.Category] = aS.Category
+	ev[utils.Account] = aS.Account
+	ev[utils.Subject] = aS.Subject
+	ev[utils.Destination] = aS.Destination
+	ev[utils.SetupTime] = aS.SetupTime
+	ev[utils.AnswerTime] = aS.AnswerTime
+	var authReply sessions.V1AuthorizeReply
+	if err = connMgr.Call(sSConns, biRPCClient, utils.SessionSv1AuthorizeEvent,
+		&sessions.V1AuthorizeArgs{
+			GetMaxUsage: true,
+			CGREvent: &utils.CGREvent{
+				Tenant: aS.Tenant,
+				ID:     utils.UUIDSha1Prefix(),
+				Event:  ev,
+			},
+		}, &authReply); err != nil {
+		return
+	}
+	if authReply.MaxUsage != nil {
+		maxUsage = *authReply.MaxUsage
+	}
+	return
+}
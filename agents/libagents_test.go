@@ -0,0 +1,105 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestReAuthorizeSession(t *testing.T) {
+	answerTime := time.Date(2020, 4, 18, 13, 37, 0, 0, time.UTC)
+	sS := &testMockSessionConn{calls: map[string]func(arg interface{}, rply interface{}) error{
+		utils.SessionSv1GetActiveSessions: func(arg interface{}, rply interface{}) error {
+			*rply.(*[]*sessions.ExternalSession) = []*sessions.ExternalSession{{
+				CGRID:       "cgrid1",
+				ToR:         utils.VOICE,
+				OriginID:    "uuid1",
+				OriginHost:  "fs1",
+				RequestType: utils.META_PREPAID,
+				Tenant:      "cgrates.org",
+				Category:    "call",
+				Account:     "1001",
+				Subject:     "1001",
+				Destination: "1002",
+				AnswerTime:  answerTime,
+				Usage:       time.Minute,
+				ExtraFields: map[string]string{FsConnID: "0"},
+			}}
+			return nil
+		},
+		utils.SessionSv1AuthorizeEvent: func(arg interface{}, rply interface{}) error {
+			args := arg.(*sessions.V1AuthorizeArgs)
+			if !args.GetMaxUsage {
+				t.Errorf("expecting the MaxUsage to be requested")
+			}
+			expEv := map[string]interface{}{
+				FsConnID:          "0",
+				utils.OriginID:    "uuid1",
+				utils.OriginHost:  "fs1",
+				utils.ToR:         utils.VOICE,
+				utils.RequestType: utils.META_PREPAID,
+				utils.Tenant:      "cgrates.org",
+				utils.Category:    "call",
+				utils.Account:     "1001",
+				utils.Subject:     "1001",
+				utils.Destination: "1002",
+				utils.SetupTime:   time.Time{},
+				utils.AnswerTime:  answerTime,
+			}
+			if !reflect.DeepEqual(expEv, args.Event) {
+				t.Errorf("expecting: %s, received: %s", utils.ToJSON(expEv), utils.ToJSON(args.Event))
+			}
+			*rply.(*sessions.V1AuthorizeReply) = sessions.V1AuthorizeReply{
+				MaxUsage: utils.DurationPointer(5 * time.Minute),
+			}
+			return nil
+		},
+		// the session must not be debited again
+		utils.SessionSv1UpdateSession: func(arg interface{}, rply interface{}) error {
+			t.Errorf("unexpected update of the session: %s", utils.ToJSON(arg))
+			return nil
+		},
+	}}
+	connID := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)
+	engine.Cache.Remove(utils.CacheRPCConnections, connID, true, utils.NonTransactional)
+	defer engine.Cache.Remove(utils.CacheRPCConnections, connID, true, utils.NonTransactional)
+	internalSessionSChan := make(chan rpcclient.ClientConnector, 1)
+	internalSessionSChan <- sS
+	connMgr := engine.NewConnManager(config.CgrConfig(), map[string]chan rpcclient.ClientConnector{
+		connID: internalSessionSChan,
+	})
+	aS, maxUsage, err := reAuthorizeSession(connMgr, []string{connID}, nil, "uuid1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aS.CGRID != "cgrid1" {
+		t.Errorf("received: %s", utils.ToJSON(aS))
+	}
+	if maxUsage != 5*time.Minute {
+		t.Errorf("expecting: %v, received: %v", 5*time.Minute, maxUsage)
+	}
+}
@@ -278,6 +278,19 @@ func messageSetAVPsWithPath(m *diam.Message, pathStr []string,
 	return nil
 }
 
+// appendDiamAVP appends the AVP value at path to the navigable map used to build
+// the Diameter message, newBranch starting a new group for the first AVP in path
+func appendDiamAVP(nm *utils.OrderedNavigableMap, val string, newBranch bool, path ...string) error {
+	nmItm := &config.NMItem{Data: val, Path: path}
+	if newBranch {
+		nmItm.Config = &config.FCTemplate{NewBranch: true}
+	}
+	return utils.AppendNavMapVal(nm, &utils.FullPath{
+		PathItems: utils.NewPathItems(path),
+		Path:      strings.Join(path, utils.NestingSep),
+	}, nmItm)
+}
+
 // writeOnConn writes the message on connection, logs failures
 func writeOnConn(c diam.Conn, m *diam.Message) (err error) {
 	if _, err = m.WriteTo(c); err != nil {
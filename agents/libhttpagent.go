@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"encoding/json"
 	"encoding/xml"
 	"fmt"
 	"io/ioutil"
@@ -43,7 +44,10 @@ func newHADataProvider(reqPayload string,
 		return newHTTPUrlDP(req)
 	case utils.MetaXml:
 		return newHTTPXmlDP(req)
-
+	case utils.MetaJSON:
+		return newHTTPJSONDP(req)
+	case utils.MetaForm:
+		return newHTTPFormDP(req)
 	}
 }
 
@@ -187,6 +191,107 @@ func (hU *httpXmlDP) RemoteHost() net.Addr {
 	return utils.NewNetAddr("TCP", hU.addr)
 }
 
+func newHTTPJSONDP(req *http.Request) (dP utils.DataProvider, err error) {
+	byteData, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	var body map[string]interface{}
+	if err = json.Unmarshal(byteData, &body); err != nil {
+		return nil, err
+	}
+	dP = &httpJSONDP{body: body, addr: req.RemoteAddr}
+	return
+}
+
+// httpJSONDP implements utils.DataProvider, serving as json data decoder
+// nested objects and arrays are navigated with paths like a.b[0].c
+type httpJSONDP struct {
+	body utils.MapStorage
+	addr string
+}
+
+// String is part of utils.DataProvider interface
+func (hJ *httpJSONDP) String() string {
+	return utils.ToJSON(hJ.body)
+}
+
+// FieldAsInterface is part of utils.DataProvider interface
+func (hJ *httpJSONDP) FieldAsInterface(fldPath []string) (data interface{}, err error) {
+	return hJ.body.FieldAsInterface(fldPath)
+}
+
+// FieldAsString is part of utils.DataProvider interface
+func (hJ *httpJSONDP) FieldAsString(fldPath []string) (data string, err error) {
+	var valIface interface{}
+	valIface, err = hJ.FieldAsInterface(fldPath)
+	if err != nil {
+		return
+	}
+	return utils.IfaceAsString(valIface), nil
+}
+
+// RemoteHost is part of utils.DataProvider interface
+func (hJ *httpJSONDP) RemoteHost() net.Addr {
+	return utils.NewNetAddr("TCP", hJ.addr)
+}
+
+func newHTTPFormDP(req *http.Request) (dP utils.DataProvider, err error) {
+	if err = req.ParseForm(); err != nil {
+		return
+	}
+	dP = &httpFormDP{req: req}
+	return
+}
+
+// httpFormDP implements utils.DataProvider, serving as decoder for form-encoded bodies
+// the repeated fields are selected with an index, ie: field[1]
+type httpFormDP struct {
+	req *http.Request
+}
+
+// String is part of utils.DataProvider interface
+func (hF *httpFormDP) String() string {
+	return hF.req.PostForm.Encode()
+}
+
+// FieldAsInterface is part of utils.DataProvider interface
+func (hF *httpFormDP) FieldAsInterface(fldPath []string) (data interface{}, err error) {
+	if len(fldPath) != 1 {
+		return nil, utils.ErrNotFound
+	}
+	fld, idxStr := utils.GetPathIndexString(fldPath[0])
+	vals, has := hF.req.PostForm[fld]
+	if !has {
+		return nil, utils.ErrNotFound
+	}
+	var idx int
+	if idxStr != nil {
+		if idx, err = strconv.Atoi(*idxStr); err != nil {
+			return
+		}
+	}
+	if idx >= len(vals) {
+		return nil, utils.ErrNotFound
+	}
+	return vals[idx], nil
+}
+
+// FieldAsString is part of utils.DataProvider interface
+func (hF *httpFormDP) FieldAsString(fldPath []string) (data string, err error) {
+	var valIface interface{}
+	valIface, err = hF.FieldAsInterface(fldPath)
+	if err != nil {
+		return
+	}
+	return utils.IfaceAsString(valIface), nil
+}
+
+// RemoteHost is part of utils.DataProvider interface
+func (hF *httpFormDP) RemoteHost() net.Addr {
+	return utils.NewNetAddr("TCP", hF.req.RemoteAddr)
+}
+
 // httpAgentReplyEncoder will encode  []*engine.NMElement
 // and write content to http writer
 type httpAgentReplyEncoder interface {
@@ -203,6 +308,8 @@ func newHAReplyEncoder(encType string,
 		return newHAXMLEncoder(w)
 	case utils.MetaTextPlain:
 		return newHATextPlainEncoder(w)
+	case utils.MetaJSON:
+		return newHAJSONEncoder(w)
 	}
 }
 
@@ -265,3 +372,53 @@ func (xE *haTextPlainEncoder) Encode(nM *utils.OrderedNavigableMap) (err error)
 	_, err = xE.w.Write([]byte(str))
 	return
 }
+
+func newHAJSONEncoder(w http.ResponseWriter) (jE httpAgentReplyEncoder, err error) {
+	return &haJSONEncoder{w: w}, nil
+}
+
+type haJSONEncoder struct {
+	w http.ResponseWriter
+}
+
+// Encode implements httpAgentReplyEncoder
+func (jE *haJSONEncoder) Encode(nM *utils.OrderedNavigableMap) (err error) {
+	if nM.Empty() {
+		return
+	}
+	var jsonOut []byte
+	if jsonOut, err = json.Marshal(nmAsJSONValue(nM.Interface().(utils.NMInterface))); err != nil {
+		return
+	}
+	jE.w.Header().Set("Content-Type", "application/json")
+	_, err = jE.w.Write(jsonOut)
+	return
+}
+
+// nmAsJSONValue converts the NavigableMap into nested objects and arrays
+// the templates set each value as a single item list which is encoded as the value itself
+// while the *group fields stay arrays even with one item, so the JSON type does not change
+func nmAsJSONValue(nm utils.NMInterface) interface{} {
+	switch val := nm.(type) {
+	case utils.NavigableMap2:
+		obj := make(map[string]interface{}, len(val))
+		for k, itm := range val {
+			obj[k] = nmAsJSONValue(itm)
+		}
+		return obj
+	case *utils.NMSlice:
+		if len(*val) == 1 {
+			if nmItm, isNMItem := (*val)[0].(*config.NMItem); isNMItem &&
+				(nmItm.Config == nil || nmItm.Config.Type != utils.MetaGroup) {
+				return nmItm.Data
+			}
+		}
+		arr := make([]interface{}, len(*val))
+		for i, itm := range *val {
+			arr[i] = nmAsJSONValue(itm)
+		}
+		return arr
+	default:
+		return nm.Interface()
+	}
+}
@@ -22,8 +22,13 @@ import (
 	"bufio"
 	"bytes"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
 )
 
 func TestHttpUrlDPFieldAsInterface(t *testing.T) {
@@ -190,3 +195,114 @@ func TestHttpXmlDPFieldAsInterface2(t *testing.T) {
 		t.Errorf("expecting: 0.0225, received: <%s>", data)
 	}
 }
+
+func TestHttpJSONDPFieldAsInterface(t *testing.T) {
+	body := `{"msisdn":"497924804904","sms":{"text":"Hello","parts":[{"id":1,"size":160},{"id":2,"size":20}]}}`
+	req, err := http.NewRequest(http.MethodPost, "http://localhost:2080/sms", strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	hJ, err := newHTTPJSONDP(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := hJ.FieldAsString([]string{"msisdn"}); err != nil {
+		t.Error(err)
+	} else if data != "497924804904" {
+		t.Errorf("expecting: 497924804904, received: <%s>", data)
+	}
+	if data, err := hJ.FieldAsString([]string{"sms", "text"}); err != nil {
+		t.Error(err)
+	} else if data != "Hello" {
+		t.Errorf("expecting: Hello, received: <%s>", data)
+	}
+	if data, err := hJ.FieldAsString([]string{"sms", "parts[1]", "size"}); err != nil {
+		t.Error(err)
+	} else if data != "20" {
+		t.Errorf("expecting: 20, received: <%s>", data)
+	}
+	if _, err := hJ.FieldAsString([]string{"sms", "parts[2]", "size"}); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := newHTTPJSONDP(httptest.NewRequest(http.MethodPost, "/sms",
+		strings.NewReader("not json"))); err == nil {
+		t.Error("expecting error for invalid body")
+	}
+}
+
+func TestHttpFormDPFieldAsInterface(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/sms?msisdn=ignored",
+		strings.NewReader("msisdn=%2B497924804904&text=Hello+World&part=1&part=2"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	hF, err := newHTTPFormDP(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, err := hF.FieldAsString([]string{"msisdn"}); err != nil {
+		t.Error(err)
+	} else if data != "+497924804904" {
+		t.Errorf("expecting: +497924804904, received: <%s>", data)
+	}
+	if data, err := hF.FieldAsString([]string{"text"}); err != nil {
+		t.Error(err)
+	} else if data != "Hello World" {
+		t.Errorf("expecting: Hello World, received: <%s>", data)
+	}
+	if data, err := hF.FieldAsString([]string{"part[1]"}); err != nil {
+		t.Error(err)
+	} else if data != "2" {
+		t.Errorf("expecting: 2, received: <%s>", data)
+	}
+	if _, err := hF.FieldAsString([]string{"part[2]"}); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := hF.FieldAsString([]string{"nonexistent"}); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestHAJSONEncoder(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items),
+		cfg.CacheCfg(), nil)
+	filterS := engine.NewFilterS(cfg, nil, dm)
+	rply := utils.NewOrderedNavigableMap()
+	agReq := NewAgentRequest(nil, nil, nil, rply, nil, nil, "cgrates.org", "", filterS, nil, nil)
+	tplFlds := []*config.FCTemplate{
+		{Tag: "Code", Path: "*rep.result.code", Type: utils.META_CONSTANT,
+			Value: config.NewRSRParsersMustCompile("200", utils.INFIELD_SEP)},
+		{Tag: "Message", Path: "*rep.result.message", Type: utils.META_CONSTANT,
+			Value: config.NewRSRParsersMustCompile("OK", utils.INFIELD_SEP)},
+		{Tag: "Balance1", Path: "*rep.balances[0].id", Type: utils.META_CONSTANT,
+			Value: config.NewRSRParsersMustCompile("MONETARY", utils.INFIELD_SEP)},
+		{Tag: "Balance2", Path: "*rep.balances[1].id", Type: utils.META_CONSTANT,
+			Value: config.NewRSRParsersMustCompile("SMS", utils.INFIELD_SEP)},
+		{Tag: "Warning1", Path: "*rep.warnings", Type: utils.MetaGroup,
+			Value: config.NewRSRParsersMustCompile("low balance", utils.INFIELD_SEP)},
+		{Tag: "Warning2", Path: "*rep.warnings", Type: utils.MetaGroup,
+			Value: config.NewRSRParsersMustCompile("expiring", utils.INFIELD_SEP)},
+		{Tag: "Note", Path: "*rep.notes", Type: utils.MetaGroup, // one item group is still an array
+			Value: config.NewRSRParsersMustCompile("first", utils.INFIELD_SEP)},
+	}
+	for _, tplFld := range tplFlds {
+		tplFld.ComputePath()
+	}
+	if err := agReq.SetFields(tplFlds); err != nil {
+		t.Fatal(err)
+	}
+	w := httptest.NewRecorder()
+	jE, err := newHAReplyEncoder(utils.MetaJSON, w)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := jE.Encode(agReq.Reply); err != nil {
+		t.Fatal(err)
+	}
+	exp := `{"balances":[{"id":"MONETARY"},{"id":"SMS"}],"notes":["first"],"result":{"code":"200","message":"OK"},"warnings":["low balance","expiring"]}`
+	if rcv := w.Body.String(); rcv != exp {
+		t.Errorf("expecting: %s, received: %s", exp, rcv)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("expecting: application/json, received: %s", ct)
+	}
+}
@@ -0,0 +1,321 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package agents
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/diam"
+	"github.com/fiorix/go-diameter/diam/avp"
+	"github.com/fiorix/go-diameter/diam/dict"
+)
+
+// AVP names used when building the MSCC answers
+const (
+	msccAVP                  = "Multiple-Services-Credit-Control"
+	ratingGroupAVP           = "Rating-Group"
+	serviceIdentifierAVP     = "Service-Identifier"
+	grantedServiceUnitAVP    = "Granted-Service-Unit"
+	ccTimeAVP                = "CC-Time"
+	ccTotalOctetsAVP         = "CC-Total-Octets"
+	ccServiceSpecUnitsAVP    = "CC-Service-Specific-Units"
+	validityTimeAVP          = "Validity-Time"
+	resultCodeAVP            = "Result-Code"
+	finalUnitIndicationAVP   = "Final-Unit-Indication"
+	finalUnitActionAVP       = "Final-Unit-Action"
+	redirectServerAVP        = "Redirect-Server"
+	redirectAddressTypeAVP   = "Redirect-Address-Type"
+	redirectServerAddrAVP    = "Redirect-Server-Address"
+	diamCreditLimitReached   = 4012 // DIAMETER_CREDIT_LIMIT_REACHED
+	reportingReasonFinal     = "2"  // FINAL
+	finalUnitActionTerminate = "0"
+	finalUnitActionRedirect  = "1"
+)
+
+// msccData is the quota request of one Multiple-Services-Credit-Control AVP
+type msccData struct {
+	ratingGroup       string
+	serviceIdentifier string
+	unitAVP           string         // AVP expressing the units: <CC-Time|CC-Total-Octets|CC-Service-Specific-Units>
+	hasRSU            bool           // Requested-Service-Unit present in request
+	requested         *time.Duration // units requested, nil if not specified
+	used              *time.Duration // units reported as used, nil if not specified
+}
+
+// msccUnitAVP returns the unit AVP used when the request does not specify one
+func msccUnitAVP(tor string) string {
+	switch tor {
+	case utils.VOICE:
+		return ccTimeAVP
+	case utils.DATA:
+		return ccTotalOctetsAVP
+	default:
+		return ccServiceSpecUnitsAVP
+	}
+}
+
+// msccFromDiamMessage extracts the Multiple-Services-Credit-Control AVPs out of the request
+func msccFromDiamMessage(m *diam.Message, tor string) (mscc []*msccData, err error) {
+	var avps []*diam.AVP
+	if avps, err = m.FindAVPsWithPath([]interface{}{avp.MultipleServicesCreditControl},
+		dict.UndefinedVendorID); err != nil {
+		return
+	}
+	mscc = make([]*msccData, len(avps))
+	for i, msccAVP := range avps {
+		grp, canCast := msccAVP.Data.(*diam.GroupedAVP)
+		if !canCast {
+			return nil, fmt.Errorf("invalid %s AVP", msccAVP)
+		}
+		ms := &msccData{unitAVP: msccUnitAVP(tor)}
+		for _, a := range grp.AVP {
+			switch a.Code {
+			case avp.RatingGroup:
+				if ms.ratingGroup, err = diamAVPAsString(a); err != nil {
+					return
+				}
+			case avp.ServiceIdentifier:
+				if ms.serviceIdentifier, err = diamAVPAsString(a); err != nil {
+					return
+				}
+			case avp.RequestedServiceUnit:
+				ms.hasRSU = true
+				if ms.requested, err = ms.parseUnits(a); err != nil {
+					return
+				}
+			case avp.UsedServiceUnit:
+				var used *time.Duration
+				if used, err = ms.parseUnits(a); err != nil {
+					return
+				}
+				if used != nil { // multiple USU can be reported, sum them up
+					if ms.used != nil {
+						*used += *ms.used
+					}
+					ms.used = used
+				}
+			}
+		}
+		mscc[i] = ms
+	}
+	return
+}
+
+// parseUnits returns the usage out of a *-Service-Unit AVP, nil if no units are present
+func (ms *msccData) parseUnits(a *diam.AVP) (usage *time.Duration, err error) {
+	grp, canCast := a.Data.(*diam.GroupedAVP)
+	if !canCast {
+		return nil, fmt.Errorf("invalid %s AVP", a)
+	}
+	var octets *time.Duration
+	for _, u := range grp.AVP {
+		switch u.Code {
+		default:
+			continue
+		case avp.CCTime, avp.CCTotalOctets, avp.CCInputOctets,
+			avp.CCOutputOctets, avp.CCServiceSpecificUnits:
+		}
+		var val string
+		if val, err = diamAVPAsString(u); err != nil {
+			return
+		}
+		var units int64
+		if units, err = strconv.ParseInt(val, 10, 64); err != nil {
+			return
+		}
+		switch u.Code {
+		case avp.CCTime:
+			ms.unitAVP = ccTimeAVP
+			usage = utils.DurationPointer(time.Duration(units) * time.Second)
+		case avp.CCTotalOctets:
+			ms.unitAVP = ccTotalOctetsAVP
+			usage = utils.DurationPointer(time.Duration(units))
+		case avp.CCServiceSpecificUnits:
+			ms.unitAVP = ccServiceSpecUnitsAVP
+			usage = utils.DurationPointer(time.Duration(units))
+		default: // input and output octets are considered only without total
+			if octets == nil {
+				octets = utils.DurationPointer(0)
+			}
+			*octets += time.Duration(units)
+		}
+	}
+	if usage == nil && octets != nil {
+		ms.unitAVP = ccTotalOctetsAVP
+		usage = octets
+	}
+	return
+}
+
+// id returns the identifier of the MSCC within the Diameter session
+func (ms *msccData) id() string {
+	return utils.FirstNonEmpty(ms.ratingGroup, ms.serviceIdentifier)
+}
+
+// childEvent builds the event of the child session charging this MSCC
+func (ms *msccData) childEvent(cgrEv *utils.CGREvent) (ev *utils.CGREvent) {
+	ev = cgrEv.Clone()
+	if id := ms.id(); id != utils.EmptyString {
+		ev.ID = utils.ConcatenatedKey(cgrEv.ID, id)
+		ev.Event[utils.OriginID] = utils.ConcatenatedKey(
+			utils.IfaceAsString(cgrEv.Event[utils.OriginID]), id)
+	}
+	delete(ev.Event, utils.CGRID) // computed again by SessionS out of the new OriginID
+	delete(ev.Event, utils.Usage)
+	delete(ev.Event, utils.LastUsed)
+	if ms.ratingGroup != utils.EmptyString {
+		ev.Event[utils.RatingGroup] = ms.ratingGroup
+	}
+	if ms.serviceIdentifier != utils.EmptyString {
+		ev.Event[utils.ServiceIdentifier] = ms.serviceIdentifier
+	}
+	if ms.requested != nil {
+		ev.Event[utils.Usage] = *ms.requested
+	}
+	if ms.used != nil {
+		ev.Event[utils.LastUsed] = *ms.used
+	}
+	return
+}
+
+// grantedUnits converts the usage into the units of the MSCC
+func (ms *msccData) grantedUnits(usage time.Duration) string {
+	if ms.unitAVP == ccTimeAVP {
+		return strconv.FormatInt(int64(usage/time.Second), 10)
+	}
+	return strconv.FormatInt(int64(usage), 10)
+}
+
+// msccResultCode returns the Result-Code of the MSCC based on the SessionS answer
+func msccResultCode(maxUsage *time.Duration, err error) string {
+	switch {
+	case err != nil && (utils.ErrHasPrefix(err, utils.RalsErrorPrfx) ||
+		strings.Contains(err.Error(), utils.ErrInsufficientCredit.Error())):
+		return strconv.Itoa(diamCreditLimitReached)
+	case err != nil:
+		return strconv.Itoa(diam.UnableToComply)
+	case maxUsage != nil && *maxUsage == 0:
+		return strconv.Itoa(diamCreditLimitReached)
+	default:
+		return strconv.Itoa(diam.Success)
+	}
+}
+
+// redirectAddressType returns the Redirect-Address-Type matching the address
+func redirectAddressType(addr string) string {
+	switch {
+	case strings.HasPrefix(addr, "sip:") || strings.HasPrefix(addr, "sips:"):
+		return "3" // SIP URI
+	case net.ParseIP(addr) == nil:
+		return "2" // URL
+	case net.ParseIP(addr).To4() != nil:
+		return "0" // IPv4 Address
+	default:
+		return "1" // IPv6 Address
+	}
+}
+
+// setReply appends the MSCC answer to the reply
+func (ms *msccData) setReply(rply *utils.OrderedNavigableMap, reqType string,
+	maxUsage *time.Duration, errRply error, daCfg *config.DiameterAgentCfg) (err error) {
+	newBranch := true // first AVP starts a new MSCC
+	appendAVP := func(val string, path ...string) (err error) {
+		err = appendDiamAVP(rply, val, newBranch, append([]string{msccAVP}, path...)...)
+		newBranch = false
+		return
+	}
+	if ms.ratingGroup != utils.EmptyString {
+		if err = appendAVP(ms.ratingGroup, ratingGroupAVP); err != nil {
+			return
+		}
+	}
+	if ms.serviceIdentifier != utils.EmptyString {
+		if err = appendAVP(ms.serviceIdentifier, serviceIdentifierAVP); err != nil {
+			return
+		}
+	}
+	granted := errRply == nil && reqType != utils.MetaTerminate &&
+		maxUsage != nil && *maxUsage != 0
+	if granted {
+		if err = appendAVP(ms.grantedUnits(*maxUsage),
+			grantedServiceUnitAVP, ms.unitAVP); err != nil {
+			return
+		}
+		if daCfg.MSCCValidityTime != 0 {
+			if err = appendAVP(strconv.FormatInt(int64(daCfg.MSCCValidityTime/time.Second), 10),
+				validityTimeAVP); err != nil {
+				return
+			}
+		}
+	}
+	if err = appendAVP(msccResultCode(maxUsage, errRply), resultCodeAVP); err != nil {
+		return
+	}
+	if !granted || ms.requested == nil || *maxUsage >= *ms.requested {
+		return
+	}
+	// less than requested was granted, these are the final units
+	if daCfg.FinalUnitAction != utils.MetaRedirect {
+		return appendAVP(finalUnitActionTerminate, finalUnitIndicationAVP, finalUnitActionAVP)
+	}
+	if err = appendAVP(finalUnitActionRedirect, finalUnitIndicationAVP, finalUnitActionAVP); err != nil {
+		return
+	}
+	if err = appendAVP(redirectAddressType(daCfg.RedirectServer),
+		finalUnitIndicationAVP, redirectServerAVP, redirectAddressTypeAVP); err != nil {
+		return
+	}
+	return appendAVP(daCfg.RedirectServer,
+		finalUnitIndicationAVP, redirectServerAVP, redirectServerAddrAVP)
+}
+
+// isMSCCFinal checks if a request without MSCC ends all the child sessions of the
+// Diameter session: a CCR-T or a CCR-U with Termination-Cause or a FINAL Reporting-Reason
+func isMSCCFinal(m *diam.Message, reqType string) bool {
+	switch reqType {
+	case utils.MetaTerminate:
+		return true
+	case utils.MetaUpdate:
+	default:
+		return false
+	}
+	if avps, err := m.FindAVPsWithPath([]interface{}{avp.TerminationCause},
+		dict.UndefinedVendorID); err == nil && len(avps) != 0 {
+		return true
+	}
+	avps, err := m.FindAVPsWithPath([]interface{}{avp.ReportingReason}, dict.UndefinedVendorID)
+	if err != nil || len(avps) == 0 {
+		return false
+	}
+	reason, err := diamAVPAsString(avps[0])
+	return err == nil && reason == reportingReasonFinal
+}
+
+// msccParentID returns the Diameter Session-Id of a MSCC child session
+func msccParentID(originID string) string {
+	if idx := strings.LastIndex(originID, utils.CONCATENATED_KEY_SEP); idx != -1 {
+		return originID[:idx]
+	}
+	return originID
+}
@@ -0,0 +1,227 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package agents
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/diam"
+	"github.com/fiorix/go-diameter/diam/avp"
+	"github.com/fiorix/go-diameter/diam/datatype"
+	"github.com/fiorix/go-diameter/diam/dict"
+)
+
+func TestMSCCFromDiamMessage(t *testing.T) {
+	m := diam.NewRequest(diam.CreditControl, 4, nil)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("simuhuawei;1449573472;00002"))
+	m.NewAVP(avp.MultipleServicesCreditControl, avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.RatingGroup, avp.Mbit, 0, datatype.Unsigned32(1)),
+			diam.NewAVP(avp.RequestedServiceUnit, avp.Mbit, 0, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.CCTime, avp.Mbit, 0, datatype.Unsigned32(300)),
+				}}),
+			diam.NewAVP(avp.UsedServiceUnit, avp.Mbit, 0, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.CCTime, avp.Mbit, 0, datatype.Unsigned32(250)),
+				}}),
+		}})
+	m.NewAVP(avp.MultipleServicesCreditControl, avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.ServiceIdentifier, avp.Mbit, 0, datatype.Unsigned32(2)),
+			diam.NewAVP(avp.UsedServiceUnit, avp.Mbit, 0, &diam.GroupedAVP{
+				AVP: []*diam.AVP{
+					diam.NewAVP(avp.CCInputOctets, avp.Mbit, 0, datatype.Unsigned64(1000)),
+					diam.NewAVP(avp.CCOutputOctets, avp.Mbit, 0, datatype.Unsigned64(24)),
+				}}),
+		}})
+	m.NewAVP(avp.MultipleServicesCreditControl, avp.Mbit, 0, &diam.GroupedAVP{
+		AVP: []*diam.AVP{
+			diam.NewAVP(avp.RatingGroup, avp.Mbit, 0, datatype.Unsigned32(3)),
+			diam.NewAVP(avp.RequestedServiceUnit, avp.Mbit, 0, &diam.GroupedAVP{}),
+		}})
+	eMSCC := []*msccData{
+		{
+			ratingGroup: "1",
+			unitAVP:     ccTimeAVP,
+			hasRSU:      true,
+			requested:   utils.DurationPointer(5 * time.Minute),
+			used:        utils.DurationPointer(250 * time.Second),
+		},
+		{
+			serviceIdentifier: "2",
+			unitAVP:           ccTotalOctetsAVP,
+			used:              utils.DurationPointer(1024),
+		},
+		{
+			ratingGroup: "3",
+			unitAVP:     ccTotalOctetsAVP,
+			hasRSU:      true,
+		},
+	}
+	if mscc, err := msccFromDiamMessage(m, utils.DATA); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eMSCC, mscc) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eMSCC), utils.ToJSON(mscc))
+	}
+	m = diam.NewRequest(diam.CreditControl, 4, nil)
+	if mscc, err := msccFromDiamMessage(m, utils.VOICE); err != nil {
+		t.Error(err)
+	} else if len(mscc) != 0 {
+		t.Errorf("Expecting no MSCC, received: %+v", utils.ToJSON(mscc))
+	}
+}
+
+func TestMSCCChildEvent(t *testing.T) {
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "Ev1",
+		Event: map[string]interface{}{
+			utils.CGRID:    "cgrid1",
+			utils.OriginID: "simuhuawei;1449573472;00002",
+			utils.ToR:      utils.DATA,
+			utils.Usage:    time.Minute,
+		},
+	}
+	ms := &msccData{
+		ratingGroup: "1",
+		unitAVP:     ccTotalOctetsAVP,
+		hasRSU:      true,
+		requested:   utils.DurationPointer(1000),
+		used:        utils.DurationPointer(300),
+	}
+	eEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "Ev1:1",
+		Event: map[string]interface{}{
+			utils.OriginID:    "simuhuawei;1449573472;00002:1",
+			utils.ToR:         utils.DATA,
+			utils.RatingGroup: "1",
+			utils.Usage:       time.Duration(1000),
+			utils.LastUsed:    time.Duration(300),
+		},
+	}
+	if rcv := ms.childEvent(cgrEv); !reflect.DeepEqual(eEv, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eEv), utils.ToJSON(rcv))
+	}
+	if _, has := cgrEv.Event[utils.RatingGroup]; has {
+		t.Error("parent event modified")
+	}
+	if rcv := msccParentID(eEv.Event[utils.OriginID].(string)); rcv != "simuhuawei;1449573472;00002" {
+		t.Errorf("Expecting: simuhuawei;1449573472;00002, received: %s", rcv)
+	}
+}
+
+func TestMSCCSetReply(t *testing.T) {
+	daCfg := &config.DiameterAgentCfg{
+		MSCCValidityTime: time.Hour,
+		FinalUnitAction:  utils.MetaRedirect,
+		RedirectServer:   "http://cgrates.org/topup",
+	}
+	rply := utils.NewOrderedNavigableMap()
+	ms1 := &msccData{
+		ratingGroup: "1",
+		unitAVP:     ccTimeAVP,
+		hasRSU:      true,
+		requested:   utils.DurationPointer(5 * time.Minute),
+	}
+	if err := ms1.setReply(rply, utils.MetaUpdate,
+		utils.DurationPointer(time.Minute), nil, daCfg); err != nil {
+		t.Fatal(err)
+	}
+	ms2 := &msccData{
+		ratingGroup: "2",
+		unitAVP:     ccTotalOctetsAVP,
+		hasRSU:      true,
+	}
+	if err := ms2.setReply(rply, utils.MetaUpdate,
+		nil, utils.NewErrRALs(utils.ErrInsufficientCredit), daCfg); err != nil {
+		t.Fatal(err)
+	}
+	m := diam.NewRequest(diam.CreditControl, 4, nil)
+	if err := updateDiamMsgFromNavMap(m, rply, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	eMSCC := []*diam.AVP{
+		diam.NewAVP(avp.MultipleServicesCreditControl, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.RatingGroup, avp.Mbit, 0, datatype.Unsigned32(1)),
+				diam.NewAVP(avp.GrantedServiceUnit, avp.Mbit, 0, &diam.GroupedAVP{
+					AVP: []*diam.AVP{
+						diam.NewAVP(avp.CCTime, avp.Mbit, 0, datatype.Unsigned32(60)),
+					}}),
+				diam.NewAVP(avp.ValidityTime, avp.Mbit, 0, datatype.Unsigned32(3600)),
+				diam.NewAVP(avp.ResultCode, avp.Mbit, 0, datatype.Unsigned32(2001)),
+				diam.NewAVP(avp.FinalUnitIndication, avp.Mbit, 0, &diam.GroupedAVP{
+					AVP: []*diam.AVP{
+						diam.NewAVP(avp.FinalUnitAction, avp.Mbit, 0, datatype.Enumerated(1)),
+						diam.NewAVP(avp.RedirectServer, avp.Mbit, 0, &diam.GroupedAVP{
+							AVP: []*diam.AVP{
+								diam.NewAVP(avp.RedirectAddressType, avp.Mbit, 0, datatype.Enumerated(2)),
+								diam.NewAVP(avp.RedirectServerAddress, avp.Mbit, 0, datatype.UTF8String("http://cgrates.org/topup")),
+							}}),
+					}}),
+			}}),
+		diam.NewAVP(avp.MultipleServicesCreditControl, avp.Mbit, 0, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.RatingGroup, avp.Mbit, 0, datatype.Unsigned32(2)),
+				diam.NewAVP(avp.ResultCode, avp.Mbit, 0, datatype.Unsigned32(4012)),
+			}}),
+	}
+	if avps, err := m.FindAVPsWithPath([]interface{}{avp.MultipleServicesCreditControl},
+		dict.UndefinedVendorID); err != nil {
+		t.Error(err)
+	} else if len(avps) != len(eMSCC) {
+		t.Errorf("Expecting: %+v, received: %+v", eMSCC, avps)
+	} else {
+		for i := range eMSCC {
+			if eMSCC[i].String() != avps[i].String() {
+				t.Errorf("Expecting: %s, received: %s", eMSCC[i], avps[i])
+			}
+		}
+	}
+}
+
+func TestMSCCResultCode(t *testing.T) {
+	if rcv := msccResultCode(utils.DurationPointer(time.Second), nil); rcv != "2001" {
+		t.Errorf("Expecting: 2001, received: %s", rcv)
+	}
+	if rcv := msccResultCode(utils.DurationPointer(0), nil); rcv != "4012" {
+		t.Errorf("Expecting: 4012, received: %s", rcv)
+	}
+	if rcv := msccResultCode(nil, utils.ErrServerError); rcv != "5012" {
+		t.Errorf("Expecting: 5012, received: %s", rcv)
+	}
+}
+
+func TestRedirectAddressType(t *testing.T) {
+	for addr, eTyp := range map[string]string{
+		"10.0.0.1":                 "0",
+		"2001:db8::1":              "1",
+		"http://cgrates.org/topup": "2",
+		"sip:topup@cgrates.org":    "3",
+	} {
+		if rcv := redirectAddressType(addr); rcv != eTyp {
+			t.Errorf("Expecting: %s for %s, received: %s", eTyp, addr, rcv)
+		}
+	}
+}
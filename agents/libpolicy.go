@@ -0,0 +1,162 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package agents
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/diam"
+	"github.com/fiorix/go-diameter/diam/avp"
+	"github.com/fiorix/go-diameter/diam/dict"
+)
+
+// AVPs and applications used in policy mode
+const (
+	rxAppID                  = 16777236
+	chargingRuleInstallAVP   = "Charging-Rule-Install"
+	chargingRuleRemoveAVP    = "Charging-Rule-Remove"
+	chargingRuleNameAVP      = "Charging-Rule-Name"
+	qosInformationAVP        = "QoS-Information"
+	qosClassIdentifierAVP    = "QoS-Class-Identifier"
+	maxReqBandwidthULAVP     = "Max-Requested-Bandwidth-UL"
+	maxReqBandwidthDLAVP     = "Max-Requested-Bandwidth-DL"
+	ccRequestTypeTermination = "3" // TERMINATION_REQUEST
+)
+
+// policyDecision is the policy decided by AttributeS for a subscriber
+type policyDecision struct {
+	install []string // rules which should be active
+	remove  []string // rules which should not be active
+	qci     string
+	mbrUL   string
+	mbrDL   string
+}
+
+// newPolicyDecision builds the policy out of the attributes within CGRReply
+func newPolicyDecision(cgrRply utils.NavigableMap2) (pd *policyDecision) {
+	attr := func(fldName string) string {
+		val, err := cgrRply.FieldAsString([]string{utils.CapAttributes, fldName})
+		if err != nil {
+			return utils.EmptyString
+		}
+		return val
+	}
+	pd = &policyDecision{
+		qci:   attr(utils.QoSClassIdentifier),
+		mbrUL: attr(utils.MaxRequestedBandwidthUL),
+		mbrDL: attr(utils.MaxRequestedBandwidthDL),
+	}
+	if rules := attr(utils.ChargingRuleInstall); rules != utils.EmptyString {
+		pd.install = strings.Split(rules, utils.INFIELD_SEP)
+	}
+	if rules := attr(utils.ChargingRuleRemove); rules != utils.EmptyString {
+		pd.remove = strings.Split(rules, utils.INFIELD_SEP)
+	}
+	return
+}
+
+// gxPolicy is the policy state of a Gx session
+type gxPolicy struct {
+	cgrEv    *utils.CGREvent
+	opts     map[string]interface{}
+	attrIDs  []string
+	argDisp  *utils.ArgDispatcher
+	decision *policyDecision     // last decision received from AttributeS
+	afRules  map[string][]string // rules requested by AF sessions over Rx, indexed on their Session-Id
+	rules    utils.StringSet     // rules installed on the PCEF
+}
+
+// update computes the rules to be installed and removed on the PCEF
+func (gp *gxPolicy) update() (install, remove []string) {
+	want := utils.NewStringSet(nil)
+	if gp.decision != nil {
+		want.AddSlice(gp.decision.install)
+	}
+	for _, rules := range gp.afRules {
+		want.AddSlice(rules)
+	}
+	if gp.decision != nil {
+		for _, rule := range gp.decision.remove {
+			want.Remove(rule)
+		}
+	}
+	for rule := range want {
+		if !gp.rules.Has(rule) {
+			install = append(install, rule)
+		}
+	}
+	for rule := range gp.rules {
+		if !want.Has(rule) {
+			remove = append(remove, rule)
+		}
+	}
+	sort.Strings(install)
+	sort.Strings(remove)
+	gp.rules = want
+	return
+}
+
+// setPolicyAVPs writes the charging rules and QoS into the navigable map used to build the Diameter message
+func setPolicyAVPs(nm *utils.OrderedNavigableMap, install, remove []string, pd *policyDecision) (err error) {
+	for i, rule := range remove {
+		if err = appendDiamAVP(nm, rule, i == 0,
+			chargingRuleRemoveAVP, chargingRuleNameAVP); err != nil {
+			return
+		}
+	}
+	for i, rule := range install {
+		if err = appendDiamAVP(nm, rule, i == 0,
+			chargingRuleInstallAVP, chargingRuleNameAVP); err != nil {
+			return
+		}
+	}
+	if pd == nil {
+		return
+	}
+	newBranch := true
+	for _, qos := range [][2]string{
+		{pd.qci, qosClassIdentifierAVP},
+		{pd.mbrUL, maxReqBandwidthULAVP},
+		{pd.mbrDL, maxReqBandwidthDLAVP}} {
+		if qos[0] == utils.EmptyString {
+			continue
+		}
+		if err = appendDiamAVP(nm, qos[0], newBranch,
+			qosInformationAVP, qos[1]); err != nil {
+			return
+		}
+		newBranch = false
+	}
+	return
+}
+
+// isPolicyTermination checks if the request ends the policy session (CCR-T or STR)
+func isPolicyTermination(m *diam.Message, reqType string) bool {
+	if reqType == utils.MetaTerminate ||
+		m.Header.CommandCode == diam.SessionTermination {
+		return true
+	}
+	avps, err := m.FindAVPsWithPath([]interface{}{avp.CCRequestType}, dict.UndefinedVendorID)
+	if err != nil || len(avps) == 0 {
+		return false
+	}
+	reqTypeVal, err := diamAVPAsString(avps[0])
+	return err == nil && reqTypeVal == ccRequestTypeTermination
+}
@@ -0,0 +1,205 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package agents
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/fiorix/go-diameter/diam"
+	"github.com/fiorix/go-diameter/diam/avp"
+	"github.com/fiorix/go-diameter/diam/datatype"
+)
+
+func TestNewPolicyDecision(t *testing.T) {
+	cgrRply := utils.NavigableMap2{
+		utils.CapAttributes: utils.NavigableMap2{
+			utils.ChargingRuleInstall:     utils.NewNMData("RULE_DEFAULT;RULE_VIDEO"),
+			utils.ChargingRuleRemove:      utils.NewNMData("RULE_FULLSPEED"),
+			utils.QoSClassIdentifier:      utils.NewNMData("9"),
+			utils.MaxRequestedBandwidthDL: utils.NewNMData("256000"),
+		},
+		utils.Error: utils.NewNMData(""),
+	}
+	ePd := &policyDecision{
+		install: []string{"RULE_DEFAULT", "RULE_VIDEO"},
+		remove:  []string{"RULE_FULLSPEED"},
+		qci:     "9",
+		mbrDL:   "256000",
+	}
+	if pd := newPolicyDecision(cgrRply); !reflect.DeepEqual(ePd, pd) {
+		t.Errorf("Expecting: %+v, received: %+v", ePd, pd)
+	}
+	if pd := newPolicyDecision(utils.NavigableMap2{}); !reflect.DeepEqual(&policyDecision{}, pd) {
+		t.Errorf("Expecting empty policy, received: %+v", pd)
+	}
+}
+
+func TestGxPolicyUpdate(t *testing.T) {
+	gp := &gxPolicy{
+		decision: &policyDecision{install: []string{"RULE_FULLSPEED", "RULE_VIDEO"}},
+		afRules:  make(map[string][]string),
+		rules:    utils.NewStringSet(nil),
+	}
+	if install, remove := gp.update(); !reflect.DeepEqual([]string{"RULE_FULLSPEED", "RULE_VIDEO"}, install) {
+		t.Errorf("Received install: %+v", install)
+	} else if len(remove) != 0 {
+		t.Errorf("Received remove: %+v", remove)
+	}
+	// balance depleted, throttle
+	gp.decision = &policyDecision{
+		install: []string{"RULE_THROTTLE", "RULE_VIDEO"},
+		remove:  []string{"RULE_FULLSPEED"},
+	}
+	gp.afRules["rxSession1"] = []string{"RULE_VOLTE"}
+	if install, remove := gp.update(); !reflect.DeepEqual([]string{"RULE_THROTTLE", "RULE_VOLTE"}, install) {
+		t.Errorf("Received install: %+v", install)
+	} else if !reflect.DeepEqual([]string{"RULE_FULLSPEED"}, remove) {
+		t.Errorf("Received remove: %+v", remove)
+	}
+	delete(gp.afRules, "rxSession1")
+	if install, remove := gp.update(); len(install) != 0 {
+		t.Errorf("Received install: %+v", install)
+	} else if !reflect.DeepEqual([]string{"RULE_VOLTE"}, remove) {
+		t.Errorf("Received remove: %+v", remove)
+	}
+	if eRules := utils.NewStringSet([]string{"RULE_THROTTLE", "RULE_VIDEO"}); !reflect.DeepEqual(eRules, gp.rules) {
+		t.Errorf("Expecting: %+v, received: %+v", eRules, gp.rules)
+	}
+}
+
+func TestSetPolicyAVPs(t *testing.T) {
+	nm := utils.NewOrderedNavigableMap()
+	if err := setPolicyAVPs(nm, []string{"RULE_THROTTLE", "RULE_VIDEO"}, []string{"RULE_FULLSPEED"},
+		&policyDecision{qci: "9", mbrUL: "128000", mbrDL: "256000"}); err != nil {
+		t.Fatal(err)
+	}
+	m := diam.NewRequest(diam.CreditControl, 16777238, nil)
+	if err := updateDiamMsgFromNavMap(m, nm, utils.EmptyString); err != nil {
+		t.Fatal(err)
+	}
+	eAVPs := []*diam.AVP{
+		diam.NewAVP(avp.ChargingRuleRemove, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.ChargingRuleName, avp.Mbit|avp.Vbit, 10415, datatype.OctetString("RULE_FULLSPEED")),
+			}}),
+		diam.NewAVP(avp.ChargingRuleInstall, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.ChargingRuleName, avp.Mbit|avp.Vbit, 10415, datatype.OctetString("RULE_THROTTLE")),
+				diam.NewAVP(avp.ChargingRuleName, avp.Mbit|avp.Vbit, 10415, datatype.OctetString("RULE_VIDEO")),
+			}}),
+		diam.NewAVP(avp.QoSInformation, avp.Mbit|avp.Vbit, 10415, &diam.GroupedAVP{
+			AVP: []*diam.AVP{
+				diam.NewAVP(avp.QoSClassIdentifier, avp.Mbit|avp.Vbit, 10415, datatype.Enumerated(9)),
+				diam.NewAVP(avp.MaxRequestedBandwidthUL, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(128000)),
+				diam.NewAVP(avp.MaxRequestedBandwidthDL, avp.Mbit|avp.Vbit, 10415, datatype.Unsigned32(256000)),
+			}}),
+	}
+	if len(m.AVP) != len(eAVPs) {
+		t.Fatalf("Expecting: %+v, received: %+v", eAVPs, m.AVP)
+	}
+	for i := range eAVPs {
+		if eAVPs[i].String() != m.AVP[i].String() {
+			t.Errorf("Expecting: %s, received: %s", eAVPs[i], m.AVP[i])
+		}
+	}
+}
+
+func TestIsPolicyTermination(t *testing.T) {
+	m := diam.NewRequest(diam.CreditControl, 16777238, nil)
+	m.NewAVP(avp.CCRequestType, avp.Mbit, 0, datatype.Enumerated(2))
+	if isPolicyTermination(m, utils.META_NONE) {
+		t.Error("CCR-U considered termination")
+	}
+	if !isPolicyTermination(m, utils.MetaTerminate) {
+		t.Error("*terminate not considered termination")
+	}
+	m = diam.NewRequest(diam.CreditControl, 16777238, nil)
+	m.NewAVP(avp.CCRequestType, avp.Mbit, 0, datatype.Enumerated(3))
+	if !isPolicyTermination(m, utils.META_NONE) {
+		t.Error("CCR-T not considered termination")
+	}
+	if m = diam.NewRequest(diam.SessionTermination, rxAppID, nil); !isPolicyTermination(m, utils.META_NONE) {
+		t.Error("STR not considered termination")
+	}
+}
+
+func TestDiameterAgentProcessPolicy(t *testing.T) {
+	da := &DiameterAgent{
+		cgrCfg:   config.CgrConfig(),
+		policies: make(map[string]*gxPolicy),
+	}
+	reqProcessor := &config.RequestProcessor{}
+	reqProcessor.Flags, _ = utils.FlagsWithParamsFromSlice([]string{utils.MetaEvent, utils.MetaAttributes, utils.MetaPolicy})
+	m := diam.NewRequest(diam.CreditControl, 16777238, nil)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("gx;1449573472;00001"))
+	m.NewAVP(avp.CCRequestType, avp.Mbit, 0, datatype.Enumerated(1))
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "Gx1",
+		Event: map[string]interface{}{
+			utils.OriginID: "gx;1449573472;00001",
+			utils.Account:  "1001",
+		},
+	}
+	cgrRply := utils.NavigableMap2{
+		utils.CapAttributes: utils.NavigableMap2{
+			utils.ChargingRuleInstall: utils.NewNMData("RULE_FULLSPEED"),
+		},
+		utils.Error: utils.NewNMData(""),
+	}
+	rply := utils.NewOrderedNavigableMap()
+	agReq := NewAgentRequest(newDADataProvider(nil, m), nil, &cgrRply, rply, nil,
+		nil, "cgrates.org", utils.EmptyString, nil, nil, nil)
+	if err := da.processPolicy(reqProcessor, utils.MetaEvent, agReq,
+		cgrEv, utils.ExtractedArgs{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := rply.Field(utils.PathItems{{Field: chargingRuleInstallAVP},
+		{Field: chargingRuleNameAVP, Index: utils.StringPointer("0")}}); err != nil {
+		t.Error(err)
+	} else if itm, canCast := rcv.(*config.NMItem); !canCast || itm.Data != "RULE_FULLSPEED" {
+		t.Errorf("Expecting: RULE_FULLSPEED, received: %s", rcv)
+	}
+	gp, has := da.policies["gx;1449573472;00001"]
+	if !has {
+		t.Fatal("policy not stored")
+	}
+	// AF session of the same account requests one more rule
+	da.setAFRules(cgrEv, "rx;1449573472;00002", []string{"RULE_VOLTE"})
+	da.policyLck.Lock()
+	if eRules := utils.NewStringSet([]string{"RULE_FULLSPEED", "RULE_VOLTE"}); !reflect.DeepEqual(eRules, gp.rules) {
+		t.Errorf("Expecting: %+v, received: %+v", eRules, gp.rules)
+	}
+	da.policyLck.Unlock()
+	// CCR-T clears the policy
+	m = diam.NewRequest(diam.CreditControl, 16777238, nil)
+	m.NewAVP(avp.SessionID, avp.Mbit, 0, datatype.UTF8String("gx;1449573472;00001"))
+	m.NewAVP(avp.CCRequestType, avp.Mbit, 0, datatype.Enumerated(3))
+	agReq = NewAgentRequest(newDADataProvider(nil, m), nil, &cgrRply, utils.NewOrderedNavigableMap(), nil,
+		nil, "cgrates.org", utils.EmptyString, nil, nil, nil)
+	if err := da.processPolicy(reqProcessor, utils.MetaEvent, agReq,
+		cgrEv, utils.ExtractedArgs{}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, has := da.policies["gx;1449573472;00001"]; has {
+		t.Error("policy not removed on termination")
+	}
+}
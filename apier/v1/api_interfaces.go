@@ -97,6 +97,7 @@ type SessionSv1Interface interface {
 	DeactivateSessions(args *utils.SessionIDsWithArgsDispatcher, reply *string) error
 
 	STIRAuthenticate(args *sessions.V1STIRAuthenticateArgs, reply *string) error
+	STIRVerify(args *sessions.V1STIRAuthenticateArgs, reply *sessions.STIRVerification) error
 	STIRIdentity(args *sessions.V1STIRIdentityArgs, reply *string) error
 }
 
@@ -169,10 +170,15 @@ type ConfigSv1Interface interface {
 	GetJSONSection(section *config.StringWithArgDispatcher, reply *map[string]interface{}) (err error)
 	ReloadConfigFromPath(section *config.ConfigReloadWithArgDispatcher, reply *string) (err error)
 	ReloadConfigFromJSON(args *config.JSONReloadWithArgDispatcher, reply *string) (err error)
+	GetDBSection(args *config.StringWithArgDispatcher, reply *engine.ConfigSection) (err error)
+	GetDBSectionVersions(args *config.StringWithArgDispatcher, reply *map[string]int64) (err error)
+	SetDBSection(args *config.DBSectionWithArgDispatcher, reply *string) (err error)
+	RemoveDBSection(args *config.StringWithArgDispatcher, reply *string) (err error)
 }
 
 type CoreSv1Interface interface {
 	Status(arg *utils.TenantWithArgDispatcher, reply *map[string]interface{}) error
+	RequestLimiterMetrics(arg *utils.TenantWithArgDispatcher, reply *map[string]*utils.RequestLimiterMetrics) error
 	Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error
 }
 
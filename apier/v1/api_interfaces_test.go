@@ -96,7 +96,7 @@ func TestRALsV1Interface(t *testing.T) {
 
 func TestConfigSv1Interface(t *testing.T) {
 	_ = ConfigSv1Interface(NewDispatcherConfigSv1(nil))
-	_ = ConfigSv1Interface(NewConfigSv1(nil))
+	_ = ConfigSv1Interface(NewConfigSv1(nil, nil))
 }
 
 func TestCoreSv1Interface(t *testing.T) {
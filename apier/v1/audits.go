@@ -0,0 +1,51 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNEtS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewAuditSv1 initializes AuditSv1
+func NewAuditSv1(aS *engine.AuditS) *AuditSv1 {
+	return &AuditSv1{aS: aS}
+}
+
+// AuditSv1 exports RPC from AuditS
+type AuditSv1 struct {
+	aS *engine.AuditS
+}
+
+// Call implements rpcclient.ClientConnector interface for internal RPC
+func (aSv1 *AuditSv1) Call(serviceMethod string,
+	args interface{}, reply interface{}) error {
+	return utils.APIerRPCCall(aSv1, serviceMethod, args, reply)
+}
+
+// Ping return pong if the service is active
+func (aSv1 *AuditSv1) Ping(ign *utils.CGREvent, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
+
+// GetAuditRecords returns the audit records of a tenant matching the filter
+func (aSv1 *AuditSv1) GetAuditRecords(args *utils.AuditRecordsFilter, reply *[]*engine.AuditRecord) error {
+	return aSv1.aS.V1GetAuditRecords(args, reply)
+}
@@ -20,17 +20,19 @@ package v1
 
 import (
 	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
 
 // NewConfigSv1 returns a new ConfigSv1
-func NewConfigSv1(cfg *config.CGRConfig) *ConfigSv1 {
-	return &ConfigSv1{cfg: cfg}
+func NewConfigSv1(cfg *config.CGRConfig, cdbS *engine.ConfigDBS) *ConfigSv1 {
+	return &ConfigSv1{cfg: cfg, cdbS: cdbS}
 }
 
 // ConfigSv1 exports RPC for config
 type ConfigSv1 struct {
-	cfg *config.CGRConfig
+	cfg  *config.CGRConfig
+	cdbS *engine.ConfigDBS
 }
 
 // GetJSONSection will retrieve from CGRConfig a section
@@ -48,6 +50,26 @@ func (cSv1 *ConfigSv1) ReloadConfigFromJSON(args *config.JSONReloadWithArgDispat
 	return cSv1.cfg.V1ReloadConfigFromJSON(args, reply)
 }
 
+// GetDBSection returns the configuration section stored in DataDB
+func (cSv1 *ConfigSv1) GetDBSection(args *config.StringWithArgDispatcher, reply *engine.ConfigSection) (err error) {
+	return cSv1.cdbS.V1GetSection(args, reply)
+}
+
+// GetDBSectionVersions returns the versions of the sections stored in DataDB
+func (cSv1 *ConfigSv1) GetDBSectionVersions(args *config.StringWithArgDispatcher, reply *map[string]int64) (err error) {
+	return cSv1.cdbS.V1GetSectionVersions(args, reply)
+}
+
+// SetDBSection stores the configuration section in DataDB, shared with the other engines
+func (cSv1 *ConfigSv1) SetDBSection(args *config.DBSectionWithArgDispatcher, reply *string) (err error) {
+	return cSv1.cdbS.V1SetSection(args, reply)
+}
+
+// RemoveDBSection removes the configuration section out of DataDB
+func (cSv1 *ConfigSv1) RemoveDBSection(args *config.StringWithArgDispatcher, reply *string) (err error) {
+	return cSv1.cdbS.V1RemoveSection(args, reply)
+}
+
 // Call implements rpcclient.ClientConnector interface for internal RPC
 func (cSv1 *ConfigSv1) Call(serviceMethod string,
 	args interface{}, reply interface{}) error {
@@ -44,6 +44,12 @@ func (cS *CoreSv1) Status(arg *utils.TenantWithArgDispatcher, reply *map[string]
 	return cS.cS.Status(arg, reply)
 }
 
+// RequestLimiterMetrics returns the active, queued, allowed and rejected requests of each request limiter
+func (cS *CoreSv1) RequestLimiterMetrics(arg *utils.TenantWithArgDispatcher,
+	reply *map[string]*utils.RequestLimiterMetrics) error {
+	return cS.cS.RequestLimiterMetrics(arg, reply)
+}
+
 // Ping used to determinate if component is active
 func (cS *CoreSv1) Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error {
 	*reply = utils.Pong
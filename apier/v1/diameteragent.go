@@ -0,0 +1,47 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewDiameterAgentV1 returns the API object for DiameterAgent
+func NewDiameterAgentV1(da *agents.DiameterAgent) *DiameterAgentV1 {
+	return &DiameterAgentV1{da: da}
+}
+
+// DiameterAgentV1 exports the DiameterAgent methods over RPC
+type DiameterAgentV1 struct {
+	da *agents.DiameterAgent
+}
+
+// Ping return pong if the service is active
+func (dAv1 *DiameterAgentV1) Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
+
+// SendRequest builds a Diameter request out of the template and sends it to the upstream peers,
+// returning the AVPs of the answer
+func (dAv1 *DiameterAgentV1) SendRequest(args *utils.DiamRequestArgs,
+	reply *map[string]interface{}) error {
+	return dAv1.da.V1SendRequest(args, reply)
+}
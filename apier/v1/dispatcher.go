@@ -523,6 +523,9 @@ func (dS *DispatcherSessionSv1) SyncSessions(args *utils.TenantWithArgDispatcher
 func (dS *DispatcherSessionSv1) STIRAuthenticate(args *sessions.V1STIRAuthenticateArgs, reply *string) error {
 	return dS.dS.SessionSv1STIRAuthenticate(args, reply)
 }
+func (dS *DispatcherSessionSv1) STIRVerify(args *sessions.V1STIRAuthenticateArgs, reply *sessions.STIRVerification) error {
+	return dS.dS.SessionSv1STIRVerify(args, reply)
+}
 func (dS *DispatcherSessionSv1) STIRIdentity(args *sessions.V1STIRIdentityArgs, reply *string) error {
 	return dS.dS.SessionSv1STIRIdentity(args, reply)
 }
@@ -819,6 +822,22 @@ func (dS *DispatcherConfigSv1) ReloadConfigFromJSON(args *config.JSONReloadWithA
 	return dS.dS.ConfigSv1ReloadConfigFromJSON(args, reply)
 }
 
+func (dS *DispatcherConfigSv1) GetDBSection(args *config.StringWithArgDispatcher, reply *engine.ConfigSection) (err error) {
+	return dS.dS.ConfigSv1GetDBSection(args, reply)
+}
+
+func (dS *DispatcherConfigSv1) GetDBSectionVersions(args *config.StringWithArgDispatcher, reply *map[string]int64) (err error) {
+	return dS.dS.ConfigSv1GetDBSectionVersions(args, reply)
+}
+
+func (dS *DispatcherConfigSv1) SetDBSection(args *config.DBSectionWithArgDispatcher, reply *string) (err error) {
+	return dS.dS.ConfigSv1SetDBSection(args, reply)
+}
+
+func (dS *DispatcherConfigSv1) RemoveDBSection(args *config.StringWithArgDispatcher, reply *string) (err error) {
+	return dS.dS.ConfigSv1RemoveDBSection(args, reply)
+}
+
 func NewDispatcherCoreSv1(dps *dispatchers.DispatcherService) *DispatcherCoreSv1 {
 	return &DispatcherCoreSv1{dS: dps}
 }
@@ -832,6 +851,11 @@ func (dS *DispatcherCoreSv1) Status(args *utils.TenantWithArgDispatcher, reply *
 	return dS.dS.CoreSv1Status(args, reply)
 }
 
+func (dS *DispatcherCoreSv1) RequestLimiterMetrics(args *utils.TenantWithArgDispatcher,
+	reply *map[string]*utils.RequestLimiterMetrics) error {
+	return dS.dS.CoreSv1RequestLimiterMetrics(args, reply)
+}
+
 // Ping used to detreminate if component is active
 func (dS *DispatcherCoreSv1) Ping(args *utils.CGREventWithArgDispatcher, reply *string) error {
 	return dS.dS.CoreSv1Ping(args, reply)
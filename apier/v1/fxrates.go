@@ -0,0 +1,83 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AttrFxRate identifies the FxRate between two currencies
+type AttrFxRate struct {
+	FromCurrency string
+	ToCurrency   string
+}
+
+// GetFxRate returns the FxRate defined between two currencies
+func (apierSv1 *APIerSv1) GetFxRate(arg *AttrFxRate, reply *engine.FxRate) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.FromCurrency, utils.ToCurrency}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	fx, err := apierSv1.DataManager.GetFxRate(utils.ConcatenatedKey(arg.FromCurrency, arg.ToCurrency),
+		true, utils.NonTransactional)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *fx
+	return nil
+}
+
+// SetFxRate add/update the FxRate between two currencies
+func (apierSv1 *APIerSv1) SetFxRate(arg *engine.FxRate, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.FromCurrency, utils.ToCurrency, utils.FxRate}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierSv1.DataManager.SetFxRate(arg); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	return apierSv1.fxRateUpdated(arg.ID(), reply)
+}
+
+// RemoveFxRate removes the FxRate defined between two currencies
+func (apierSv1 *APIerSv1) RemoveFxRate(arg *AttrFxRate, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.FromCurrency, utils.ToCurrency}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	fxID := utils.ConcatenatedKey(arg.FromCurrency, arg.ToCurrency)
+	if err := apierSv1.DataManager.RemoveFxRate(fxID, utils.NonTransactional); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	return apierSv1.fxRateUpdated(fxID, reply)
+}
+
+// fxRateUpdated stores the loadID and removes the old FxRate from caches
+func (apierSv1 *APIerSv1) fxRateUpdated(fxID string, reply *string) error {
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheFxRates: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	// FxRates are not part of the cache reload arguments so they are removed and cached again on first use
+	if err := apierSv1.CallCache(utils.MetaRemove, utils.ArgsGetCacheItem{
+		CacheID: utils.CacheFxRates, ItemID: fxID}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
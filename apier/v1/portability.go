@@ -0,0 +1,93 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AttrPortedNumber identifies the ported number or range
+type AttrPortedNumber struct {
+	Number string
+}
+
+// GetPortedNumber returns the PortedNumber stored for the number or range
+func (apierSv1 *APIerSv1) GetPortedNumber(arg *AttrPortedNumber, reply *engine.PortedNumber) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.Number}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	pn, err := apierSv1.DataManager.GetPortedNumber(arg.Number, true, utils.NonTransactional)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *pn
+	return nil
+}
+
+// LookupPortedNumber returns the PortedNumber covering the number, out of single numbers and ranges
+func (apierSv1 *APIerSv1) LookupPortedNumber(number *string, reply *engine.PortedNumber) error {
+	if *number == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.Number)
+	}
+	pn, err := apierSv1.DataManager.LookupPortedNumber(*number)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *pn
+	return nil
+}
+
+// SetPortedNumber add/update the PortedNumber
+func (apierSv1 *APIerSv1) SetPortedNumber(arg *engine.PortedNumber, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.Number, utils.LRN}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierSv1.DataManager.SetPortedNumber(arg); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	return apierSv1.portedNumberUpdated(arg.ID(), reply)
+}
+
+// RemovePortedNumber removes the PortedNumber
+func (apierSv1 *APIerSv1) RemovePortedNumber(arg *AttrPortedNumber, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.Number}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierSv1.DataManager.RemovePortedNumber(arg.Number, utils.NonTransactional); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	return apierSv1.portedNumberUpdated(arg.Number, reply)
+}
+
+// portedNumberUpdated stores the loadID and lets the engines using it update their lookup index
+func (apierSv1 *APIerSv1) portedNumberUpdated(pnID string, reply *string) error {
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CachePortedNumbers: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	// removing the item from cache reloads it from dataDB into the lookup index
+	if err := apierSv1.CallCache(utils.MetaRemove, utils.ArgsGetCacheItem{
+		CacheID: utils.CachePortedNumbers, ItemID: pnID}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
@@ -0,0 +1,87 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cenkalti/rpc2"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewPubSubSv1 initializes PubSubSv1
+func NewPubSubSv1(ps *engine.PubSubService) *PubSubSv1 {
+	return &PubSubSv1{ps: ps}
+}
+
+// PubSubSv1 exports RPC from PubSubS
+type PubSubSv1 struct {
+	ps *engine.PubSubService
+}
+
+// Call implements rpcclient.ClientConnector interface for internal RPC
+func (psv1 *PubSubSv1) Call(serviceMethod string,
+	args interface{}, reply interface{}) error {
+	return utils.APIerRPCCall(psv1, serviceMethod, args, reply)
+}
+
+// Ping return pong if the service is active
+func (psv1 *PubSubSv1) Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
+
+// Publish pushes the event towards the matching subscribers
+func (psv1 *PubSubSv1) Publish(args *utils.CGREventWithArgDispatcher, reply *string) error {
+	return psv1.ps.V1Publish(args, reply)
+}
+
+// Handlers returns the bidirectional JSON methods
+func (psv1 *PubSubSv1) Handlers() map[string]interface{} {
+	return map[string]interface{}{
+		utils.PubSubSv1Subscribe:   psv1.BiRPCv1Subscribe,
+		utils.PubSubSv1Unsubscribe: psv1.BiRPCv1Unsubscribe,
+		utils.PubSubSv1Publish:     psv1.BiRPCv1Publish,
+		utils.PubSubSv1Ping:        psv1.BiRPCPing,
+	}
+}
+
+// BiRPCv1Subscribe subscribes the client for the events matching the filters
+// replying with the subscription ID
+func (psv1 *PubSubSv1) BiRPCv1Subscribe(clnt *rpc2.Client,
+	args *engine.ArgsPubSubSubscribe, reply *string) error {
+	return psv1.ps.BiRPCv1Subscribe(clnt, args, reply)
+}
+
+// BiRPCv1Unsubscribe removes the subscription
+func (psv1 *PubSubSv1) BiRPCv1Unsubscribe(clnt *rpc2.Client,
+	args *utils.TenantID, reply *string) error {
+	return psv1.ps.BiRPCv1Unsubscribe(clnt, args, reply)
+}
+
+// BiRPCv1Publish pushes the event towards the matching subscribers
+func (psv1 *PubSubSv1) BiRPCv1Publish(clnt *rpc2.Client,
+	args *utils.CGREventWithArgDispatcher, reply *string) error {
+	return psv1.Publish(args, reply)
+}
+
+// BiRPCPing return pong if the service is active
+func (psv1 *PubSubSv1) BiRPCPing(clnt *rpc2.Client,
+	ign *utils.CGREventWithArgDispatcher, reply *string) error {
+	return psv1.Ping(ign, reply)
+}
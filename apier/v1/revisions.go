@@ -0,0 +1,126 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNEtS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetProfileRevisions returns the revision history of a profile
+func (apierSv1 *APIerSv1) GetProfileRevisions(args *utils.ArgsProfileRevisions,
+	reply *engine.ProfileRevisions) error {
+	if missing := utils.MissingStructFields(args, []string{utils.ItemType, utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	prvs, err := apierSv1.DataManager.GetProfileRevisions(args.ItemType, tnt, args.ID)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *prvs
+	return nil
+}
+
+// GetProfileRevisionsDiff returns the fields changed between two revisions of a profile
+func (apierSv1 *APIerSv1) GetProfileRevisionsDiff(args *utils.ArgsProfileRevisionsDiff,
+	reply *map[string]*engine.AuditChange) error {
+	var prvs engine.ProfileRevisions
+	if err := apierSv1.GetProfileRevisions(&args.ArgsProfileRevisions, &prvs); err != nil {
+		return err
+	}
+	diff, err := prvs.Diff(args.FromRevision, args.ToRevision)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = diff
+	return nil
+}
+
+// RollbackProfile restores a previous revision of a profile, updating the indexes and the caches
+// the restored profile is recorded as a new revision
+func (apierSv1 *APIerSv1) RollbackProfile(args *utils.ArgsProfileRollback, reply *string) error {
+	var prvs engine.ProfileRevisions
+	if err := apierSv1.GetProfileRevisions(&args.ArgsProfileRevisions, &prvs); err != nil {
+		return err
+	}
+	prf, err := prvs.ProfileWithRevision(args.Revision)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	if prf == nil { // the profile was removed in this revision
+		return apierSv1.rollbackRemovedProfile(prvs.ItemType, &utils.TenantIDWithCache{
+			Tenant: prvs.Tenant, ID: prvs.ID, Cache: args.Cache}, reply)
+	}
+	switch prf := prf.(type) {
+	case *engine.Filter:
+		return apierSv1.SetFilter(&FilterWithCache{Filter: prf, Cache: args.Cache}, reply)
+	case *engine.AttributeProfile:
+		return apierSv1.SetAttributeProfile(&AttributeWithCache{AttributeProfile: prf, Cache: args.Cache}, reply)
+	case *engine.ChargerProfile:
+		return apierSv1.SetChargerProfile(&ChargerWithCache{ChargerProfile: prf, Cache: args.Cache}, reply)
+	case *engine.RouteProfile:
+		return apierSv1.SetRouteProfile(&RouteWithCache{RouteProfile: prf, Cache: args.Cache}, reply)
+	case *engine.ResourceProfile:
+		return apierSv1.SetResourceProfile(&ResourceWithCache{ResourceProfile: prf, Cache: args.Cache}, reply)
+	case *engine.StatQueueProfile:
+		return apierSv1.SetStatQueueProfile(&engine.StatQueueWithCache{StatQueueProfile: prf, Cache: args.Cache}, reply)
+	case *engine.ThresholdProfile:
+		return apierSv1.SetThresholdProfile(&engine.ThresholdWithCache{ThresholdProfile: prf, Cache: args.Cache}, reply)
+	case *engine.DispatcherProfile:
+		return apierSv1.SetDispatcherProfile(&DispatcherWithCache{DispatcherProfile: prf, Cache: args.Cache}, reply)
+	case *engine.RateProfile:
+		return apierSv1.SetRateProfile(&RateProfileWithCache{
+			RateProfileWithArgDispatcher: &engine.RateProfileWithArgDispatcher{RateProfile: prf},
+			Cache:                        args.Cache}, reply)
+	case *engine.TaxProfile:
+		return apierSv1.SetTaxProfile(&TaxProfileWithCache{TaxProfile: prf, Cache: args.Cache}, reply)
+	}
+	return utils.ErrNotImplemented
+}
+
+// rollbackRemovedProfile removes the profile to restore a revision which recorded its removal
+func (apierSv1 *APIerSv1) rollbackRemovedProfile(itemType string, args *utils.TenantIDWithCache, reply *string) error {
+	switch itemType {
+	case utils.CacheFilters:
+		return apierSv1.RemoveFilter(args, reply)
+	case utils.CacheAttributeProfiles:
+		return apierSv1.RemoveAttributeProfile(args, reply)
+	case utils.CacheChargerProfiles:
+		return apierSv1.RemoveChargerProfile(args, reply)
+	case utils.CacheRouteProfiles:
+		return apierSv1.RemoveRouteProfile(args, reply)
+	case utils.CacheResourceProfiles:
+		return apierSv1.RemoveResourceProfile(args, reply)
+	case utils.CacheStatQueueProfiles:
+		return apierSv1.RemoveStatQueueProfile(args, reply)
+	case utils.CacheThresholdProfiles:
+		return apierSv1.RemoveThresholdProfile(args, reply)
+	case utils.CacheDispatcherProfiles:
+		return apierSv1.RemoveDispatcherProfile(args, reply)
+	case utils.CacheRateProfiles:
+		return apierSv1.RemoveRateProfile(args, reply)
+	case utils.CacheTaxProfiles:
+		return apierSv1.RemoveTaxProfile(args, reply)
+	}
+	return utils.ErrNotImplemented
+}
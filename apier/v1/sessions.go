@@ -157,6 +157,11 @@ func (ssv1 *SessionSv1) STIRAuthenticate(args *sessions.V1STIRAuthenticateArgs,
 	return ssv1.Ss.BiRPCv1STIRAuthenticate(nil, args, reply)
 }
 
+// STIRVerify checks the identity using STIR/SHAKEN returning the verification status
+func (ssv1 *SessionSv1) STIRVerify(args *sessions.V1STIRAuthenticateArgs, reply *sessions.STIRVerification) error {
+	return ssv1.Ss.BiRPCv1STIRVerify(nil, args, reply)
+}
+
 // STIRIdentity creates the identity for STIR/SHAKEN
 func (ssv1 *SessionSv1) STIRIdentity(args *sessions.V1STIRIdentityArgs, reply *string) error {
 	return ssv1.Ss.BiRPCv1STIRIdentity(nil, args, reply)
@@ -59,6 +59,7 @@ func (ssv1 *SessionSv1) Handlers() map[string]interface{} {
 		utils.SessionSv1DisconnectPeer: ssv1.BiRPCV1DisconnectPeer,
 
 		utils.SessionSv1STIRAuthenticate: ssv1.BiRPCV1STIRAuthenticate,
+		utils.SessionSv1STIRVerify:       ssv1.BiRPCV1STIRVerify,
 		utils.SessionSv1STIRIdentity:     ssv1.BiRPCV1STIRIdentity,
 
 		utils.SessionSv1Sleep: ssv1.BiRPCV1Sleep, // Sleep method is used to test the concurrent requests mechanism
@@ -293,6 +294,16 @@ func (ssv1 *SessionSv1) BiRPCV1STIRAuthenticate(clnt *rpc2.Client,
 	return ssv1.Ss.BiRPCv1STIRAuthenticate(clnt, args, reply)
 }
 
+// BiRPCV1STIRVerify checks the identity using STIR/SHAKEN returning the verification status
+func (ssv1 *SessionSv1) BiRPCV1STIRVerify(clnt *rpc2.Client,
+	args *sessions.V1STIRAuthenticateArgs, reply *sessions.STIRVerification) (err error) {
+	if err = utils.ConReqs.Allocate(); err != nil {
+		return
+	}
+	defer utils.ConReqs.Deallocate()
+	return ssv1.Ss.BiRPCv1STIRVerify(clnt, args, reply)
+}
+
 // BiRPCV1STIRIdentity creates the identity for STIR/SHAKEN
 func (ssv1 *SessionSv1) BiRPCV1STIRIdentity(clnt *rpc2.Client,
 	args *sessions.V1STIRIdentityArgs, reply *string) (err error) {
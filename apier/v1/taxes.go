@@ -0,0 +1,149 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// GetTaxProfile returns a Tax Profile
+func (apierSv1 *APIerSv1) GetTaxProfile(arg *utils.TenantID, reply *engine.TaxProfile) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.Tenant, utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	txp, err := apierSv1.DataManager.GetTaxProfile(arg.Tenant, arg.ID, true, true, utils.NonTransactional)
+	if err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = *txp
+	return nil
+}
+
+// GetTaxProfileIDs returns list of TaxProfile IDs registered for a tenant
+func (apierSv1 *APIerSv1) GetTaxProfileIDs(args *utils.TenantArgWithPaginator, txPrfIDs *[]string) error {
+	if missing := utils.MissingStructFields(args, []string{utils.Tenant}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	prfx := utils.TaxProfilePrefix + args.Tenant + ":"
+	keys, err := apierSv1.DataManager.DataDB().GetKeysForPrefix(prfx)
+	if err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return utils.ErrNotFound
+	}
+	retIDs := make([]string, len(keys))
+	for i, key := range keys {
+		retIDs[i] = key[len(prfx):]
+	}
+	*txPrfIDs = args.PaginateStringSlice(retIDs)
+	return nil
+}
+
+// TaxProfileWithCache is used in SetTaxProfile
+type TaxProfileWithCache struct {
+	*engine.TaxProfile
+	Cache *string
+}
+
+// SetTaxProfile add/update a new Tax Profile
+func (apierSv1 *APIerSv1) SetTaxProfile(arg *TaxProfileWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(arg.TaxProfile, []string{utils.Tenant, utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierSv1.DataManager.SetTaxProfile(arg.TaxProfile, true); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheTaxProfiles and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheTaxProfiles: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for TaxProfile
+	argCache := utils.ArgsGetCacheItem{
+		CacheID: utils.CacheTaxProfiles,
+		ItemID:  arg.TenantID(),
+	}
+	if err := apierSv1.CallCache(GetCacheOpt(arg.Cache), argCache); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// RemoveTaxProfile remove a specific Tax Profile
+func (apierSv1 *APIerSv1) RemoveTaxProfile(arg *utils.TenantIDWithCache, reply *string) error {
+	if missing := utils.MissingStructFields(arg, []string{utils.Tenant, utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierSv1.DataManager.RemoveTaxProfile(arg.Tenant,
+		arg.ID, utils.NonTransactional, true); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//generate a loadID for CacheTaxProfiles and store it in database
+	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheTaxProfiles: time.Now().UnixNano()}); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	//handle caching for TaxProfile
+	argCache := utils.ArgsGetCacheItem{
+		CacheID: utils.CacheTaxProfiles,
+		ItemID:  arg.TenantID(),
+	}
+	if err := apierSv1.CallCache(GetCacheOpt(arg.Cache), argCache); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+// NewTaxSv1 initializes TaxSv1
+func NewTaxSv1(tS *engine.TaxService) *TaxSv1 {
+	return &TaxSv1{tS: tS}
+}
+
+// TaxSv1 exports RPC from TaxS
+type TaxSv1 struct {
+	tS *engine.TaxService
+}
+
+// Call implements rpcclient.ClientConnector interface for internal RPC
+func (tSv1 *TaxSv1) Call(serviceMethod string,
+	args interface{}, reply interface{}) error {
+	return utils.APIerRPCCall(tSv1, serviceMethod, args, reply)
+}
+
+// Ping return pong if the service is active
+func (tSv1 *TaxSv1) Ping(ign *utils.CGREventWithArgDispatcher, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
+
+// ComputeTaxes returns the tax breakdown for the Cost of the event
+func (tSv1 *TaxSv1) ComputeTaxes(args *utils.CGREventWithArgDispatcher,
+	reply *engine.EventTaxes) error {
+	return tSv1.tS.V1ComputeTaxes(args, reply)
+}
+
+// GetTaxProfilesForEvent returns the matching TaxProfiles for the event
+func (tSv1 *TaxSv1) GetTaxProfilesForEvent(args *utils.CGREventWithArgDispatcher,
+	reply *engine.TaxProfiles) error {
+	return tSv1.tS.V1GetTaxProfilesForEvent(args, reply)
+}
@@ -0,0 +1,70 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SetTPFxRate creates a new FxRate within a tariff plan
+func (apierSv1 *APIerSv1) SetTPFxRate(attrs *utils.TPFxRate, reply *string) error {
+	if missing := utils.MissingStructFields(attrs, []string{"TPid", "FromCurrency", "ToCurrency", "Rate"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierSv1.StorDb.SetTPFxRates([]*utils.TPFxRate{attrs}); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+type AttrGetTPFxRate struct {
+	TPid         string // Tariff plan id
+	FromCurrency string
+	ToCurrency   string
+}
+
+// GetTPFxRate queries specific FxRate on Tariff plan
+func (apierSv1 *APIerSv1) GetTPFxRate(attrs *AttrGetTPFxRate, reply *utils.TPFxRate) error {
+	if missing := utils.MissingStructFields(attrs, []string{"TPid", "FromCurrency", "ToCurrency"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	fxs, err := apierSv1.StorDb.GetTPFxRates(attrs.TPid, attrs.FromCurrency, attrs.ToCurrency)
+	if err != nil {
+		if err.Error() != utils.ErrNotFound.Error() {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	*reply = *fxs[0]
+	return nil
+}
+
+// RemoveTPFxRate removes specific FxRate on Tariff plan
+func (apierSv1 *APIerSv1) RemoveTPFxRate(attrs *AttrGetTPFxRate, reply *string) error {
+	if missing := utils.MissingStructFields(attrs, []string{"TPid", "FromCurrency", "ToCurrency"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierSv1.StorDb.RemTpData(utils.TBLTPFxRates, attrs.TPid,
+		map[string]string{"from_currency": attrs.FromCurrency, "to_currency": attrs.ToCurrency}); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = utils.OK
+	return nil
+}
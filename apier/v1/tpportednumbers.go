@@ -0,0 +1,69 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SetTPPortedNumber creates a new PortedNumber within a tariff plan
+func (apierSv1 *APIerSv1) SetTPPortedNumber(attrs *utils.TPPortedNumber, reply *string) error {
+	if missing := utils.MissingStructFields(attrs, []string{"TPid", "Number", "LRN"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierSv1.StorDb.SetTPPortedNumbers([]*utils.TPPortedNumber{attrs}); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = utils.OK
+	return nil
+}
+
+type AttrGetTPPortedNumber struct {
+	TPid   string // Tariff plan id
+	Number string
+}
+
+// GetTPPortedNumber queries specific PortedNumber on Tariff plan
+func (apierSv1 *APIerSv1) GetTPPortedNumber(attrs *AttrGetTPPortedNumber, reply *utils.TPPortedNumber) error {
+	if missing := utils.MissingStructFields(attrs, []string{"TPid", "Number"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	pns, err := apierSv1.StorDb.GetTPPortedNumbers(attrs.TPid, attrs.Number)
+	if err != nil {
+		if err.Error() != utils.ErrNotFound.Error() {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	*reply = *pns[0]
+	return nil
+}
+
+// RemoveTPPortedNumber removes specific PortedNumber on Tariff plan
+func (apierSv1 *APIerSv1) RemoveTPPortedNumber(attrs *AttrGetTPPortedNumber, reply *string) error {
+	if missing := utils.MissingStructFields(attrs, []string{"TPid", "Number"}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err := apierSv1.StorDb.RemTpData(utils.TBLTPPortedNumbers, attrs.TPid,
+		map[string]string{"number": attrs.Number}); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = utils.OK
+	return nil
+}
@@ -21,6 +21,7 @@ package v2
 import (
 	"errors"
 	"math"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
@@ -106,6 +107,9 @@ func (apiv2 *APIerSv2) GetAccount(attr *utils.AttrGetAccount, reply *engine.Acco
 		return err
 	}
 	*reply = *account
+	// report the consumption of the current periods
+	reply.Caps = account.Caps.Clone()
+	reply.Caps.Refresh(time.Now())
 	return nil
 }
 
@@ -116,6 +120,8 @@ type AttrSetAccount struct {
 	ActionPlansOverwrite   bool
 	ActionTriggerIDs       []string
 	ActionTriggerOverwrite bool
+	Caps                   []*engine.AccountCap // set by ID, keeping the consumption of the existing ones
+	CapsOverwrite          bool                 // remove the caps not in Caps
 	ExtraOptions           map[string]bool
 	ReloadScheduler        bool
 }
@@ -124,6 +130,11 @@ func (apiv2 *APIerSv2) SetAccount(attr *AttrSetAccount, reply *string) error {
 	if missing := utils.MissingStructFields(attr, []string{"Tenant", "Account"}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
 	}
+	for _, aCap := range attr.Caps {
+		if err := aCap.Validate(); err != nil {
+			return err
+		}
+	}
 	accID := utils.ConcatenatedKey(attr.Tenant, attr.Account)
 	dirtyActionPlans := make(map[string]*engine.ActionPlan)
 	var ub *engine.Account
@@ -238,6 +249,34 @@ func (apiv2 *APIerSv2) SetAccount(attr *AttrSetAccount, reply *string) error {
 			}
 		}
 
+		oldCaps := make(map[string]*engine.AccountCap)
+		for _, aCap := range ub.Caps {
+			oldCaps[aCap.ID] = aCap
+		}
+		if attr.CapsOverwrite {
+			ub.Caps = nil
+		}
+		for _, aCap := range attr.Caps {
+			if oldCap, has := oldCaps[aCap.ID]; has && oldCap.Period == aCap.Period {
+				aCap.Consumed = oldCap.Consumed
+				aCap.PeriodStart = oldCap.PeriodStart
+			} else {
+				aCap.Consumed = 0
+				aCap.PeriodStart = time.Time{}
+			}
+			var found bool
+			for i, existingCap := range ub.Caps {
+				if existingCap.ID == aCap.ID {
+					ub.Caps[i] = aCap
+					found = true
+					break
+				}
+			}
+			if !found {
+				ub.Caps = append(ub.Caps, aCap)
+			}
+		}
+
 		ub.InitCounters()
 		if alNeg, has := attr.ExtraOptions[utils.AllowNegative]; has {
 			ub.AllowNegative = alNeg
@@ -115,7 +115,8 @@ func startRpc(server *utils.Server, internalRaterChan,
 	internalAttrSChan, internalChargerSChan, internalThdSChan, internalSuplSChan,
 	internalSMGChan, internalAnalyzerSChan, internalDispatcherSChan,
 	internalLoaderSChan, internalRALsv1Chan, internalCacheSChan,
-	internalEEsChan, internalRateSChan chan rpcclient.ClientConnector,
+	internalEEsChan, internalRateSChan, internalTaxSChan,
+	internalPubSubSChan chan rpcclient.ClientConnector,
 	exitChan chan bool) {
 	if !cfg.DispatcherSCfg().Enabled {
 		select { // Any of the rpc methods will unlock listening to rpc requests
@@ -149,6 +150,10 @@ func startRpc(server *utils.Server, internalRaterChan,
 			internalEEsChan <- eeS
 		case rateS := <-internalRateSChan:
 			internalRateSChan <- rateS
+		case taxS := <-internalTaxSChan:
+			internalTaxSChan <- taxS
+		case pubSubS := <-internalPubSubSChan:
+			internalPubSubSChan <- pubSubS
 		}
 	} else {
 		select {
@@ -163,10 +168,14 @@ func startRpc(server *utils.Server, internalRaterChan,
 		cfg.ListenCfg().HTTPListen,
 		cfg.HTTPCfg().HTTPJsonRPCURL,
 		cfg.HTTPCfg().HTTPWSURL,
+		cfg.HTTPCfg().HTTPRESTURL,
 		cfg.HTTPCfg().HTTPUseBasicAuth,
 		cfg.HTTPCfg().HTTPAuthUsers,
 		exitChan,
 	)
+	if cfg.ListenCfg().RPCGRPCListen != "" {
+		go server.ServeGRPC(cfg.ListenCfg().RPCGRPCListen, exitChan)
+	}
 	if cfg.ListenCfg().RPCGOBTLSListen != "" {
 		if cfg.TlsCfg().ServerCerificate == "" || cfg.TlsCfg().ServerKey == "" {
 			utils.Logger.Warning("WARNING: missing TLS certificate/key file!")
@@ -197,6 +206,21 @@ func startRpc(server *utils.Server, internalRaterChan,
 			)
 		}
 	}
+	if cfg.ListenCfg().RPCGRPCTLSListen != "" {
+		if cfg.TlsCfg().ServerCerificate == "" || cfg.TlsCfg().ServerKey == "" {
+			utils.Logger.Warning("WARNING: missing TLS certificate/key file!")
+		} else {
+			go server.ServeGRPCTLS(
+				cfg.ListenCfg().RPCGRPCTLSListen,
+				cfg.TlsCfg().ServerCerificate,
+				cfg.TlsCfg().ServerKey,
+				cfg.TlsCfg().CaCertificate,
+				cfg.TlsCfg().ServerPolicy,
+				cfg.TlsCfg().ServerName,
+				exitChan,
+			)
+		}
+	}
 	if cfg.ListenCfg().HTTPTLSListen != "" {
 		if cfg.TlsCfg().ServerCerificate == "" || cfg.TlsCfg().ServerKey == "" {
 			utils.Logger.Warning("WARNING: missing TLS certificate/key file!")
@@ -210,6 +234,7 @@ func startRpc(server *utils.Server, internalRaterChan,
 				cfg.TlsCfg().ServerName,
 				cfg.HTTPCfg().HTTPJsonRPCURL,
 				cfg.HTTPCfg().HTTPWSURL,
+				cfg.HTTPCfg().HTTPRESTURL,
 				cfg.HTTPCfg().HTTPUseBasicAuth,
 				cfg.HTTPCfg().HTTPAuthUsers,
 				exitChan,
@@ -240,8 +265,8 @@ func initLogger(cfg *config.CGRConfig) error {
 }
 
 func initConfigSv1(internalConfigChan chan rpcclient.ClientConnector,
-	server *utils.Server) {
-	cfgSv1 := v1.NewConfigSv1(cfg)
+	server *utils.Server, cfgDBS *engine.ConfigDBS) {
+	cfgSv1 := v1.NewConfigSv1(cfg, cfgDBS)
 	if !cfg.DispatcherSCfg().Enabled {
 		server.RpcRegister(cfgSv1)
 	}
@@ -419,6 +444,8 @@ func main() {
 	internalLoaderSChan := make(chan rpcclient.ClientConnector, 1)
 	internalEEsChan := make(chan rpcclient.ClientConnector, 1)
 	internalRateSChan := make(chan rpcclient.ClientConnector, 1)
+	internalTaxSChan := make(chan rpcclient.ClientConnector, 1)
+	internalPubSubSChan := make(chan rpcclient.ClientConnector, 1)
 
 	// initialize the connManager before creating the DMService
 	// because we need to pass the connection to it
@@ -444,6 +471,8 @@ func main() {
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs):           internalRALsChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs):            internalEEsChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRateS):          internalRateSChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTaxes):          internalTaxSChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs):        internalPubSubSChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaDispatchers):    internalDispatcherSChan,
 	})
 
@@ -455,11 +484,25 @@ func main() {
 		}
 	}
 	// Done initing DBs
+
+	// load the configuration shared over DataDB before starting the services
+	cfgDBS := engine.NewConfigDBS(cfg, dmService.GetDM())
+	cfgDBStop := make(chan struct{})
+	if cfg.ConfigDBCfg().Enabled {
+		if err = cfgDBS.LoadSections(false); err != nil {
+			utils.Logger.Crit(fmt.Sprintf("<%s> could not load the configuration out of DataDB: %s",
+				utils.ConfigDBS, err.Error()))
+			return
+		}
+		go cfgDBS.ListenAndServe(cfgDBStop)
+	}
 	engine.SetRoundingDecimals(cfg.GeneralCfg().RoundingDecimals)
 	engine.SetFailedPostCacheTTL(cfg.GeneralCfg().FailedPostsTTL)
 
 	// Rpc/http server
 	server := utils.NewServer()
+	server.SetRPCAuthorizer(cfg) // the authorization can be enabled on config reload
+	server.SetRPCLimiter(cfg)    // the request limiters can be enabled on config reload
 
 	if *httpPprofPath != "" {
 		go server.RegisterProfiler(*httpPprofPath)
@@ -520,16 +563,19 @@ func main() {
 		services.NewDNSAgent(cfg, filterSChan, exitChan, connManager),
 		services.NewFreeswitchAgent(cfg, exitChan, connManager),
 		services.NewKamailioAgent(cfg, exitChan, connManager),
-		services.NewAsteriskAgent(cfg, exitChan, connManager),              // partial reload
-		services.NewRadiusAgent(cfg, filterSChan, exitChan, connManager),   // partial reload
-		services.NewDiameterAgent(cfg, filterSChan, exitChan, connManager), // partial reload
-		services.NewHTTPAgent(cfg, filterSChan, server, connManager),       // no reload
+		services.NewAsteriskAgent(cfg, exitChan, connManager),                      // partial reload
+		services.NewRadiusAgent(cfg, filterSChan, exitChan, connManager),           // partial reload
+		services.NewDiameterAgent(cfg, filterSChan, exitChan, connManager, server), // partial reload
+		services.NewHTTPAgent(cfg, filterSChan, server, connManager),               // no reload
 		ldrs, anz, dspS, dmService, storDBService,
 		services.NewEventExporterService(cfg, filterSChan,
 			connManager, server, exitChan, internalEEsChan),
 		services.NewRateService(cfg, cacheS, filterSChan, dmService,
 			server, exitChan, internalRateSChan),
 		services.NewSIPAgent(cfg, filterSChan, exitChan, connManager),
+		services.NewAuditService(cfg, dmService, storDBService, server, connManager),
+		services.NewTaxService(cfg, dmService, cacheS, filterSChan, server, internalTaxSChan),
+		services.NewPubSubService(cfg, filterSChan, server, internalPubSubSChan),
 	)
 	srvManager.StartServices()
 	// Start FilterS
@@ -562,8 +608,10 @@ func main() {
 	engine.IntRPC.AddInternalRPCClient(utils.CoreSv1, internalCoreSv1Chan)
 	engine.IntRPC.AddInternalRPCClient(utils.RALsV1, internalRALsChan)
 	engine.IntRPC.AddInternalRPCClient(utils.RateSv1, internalRateSChan)
+	engine.IntRPC.AddInternalRPCClient(utils.TaxSv1, internalTaxSChan)
+	engine.IntRPC.AddInternalRPCClient(utils.PubSubSv1, internalPubSubSChan)
 
-	initConfigSv1(internalConfigChan, server)
+	initConfigSv1(internalConfigChan, server, cfgDBS)
 
 	// Serve rpc connections
 	go startRpc(server, internalResponderChan, internalCDRServerChan,
@@ -571,8 +619,10 @@ func main() {
 		internalAttributeSChan, internalChargerSChan, internalThresholdSChan,
 		internalRouteSChan, internalSessionSChan, internalAnalyzerSChan,
 		internalDispatcherSChan, internalLoaderSChan, internalRALsChan,
-		internalCacheSChan, internalEEsChan, internalRateSChan, exitChan)
+		internalCacheSChan, internalEEsChan, internalRateSChan,
+		internalTaxSChan, internalPubSubSChan, exitChan)
 	<-exitChan
+	close(cfgDBStop)
 
 	if *cpuProfDir != "" { // wait to end cpuProfiling
 		cpuProfChanStop <- struct{}{}
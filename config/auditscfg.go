@@ -0,0 +1,74 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AuditSCfg is the configuration of the audit service
+type AuditSCfg struct {
+	Enabled  bool
+	Methods  []string // patterns of the audited API methods
+	EEsConns []string
+}
+
+func (aS *AuditSCfg) loadFromJsonCfg(jsnCfg *AuditSJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		aS.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Methods != nil {
+		aS.Methods = make([]string, len(*jsnCfg.Methods))
+		copy(aS.Methods, *jsnCfg.Methods)
+	}
+	if jsnCfg.Ees_conns != nil {
+		aS.EEsConns = make([]string, len(*jsnCfg.Ees_conns))
+		for idx, connID := range *jsnCfg.Ees_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			if connID == utils.MetaInternal {
+				aS.EEsConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)
+			} else {
+				aS.EEsConns[idx] = connID
+			}
+		}
+	}
+	return
+}
+
+func (aS *AuditSCfg) AsMapInterface() map[string]interface{} {
+	eesConns := make([]string, len(aS.EEsConns))
+	for i, item := range aS.EEsConns {
+		buf := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)
+		if item == buf {
+			eesConns[i] = strings.ReplaceAll(item, utils.CONCATENATED_KEY_SEP+utils.MetaEEs, utils.EmptyString)
+		} else {
+			eesConns[i] = item
+		}
+	}
+	return map[string]interface{}{
+		utils.EnabledCfg:  aS.Enabled,
+		utils.MethodsCfg:  aS.Methods,
+		utils.EEsConnsCfg: eesConns,
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAuditSCfgloadFromJsonCfg(t *testing.T) {
+	var aS, expected AuditSCfg
+	if err := aS.loadFromJsonCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(aS, expected) {
+		t.Errorf("Expected: %+v ,recived: %+v", expected, aS)
+	}
+	cfgJSONStr := `{
+		"audits": {
+			"enabled": true,
+			"methods": ["APIerSv1.Set*"],
+			"ees_conns": ["*internal", "conn1"],
+		},
+}`
+	expected = AuditSCfg{
+		Enabled:  true,
+		Methods:  []string{"APIerSv1.Set*"},
+		EEsConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs), "conn1"},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnAS, err := jsnCfg.AuditSJsonCfg(); err != nil {
+		t.Error(err)
+	} else if err = aS.loadFromJsonCfg(jsnAS); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, aS) {
+		t.Errorf("Expected: %+v , recived: %+v", expected, aS)
+	}
+	eMap := map[string]interface{}{
+		"enabled":   true,
+		"methods":   []string{"APIerSv1.Set*"},
+		"ees_conns": []string{utils.MetaInternal, "conn1"},
+	}
+	if rcv := aS.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("\nExpected: %+v\nRecived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
@@ -37,6 +37,8 @@ type CdrsCfg struct {
 	OnlineCDRExports []string // list of CDRE templates to use for real-time CDR exports
 	SchedulerConns   []string
 	EEsConns         []string
+	TaxSConns        []string
+	PubSubSConns     []string
 }
 
 //loadFromJsonCfg loads Cdrs config from JsonCfg
@@ -141,6 +143,28 @@ func (cdrscfg *CdrsCfg) loadFromJsonCfg(jsnCdrsCfg *CdrsJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCdrsCfg.Taxs_conns != nil {
+		cdrscfg.TaxSConns = make([]string, len(*jsnCdrsCfg.Taxs_conns))
+		for idx, connID := range *jsnCdrsCfg.Taxs_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			if connID == utils.MetaInternal {
+				cdrscfg.TaxSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTaxes)
+			} else {
+				cdrscfg.TaxSConns[idx] = connID
+			}
+		}
+	}
+	if jsnCdrsCfg.Pubsubs_conns != nil {
+		cdrscfg.PubSubSConns = make([]string, len(*jsnCdrsCfg.Pubsubs_conns))
+		for idx, connID := range *jsnCdrsCfg.Pubsubs_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			if connID == utils.MetaInternal {
+				cdrscfg.PubSubSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs)
+			} else {
+				cdrscfg.PubSubSConns[idx] = connID
+			}
+		}
+	}
 	return nil
 }
 
@@ -211,6 +235,24 @@ func (cdrscfg *CdrsCfg) AsMapInterface() map[string]interface{} {
 			schedulerConns[i] = item
 		}
 	}
+	taxSConns := make([]string, len(cdrscfg.TaxSConns))
+	for i, item := range cdrscfg.TaxSConns {
+		buf := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaTaxes)
+		if item == buf {
+			taxSConns[i] = strings.ReplaceAll(item, utils.CONCATENATED_KEY_SEP+utils.MetaTaxes, utils.EmptyString)
+		} else {
+			taxSConns[i] = item
+		}
+	}
+	pubSubSConns := make([]string, len(cdrscfg.PubSubSConns))
+	for i, item := range cdrscfg.PubSubSConns {
+		buf := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs)
+		if item == buf {
+			pubSubSConns[i] = strings.ReplaceAll(item, utils.CONCATENATED_KEY_SEP+utils.MetaPubSubs, utils.EmptyString)
+		} else {
+			pubSubSConns[i] = item
+		}
+	}
 
 	return map[string]interface{}{
 		utils.EnabledCfg:          cdrscfg.Enabled,
@@ -224,5 +266,7 @@ func (cdrscfg *CdrsCfg) AsMapInterface() map[string]interface{} {
 		utils.StatSConnsCfg:       statSConns,
 		utils.OnlineCDRExportsCfg: onlineCDRExports,
 		utils.SchedulerConnsCfg:   schedulerConns,
+		utils.TaxSConnsCfg:        taxSConns,
+		utils.PubSubSConnsCfg:     pubSubSConns,
 	}
 }
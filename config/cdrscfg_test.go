@@ -122,6 +122,8 @@ func TestCdrsCfgAsMapInterface(t *testing.T) {
 		"stats_conns":          []string{},
 		"online_cdr_exports":   []string{},
 		"scheduler_conns":      []string{},
+		"taxs_conns":           []string{},
+		"pubsubs_conns":        []string{},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -146,6 +148,7 @@ func TestCdrsCfgAsMapInterface(t *testing.T) {
 			"stats_conns": ["*internal"],						
 			"online_cdr_exports":["http_localhost", "amqp_localhost", "http_test_file", "amqp_test_file","aws_test_file","sqs_test_file","kafka_localhost","s3_test_file"],
 			"scheduler_conns": ["*internal"],				
+			"taxs_conns": ["*internal"],
 		},
 	}`
 	eMap = map[string]interface{}{
@@ -160,6 +163,8 @@ func TestCdrsCfgAsMapInterface(t *testing.T) {
 		"stats_conns":          []string{"*internal"},
 		"online_cdr_exports":   []string{"http_localhost", "amqp_localhost", "http_test_file", "amqp_test_file", "aws_test_file", "sqs_test_file", "kafka_localhost", "s3_test_file"},
 		"scheduler_conns":      []string{"*internal"},
+		"taxs_conns":           []string{"*internal"},
+		"pubsubs_conns":        []string{},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -157,6 +157,12 @@ func NewDefaultCGRConfig() (cfg *CGRConfig, err error) {
 	cfg.cdrsCfg = new(CdrsCfg)
 	cfg.CdreProfiles = make(map[string]*CdreCfg)
 	cfg.analyzerSCfg = new(AnalyzerSCfg)
+	cfg.rpcAuthCfg = new(RPCAuthCfg)
+	cfg.reqLimitersCfg = new(ReqLimitersCfg)
+	cfg.auditSCfg = new(AuditSCfg)
+	cfg.taxSCfg = new(TaxSCfg)
+	cfg.pubSubSCfg = new(PubSubSCfg)
+	cfg.configDBCfg = new(ConfigDBCfg)
 	cfg.sessionSCfg = new(SessionSCfg)
 	cfg.sessionSCfg.STIRCfg = new(STIRcfg)
 	cfg.fsAgentCfg = new(FsAgentCfg)
@@ -307,6 +313,12 @@ type CGRConfig struct {
 	eesCfg           *EEsCfg           // EventExporter config
 	rateSCfg         *RateSCfg         // RateS config
 	sipAgentCfg      *SIPAgentCfg      // SIPAgent config
+	rpcAuthCfg       *RPCAuthCfg       // RPC authorization config
+	reqLimitersCfg   *ReqLimitersCfg   // request limiters config
+	auditSCfg        *AuditSCfg        // AuditS config
+	taxSCfg          *TaxSCfg          // TaxS config
+	pubSubSCfg       *PubSubSCfg       // PubSubS config
+	configDBCfg      *ConfigDBCfg      // ConfigDB config
 }
 
 var posibleLoaderTypes = utils.NewStringSet([]string{utils.MetaAttributes,
@@ -370,7 +382,8 @@ func (cfg *CGRConfig) loadFromJsonCfg(jsnCfg *CgrJsonCfg) (err error) {
 		cfg.loadMailerCfg, cfg.loadSureTaxCfg, cfg.loadDispatcherSCfg,
 		cfg.loadLoaderCgrCfg, cfg.loadMigratorCgrCfg, cfg.loadTlsCgrCfg,
 		cfg.loadAnalyzerCgrCfg, cfg.loadApierCfg, cfg.loadErsCfg, cfg.loadEesCfg,
-		cfg.loadRateSCfg, cfg.loadSIPAgentCfg} {
+		cfg.loadRateSCfg, cfg.loadSIPAgentCfg, cfg.loadRPCAuthCfg, cfg.loadReqLimitersCfg,
+		cfg.loadAuditSCfg, cfg.loadTaxSCfg, cfg.loadPubSubSCfg, cfg.loadConfigDBCfg} {
 		if err = loadFunc(jsnCfg); err != nil {
 			return
 		}
@@ -768,6 +781,60 @@ func (cfg *CGRConfig) loadSIPAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.sipAgentCfg.loadFromJsonCfg(jsnSIPAgentCfg, cfg.generalCfg.RSRSep)
 }
 
+// loadRPCAuthCfg loads the rpc_auth section of the configuration
+func (cfg *CGRConfig) loadRPCAuthCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnRPCAuthCfg *RPCAuthJsonCfg
+	if jsnRPCAuthCfg, err = jsnCfg.RPCAuthJsonCfg(); err != nil {
+		return
+	}
+	return cfg.rpcAuthCfg.loadFromJsonCfg(jsnRPCAuthCfg)
+}
+
+// loadReqLimitersCfg loads the request_limiters section of the configuration
+func (cfg *CGRConfig) loadReqLimitersCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnReqLimitersCfg *ReqLimitersJsonCfg
+	if jsnReqLimitersCfg, err = jsnCfg.ReqLimitersJsonCfg(); err != nil {
+		return
+	}
+	return cfg.reqLimitersCfg.loadFromJsonCfg(jsnReqLimitersCfg)
+}
+
+// loadAuditSCfg loads the audits section of the configuration
+func (cfg *CGRConfig) loadAuditSCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnAuditSCfg *AuditSJsonCfg
+	if jsnAuditSCfg, err = jsnCfg.AuditSJsonCfg(); err != nil {
+		return
+	}
+	return cfg.auditSCfg.loadFromJsonCfg(jsnAuditSCfg)
+}
+
+// loadTaxSCfg loads the TaxS section of the configuration
+func (cfg *CGRConfig) loadTaxSCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnTaxSCfg *TaxSJsonCfg
+	if jsnTaxSCfg, err = jsnCfg.TaxSJsonCfg(); err != nil {
+		return
+	}
+	return cfg.taxSCfg.loadFromJsonCfg(jsnTaxSCfg)
+}
+
+// loadPubSubSCfg loads the PubSubS section of the configuration
+func (cfg *CGRConfig) loadPubSubSCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnPubSubSCfg *PubSubSJsonCfg
+	if jsnPubSubSCfg, err = jsnCfg.PubSubSJsonCfg(); err != nil {
+		return
+	}
+	return cfg.pubSubSCfg.loadFromJsonCfg(jsnPubSubSCfg)
+}
+
+// loadConfigDBCfg loads the config_db section of the configuration
+func (cfg *CGRConfig) loadConfigDBCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnConfigDBCfg *ConfigDBJsonCfg
+	if jsnConfigDBCfg, err = jsnCfg.ConfigDBJsonCfg(); err != nil {
+		return
+	}
+	return cfg.configDBCfg.loadFromJsonCfg(jsnConfigDBCfg)
+}
+
 // SureTaxCfg use locking to retrieve the configuration, possibility later for runtime reload
 func (cfg *CGRConfig) SureTaxCfg() *SureTaxCfg {
 	cfg.lks[SURETAX_JSON].Lock()
@@ -1031,6 +1098,63 @@ func (cfg *CGRConfig) SIPAgentCfg() *SIPAgentCfg {
 	return cfg.sipAgentCfg
 }
 
+// RPCAuthCfg reads the RPC authorization configuration
+func (cfg *CGRConfig) RPCAuthCfg() *RPCAuthCfg {
+	cfg.lks[RPCAuthJson].RLock()
+	defer cfg.lks[RPCAuthJson].RUnlock()
+	return cfg.rpcAuthCfg
+}
+
+// ReqLimitersCfg reads the request limiters configuration
+func (cfg *CGRConfig) ReqLimitersCfg() *ReqLimitersCfg {
+	cfg.lks[ReqLimitersJson].RLock()
+	defer cfg.lks[ReqLimitersJson].RUnlock()
+	return cfg.reqLimitersCfg
+}
+
+// AuditSCfg reads the AuditS configuration
+func (cfg *CGRConfig) AuditSCfg() *AuditSCfg {
+	cfg.lks[AuditSJson].RLock()
+	defer cfg.lks[AuditSJson].RUnlock()
+	return cfg.auditSCfg
+}
+
+// TaxSCfg reads the TaxS configuration
+func (cfg *CGRConfig) TaxSCfg() *TaxSCfg {
+	cfg.lks[TaxSJson].RLock()
+	defer cfg.lks[TaxSJson].RUnlock()
+	return cfg.taxSCfg
+}
+
+// PubSubSCfg reads the PubSubS configuration
+func (cfg *CGRConfig) PubSubSCfg() *PubSubSCfg {
+	cfg.lks[PubSubSJson].RLock()
+	defer cfg.lks[PubSubSJson].RUnlock()
+	return cfg.pubSubSCfg
+}
+
+// ConfigDBCfg reads the ConfigDB configuration
+func (cfg *CGRConfig) ConfigDBCfg() *ConfigDBCfg {
+	cfg.lks[ConfigDBJson].RLock()
+	defer cfg.lks[ConfigDBJson].RUnlock()
+	return cfg.configDBCfg
+}
+
+// AuthorizeRPC implements utils.RPCAuthorizer based on the rpc_auth section
+func (cfg *CGRConfig) AuthorizeRPC(caller *utils.RPCCaller, serviceMethod string, args interface{}) error {
+	cfg.lks[RPCAuthJson].RLock()
+	defer cfg.lks[RPCAuthJson].RUnlock()
+	return cfg.rpcAuthCfg.AuthorizeRPC(caller, serviceMethod, args, cfg.GeneralCfg().DefaultTenant)
+}
+
+// LimitRPC implements utils.RPCLimiter based on the request_limiters section
+func (cfg *CGRConfig) LimitRPC(serviceMethod string, args interface{}) (func(), error) {
+	cfg.lks[ReqLimitersJson].RLock()
+	reqLimitersCfg := *cfg.reqLimitersCfg // the lock is not held while the request waits in queue
+	cfg.lks[ReqLimitersJson].RUnlock()
+	return reqLimitersCfg.LimitRPC(serviceMethod, args, cfg.GeneralCfg().DefaultTenant)
+}
+
 // RPCConns reads the RPCConns configuration
 func (cfg *CGRConfig) RPCConns() map[string]*RPCConn {
 	cfg.lks[RPCConnsJsonName].RLock()
@@ -1059,7 +1183,17 @@ type StringWithArgDispatcher struct {
 //V1GetConfigSection will retrieve from CGRConfig a section
 func (cfg *CGRConfig) V1GetConfigSection(args *StringWithArgDispatcher, reply *map[string]interface{}) (err error) {
 	var jsonString string
-	switch args.Section {
+	if jsonString, err = cfg.sectionAsJSON(args.Section); err != nil {
+		return
+	}
+	json.Unmarshal([]byte(jsonString), reply)
+	maskSecrets(args.Section, *reply)
+	return
+}
+
+// sectionAsJSON returns the section as shown in the API replies
+func (cfg *CGRConfig) sectionAsJSON(section string) (jsonString string, err error) {
+	switch section {
 	case GENERAL_JSN:
 		jsonString = utils.ToJSON(cfg.GeneralCfg())
 	case DATADB_JSN:
@@ -1130,10 +1264,21 @@ func (cfg *CGRConfig) V1GetConfigSection(args *StringWithArgDispatcher, reply *m
 		jsonString = utils.ToJSON(cfg.RPCConns())
 	case SIPAgentJson:
 		jsonString = utils.ToJSON(cfg.SIPAgentCfg())
+	case RPCAuthJson:
+		jsonString = utils.ToJSON(cfg.RPCAuthCfg())
+	case ReqLimitersJson:
+		jsonString = utils.ToJSON(cfg.ReqLimitersCfg())
+	case AuditSJson:
+		jsonString = utils.ToJSON(cfg.AuditSCfg())
+	case TaxSJson:
+		jsonString = utils.ToJSON(cfg.TaxSCfg())
+	case PubSubSJson:
+		jsonString = utils.ToJSON(cfg.PubSubSCfg())
+	case ConfigDBJson:
+		jsonString = utils.ToJSON(cfg.ConfigDBCfg())
 	default:
-		return errors.New("Invalid section")
+		return utils.EmptyString, errors.New("Invalid section")
 	}
-	json.Unmarshal([]byte(jsonString), reply)
 	return
 }
 
@@ -1189,6 +1334,7 @@ func (cfg *CGRConfig) V1ReloadConfigFromPath(args *ConfigReloadWithArgDispatcher
 	if missing := utils.MissingStructFields(args, []string{"Path"}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
 	}
+	expireHTTPSecrets() // re-resolve the secrets
 	if err = cfg.loadCfgWithLocks(args.Path, args.Section); err != nil {
 		return
 	}
@@ -1256,6 +1402,12 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		RPCConnsJsonName:   cfg.loadRPCConns,
 		RateSJson:          cfg.loadRateSCfg,
 		SIPAgentJson:       cfg.loadSIPAgentCfg,
+		RPCAuthJson:        cfg.loadRPCAuthCfg,
+		ReqLimitersJson:    cfg.loadReqLimitersCfg,
+		AuditSJson:         cfg.loadAuditSCfg,
+		TaxSJson:           cfg.loadTaxSCfg,
+		PubSubSJson:        cfg.loadPubSubSCfg,
+		ConfigDBJson:       cfg.loadConfigDBCfg,
 	}
 }
 
@@ -1411,6 +1563,7 @@ func (cfg *CGRConfig) V1ReloadConfigFromJSON(args *JSONReloadWithArgDispatcher,
 	if b, err = json.Marshal(args.JSON); err != nil {
 		return
 	}
+	expireHTTPSecrets() // re-resolve the secrets
 
 	if err = cfg.loadCfgFromJSONWithLocks(bytes.NewBuffer(b), sections); err != nil {
 		return
@@ -1446,12 +1599,123 @@ func (cfg *CGRConfig) loadCfgFromJSONWithLocks(rdr io.Reader, sections []string)
 	return cfg.loadConfigFromReader(rdr, loadFuncs)
 }
 
+// DBSectionWithArgDispatcher the API params for the sections stored in DataDB
+type DBSectionWithArgDispatcher struct {
+	*utils.ArgDispatcher
+	utils.TenantArg
+	Section string
+	Config  interface{} // content of the section
+	Version int64       // expected version of the stored section, 0 to skip the check
+}
+
+// CheckDBSection returns error if the section can not be shared over DataDB
+func CheckDBSection(section string) (err error) {
+	if !utils.NewStringSet(sortedCfgSections).Has(section) {
+		return fmt.Errorf("Invalid section: <%s>", section)
+	}
+	if section == DATADB_JSN || section == ConfigDBJson { // needed before reaching DataDB
+		return fmt.Errorf("section <%s> can not be stored in DataDB", section)
+	}
+	return
+}
+
+// CheckDBSections validates the configuration resulting out of the local files
+// with the sections stored in DataDB applied on top
+func (cfg *CGRConfig) CheckDBSections(dbSections map[string]string) (err error) {
+	var cndCfg *CGRConfig
+	if cndCfg, err = NewDefaultCGRConfig(); err != nil {
+		return
+	}
+	cndCfg.ConfigPath = cfg.ConfigPath
+	if cndCfg.ConfigPath != utils.EmptyString {
+		if err = cndCfg.loadConfigFromPath(cndCfg.ConfigPath,
+			[]func(*CgrJsonCfg) error{cndCfg.loadFromJsonCfg}); err != nil {
+			return
+		}
+	}
+	if err = cndCfg.loadDBSections(dbSections); err != nil {
+		return
+	}
+	return cndCfg.checkConfigSanity()
+}
+
+// LoadDBSections loads the sections out of DataDB into the running configuration,
+// optionally signaling the subsystems to reload
+func (cfg *CGRConfig) LoadDBSections(dbSections map[string]string, reload bool) (err error) {
+	if len(dbSections) == 0 {
+		return
+	}
+	if err = cfg.loadDBSectionsWithLocks(dbSections); err != nil {
+		return
+	}
+	//  lock all sections
+	cfg.rLockSections()
+
+	err = cfg.checkConfigSanity()
+
+	cfg.rUnlockSections() // unlock before checking the error
+
+	if err != nil || !reload {
+		return
+	}
+	for _, section := range sortedCfgSections {
+		if _, has := dbSections[section]; !has {
+			continue
+		}
+		if err = cfg.reloadSections(section); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (cfg *CGRConfig) loadDBSectionsWithLocks(dbSections map[string]string) (err error) {
+	for _, section := range sortedCfgSections {
+		if _, has := dbSections[section]; !has {
+			continue
+		}
+		cfg.lks[section].Lock()
+		defer cfg.lks[section].Unlock()
+	}
+	return cfg.loadDBSections(dbSections)
+}
+
+// loadDBSections loads the sections stored in DataDB, followed by the local overrides
+func (cfg *CGRConfig) loadDBSections(dbSections map[string]string) (err error) {
+	loadMap := cfg.getLoadFunctions()
+	localOvrds := utils.NewStringSet(cfg.ConfigDBCfg().LocalOverrides)
+	var ovrdFuncs []func(*CgrJsonCfg) error
+	for _, section := range sortedCfgSections {
+		jsnSection, has := dbSections[section]
+		if !has {
+			continue
+		}
+		if err = CheckDBSection(section); err != nil {
+			return
+		}
+		jsnCfg := new(CgrJsonCfg) // decoded by the reader to resolve the *env and secret references
+		if err = NewRjReaderFromBytes([]byte(fmt.Sprintf(`{"%s":%s}`, section, jsnSection))).Decode(jsnCfg); err != nil {
+			return fmt.Errorf("section <%s>:%s", section, err.Error())
+		}
+		if err = loadMap[section](jsnCfg); err != nil {
+			return fmt.Errorf("section <%s>:%s", section, err.Error())
+		}
+		if localOvrds.Has(section) {
+			ovrdFuncs = append(ovrdFuncs, loadMap[section])
+		}
+	}
+	if len(ovrdFuncs) == 0 || cfg.ConfigPath == utils.EmptyString {
+		return
+	}
+	return cfg.loadConfigFromPath(cfg.ConfigPath, ovrdFuncs)
+}
+
 func (cfg *CGRConfig) reloadSections(sections ...string) (err error) {
 	subsystemsThatNeedDataDB := utils.NewStringSet([]string{DATADB_JSN, SCHEDULER_JSN,
 		RALS_JSN, CDRS_JSN, SessionSJson, ATTRIBUTE_JSN,
 		ChargerSCfgJson, RESOURCES_JSON, STATS_JSON, THRESHOLDS_JSON,
-		RouteSJson, LoaderJson, DispatcherSJson, RateSJson})
-	subsystemsThatNeedStorDB := utils.NewStringSet([]string{STORDB_JSN, RALS_JSN, CDRS_JSN, ApierS})
+		RouteSJson, LoaderJson, DispatcherSJson, RateSJson, AuditSJson, TaxSJson})
+	subsystemsThatNeedStorDB := utils.NewStringSet([]string{STORDB_JSN, RALS_JSN, CDRS_JSN, ApierS, AuditSJson})
 	needsDataDB := false
 	needsStorDB := false
 	for _, section := range sections {
@@ -1529,6 +1793,16 @@ func (cfg *CGRConfig) reloadSections(sections ...string) (err error) {
 			cfg.rldChans[SIPAgentJson] <- struct{}{}
 		case RateSJson:
 			cfg.rldChans[RateSJson] <- struct{}{}
+		case RPCAuthJson: // nothing to reload
+		case ReqLimitersJson: // the limiters of the removed profiles are not needed anymore
+			utils.ReqLimiters.Prune(cfg.ReqLimitersCfg().hasLimiter)
+		case AuditSJson:
+			cfg.rldChans[AuditSJson] <- struct{}{}
+		case TaxSJson:
+			cfg.rldChans[TaxSJson] <- struct{}{}
+		case PubSubSJson:
+			cfg.rldChans[PubSubSJson] <- struct{}{}
+		case ConfigDBJson: // the sync interval is read on each check
 		}
 		return
 	}
@@ -1568,6 +1842,8 @@ func (cfg *CGRConfig) AsMapInterface(separator string) map[string]interface{} {
 		utils.CacheCfg:         cfg.cacheCfg.AsMapInterface(),
 		utils.ListenCfg:        cfg.listenCfg.AsMapInterface(),
 		utils.HttpCfg:          cfg.httpCfg.AsMapInterface(),
+		utils.RPCAuthCfg:       cfg.rpcAuthCfg.AsMapInterface(),
+		utils.ReqLimitersCfg:   cfg.reqLimitersCfg.AsMapInterface(),
 		utils.FilterSCfg:       cfg.filterSCfg.AsMapInterface(),
 		utils.RalsCfg:          cfg.ralsCfg.AsMapInterface(),
 		utils.SchedulerCfg:     cfg.schedulerCfg.AsMapInterface(),
@@ -1591,6 +1867,10 @@ func (cfg *CGRConfig) AsMapInterface(separator string) map[string]interface{} {
 		utils.MigratorCgrCfg:   cfg.migratorCgrCfg.AsMapInterface(),
 		utils.MailerCfg:        cfg.mailerCfg.AsMapInterface(),
 		utils.AnalyzerSCfg:     cfg.analyzerSCfg.AsMapInterface(),
+		utils.AuditSCfg:        cfg.auditSCfg.AsMapInterface(),
+		utils.TaxSCfg:          cfg.taxSCfg.AsMapInterface(),
+		utils.PubSubSCfg:       cfg.pubSubSCfg.AsMapInterface(),
+		utils.ConfigDBCfg:      cfg.configDBCfg.AsMapInterface(),
 		utils.Apier:            cfg.apier.AsMapInterface(),
 		utils.ErsCfg:           cfg.ersCfg.AsMapInterface(separator),
 	}
@@ -62,14 +62,20 @@ const (
 	RateSJson          = "rates"
 	RPCConnsJsonName   = "rpc_conns"
 	SIPAgentJson       = "sip_agent"
+	RPCAuthJson        = "rpc_auth"
+	ReqLimitersJson    = "request_limiters"
+	AuditSJson         = "audits"
+	TaxSJson           = "taxs"
+	PubSubSJson        = "pubsubs"
+	ConfigDBJson       = "config_db"
 )
 
 var (
-	sortedCfgSections = []string{GENERAL_JSN, RPCConnsJsonName, DATADB_JSN, STORDB_JSN, LISTEN_JSN, TlsCfgJson, HTTP_JSN, SCHEDULER_JSN,
+	sortedCfgSections = []string{GENERAL_JSN, RPCConnsJsonName, DATADB_JSN, STORDB_JSN, LISTEN_JSN, TlsCfgJson, HTTP_JSN, RPCAuthJson, ReqLimitersJson, SCHEDULER_JSN,
 		CACHE_JSN, FilterSjsn, RALS_JSN, CDRS_JSN, CDRE_JSN, ERsJson, SessionSJson, AsteriskAgentJSN, FreeSWITCHAgentJSN,
 		KamailioAgentJSN, DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON,
 		THRESHOLDS_JSON, RouteSJson, LoaderJson, MAILER_JSN, SURETAX_JSON, CgrLoaderCfgJson, CgrMigratorCfgJson, DispatcherSJson,
-		AnalyzerCfgJson, ApierS, EEsJson, RateSJson, SIPAgentJson}
+		AnalyzerCfgJson, ApierS, EEsJson, RateSJson, SIPAgentJson, AuditSJson, TaxSJson, PubSubSJson, ConfigDBJson}
 )
 
 // Loads the json config out of io.Reader, eg other sources than file, maybe over http
@@ -531,3 +537,75 @@ func (self CgrJsonCfg) SIPAgentJsonCfg() (*SIPAgentJsonCfg, error) {
 	}
 	return sipAgnt, nil
 }
+
+func (self CgrJsonCfg) RPCAuthJsonCfg() (*RPCAuthJsonCfg, error) {
+	rawCfg, hasKey := self[RPCAuthJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(RPCAuthJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (self CgrJsonCfg) ReqLimitersJsonCfg() (*ReqLimitersJsonCfg, error) {
+	rawCfg, hasKey := self[ReqLimitersJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(ReqLimitersJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (self CgrJsonCfg) AuditSJsonCfg() (*AuditSJsonCfg, error) {
+	rawCfg, hasKey := self[AuditSJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(AuditSJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (self CgrJsonCfg) TaxSJsonCfg() (*TaxSJsonCfg, error) {
+	rawCfg, hasKey := self[TaxSJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(TaxSJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (self CgrJsonCfg) PubSubSJsonCfg() (*PubSubSJsonCfg, error) {
+	rawCfg, hasKey := self[PubSubSJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(PubSubSJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (self CgrJsonCfg) ConfigDBJsonCfg() (*ConfigDBJsonCfg, error) {
+	rawCfg, hasKey := self[ConfigDBJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(ConfigDBJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -103,6 +103,12 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheTimings: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheFxRates: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CachePortedNumbers: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
 			utils.CacheResourceProfiles: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
@@ -142,6 +148,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheRateProfiles: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
+			utils.CacheTaxProfiles: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
 			utils.CacheDispatcherHosts: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Precache: utils.BoolPointer(false), Replicate: utils.BoolPointer(false)},
@@ -172,6 +181,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheRateFilterIndexes: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheTaxFilterIndexes: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheReverseFilterIndexes: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -218,6 +230,15 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheAccounts: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheProfileRevisions: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheStoredSessions: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheConfigSections: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 
 			utils.CacheTBLTPTimings: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
@@ -270,6 +291,9 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheCDRsTBL: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheAuditRecordsTBL: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 			utils.CacheTBLTPRoutes: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
@@ -288,6 +312,12 @@ func TestCacheJsonCfg(t *testing.T) {
 			utils.CacheTBLTPRateProfiles: {Limit: utils.IntPointer(-1),
 				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
 				Replicate: utils.BoolPointer(false)},
+			utils.CacheTBLTPFxRates: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
+			utils.CacheTBLTPPortedNumbers: {Limit: utils.IntPointer(-1),
+				Ttl: utils.StringPointer(""), Static_ttl: utils.BoolPointer(false),
+				Replicate: utils.BoolPointer(false)},
 		},
 		Replication_conns: &[]string{},
 	}
@@ -307,6 +337,8 @@ func TestDfListenJsonCfg(t *testing.T) {
 		Rpc_json_tls: utils.StringPointer("127.0.0.1:2022"),
 		Rpc_gob_tls:  utils.StringPointer("127.0.0.1:2023"),
 		Http_tls:     utils.StringPointer("127.0.0.1:2280"),
+		Rpc_grpc:     utils.StringPointer(""),
+		Rpc_grpc_tls: utils.StringPointer(""),
 	}
 	if cfg, err := dfCgrJSONCfg.ListenJsonCfg(); err != nil {
 		t.Error(err)
@@ -327,6 +359,7 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 		Query_timeout:     utils.StringPointer("10s"),
 		Replication_conns: &[]string{},
 		Remote_conns:      &[]string{},
+		Profile_revisions: utils.IntPointer(0),
 		Items: &map[string]*ItemOptJson{
 			utils.MetaAccounts: {
 				Replicate: utils.BoolPointer(false),
@@ -541,6 +574,14 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 				Replicate: utils.BoolPointer(false),
 				Remote:    utils.BoolPointer(false),
 			},
+			utils.CacheTBLTPFxRates: {
+				Replicate: utils.BoolPointer(false),
+				Remote:    utils.BoolPointer(false),
+			},
+			utils.CacheTBLTPPortedNumbers: {
+				Replicate: utils.BoolPointer(false),
+				Remote:    utils.BoolPointer(false),
+			},
 			utils.CacheTBLTPDispatcherHosts: {
 				Replicate: utils.BoolPointer(false),
 				Remote:    utils.BoolPointer(false),
@@ -549,6 +590,10 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 				Replicate: utils.BoolPointer(false),
 				Remote:    utils.BoolPointer(false),
 			},
+			utils.CacheAuditRecordsTBL: {
+				Replicate: utils.BoolPointer(false),
+				Remote:    utils.BoolPointer(false),
+			},
 			utils.CacheVersions: {
 				Replicate: utils.BoolPointer(false),
 				Remote:    utils.BoolPointer(false),
@@ -583,6 +628,7 @@ func TestDfRalsJsonCfg(t *testing.T) {
 			utils.VOICE: "*zero1s",
 		},
 		Dynaprepaid_actionplans: &[]string{},
+		Pubsubs_conns:           &[]string{},
 	}
 	if cfg, err := dfCgrJSONCfg.RalsJsonCfg(); err != nil {
 		t.Error(err)
@@ -618,6 +664,8 @@ func TestDfCdrsJsonCfg(t *testing.T) {
 		Online_cdr_exports:   &[]string{},
 		Scheduler_conns:      &[]string{},
 		Ees_conns:            &[]string{},
+		Taxs_conns:           &[]string{},
+		Pubsubs_conns:        &[]string{},
 	}
 	if cfg, err := dfCgrJSONCfg.CdrsJsonCfg(); err != nil {
 		t.Error(err)
@@ -745,6 +793,8 @@ func TestSmgJsonCfg(t *testing.T) {
 		Session_indexes:       &[]string{},
 		Client_protocol:       utils.Float64Pointer(1.0),
 		Channel_sync_interval: utils.StringPointer("0"),
+		Store_interval:        utils.StringPointer("0"),
+		Restore_grace_period:  utils.StringPointer("1m"),
 		Terminate_attempts:    utils.IntPointer(5),
 		Alterable_fields:      &[]string{},
 		Stir: &STIRJsonCfg{
@@ -752,9 +802,12 @@ func TestSmgJsonCfg(t *testing.T) {
 			Payload_maxduration: utils.StringPointer("-1"),
 			Default_attest:      utils.StringPointer("A"),
 			Privatekey_path:     utils.StringPointer(""),
+			Trust_anchors:       &[]string{},
+			Crls:                &[]string{},
 			Publickey_path:      utils.StringPointer(""),
 		},
 		Scheduler_conns: &[]string{},
+		Pubsubs_conns:   &[]string{},
 	}
 	if cfg, err := dfCgrJSONCfg.SessionSJsonCfg(); err != nil {
 		t.Error(err)
@@ -811,9 +864,11 @@ func TestKamAgentJsonCfg(t *testing.T) {
 
 func TestAsteriskAgentJsonCfg(t *testing.T) {
 	eCfg := &AsteriskAgentJsonCfg{
-		Enabled:        utils.BoolPointer(false),
-		Sessions_conns: &[]string{utils.MetaInternal},
-		Create_cdr:     utils.BoolPointer(false),
+		Enabled:              utils.BoolPointer(false),
+		Sessions_conns:       &[]string{utils.MetaInternal},
+		Create_cdr:           utils.BoolPointer(false),
+		Update_interval:      utils.StringPointer("0"),
+		Low_balance_ann_file: utils.StringPointer(""),
 		Asterisk_conns: &[]*AstConnJsonCfg{
 			{
 				Address:          utils.StringPointer("127.0.0.1:8088"),
@@ -833,20 +888,24 @@ func TestAsteriskAgentJsonCfg(t *testing.T) {
 
 func TestDiameterAgentJsonCfg(t *testing.T) {
 	eCfg := &DiameterAgentJsonCfg{
-		Enabled:              utils.BoolPointer(false),
-		Listen:               utils.StringPointer("127.0.0.1:3868"),
-		Listen_net:           utils.StringPointer(utils.TCP),
-		Dictionaries_path:    utils.StringPointer("/usr/share/cgrates/diameter/dict/"),
-		Sessions_conns:       &[]string{utils.MetaInternal},
-		Origin_host:          utils.StringPointer("CGR-DA"),
-		Origin_realm:         utils.StringPointer("cgrates.org"),
-		Vendor_id:            utils.IntPointer(0),
-		Product_name:         utils.StringPointer("CGRateS"),
-		Concurrent_requests:  utils.IntPointer(-1),
-		Synced_conn_requests: utils.BoolPointer(false),
-		Asr_template:         utils.StringPointer(""),
-		Rar_template:         utils.StringPointer(""),
-		Forced_disconnect:    utils.StringPointer(utils.META_NONE),
+		Enabled:                 utils.BoolPointer(false),
+		Listen:                  utils.StringPointer("127.0.0.1:3868"),
+		Listen_net:              utils.StringPointer(utils.TCP),
+		Dictionaries_path:       utils.StringPointer("/usr/share/cgrates/diameter/dict/"),
+		Sessions_conns:          &[]string{utils.MetaInternal},
+		Origin_host:             utils.StringPointer("CGR-DA"),
+		Origin_realm:            utils.StringPointer("cgrates.org"),
+		Vendor_id:               utils.IntPointer(0),
+		Product_name:            utils.StringPointer("CGRateS"),
+		Concurrent_requests:     utils.IntPointer(-1),
+		Synced_conn_requests:    utils.BoolPointer(false),
+		Asr_template:            utils.StringPointer(""),
+		Rar_template:            utils.StringPointer(""),
+		Forced_disconnect:       utils.StringPointer(utils.META_NONE),
+		Mscc_validity_time:      utils.StringPointer("0s"),
+		Final_unit_action:       utils.StringPointer(utils.MetaTerminate),
+		Redirect_server_address: utils.StringPointer(""),
+		Peers:                   &[]*DiameterPeerJsonCfg{},
 		Templates: map[string][]*FcTemplateJsonCfg{
 			utils.MetaErr: {
 				{
@@ -1122,6 +1181,7 @@ func TestDfStatServiceJsonCfg(t *testing.T) {
 		String_indexed_fields:    nil,
 		Prefix_indexed_fields:    &[]string{},
 		Nested_fields:            utils.BoolPointer(false),
+		Pubsubs_conns:            &[]string{},
 	}
 	if cfg, err := dfCgrJSONCfg.StatSJsonCfg(); err != nil {
 		t.Error(err)
@@ -1138,6 +1198,7 @@ func TestDfThresholdSJsonCfg(t *testing.T) {
 		String_indexed_fields: nil,
 		Prefix_indexed_fields: &[]string{},
 		Nested_fields:         utils.BoolPointer(false),
+		Pubsubs_conns:         &[]string{},
 	}
 	if cfg, err := dfCgrJSONCfg.ThresholdSJsonCfg(); err != nil {
 		t.Error(err)
@@ -1177,6 +1238,9 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 			Lock_filename:   utils.StringPointer(".cgr.lck"),
 			Caches_conns:    &[]string{utils.MetaInternal},
 			Field_separator: utils.StringPointer(","),
+			Source_type:     utils.StringPointer(utils.MetaFile),
+			Source_path:     utils.StringPointer(""),
+			Source_timeout:  utils.StringPointer("1m"),
 			Tp_in_dir:       utils.StringPointer("/var/spool/cgrates/loader/in"),
 			Tp_out_dir:      utils.StringPointer("/var/spool/cgrates/loader/out"),
 			Data: &[]*LoaderJsonDataType{
@@ -1778,6 +1842,7 @@ func TestDfHttpJsonCfg(t *testing.T) {
 	eCfg := &HTTPJsonCfg{
 		Json_rpc_url:        utils.StringPointer("/jsonrpc"),
 		Ws_url:              utils.StringPointer("/ws"),
+		Rest_url:            utils.StringPointer(""),
 		Freeswitch_cdrs_url: utils.StringPointer("/freeswitch_json"),
 		Http_Cdrs:           utils.StringPointer("/cdr_http"),
 		Use_basic_auth:      utils.BoolPointer(false),
@@ -1790,6 +1855,78 @@ func TestDfHttpJsonCfg(t *testing.T) {
 	}
 }
 
+func TestDfConfigDBJsonCfg(t *testing.T) {
+	eCfg := &ConfigDBJsonCfg{
+		Enabled:         utils.BoolPointer(false),
+		Sync_interval:   utils.StringPointer("10s"),
+		Local_overrides: &[]string{"listen"},
+	}
+	if cfg, err := dfCgrJSONCfg.ConfigDBJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Error("Received: ", utils.ToJSON(cfg))
+	}
+}
+
+func TestDfAuditSJsonCfg(t *testing.T) {
+	eCfg := &AuditSJsonCfg{
+		Enabled: utils.BoolPointer(false),
+		Methods: &[]string{"APIerSv1.Set*", "APIerSv1.Remove*", "APIerSv2.Set*", "APIerSv2.Remove*",
+			"APIerSv1.AddBalance", "APIerSv1.DebitBalance", "APIerSv1.DebitUsage", "APIerSv1.DebitUsageWithOptions",
+			"APIerSv1.ExecuteAction", "ConfigSv1.SetDBSection", "ConfigSv1.RemoveDBSection"},
+		Ees_conns: &[]string{},
+	}
+	if cfg, err := dfCgrJSONCfg.AuditSJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Error("Received: ", utils.ToJSON(cfg))
+	}
+}
+
+func TestDfTaxSJsonCfg(t *testing.T) {
+	eCfg := &TaxSJsonCfg{
+		Enabled:               utils.BoolPointer(false),
+		Indexed_selects:       utils.BoolPointer(true),
+		String_indexed_fields: nil,
+		Prefix_indexed_fields: &[]string{},
+		Nested_fields:         utils.BoolPointer(false),
+	}
+	if cfg, err := dfCgrJSONCfg.TaxSJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Error("Received: ", utils.ToJSON(cfg))
+	}
+}
+
+func TestDfRPCAuthJsonCfg(t *testing.T) {
+	eCfg := &RPCAuthJsonCfg{
+		Enabled:      utils.BoolPointer(false),
+		Api_keys:     utils.MapStringStringPointer(map[string]string{}),
+		Users:        utils.MapStringStringPointer(map[string]string{}),
+		Certificates: utils.MapStringStringPointer(map[string]string{}),
+		Guest_role:   utils.StringPointer(""),
+		Roles:        &map[string]*RPCRoleJsonCfg{},
+	}
+	if cfg, err := dfCgrJSONCfg.RPCAuthJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Error("Received: ", utils.ToJSON(cfg))
+	}
+}
+
+func TestDfReqLimitersJsonCfg(t *testing.T) {
+	eCfg := &ReqLimitersJsonCfg{
+		Enabled:    utils.BoolPointer(false),
+		Priorities: &map[string]int{},
+		Limiters:   &map[string]*ReqLimiterJsonCfg{},
+	}
+	if cfg, err := dfCgrJSONCfg.ReqLimitersJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Error("Received: ", utils.ToJSON(cfg))
+	}
+}
+
 func TestDfDispatcherSJsonCfg(t *testing.T) {
 	eCfg := &DispatcherSJsonCfg{
 		Enabled:               utils.BoolPointer(false),
@@ -429,6 +429,8 @@ func TestCgrCfgJSONDefaultsCDRS(t *testing.T) {
 		StatSConns:      []string{},
 		SchedulerConns:  []string{},
 		EEsConns:        []string{},
+		TaxSConns:       []string{},
+		PubSubSConns:    []string{},
 	}
 	if !reflect.DeepEqual(eCdrsCfg, cgrCfg.cdrsCfg) {
 		t.Errorf("Expecting: %+v , received: %+v", eCdrsCfg, cgrCfg.cdrsCfg)
@@ -605,14 +607,18 @@ func TestCgrCfgJSONDefaultsSMGenericCfg(t *testing.T) {
 		SessionIndexes:      utils.StringMap{},
 		ClientProtocol:      1.0,
 		ChannelSyncInterval: 0,
+		RestoreGracePeriod:  time.Minute,
 		TerminateAttempts:   5,
 		AlterableFields:     utils.NewStringSet([]string{}),
 		STIRCfg: &STIRcfg{
 			AllowedAttest:      utils.NewStringSet([]string{utils.META_ANY}),
 			PayloadMaxduration: -1,
 			DefaultAttest:      "A",
+			TrustAnchors:       []string{},
+			CRLs:               []string{},
 		},
 		SchedulerConns: []string{},
+		PubSubSConns:   []string{},
 	}
 	if !reflect.DeepEqual(eSessionSCfg, cgrCfg.sessionSCfg) {
 		t.Errorf("expecting: %s, received: %s",
@@ -643,6 +649,10 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheTimings: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheFxRates: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CachePortedNumbers: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheResourceProfiles: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheResources: {Limit: -1,
@@ -669,6 +679,8 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheRateProfiles: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheTaxProfiles: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheDispatcherHosts: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheResourceFilterIndexes: {Limit: -1,
@@ -689,6 +701,8 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheRateFilterIndexes: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheTaxFilterIndexes: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheReverseFilterIndexes: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheDispatcherRoutes: {Limit: -1,
@@ -720,6 +734,12 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheAccounts: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheProfileRevisions: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheStoredSessions: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheConfigSections: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheTBLTPTimings: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheTBLTPDestinations: {Limit: -1,
@@ -754,6 +774,8 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheCDRsTBL: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheAuditRecordsTBL: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheTBLTPRoutes: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheTBLTPAttributes: {Limit: -1,
@@ -766,6 +788,10 @@ func TestCgrCfgJSONDefaultsCacheCFG(t *testing.T) {
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 			utils.CacheTBLTPRateProfiles: {Limit: -1,
 				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheTBLTPFxRates: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
+			utils.CacheTBLTPPortedNumbers: {Limit: -1,
+				TTL: time.Duration(0), StaticTTL: false, Precache: false},
 		},
 		ReplicationConns: []string{},
 	}
@@ -878,6 +904,7 @@ func TestCgrCfgJSONDefaultStatsCfg(t *testing.T) {
 		ThresholdSConns:     []string{},
 		StringIndexedFields: nil,
 		PrefixIndexedFields: &[]string{},
+		PubSubSConns:        []string{},
 	}
 	if !reflect.DeepEqual(cgrCfg.statsCfg, eStatsCfg) {
 		t.Errorf("received: %+v, expecting: %+v", cgrCfg.statsCfg, eStatsCfg)
@@ -891,6 +918,7 @@ func TestCgrCfgJSONDefaultThresholdSCfg(t *testing.T) {
 		StoreInterval:       0,
 		StringIndexedFields: nil,
 		PrefixIndexedFields: &[]string{},
+		PubSubSConns:        []string{},
 	}
 	if !reflect.DeepEqual(eThresholdSCfg, cgrCfg.thresholdSCfg) {
 		t.Errorf("received: %+v, expecting: %+v", eThresholdSCfg, cgrCfg.thresholdSCfg)
@@ -1021,6 +1049,9 @@ func TestCgrCfgJSONDefaultsHTTP(t *testing.T) {
 	if cgrCfg.HTTPCfg().HTTPWSURL != "/ws" {
 		t.Errorf("expecting: /ws , received: %+v", cgrCfg.HTTPCfg().HTTPWSURL)
 	}
+	if cgrCfg.HTTPCfg().HTTPRESTURL != "" {
+		t.Errorf("expecting: empty string , received: %+v", cgrCfg.HTTPCfg().HTTPRESTURL)
+	}
 	if cgrCfg.HTTPCfg().HTTPFreeswitchCDRsURL != "/freeswitch_json" {
 		t.Errorf("expecting: /freeswitch_json , received: %+v", cgrCfg.HTTPCfg().HTTPFreeswitchCDRsURL)
 	}
@@ -1089,6 +1120,8 @@ func TestCgrLoaderCfgITDefaults(t *testing.T) {
 			LockFileName:   ".cgr.lck",
 			CacheSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)},
 			FieldSeparator: ",",
+			SourceType:     utils.MetaFile,
+			SourceTimeout:  time.Minute,
 			TpInDir:        "/var/spool/cgrates/loader/in",
 			TpOutDir:       "/var/spool/cgrates/loader/out",
 			Data: []*LoaderDataType{
@@ -1987,6 +2020,8 @@ func TestCgrCfgV1GetConfigSection(t *testing.T) {
 		"RPCGOBTLSListen":  "127.0.0.1:2023",
 		"RPCJSONListen":    ":2012",
 		"RPCJSONTLSListen": "127.0.0.1:2022",
+		"RPCGRPCListen":    "",
+		"RPCGRPCTLSListen": "",
 	}
 	var rcv map[string]interface{}
 	if cgrCfg, err := NewCGRConfigFromJsonStringWithDefaults(jsnCfg); err != nil {
@@ -0,0 +1,63 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ConfigDBCfg is the configuration of the sections shared by the engines over DataDB
+type ConfigDBCfg struct {
+	Enabled        bool
+	SyncInterval   time.Duration // interval to check DataDB for changed sections
+	LocalOverrides []string      // sections for which the local files take precedence over DataDB
+}
+
+func (cDB *ConfigDBCfg) loadFromJsonCfg(jsnCfg *ConfigDBJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		cDB.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Sync_interval != nil {
+		if cDB.SyncInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Sync_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Local_overrides != nil {
+		cDB.LocalOverrides = make([]string, len(*jsnCfg.Local_overrides))
+		copy(cDB.LocalOverrides, *jsnCfg.Local_overrides)
+	}
+	return
+}
+
+func (cDB *ConfigDBCfg) AsMapInterface() map[string]interface{} {
+	var syncInterval string = "0"
+	if cDB.SyncInterval != 0 {
+		syncInterval = cDB.SyncInterval.String()
+	}
+	return map[string]interface{}{
+		utils.EnabledCfg:        cDB.Enabled,
+		utils.SyncIntervalCfg:   syncInterval,
+		utils.LocalOverridesCfg: cDB.LocalOverrides,
+	}
+}
@@ -0,0 +1,118 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestConfigDBCfgloadFromJsonCfg(t *testing.T) {
+	var cDB, expected ConfigDBCfg
+	if err := cDB.loadFromJsonCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(cDB, expected) {
+		t.Errorf("Expected: %+v ,recived: %+v", expected, cDB)
+	}
+	cfgJSONStr := `{
+		"config_db": {
+			"enabled": true,
+			"sync_interval": "1m",
+			"local_overrides": ["listen", "general"],
+		},
+}`
+	expected = ConfigDBCfg{
+		Enabled:        true,
+		SyncInterval:   time.Minute,
+		LocalOverrides: []string{"listen", "general"},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnCDB, err := jsnCfg.ConfigDBJsonCfg(); err != nil {
+		t.Error(err)
+	} else if err = cDB.loadFromJsonCfg(jsnCDB); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, cDB) {
+		t.Errorf("Expected: %+v , recived: %+v", expected, cDB)
+	}
+	eMap := map[string]interface{}{
+		"enabled":         true,
+		"sync_interval":   "1m0s",
+		"local_overrides": []string{"listen", "general"},
+	}
+	if rcv := cDB.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("\nExpected: %+v\nRecived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+	if err := cDB.loadFromJsonCfg(&ConfigDBJsonCfg{Sync_interval: utils.StringPointer("1xs")}); err == nil {
+		t.Error("Expected error for invalid sync_interval")
+	}
+}
+
+func TestCGRConfigLoadDBSections(t *testing.T) {
+	cfgDir, err := ioutil.TempDir("", "config_db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cfgDir)
+	if err = ioutil.WriteFile(path.Join(cfgDir, "cgrates.json"), []byte(`{
+"listen": {"rpc_json": ":3012"},
+"config_db": {"enabled": true, "local_overrides": ["listen"]},
+}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cgrCfg, err := NewCGRConfigFromPath(cfgDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dbSections := map[string]string{
+		LISTEN_JSN:   `{"rpc_json": ":4012", "http": ":4080"}`,
+		GENERAL_JSN:  `{"default_tenant": "cgrates.net"}`,
+		SessionSJson: `{"enabled": true, "chargers_conns": ["*internal"]}`,
+	}
+	expErr := "<ChargerS> not enabled but requested by <SessionS> component."
+	if err = cgrCfg.CheckDBSections(dbSections); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+	delete(dbSections, SessionSJson)
+	if err = cgrCfg.CheckDBSections(dbSections); err != nil {
+		t.Error(err)
+	}
+	if err = cgrCfg.LoadDBSections(dbSections, false); err != nil {
+		t.Fatal(err)
+	}
+	if cgrCfg.GeneralCfg().DefaultTenant != "cgrates.net" {
+		t.Errorf("Expected default tenant cgrates.net, received: %s", cgrCfg.GeneralCfg().DefaultTenant)
+	}
+	if cgrCfg.ListenCfg().RPCJSONListen != ":3012" { // local file takes precedence
+		t.Errorf("Expected rpc_json :3012, received: %s", cgrCfg.ListenCfg().RPCJSONListen)
+	}
+	if cgrCfg.ListenCfg().HTTPListen != ":4080" {
+		t.Errorf("Expected http :4080, received: %s", cgrCfg.ListenCfg().HTTPListen)
+	}
+	expErr = "section <data_db> can not be stored in DataDB"
+	if err = cgrCfg.LoadDBSections(map[string]string{DATADB_JSN: `{}`}, false); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+}
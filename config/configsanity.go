@@ -21,6 +21,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"strings"
 
 	"github.com/cgrates/cgrates/utils"
@@ -50,6 +51,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RALService, connID)
 			}
 		}
+		for _, connID := range cfg.ralsCfg.PubSubSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.pubSubSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component.", utils.PubSubS, utils.RALService)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RALService, connID)
+			}
+		}
 	}
 	// CDRServer checks
 	if cfg.cdrsCfg.Enabled {
@@ -106,6 +115,22 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CDRs, connID)
 			}
 		}
+		for _, connID := range cfg.cdrsCfg.TaxSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.taxSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component.", utils.TaxS, utils.CDRs)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CDRs, connID)
+			}
+		}
+		for _, connID := range cfg.cdrsCfg.PubSubSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.pubSubSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component.", utils.PubSubS, utils.CDRs)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CDRs, connID)
+			}
+		}
 		for prfl, cdre := range cfg.CdreProfiles {
 			for _, field := range cdre.Fields {
 				if field.Type != utils.META_NONE && field.Path == utils.EmptyString {
@@ -119,10 +144,19 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		if !ldrSCfg.Enabled {
 			continue
 		}
-		for _, dir := range []string{ldrSCfg.TpInDir, ldrSCfg.TpOutDir} {
-			if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
-				return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, dir)
+		switch ldrSCfg.SourceType {
+		case utils.EmptyString, utils.MetaFile:
+			for _, dir := range []string{ldrSCfg.TpInDir, ldrSCfg.TpOutDir} {
+				if _, err := os.Stat(dir); err != nil && os.IsNotExist(err) {
+					return fmt.Errorf("<%s> nonexistent folder: %s", utils.LoaderS, dir)
+				}
+			}
+		case utils.MetaHTTP, utils.MetaS3, utils.MetaSQL:
+			if ldrSCfg.SourcePath == utils.EmptyString {
+				return fmt.Errorf("<%s> empty source_path for %s source of loader %s", utils.LoaderS, ldrSCfg.SourceType, ldrSCfg.Id)
 			}
+		default:
+			return fmt.Errorf("<%s> unsupported source_type %s for loader %s", utils.LoaderS, ldrSCfg.SourceType, ldrSCfg.Id)
 		}
 		for _, data := range ldrSCfg.Data {
 			if !posibleLoaderTypes.Has(data.Type) {
@@ -144,6 +178,9 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		if cfg.sessionSCfg.TerminateAttempts < 1 {
 			return fmt.Errorf("<%s> 'terminate_attempts' should be at least 1", utils.SessionS)
 		}
+		if cfg.sessionSCfg.StoreInterval != 0 && cfg.sessionSCfg.ChannelSyncInterval == 0 {
+			return fmt.Errorf("<%s> 'store_interval' requires 'channel_sync_interval' in order to reconcile the restored sessions", utils.SessionS)
+		}
 		for _, connID := range cfg.sessionSCfg.ChargerSConns {
 			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.chargerSCfg.Enabled {
 				return fmt.Errorf("<%s> not enabled but requested by <%s> component.", utils.ChargerS, utils.SessionS)
@@ -208,6 +245,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SessionS, connID)
 			}
 		}
+		for _, connID := range cfg.sessionSCfg.PubSubSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.pubSubSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component.", utils.PubSubS, utils.SessionS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SessionS, connID)
+			}
+		}
 		for _, connID := range cfg.sessionSCfg.ReplicationConns {
 			if _, has := cfg.rpcConns[connID]; !has {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SessionS, connID)
@@ -283,6 +328,23 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.DiameterAgent, connID)
 			}
 		}
+		if !utils.SliceHasMember([]string{utils.MetaRedirect, utils.MetaTerminate}, cfg.diameterAgentCfg.FinalUnitAction) {
+			return fmt.Errorf("<%s> unsupported final unit action %s", utils.DiameterAgent, cfg.diameterAgentCfg.FinalUnitAction)
+		}
+		if cfg.diameterAgentCfg.FinalUnitAction == utils.MetaRedirect &&
+			cfg.diameterAgentCfg.RedirectServer == utils.EmptyString {
+			return fmt.Errorf("<%s> %s required for %s final unit action", utils.DiameterAgent, utils.RedirectServerCfg, utils.MetaRedirect)
+		}
+		peerIDs := make(utils.StringSet)
+		for _, peer := range cfg.diameterAgentCfg.Peers {
+			if peer.ID == utils.EmptyString || peer.Address == utils.EmptyString {
+				return fmt.Errorf("<%s> %s for peer %s", utils.DiameterAgent, utils.NewErrMandatoryIeMissing(utils.IDCfg, utils.AddressCfg), peer.ID)
+			}
+			if peerIDs.Has(peer.ID) {
+				return fmt.Errorf("<%s> duplicated peer with id: <%s>", utils.DiameterAgent, peer.ID)
+			}
+			peerIDs.Add(peer.ID)
+		}
 		for prf, tmp := range cfg.diameterAgentCfg.Templates {
 			for _, field := range tmp {
 				if field.Type != utils.META_NONE && field.Path == utils.EmptyString {
@@ -372,10 +434,10 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> template with ID <%s> has connection with id: <%s> not defined", utils.HTTPAgent, httpAgentCfg.ID, connID)
 			}
 		}
-		if !utils.SliceHasMember([]string{utils.MetaUrl, utils.MetaXml}, httpAgentCfg.RequestPayload) {
+		if !utils.SliceHasMember([]string{utils.MetaForm, utils.MetaJSON, utils.MetaUrl, utils.MetaXml}, httpAgentCfg.RequestPayload) {
 			return fmt.Errorf("<%s> unsupported request payload %s", utils.HTTPAgent, httpAgentCfg.RequestPayload)
 		}
-		if !utils.SliceHasMember([]string{utils.MetaTextPlain, utils.MetaXml}, httpAgentCfg.ReplyPayload) {
+		if !utils.SliceHasMember([]string{utils.MetaJSON, utils.MetaTextPlain, utils.MetaXml}, httpAgentCfg.ReplyPayload) {
 			return fmt.Errorf("<%s> unsupported reply payload %s", utils.HTTPAgent, httpAgentCfg.ReplyPayload)
 		}
 		for _, req := range httpAgentCfg.RequestProcessors {
@@ -456,6 +518,25 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.StatS, connID)
 			}
 		}
+		for _, connID := range cfg.statsCfg.PubSubSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.pubSubSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component.", utils.PubSubS, utils.StatS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.StatS, connID)
+			}
+		}
+	}
+	// ThresholdS checks
+	if cfg.thresholdSCfg.Enabled {
+		for _, connID := range cfg.thresholdSCfg.PubSubSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.pubSubSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component.", utils.PubSubS, utils.ThresholdS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.ThresholdS, connID)
+			}
+		}
 	}
 	// RouteS checks
 	if cfg.routeSCfg.Enabled {
@@ -610,6 +691,17 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("replicate connections required by: <%s>", item)
 		}
 	}
+	// ConfigDB sanity checks
+	if cfg.configDBCfg.Enabled {
+		if cfg.configDBCfg.SyncInterval <= 0 {
+			return fmt.Errorf("<%s> the sync_interval needs to be positive, received: %s", utils.ConfigDBCfg, cfg.configDBCfg.SyncInterval)
+		}
+		for _, section := range cfg.configDBCfg.LocalOverrides {
+			if err := CheckDBSection(section); err != nil {
+				return fmt.Errorf("<%s> %s", utils.ConfigDBCfg, err.Error())
+			}
+		}
+	}
 	// APIer sanity checks
 	for _, connID := range cfg.apier.AttributeSConns {
 		if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.attributeSCfg.Enabled {
@@ -680,6 +772,71 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.FilterS, connID)
 		}
 	}
+	// AuditS sanity check
+	if cfg.auditSCfg.Enabled {
+		for _, pattern := range cfg.auditSCfg.Methods {
+			if _, err := path.Match(pattern, utils.EmptyString); err != nil {
+				return fmt.Errorf("<%s> invalid method pattern <%s>", utils.AuditS, pattern)
+			}
+		}
+		for _, connID := range cfg.auditSCfg.EEsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.eesCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component.", utils.EEs, utils.AuditS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.AuditS, connID)
+			}
+		}
+	}
+	// RPCAuth sanity check
+	if cfg.rpcAuthCfg.Enabled {
+		for name, role := range cfg.rpcAuthCfg.Roles {
+			for _, pattern := range role.Methods {
+				if _, err := path.Match(pattern, utils.EmptyString); err != nil {
+					return fmt.Errorf("<%s> invalid method pattern <%s> for role <%s>", utils.RPCAuthCfg, pattern, name)
+				}
+			}
+		}
+		for _, roles := range []map[string]string{cfg.rpcAuthCfg.APIKeys,
+			cfg.rpcAuthCfg.Users, cfg.rpcAuthCfg.Certificates} {
+			for _, role := range roles {
+				if _, has := cfg.rpcAuthCfg.Roles[role]; !has {
+					return fmt.Errorf("<%s> role <%s> not defined", utils.RPCAuthCfg, role)
+				}
+			}
+		}
+		if _, has := cfg.rpcAuthCfg.Roles[cfg.rpcAuthCfg.GuestRole]; !has &&
+			cfg.rpcAuthCfg.GuestRole != utils.EmptyString {
+			return fmt.Errorf("<%s> role <%s> not defined", utils.RPCAuthCfg, cfg.rpcAuthCfg.GuestRole)
+		}
+	}
+	// RequestLimiters sanity check
+	if cfg.reqLimitersCfg.Enabled {
+		for pattern := range cfg.reqLimitersCfg.Priorities {
+			if _, err := path.Match(pattern, utils.EmptyString); err != nil {
+				return fmt.Errorf("<%s> invalid method pattern <%s> in priorities", utils.ReqLimitersCfg, pattern)
+			}
+		}
+		for id, prf := range cfg.reqLimitersCfg.Limiters {
+			if strings.Contains(id, utils.CONCATENATED_KEY_SEP) {
+				return fmt.Errorf("<%s> invalid limiter ID <%s>", utils.ReqLimitersCfg, id)
+			}
+			for _, pattern := range prf.Methods {
+				if _, err := path.Match(pattern, utils.EmptyString); err != nil {
+					return fmt.Errorf("<%s> invalid method pattern <%s> for limiter <%s>", utils.ReqLimitersCfg, pattern, id)
+				}
+			}
+			if !utils.SliceHasMember([]string{utils.MetaBusy, utils.MetaQueue}, prf.Strategy) {
+				return fmt.Errorf("<%s> unsupported strategy <%s> for limiter <%s>", utils.ReqLimitersCfg, prf.Strategy, id)
+			}
+			if prf.ConcurrentRequests < 0 || prf.RateLimit < 0 {
+				return fmt.Errorf("<%s> negative limits for limiter <%s>", utils.ReqLimitersCfg, id)
+			}
+			if prf.RateLimit != 0 && prf.RateInterval <= 0 {
+				return fmt.Errorf("<%s> rate_interval needs to be positive for limiter <%s>", utils.ReqLimitersCfg, id)
+			}
+		}
+	}
 
 	return nil
 }
@@ -19,6 +19,7 @@ package config
 
 import (
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -50,6 +51,17 @@ func TestConfigSanityRater(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.ralsCfg.ThresholdSConns = []string{}
+	cfg.ralsCfg.PubSubSConns = []string{utils.MetaInternal}
+	expected = "<PubSubS> not enabled but requested by <RALs> component."
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.ralsCfg.PubSubSConns = []string{"test"}
+	expected = "<RALs> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityCDRServer(t *testing.T) {
@@ -145,6 +157,18 @@ func TestConfigSanityLoaders(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 
+	cfg.loaderCfg[0].Id = "remote"
+	cfg.loaderCfg[0].SourceType = utils.MetaHTTP
+	expected = "<LoaderS> empty source_path for *http source of loader remote"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.loaderCfg[0].SourceType = "*ftp"
+	expected = "<LoaderS> unsupported source_type *ftp for loader remote"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+
 	cfg.loaderCfg = LoaderSCfgs{
 		&LoaderSCfg{
 			Enabled:  true,
@@ -198,6 +222,13 @@ func TestConfigSanitySessionS(t *testing.T) {
 	}
 	cfg.sessionSCfg.TerminateAttempts = 1
 
+	cfg.sessionSCfg.StoreInterval = time.Minute
+	expected = "<SessionS> 'store_interval' requires 'channel_sync_interval' in order to reconcile the restored sessions"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.sessionSCfg.StoreInterval = 0
+
 	cfg.sessionSCfg.ChargerSConns = []string{utils.MetaInternal}
 	expected = "<ChargerS> not enabled but requested by <SessionS> component."
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
@@ -411,6 +442,32 @@ func TestConfigSanityDAgent(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.sessionSCfg.Enabled = true
+	cfg.diameterAgentCfg.SessionSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)}
+	cfg.diameterAgentCfg.FinalUnitAction = "*wrong"
+	expected = "<DiameterAgent> unsupported final unit action *wrong"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.FinalUnitAction = utils.MetaRedirect
+	expected = "<DiameterAgent> redirect_server_address required for *redirect final unit action"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.RedirectServer = "http://127.0.0.1/topup"
+	cfg.diameterAgentCfg.Peers = []*DiameterPeerCfg{{ID: "OCS1"}}
+	expected = "<DiameterAgent> MANDATORY_IE_MISSING: [id address] for peer OCS1"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.diameterAgentCfg.Peers = []*DiameterPeerCfg{
+		{ID: "OCS1", Address: "127.0.0.1:3869"},
+		{ID: "OCS1", Address: "127.0.0.1:3870"},
+	}
+	expected = "<DiameterAgent> duplicated peer with id: <OCS1>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityRadiusAgent(t *testing.T) {
@@ -559,6 +616,29 @@ func TestConfigSanityStatS(t *testing.T) {
 	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
+	cfg.statsCfg.ThresholdSConns = []string{}
+	cfg.statsCfg.PubSubSConns = []string{utils.MetaInternal}
+	expected = "<PubSubS> not enabled but requested by <Stats> component."
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+}
+
+func TestConfigSanityThresholdS(t *testing.T) {
+	cfg, _ = NewDefaultCGRConfig()
+	cfg.thresholdSCfg = &ThresholdSCfg{
+		Enabled:      true,
+		PubSubSConns: []string{utils.MetaInternal},
+	}
+	expected := "<PubSubS> not enabled but requested by <ThresholdS> component."
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.thresholdSCfg.PubSubSConns = []string{"test"}
+	expected = "<ThresholdS> connection with id: <test> not defined"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
 }
 
 func TestConfigSanityRouteS(t *testing.T) {
@@ -887,3 +967,56 @@ func TestConfigSanityFilterS(t *testing.T) {
 		t.Errorf("Expecting: %+q  received: %+q", expected, err)
 	}
 }
+
+func TestConfigSanityConfigDB(t *testing.T) {
+	cfg, _ = NewDefaultCGRConfig()
+	cfg.configDBCfg.Enabled = true
+	cfg.configDBCfg.SyncInterval = 0
+	expected := "<config_db> the sync_interval needs to be positive, received: 0s"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.configDBCfg.SyncInterval = time.Second
+	cfg.configDBCfg.LocalOverrides = []string{DATADB_JSN}
+	expected = "<config_db> section <data_db> can not be stored in DataDB"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.configDBCfg.LocalOverrides = []string{"invalid"}
+	expected = "<config_db> Invalid section: <invalid>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.configDBCfg.LocalOverrides = []string{LISTEN_JSN}
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestConfigSanityReqLimiters(t *testing.T) {
+	cfg, _ = NewDefaultCGRConfig()
+	cfg.reqLimitersCfg.Enabled = true
+	cfg.reqLimitersCfg.Limiters = map[string]*utils.RequestLimiterProfile{
+		"bulk": {Methods: []string{"APIerSv1.*"}, Strategy: "*reject"},
+	}
+	expected := "<request_limiters> unsupported strategy <*reject> for limiter <bulk>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.reqLimitersCfg.Limiters["bulk"].Strategy = utils.MetaQueue
+	cfg.reqLimitersCfg.Limiters["bulk"].RateLimit = 10
+	expected = "<request_limiters> rate_interval needs to be positive for limiter <bulk>"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.reqLimitersCfg.Limiters["bulk"].RateInterval = time.Second
+	cfg.reqLimitersCfg.Priorities = map[string]int{"[": 10}
+	expected = "<request_limiters> invalid method pattern <[> in priorities"
+	if err := cfg.checkConfigSanity(); err == nil || err.Error() != expected {
+		t.Errorf("Expecting: %+q  received: %+q", expected, err)
+	}
+	cfg.reqLimitersCfg.Priorities = map[string]int{"SessionSv1.*": 10}
+	if err := cfg.checkConfigSanity(); err != nil {
+		t.Error(err)
+	}
+}
@@ -39,6 +39,7 @@ type DataDbCfg struct {
 	QueryTimeout       time.Duration
 	RmtConns           []string // Remote DataDB  connIDs
 	RplConns           []string // Replication connIDs
+	ProfileRevisions   int      // number of revisions kept for each profile, 0 to disable the history
 	Items              map[string]*ItemOpt
 }
 
@@ -99,6 +100,9 @@ func (dbcfg *DataDbCfg) loadFromJsonCfg(jsnDbCfg *DbJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnDbCfg.Profile_revisions != nil {
+		dbcfg.ProfileRevisions = *jsnDbCfg.Profile_revisions
+	}
 	if jsnDbCfg.Items != nil {
 		for kJsn, vJsn := range *jsnDbCfg.Items {
 			val, has := dbcfg.Items[kJsn]
@@ -125,6 +129,7 @@ func (dbcfg *DataDbCfg) Clone() *DataDbCfg {
 		DataDbPass:         dbcfg.DataDbPass,
 		DataDbSentinelName: dbcfg.DataDbSentinelName,
 		QueryTimeout:       dbcfg.QueryTimeout,
+		ProfileRevisions:   dbcfg.ProfileRevisions,
 		Items:              dbcfg.Items,
 	}
 }
@@ -151,6 +156,7 @@ func (dbcfg *DataDbCfg) AsMapInterface() map[string]interface{} {
 		utils.QueryTimeoutCfg:       queryTimeout,
 		utils.RmtConnsCfg:           dbcfg.RmtConns,
 		utils.RplConnsCfg:           dbcfg.RplConns,
+		utils.ProfileRevisionsCfg:   dbcfg.ProfileRevisions,
 		utils.ItemsCfg:              items,
 	}
 }
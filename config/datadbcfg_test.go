@@ -404,6 +404,7 @@ func TestDataDbCfgAsMapInterface(t *testing.T) {
 		"query_timeout":     "10s",
 		"remote_conns":      []string{},
 		"replication_conns": []string{},
+		"profile_revisions": 0,
 		"items": map[string]interface{}{
 			"*accounts":             map[string]interface{}{"remote": true, "replicate": false, "APIKey": "", "RouteID": ""},
 			"*reverse_destinations": map[string]interface{}{"remote": false, "replicate": false, "APIKey": "", "RouteID": ""},
@@ -20,6 +20,7 @@ package config
 
 import (
 	"strings"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -39,6 +40,10 @@ type DiameterAgentCfg struct {
 	ASRTemplate       string
 	RARTemplate       string
 	ForcedDisconnect  string
+	MSCCValidityTime  time.Duration      // Validity-Time sent back inside each granted MSCC
+	FinalUnitAction   string             // action requested on the last granted unit: <*terminate|*redirect>
+	RedirectServer    string             // Redirect-Server-Address used with *redirect final unit action
+	Peers             []*DiameterPeerCfg // upstream peers used for outgoing requests
 	Templates         map[string][]*FCTemplate
 	RequestProcessors []*RequestProcessor
 }
@@ -97,6 +102,26 @@ func (da *DiameterAgentCfg) loadFromJsonCfg(jsnCfg *DiameterAgentJsonCfg, separa
 	if jsnCfg.Forced_disconnect != nil {
 		da.ForcedDisconnect = *jsnCfg.Forced_disconnect
 	}
+	if jsnCfg.Mscc_validity_time != nil {
+		if da.MSCCValidityTime, err = utils.ParseDurationWithNanosecs(*jsnCfg.Mscc_validity_time); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Final_unit_action != nil {
+		da.FinalUnitAction = *jsnCfg.Final_unit_action
+	}
+	if jsnCfg.Redirect_server_address != nil {
+		da.RedirectServer = *jsnCfg.Redirect_server_address
+	}
+	if jsnCfg.Peers != nil {
+		da.Peers = make([]*DiameterPeerCfg, len(*jsnCfg.Peers))
+		for i, jsnPeer := range *jsnCfg.Peers {
+			da.Peers[i] = newDefaultDiameterPeerCfg()
+			if err = da.Peers[i].loadFromJsonCfg(jsnPeer); err != nil {
+				return
+			}
+		}
+	}
 	if jsnCfg.Templates != nil {
 		if da.Templates == nil {
 			da.Templates = make(map[string][]*FCTemplate)
@@ -155,6 +180,16 @@ func (ds *DiameterAgentCfg) AsMapInterface(separator string) map[string]interfac
 		}
 	}
 
+	peers := make([]map[string]interface{}, len(ds.Peers))
+	for i, peer := range ds.Peers {
+		peers[i] = peer.AsMapInterface()
+	}
+
+	msccValidityTime := "0"
+	if ds.MSCCValidityTime != 0 {
+		msccValidityTime = ds.MSCCValidityTime.String()
+	}
+
 	return map[string]interface{}{
 		utils.EnabledCfg:           ds.Enabled,
 		utils.ListenNetCfg:         ds.ListenNet,
@@ -170,7 +205,106 @@ func (ds *DiameterAgentCfg) AsMapInterface(separator string) map[string]interfac
 		utils.ASRTemplateCfg:       ds.ASRTemplate,
 		utils.RARTemplateCfg:       ds.RARTemplate,
 		utils.ForcedDisconnectCfg:  ds.ForcedDisconnect,
+		utils.MSCCValidityTimeCfg:  msccValidityTime,
+		utils.FinalUnitActionCfg:   ds.FinalUnitAction,
+		utils.RedirectServerCfg:    ds.RedirectServer,
+		utils.PeersCfg:             peers,
 		utils.TemplatesCfg:         templates,
 		utils.RequestProcessorsCfg: requestProcessors,
 	}
 }
+
+// DiameterPeerCfg is an upstream peer towards which the DiameterAgent originates requests
+type DiameterPeerCfg struct {
+	ID                 string
+	Address            string // address of the peer <x.y.z.y:3868>
+	Transport          string // <tcp|sctp>
+	OriginHost         string // Origin-Host of the peer, used to route on Destination-Host
+	OriginRealm        string // Origin-Realm of the peer, used to route on Destination-Realm
+	AuthApplicationIDs []uint32
+	Weight             int // peers with higher weight are used first
+	WatchdogInterval   time.Duration
+	ReplyTimeout       time.Duration
+	Reconnects         int // number of reconnect attempts, -1 for unlimited
+	ReconnectInterval  time.Duration
+}
+
+// newDefaultDiameterPeerCfg returns the peer with the values used when missing from configuration
+func newDefaultDiameterPeerCfg() *DiameterPeerCfg {
+	return &DiameterPeerCfg{
+		Transport:          utils.TCP,
+		AuthApplicationIDs: []uint32{4}, // Credit-Control
+		WatchdogInterval:   5 * time.Second,
+		ReplyTimeout:       2 * time.Second,
+		Reconnects:         -1,
+		ReconnectInterval:  5 * time.Second,
+	}
+}
+
+func (dp *DiameterPeerCfg) loadFromJsonCfg(jsnCfg *DiameterPeerJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Id != nil {
+		dp.ID = *jsnCfg.Id
+	}
+	if jsnCfg.Address != nil {
+		dp.Address = *jsnCfg.Address
+	}
+	if jsnCfg.Transport != nil {
+		dp.Transport = *jsnCfg.Transport
+	}
+	if jsnCfg.Origin_host != nil {
+		dp.OriginHost = *jsnCfg.Origin_host
+	}
+	if jsnCfg.Origin_realm != nil {
+		dp.OriginRealm = *jsnCfg.Origin_realm
+	}
+	if jsnCfg.Auth_application_ids != nil {
+		dp.AuthApplicationIDs = make([]uint32, len(*jsnCfg.Auth_application_ids))
+		for i, appID := range *jsnCfg.Auth_application_ids {
+			dp.AuthApplicationIDs[i] = uint32(appID)
+		}
+	}
+	if jsnCfg.Weight != nil {
+		dp.Weight = *jsnCfg.Weight
+	}
+	if jsnCfg.Watchdog_interval != nil {
+		if dp.WatchdogInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Watchdog_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Reply_timeout != nil {
+		if dp.ReplyTimeout, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reply_timeout); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Reconnects != nil {
+		dp.Reconnects = *jsnCfg.Reconnects
+	}
+	if jsnCfg.Reconnect_interval != nil {
+		if dp.ReconnectInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Reconnect_interval); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (dp *DiameterPeerCfg) AsMapInterface() map[string]interface{} {
+	authAppIDs := make([]uint32, len(dp.AuthApplicationIDs))
+	copy(authAppIDs, dp.AuthApplicationIDs)
+	return map[string]interface{}{
+		utils.IDCfg:                 dp.ID,
+		utils.AddressCfg:            dp.Address,
+		utils.TransportCfg:          dp.Transport,
+		utils.OriginHostCfg:         dp.OriginHost,
+		utils.OriginRealmCfg:        dp.OriginRealm,
+		utils.AuthApplicationIDsCfg: authAppIDs,
+		utils.WeightCfg:             dp.Weight,
+		utils.WatchdogIntervalCfg:   dp.WatchdogInterval.String(),
+		utils.ReplyTimeoutCfg:       dp.ReplyTimeout.String(),
+		utils.ReconnectsCfg:         dp.Reconnects,
+		utils.ReconnectIntervalCfg:  dp.ReconnectInterval.String(),
+	}
+}
@@ -86,16 +86,37 @@ func TestDiameterAgentCfgAsMapInterface(t *testing.T) {
 		"vendor_id": 0,												
 		"product_name": "CGRateS",									
 		"synced_conn_requests": true,
+		"peers": [
+			{"id": "OCS1", "address": "127.0.0.1:3869", "origin_realm": "ocs.org", "weight": 10},
+		],
 		"templates":{},
 		"request_processors": [],
 	},
 }`
 	eMap := map[string]interface{}{
-		"asr_template":         "",
-		"concurrent_requests":  0,
-		"dictionaries_path":    "/usr/share/cgrates/diameter/dict/",
-		"enabled":              false,
-		"forced_disconnect":    "",
+		"asr_template":            "",
+		"concurrent_requests":     0,
+		"dictionaries_path":       "/usr/share/cgrates/diameter/dict/",
+		"enabled":                 false,
+		"forced_disconnect":       "",
+		"mscc_validity_time":      "0",
+		"final_unit_action":       "",
+		"redirect_server_address": "",
+		"peers": []map[string]interface{}{
+			{
+				"id":                   "OCS1",
+				"address":              "127.0.0.1:3869",
+				"transport":            "tcp",
+				"origin_host":          "",
+				"origin_realm":         "ocs.org",
+				"auth_application_ids": []uint32{4},
+				"weight":               10,
+				"watchdog_interval":    "5s",
+				"reply_timeout":        "2s",
+				"reconnects":           -1,
+				"reconnect_interval":   "5s",
+			},
+		},
 		"listen":               "127.0.0.1:3868",
 		"listen_net":           "",
 		"origin_host":          "CGR-DA",
@@ -24,6 +24,7 @@ import "github.com/cgrates/cgrates/utils"
 type HTTPCfg struct {
 	HTTPJsonRPCURL        string            // JSON RPC relative URL ("" to disable)
 	HTTPWSURL             string            // WebSocket relative URL ("" to disable)
+	HTTPRESTURL           string            // REST API relative URL ("" to disable)
 	HTTPFreeswitchCDRsURL string            // Freeswitch CDRS relative URL ("" to disable)
 	HTTPCDRsURL           string            // CDRS relative URL ("" to disable)
 	HTTPUseBasicAuth      bool              // Use basic auth for HTTP API
@@ -41,6 +42,9 @@ func (httpcfg *HTTPCfg) loadFromJsonCfg(jsnHttpCfg *HTTPJsonCfg) (err error) {
 	if jsnHttpCfg.Ws_url != nil {
 		httpcfg.HTTPWSURL = *jsnHttpCfg.Ws_url
 	}
+	if jsnHttpCfg.Rest_url != nil {
+		httpcfg.HTTPRESTURL = *jsnHttpCfg.Rest_url
+	}
 	if jsnHttpCfg.Freeswitch_cdrs_url != nil {
 		httpcfg.HTTPFreeswitchCDRsURL = *jsnHttpCfg.Freeswitch_cdrs_url
 	}
@@ -66,6 +70,7 @@ func (httpcfg *HTTPCfg) AsMapInterface() map[string]interface{} {
 	return map[string]interface{}{
 		utils.HTTPJsonRPCURLCfg:        httpcfg.HTTPJsonRPCURL,
 		utils.HTTPWSURLCfg:             httpcfg.HTTPWSURL,
+		utils.HTTPRESTURLCfg:           httpcfg.HTTPRESTURL,
 		utils.HTTPFreeswitchCDRsURLCfg: httpcfg.HTTPFreeswitchCDRsURL,
 		utils.HTTPCDRsURLCfg:           httpcfg.HTTPCDRsURL,
 		utils.HTTPUseBasicAuthCfg:      httpcfg.HTTPUseBasicAuth,
@@ -40,6 +40,7 @@ func TestHTTPCfgloadFromJsonCfg(t *testing.T) {
 "http": {										// HTTP server configuration
 	"json_rpc_url": "/jsonrpc",					// JSON RPC relative URL ("" to disable)
 	"ws_url": "/ws",							// WebSockets relative URL ("" to disable)
+	"rest_url": "/rest",						// REST API relative URL ("" to disable)
 	"freeswitch_cdrs_url": "/freeswitch_json",	// Freeswitch CDRS relative URL ("" to disable)
 	"http_cdrs": "/cdr_http",					// CDRS relative URL ("" to disable)
 	"use_basic_auth": false,					// use basic authentication
@@ -49,6 +50,7 @@ func TestHTTPCfgloadFromJsonCfg(t *testing.T) {
 	expected = HTTPCfg{
 		HTTPJsonRPCURL:        "/jsonrpc",
 		HTTPWSURL:             "/ws",
+		HTTPRESTURL:           "/rest",
 		HTTPFreeswitchCDRsURL: "/freeswitch_json",
 		HTTPCDRsURL:           "/cdr_http",
 		HTTPUseBasicAuth:      false,
@@ -71,6 +73,7 @@ func TestHTTPCfgAsMapInterface(t *testing.T) {
 	"http": {										
 		"json_rpc_url": "/jsonrpc",					
 		"ws_url": "/ws",							
+		"rest_url": "/rest",
 		"freeswitch_cdrs_url": "/freeswitch_json",	
 		"http_cdrs": "/cdr_http",					
 		"use_basic_auth": false,					
@@ -81,6 +84,7 @@ func TestHTTPCfgAsMapInterface(t *testing.T) {
 	eMap := map[string]interface{}{
 		"json_rpc_url":        "/jsonrpc",
 		"ws_url":              "/ws",
+		"rest_url":            "/rest",
 		"freeswitch_cdrs_url": "/freeswitch_json",
 		"http_cdrs":           "/cdr_http",
 		"use_basic_auth":      false,
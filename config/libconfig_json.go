@@ -58,12 +58,15 @@ type ListenJsonCfg struct {
 	Rpc_json_tls *string
 	Rpc_gob_tls  *string
 	Http_tls     *string
+	Rpc_grpc     *string
+	Rpc_grpc_tls *string
 }
 
 // HTTP config section
 type HTTPJsonCfg struct {
 	Json_rpc_url        *string
 	Ws_url              *string
+	Rest_url            *string
 	Freeswitch_cdrs_url *string
 	Http_Cdrs           *string
 	Use_basic_auth      *bool
@@ -98,6 +101,7 @@ type DbJsonCfg struct {
 	Sslmode               *string // Used only in case of storDb
 	Remote_conns          *[]string
 	Replication_conns     *[]string
+	Profile_revisions     *int // Used only in case of dataDb
 	Items                 *map[string]*ItemOptJson
 }
 
@@ -128,6 +132,7 @@ type RalsJsonCfg struct {
 	Max_increments             *int
 	Balance_rating_subject     *map[string]string
 	Dynaprepaid_actionplans    *[]string
+	Pubsubs_conns              *[]string
 }
 
 // Scheduler config section
@@ -151,6 +156,8 @@ type CdrsJsonCfg struct {
 	Online_cdr_exports   *[]string
 	Scheduler_conns      *[]string
 	Ees_conns            *[]string
+	Taxs_conns           *[]string
+	Pubsubs_conns        *[]string
 }
 
 // Cdre config section
@@ -246,10 +253,13 @@ type SessionSJsonCfg struct {
 	Session_indexes        *[]string
 	Client_protocol        *float64
 	Channel_sync_interval  *string
+	Store_interval         *string
+	Restore_grace_period   *string
 	Terminate_attempts     *int
 	Alterable_fields       *[]string
 	Min_dur_low_balance    *string
 	Scheduler_conns        *[]string
+	Pubsubs_conns          *[]string
 	Stir                   *STIRJsonCfg
 }
 
@@ -299,10 +309,12 @@ type AstConnJsonCfg struct {
 }
 
 type AsteriskAgentJsonCfg struct {
-	Enabled        *bool
-	Sessions_conns *[]string
-	Create_cdr     *bool
-	Asterisk_conns *[]*AstConnJsonCfg
+	Enabled              *bool
+	Sessions_conns       *[]string
+	Create_cdr           *bool
+	Update_interval      *string
+	Low_balance_ann_file *string
+	Asterisk_conns       *[]*AstConnJsonCfg
 }
 
 type CacheParamJsonCfg struct {
@@ -341,22 +353,41 @@ type OsipsConnJsonCfg struct {
 
 // DiameterAgent configuration
 type DiameterAgentJsonCfg struct {
-	Enabled              *bool
-	Listen               *string
-	Listen_net           *string
-	Dictionaries_path    *string
-	Sessions_conns       *[]string
+	Enabled                 *bool
+	Listen                  *string
+	Listen_net              *string
+	Dictionaries_path       *string
+	Sessions_conns          *[]string
+	Origin_host             *string
+	Origin_realm            *string
+	Vendor_id               *int
+	Product_name            *string
+	Concurrent_requests     *int
+	Synced_conn_requests    *bool
+	Asr_template            *string
+	Rar_template            *string
+	Forced_disconnect       *string
+	Mscc_validity_time      *string
+	Final_unit_action       *string
+	Redirect_server_address *string
+	Peers                   *[]*DiameterPeerJsonCfg
+	Templates               map[string][]*FcTemplateJsonCfg
+	Request_processors      *[]*ReqProcessorJsnCfg
+}
+
+// DiameterPeerJsonCfg is an upstream peer of the DiameterAgent
+type DiameterPeerJsonCfg struct {
+	Id                   *string
+	Address              *string
+	Transport            *string
 	Origin_host          *string
 	Origin_realm         *string
-	Vendor_id            *int
-	Product_name         *string
-	Concurrent_requests  *int
-	Synced_conn_requests *bool
-	Asr_template         *string
-	Rar_template         *string
-	Forced_disconnect    *string
-	Templates            map[string][]*FcTemplateJsonCfg
-	Request_processors   *[]*ReqProcessorJsnCfg
+	Auth_application_ids *[]int
+	Weight               *int
+	Watchdog_interval    *string
+	Reply_timeout        *string
+	Reconnects           *int
+	Reconnect_interval   *string
 }
 
 // Radius Agent configuration section
@@ -443,6 +474,7 @@ type StatServJsonCfg struct {
 	String_indexed_fields    *[]string
 	Prefix_indexed_fields    *[]string
 	Nested_fields            *bool // applies when indexed fields is not defined
+	Pubsubs_conns            *[]string
 }
 
 // Threshold service config section
@@ -453,6 +485,7 @@ type ThresholdSJsonCfg struct {
 	String_indexed_fields *[]string
 	Prefix_indexed_fields *[]string
 	Nested_fields         *bool // applies when indexed fields is not defined
+	Pubsubs_conns         *[]string
 }
 
 // Rounte service config section
@@ -485,6 +518,9 @@ type LoaderJsonCfg struct {
 	Lock_filename   *string
 	Caches_conns    *[]string
 	Field_separator *string
+	Source_type     *string
+	Source_path     *string
+	Source_timeout  *string
 	Tp_in_dir       *string
 	Tp_out_dir      *string
 	Data            *[]*LoaderJsonDataType
@@ -593,6 +629,72 @@ type AnalyzerSJsonCfg struct {
 	Enabled *bool
 }
 
+// TaxS config section
+type TaxSJsonCfg struct {
+	Enabled               *bool
+	Indexed_selects       *bool
+	String_indexed_fields *[]string
+	Prefix_indexed_fields *[]string
+	Nested_fields         *bool
+}
+
+// PubSubS config section
+type PubSubSJsonCfg struct {
+	Enabled       *bool
+	Listen_bijson *string
+	Ws_url        *string
+	Buffer_size   *int
+}
+
+// AuditS config section
+type AuditSJsonCfg struct {
+	Enabled   *bool
+	Methods   *[]string
+	Ees_conns *[]string
+}
+
+// ConfigDB config section
+type ConfigDBJsonCfg struct {
+	Enabled         *bool
+	Sync_interval   *string
+	Local_overrides *[]string
+}
+
+// RPC authorization config section
+type RPCAuthJsonCfg struct {
+	Enabled      *bool
+	Api_keys     *map[string]string
+	Users        *map[string]string
+	Certificates *map[string]string
+	Guest_role   *string
+	Roles        *map[string]*RPCRoleJsonCfg
+}
+
+// RPC role definition
+type RPCRoleJsonCfg struct {
+	Methods *[]string
+	Tenants *[]string
+}
+
+// Request limiters config section
+type ReqLimitersJsonCfg struct {
+	Enabled    *bool
+	Priorities *map[string]int
+	Limiters   *map[string]*ReqLimiterJsonCfg
+}
+
+// Request limiter definition
+type ReqLimiterJsonCfg struct {
+	Methods             *[]string
+	Tenants             *[]string
+	Per_tenant          *bool
+	Concurrent_requests *int
+	Rate_limit          *int
+	Rate_interval       *string
+	Strategy            *string
+	Queue_timeout       *string
+}
+
 type ApierJsonCfg struct {
 	Enabled          *bool
 	Caches_conns     *[]string
@@ -606,6 +708,8 @@ type STIRJsonCfg struct {
 	Default_attest      *string
 	Publickey_path      *string
 	Privatekey_path     *string
+	Trust_anchors       *[]string
+	Crls                *[]string
 }
 
 type RateSJsonCfg struct {
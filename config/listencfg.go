@@ -28,6 +28,8 @@ type ListenCfg struct {
 	RPCJSONTLSListen string // RPC JSON TLS listening address
 	RPCGOBTLSListen  string // RPC GOB TLS listening address
 	HTTPTLSListen    string // HTTP TLS listening address
+	RPCGRPCListen    string // RPC gRPC listening address
+	RPCGRPCTLSListen string // RPC gRPC TLS listening address
 }
 
 //loadFromJsonCfg loads Database config from JsonCfg
@@ -53,6 +55,12 @@ func (lstcfg *ListenCfg) loadFromJsonCfg(jsnListenCfg *ListenJsonCfg) (err error
 	if jsnListenCfg.Http_tls != nil && *jsnListenCfg.Http_tls != "" {
 		lstcfg.HTTPTLSListen = *jsnListenCfg.Http_tls
 	}
+	if jsnListenCfg.Rpc_grpc != nil {
+		lstcfg.RPCGRPCListen = *jsnListenCfg.Rpc_grpc
+	}
+	if jsnListenCfg.Rpc_grpc_tls != nil {
+		lstcfg.RPCGRPCTLSListen = *jsnListenCfg.Rpc_grpc_tls
+	}
 	return nil
 }
 
@@ -64,5 +72,7 @@ func (lstcfg *ListenCfg) AsMapInterface() map[string]interface{} {
 		utils.RPCJSONTLSListenCfg: lstcfg.RPCJSONTLSListen,
 		utils.RPCGOBTLSListenCfg:  lstcfg.RPCGOBTLSListen,
 		utils.HTTPTLSListenCfg:    lstcfg.HTTPTLSListen,
+		utils.RPCGRPCListenCfg:    lstcfg.RPCGRPCListen,
+		utils.RPCGRPCTLSListenCfg: lstcfg.RPCGRPCTLSListen,
 	}
 }
@@ -42,6 +42,8 @@ func TestListenCfgloadFromJsonCfg(t *testing.T) {
 	"rpc_json_tls" : "127.0.0.1:2022",		// RPC JSON TLS listening address
 	"rpc_gob_tls": "127.0.0.1:2023",		// RPC GOB TLS listening address
 	"http_tls": "127.0.0.1:2280",			// HTTP TLS listening address
+	"rpc_grpc": "127.0.0.1:2024",			// RPC gRPC listening address
+	"rpc_grpc_tls": "127.0.0.1:2025",		// RPC gRPC TLS listening address
 	}
 }`
 	expected = ListenCfg{
@@ -51,6 +53,8 @@ func TestListenCfgloadFromJsonCfg(t *testing.T) {
 		RPCJSONTLSListen: "127.0.0.1:2022",
 		RPCGOBTLSListen:  "127.0.0.1:2023",
 		HTTPTLSListen:    "127.0.0.1:2280",
+		RPCGRPCListen:    "127.0.0.1:2024",
+		RPCGRPCTLSListen: "127.0.0.1:2025",
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -55,6 +55,9 @@ type LoaderSCfg struct {
 	LockFileName   string
 	CacheSConns    []string
 	FieldSeparator string
+	SourceType     string        // <*file|*http|*s3|*sql>
+	SourcePath     string        // URL of the source for the types other than *file
+	SourceTimeout  time.Duration // timeout of the requests towards the *http and *s3 sources
 	TpInDir        string
 	TpOutDir       string
 	Data           []*LoaderDataType
@@ -136,6 +139,17 @@ func (self *LoaderSCfg) loadFromJsonCfg(jsnCfg *LoaderJsonCfg, separator string)
 	if jsnCfg.Field_separator != nil {
 		self.FieldSeparator = *jsnCfg.Field_separator
 	}
+	if jsnCfg.Source_type != nil {
+		self.SourceType = *jsnCfg.Source_type
+	}
+	if jsnCfg.Source_path != nil {
+		self.SourcePath = *jsnCfg.Source_path
+	}
+	if jsnCfg.Source_timeout != nil {
+		if self.SourceTimeout, err = utils.ParseDurationWithNanosecs(*jsnCfg.Source_timeout); err != nil {
+			return
+		}
+	}
 	if jsnCfg.Tp_in_dir != nil {
 		self.TpInDir = *jsnCfg.Tp_in_dir
 	}
@@ -180,6 +194,9 @@ func (self *LoaderSCfg) Clone() *LoaderSCfg {
 		clnLoader.CacheSConns[idx] = connID
 	}
 	clnLoader.FieldSeparator = self.FieldSeparator
+	clnLoader.SourceType = self.SourceType
+	clnLoader.SourcePath = self.SourcePath
+	clnLoader.SourceTimeout = self.SourceTimeout
 	clnLoader.TpInDir = self.TpInDir
 	clnLoader.TpOutDir = self.TpOutDir
 	clnLoader.Data = make([]*LoaderDataType, len(self.Data))
@@ -217,6 +234,10 @@ func (l *LoaderSCfg) AsMapInterface(separator string) map[string]interface{} {
 	if l.RunDelay != 0 {
 		runDelay = l.RunDelay.String()
 	}
+	var srcTimeout string = "0"
+	if l.SourceTimeout != 0 {
+		srcTimeout = l.SourceTimeout.String()
+	}
 	cacheSConns := make([]string, len(l.CacheSConns))
 	for i, item := range l.CacheSConns {
 		buf := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches)
@@ -236,6 +257,9 @@ func (l *LoaderSCfg) AsMapInterface(separator string) map[string]interface{} {
 		utils.LockFileNameCfg:   l.LockFileName,
 		utils.CacheSConnsCfg:    cacheSConns,
 		utils.FieldSeparatorCfg: l.FieldSeparator,
+		utils.SourceTypeCfg:     l.SourceType,
+		utils.SourcePathCfg:     l.SourcePath,
+		utils.SourceTimeoutCfg:  srcTimeout,
 		utils.TpInDirCfg:        l.TpInDir,
 		utils.TpOutDirCfg:       l.TpOutDir,
 		utils.DataCfg:           data,
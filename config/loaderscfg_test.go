@@ -145,6 +145,9 @@ func TestLoaderCfgAsMapInterface(t *testing.T) {
 		"lock_filename":   ".cgr.lck",
 		"caches_conns":    []string{"*internal"},
 		"field_separator": ",",
+		"source_type":     "",
+		"source_path":     "",
+		"source_timeout":  "0",
 		"tp_in_dir":       "/var/spool/cgrates/loader/in",
 		"tp_out_dir":      "/var/spool/cgrates/loader/out",
 		"data": []map[string]interface{}{
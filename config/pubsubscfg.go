@@ -0,0 +1,58 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package config
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// PubSubSCfg is the configuration of the event subscription service
+type PubSubSCfg struct {
+	Enabled      bool
+	ListenBijson string // address where the subscribers connect over bidirectional JSON-RPC
+	WSURL        string // WebSocket path on the HTTP listener for the subscribers
+	BufferSize   int    // number of events kept for slow subscribers and resuming from a cursor
+}
+
+func (psCfg *PubSubSCfg) loadFromJsonCfg(jsnCfg *PubSubSJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		psCfg.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Listen_bijson != nil {
+		psCfg.ListenBijson = *jsnCfg.Listen_bijson
+	}
+	if jsnCfg.Ws_url != nil {
+		psCfg.WSURL = *jsnCfg.Ws_url
+	}
+	if jsnCfg.Buffer_size != nil {
+		psCfg.BufferSize = *jsnCfg.Buffer_size
+	}
+	return
+}
+
+func (psCfg *PubSubSCfg) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.EnabledCfg:      psCfg.Enabled,
+		utils.ListenBijsonCfg: psCfg.ListenBijson,
+		utils.HTTPWSURLCfg:    psCfg.WSURL,
+		utils.BufferSizeCfg:   psCfg.BufferSize,
+	}
+}
@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestPubSubSCfgloadFromJsonCfg(t *testing.T) {
+	var psCfg, expected PubSubSCfg
+	if err := psCfg.loadFromJsonCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(psCfg, expected) {
+		t.Errorf("Expected: %+v ,recived: %+v", expected, psCfg)
+	}
+	cfgJSONStr := `{
+		"pubsubs": {
+			"enabled": true,
+			"listen_bijson": "127.0.0.1:2016",
+			"ws_url": "/events",
+			"buffer_size": 100,
+		},
+}`
+	expected = PubSubSCfg{
+		Enabled:      true,
+		ListenBijson: "127.0.0.1:2016",
+		WSURL:        "/events",
+		BufferSize:   100,
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnPS, err := jsnCfg.PubSubSJsonCfg(); err != nil {
+		t.Error(err)
+	} else if err = psCfg.loadFromJsonCfg(jsnPS); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, psCfg) {
+		t.Errorf("Expected: %+v , recived: %+v", utils.ToJSON(expected), utils.ToJSON(psCfg))
+	}
+	eMap := map[string]interface{}{
+		"enabled":       true,
+		"listen_bijson": "127.0.0.1:2016",
+		"ws_url":        "/events",
+		"buffer_size":   100,
+	}
+	if rcv := psCfg.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("\nExpected: %+v\nRecived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
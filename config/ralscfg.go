@@ -38,6 +38,7 @@ type RalsCfg struct {
 	BalanceRatingSubject    map[string]string
 	MaxIncrements           int
 	DynaprepaidActionPlans  []string
+	PubSubSConns            []string
 }
 
 //loadFromJsonCfg loads Rals config from JsonCfg
@@ -109,6 +110,17 @@ func (ralsCfg *RalsCfg) loadFromJsonCfg(jsnRALsCfg *RalsJsonCfg) (err error) {
 		}
 	}
 
+	if jsnRALsCfg.Pubsubs_conns != nil {
+		ralsCfg.PubSubSConns = make([]string, len(*jsnRALsCfg.Pubsubs_conns))
+		for idx, connID := range *jsnRALsCfg.Pubsubs_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			if connID == utils.MetaInternal {
+				ralsCfg.PubSubSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs)
+			} else {
+				ralsCfg.PubSubSConns[idx] = connID
+			}
+		}
+	}
 	return nil
 }
 
@@ -137,6 +149,16 @@ func (ralsCfg *RalsCfg) AsMapInterface() map[string]interface{} {
 		balanceRating[key] = item
 	}
 
+	pubSubSConns := make([]string, len(ralsCfg.PubSubSConns))
+	for i, item := range ralsCfg.PubSubSConns {
+		buf := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs)
+		if item == buf {
+			pubSubSConns[i] = strings.ReplaceAll(item, utils.CONCATENATED_KEY_SEP+utils.MetaPubSubs, utils.EmptyString)
+		} else {
+			pubSubSConns[i] = item
+		}
+	}
+
 	return map[string]interface{}{
 		utils.EnabledCfg:                 ralsCfg.Enabled,
 		utils.ThresholdSConnsCfg:         ralsCfg.ThresholdSConns,
@@ -148,5 +170,6 @@ func (ralsCfg *RalsCfg) AsMapInterface() map[string]interface{} {
 		utils.BalanceRatingSubjectCfg:    balanceRating,
 		utils.MaxIncrementsCfg:           ralsCfg.MaxIncrements,
 		utils.Dynaprepaid_actionplansCfg: ralsCfg.DynaprepaidActionPlans,
+		utils.PubSubSConnsCfg:            pubSubSConns,
 	}
 }
@@ -123,6 +123,7 @@ func TestRalsCfgAsMapInterface(t *testing.T) {
 			"*voice": "*zero1s",
 		},
 		"dynaprepaid_actionplans": []string{},
+		"pubsubs_conns":           []string{},
 	}
 
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
@@ -0,0 +1,198 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ReqLimitersCfg is the configuration of the request limiters applied by the listeners
+type ReqLimitersCfg struct {
+	Enabled    bool
+	Priorities map[string]int // method pattern: priority in queues, higher first
+	Limiters   map[string]*utils.RequestLimiterProfile
+
+	limiterIDs []string // sorted IDs of the limiters so the allocations are done in the same order
+}
+
+func (rl *ReqLimitersCfg) loadFromJsonCfg(jsnCfg *ReqLimitersJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		rl.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Priorities != nil {
+		rl.Priorities = make(map[string]int)
+		for pattern, priority := range *jsnCfg.Priorities {
+			rl.Priorities[pattern] = priority
+		}
+	}
+	if jsnCfg.Limiters == nil {
+		return
+	}
+	// the maps and profiles are replaced, not updated, since they are used without locks while requests are queued
+	limiters := make(map[string]*utils.RequestLimiterProfile)
+	for id, prf := range rl.Limiters {
+		limiters[id] = prf
+	}
+	for id, jsnLmt := range *jsnCfg.Limiters {
+		prf := &utils.RequestLimiterProfile{
+			RateInterval: time.Second,
+			Strategy:     utils.MetaBusy,
+		}
+		if oldPrf, has := limiters[id]; has {
+			*prf = *oldPrf
+		}
+		if jsnLmt.Methods != nil {
+			prf.Methods = make([]string, len(*jsnLmt.Methods))
+			copy(prf.Methods, *jsnLmt.Methods)
+		}
+		if jsnLmt.Tenants != nil {
+			prf.Tenants = make([]string, len(*jsnLmt.Tenants))
+			copy(prf.Tenants, *jsnLmt.Tenants)
+		}
+		if jsnLmt.Per_tenant != nil {
+			prf.PerTenant = *jsnLmt.Per_tenant
+		}
+		if jsnLmt.Concurrent_requests != nil {
+			prf.ConcurrentRequests = *jsnLmt.Concurrent_requests
+		}
+		if jsnLmt.Rate_limit != nil {
+			prf.RateLimit = *jsnLmt.Rate_limit
+		}
+		if jsnLmt.Rate_interval != nil {
+			if prf.RateInterval, err = utils.ParseDurationWithNanosecs(*jsnLmt.Rate_interval); err != nil {
+				return
+			}
+		}
+		if jsnLmt.Strategy != nil {
+			prf.Strategy = *jsnLmt.Strategy
+		}
+		if jsnLmt.Queue_timeout != nil {
+			if prf.QueueTimeout, err = utils.ParseDurationWithNanosecs(*jsnLmt.Queue_timeout); err != nil {
+				return
+			}
+		}
+		limiters[id] = prf
+	}
+	rl.Limiters = limiters
+	rl.limiterIDs = make([]string, 0, len(limiters))
+	for id := range limiters {
+		rl.limiterIDs = append(rl.limiterIDs, id)
+	}
+	sort.Strings(rl.limiterIDs)
+	return
+}
+
+// Priority returns the highest priority matching the method, 0 if none matches
+func (rl *ReqLimitersCfg) Priority(serviceMethod string) (priority int) {
+	var matched bool
+	for pattern, prio := range rl.Priorities {
+		if ok, err := path.Match(pattern, serviceMethod); err != nil || !ok {
+			continue
+		}
+		if !matched || prio > priority {
+			priority = prio
+			matched = true
+		}
+	}
+	return
+}
+
+// LimitRPC allocates the request in each of the matching limiters
+// if one of the limiters rejects the request, the previous allocations are released
+func (rl *ReqLimitersCfg) LimitRPC(serviceMethod string, args interface{},
+	dfltTnt string) (release func(), err error) {
+	if !rl.Enabled {
+		return
+	}
+	tnt, has := utils.RPCArgsTenant(args)
+	if !has || tnt == utils.EmptyString {
+		tnt = dfltTnt
+	}
+	priority := rl.Priority(serviceMethod)
+	var releases []func()
+	for _, id := range rl.limiterIDs {
+		prf := rl.Limiters[id]
+		if !prf.MatchesMethod(serviceMethod) ||
+			!prf.MatchesTenant(tnt) {
+			continue
+		}
+		key := id
+		if prf.PerTenant {
+			key = utils.ConcatenatedKey(id, tnt)
+		}
+		var rls func()
+		if rls, err = utils.ReqLimiters.Allocate(key, prf, priority); err != nil {
+			for _, rls := range releases {
+				rls()
+			}
+			return nil, err
+		}
+		releases = append(releases, rls)
+	}
+	if len(releases) == 0 {
+		return
+	}
+	return func() {
+		for _, rls := range releases {
+			rls()
+		}
+	}, nil
+}
+
+// hasLimiter checks if the key of a runtime limiter belongs to one of the configured limiters
+func (rl *ReqLimitersCfg) hasLimiter(key string) (has bool) {
+	_, has = rl.Limiters[strings.SplitN(key, utils.CONCATENATED_KEY_SEP, 2)[0]]
+	return
+}
+
+func (rl *ReqLimitersCfg) AsMapInterface() map[string]interface{} {
+	limiters := make(map[string]interface{}, len(rl.Limiters))
+	for id, prf := range rl.Limiters {
+		var rateInterval, queueTimeout string
+		if prf.RateInterval != 0 {
+			rateInterval = prf.RateInterval.String()
+		}
+		if prf.QueueTimeout != 0 {
+			queueTimeout = prf.QueueTimeout.String()
+		}
+		limiters[id] = map[string]interface{}{
+			utils.MethodsCfg:            prf.Methods,
+			utils.TenantsCfg:            prf.Tenants,
+			utils.PerTenantCfg:          prf.PerTenant,
+			utils.ConcurrentRequestsCfg: prf.ConcurrentRequests,
+			utils.RateLimitCfg:          prf.RateLimit,
+			utils.RateIntervalCfg:       rateInterval,
+			utils.StrategyCfg:           prf.Strategy,
+			utils.QueueTimeoutCfg:       queueTimeout,
+		}
+	}
+	return map[string]interface{}{
+		utils.EnabledCfg:    rl.Enabled,
+		utils.PrioritiesCfg: rl.Priorities,
+		utils.LimitersCfg:   limiters,
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestReqLimitersCfgloadFromJsonCfg(t *testing.T) {
+	var rl, expected ReqLimitersCfg
+	if err := rl.loadFromJsonCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(rl, expected) {
+		t.Errorf("Expected: %+v ,recived: %+v", expected, rl)
+	}
+	cfgJSONStr := `{
+		"request_limiters": {
+			"enabled": true,
+			"priorities": {"SessionSv1.*": 10, "APIerSv1.*": -1},
+			"limiters": {
+				"bulk": {
+					"methods": ["APIerSv1.*", "CDRsV1.*"],
+					"per_tenant": true,
+					"concurrent_requests": 10,
+					"rate_limit": 100,
+					"strategy": "*queue",
+					"queue_timeout": "5s",
+				},
+				"all": {"concurrent_requests": 1000},
+			},
+		},
+}`
+	expected = ReqLimitersCfg{
+		Enabled:    true,
+		Priorities: map[string]int{"SessionSv1.*": 10, "APIerSv1.*": -1},
+		Limiters: map[string]*utils.RequestLimiterProfile{
+			"bulk": {
+				Methods:            []string{"APIerSv1.*", "CDRsV1.*"},
+				PerTenant:          true,
+				ConcurrentRequests: 10,
+				RateLimit:          100,
+				RateInterval:       time.Second,
+				Strategy:           utils.MetaQueue,
+				QueueTimeout:       5 * time.Second,
+			},
+			"all": {
+				ConcurrentRequests: 1000,
+				RateInterval:       time.Second,
+				Strategy:           utils.MetaBusy,
+			},
+		},
+		limiterIDs: []string{"all", "bulk"},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnRl, err := jsnCfg.ReqLimitersJsonCfg(); err != nil {
+		t.Error(err)
+	} else if err = rl.loadFromJsonCfg(jsnRl); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, rl) {
+		t.Errorf("Expected: %+v , recived: %+v", utils.ToJSON(expected), utils.ToJSON(rl))
+	}
+	if prio := rl.Priority(utils.SessionSv1UpdateSession); prio != 10 {
+		t.Errorf("Expected priority 10, received: %d", prio)
+	}
+	if prio := rl.Priority(utils.APIerSv1GetAccount); prio != -1 {
+		t.Errorf("Expected priority -1, received: %d", prio)
+	}
+	if prio := rl.Priority(utils.CoreSv1Ping); prio != 0 {
+		t.Errorf("Expected priority 0, received: %d", prio)
+	}
+}
+
+func TestReqLimitersCfgLimitRPC(t *testing.T) {
+	rl := &ReqLimitersCfg{
+		Enabled: true,
+		Limiters: map[string]*utils.RequestLimiterProfile{
+			"bulk": {
+				Methods:            []string{"APIerSv1.*"},
+				PerTenant:          true,
+				ConcurrentRequests: 1,
+				Strategy:           utils.MetaBusy,
+			},
+		},
+		limiterIDs: []string{"bulk"},
+	}
+	release, err := rl.LimitRPC(utils.APIerSv1GetAccount, &utils.AttrGetAccount{}, "cgrates.org")
+	if err != nil {
+		t.Fatal(err)
+	} else if release == nil {
+		t.Fatal("Expected allocation in limiter")
+	}
+	if _, err = rl.LimitRPC(utils.APIerSv1GetAccount,
+		&utils.AttrGetAccount{Tenant: "cgrates.org"}, "cgrates.org"); err != utils.ErrMaxRequestsExceeded {
+		t.Errorf("Expected %v, received: %v", utils.ErrMaxRequestsExceeded, err)
+	}
+	if rlsOther, err := rl.LimitRPC(utils.APIerSv1GetAccount, // own limits for each tenant
+		&utils.AttrGetAccount{Tenant: "itsyscom.com"}, "cgrates.org"); err != nil {
+		t.Error(err)
+	} else {
+		rlsOther()
+	}
+	if rlsSession, err := rl.LimitRPC(utils.SessionSv1UpdateSession, // not limited
+		&utils.AttrGetAccount{Tenant: "cgrates.org"}, "cgrates.org"); err != nil {
+		t.Error(err)
+	} else if rlsSession != nil {
+		t.Error("Expected no allocation for methods not limited")
+	}
+	release()
+	mtrcs := utils.ReqLimiters.Metrics()
+	exp := &utils.RequestLimiterMetrics{Allowed: 1, Rejected: 1}
+	if rcv := mtrcs[utils.ConcatenatedKey("bulk", "cgrates.org")]; rcv == nil || *rcv != *exp {
+		t.Errorf("Expected %+v, received: %+v", exp, rcv)
+	}
+	utils.ReqLimiters.Prune(func(string) bool { return false })
+}
+
+func TestReqLimitersCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+		"request_limiters": {
+			"enabled": true,
+			"priorities": {"SessionSv1.*": 10},
+			"limiters": {
+				"bulk": {"methods": ["APIerSv1.*"], "rate_limit": 100, "strategy": "*queue"},
+			},
+		},
+}`
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:    true,
+		utils.PrioritiesCfg: map[string]int{"SessionSv1.*": 10},
+		utils.LimitersCfg: map[string]interface{}{
+			"bulk": map[string]interface{}{
+				utils.MethodsCfg:            []string{"APIerSv1.*"},
+				utils.TenantsCfg:            []string(nil),
+				utils.PerTenantCfg:          false,
+				utils.ConcurrentRequestsCfg: 0,
+				utils.RateLimitCfg:          100,
+				utils.RateIntervalCfg:       "1s",
+				utils.StrategyCfg:           utils.MetaQueue,
+				utils.QueueTimeoutCfg:       "",
+			},
+		},
+	}
+	if cgrCfg, err := NewCGRConfigFromJsonStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.reqLimitersCfg.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected: %+v , recived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
@@ -72,22 +72,38 @@ func isAlfanum(bit byte) bool {
 		(bit >= '0' && bit <= '9')
 }
 
-// structure that implements io.Reader to read json files ignoring C style comments and replacing *env:
+// structure that implements io.Reader to read json files ignoring C style comments and replacing *env: and the secret references
 type rjReader struct {
-	buf        []byte
-	isInString bool // ignore character in strings
-	indx       int  // used to parse the buffer
+	buf         []byte
+	isInString  bool     // ignore character in strings
+	err         error    // keep the replace errors so the decoder can not skip them
+	indx        int      // used to parse the buffer
+	secrets     []string // the secrets replaced, in the order of their references
+	markSecrets bool     // replace the secret references with markers instead of resolving them
 }
 
 // Read implementation
 func (rjr *rjReader) Read(p []byte) (n int, err error) {
+	if rjr.err != nil {
+		return 0, rjr.err
+	}
 	for n = range p {
 		p[n], err = rjr.ReadByte()
 		if p[n] == '*' && rjr.checkMeta() {
 			if err = rjr.replaceEnv(rjr.indx - 1); err != nil {
+				rjr.err = err
 				return
 			}
 			p[n] = rjr.buf[rjr.indx-1] // replace with first value
+		} else if p[n] == '*' && rjr.isInString &&
+			rjr.buf[rjr.indx-2] == '"' { // the references are the whole string
+			if prfx, has := secretPrefix(rjr.buf[rjr.indx-1:]); has {
+				if err = rjr.replaceSecret(rjr.indx-1, prfx); err != nil {
+					rjr.err = err
+					return
+				}
+				p[n] = rjr.buf[rjr.indx-1] // replace with first value
+			}
 		}
 		if err != nil {
 			return
@@ -266,6 +282,32 @@ func (rjr *rjReader) replaceEnv(startEnv int) error {
 	return nil
 }
 
+// replaceSecret replaces the secret reference, up to the end of the string, with the resolved secret
+func (rjr *rjReader) replaceSecret(startRef int, prfx string) (err error) {
+	endRef := startRef + len(prfx)
+	for endRef < len(rjr.buf) && rjr.buf[endRef] != '"' {
+		endRef++
+	}
+	ref := string(rjr.buf[startRef+len(prfx) : endRef])
+	var value string
+	if rjr.markSecrets {
+		value = secretMarker(len(rjr.secrets))
+	} else if value, err = resolveSecret(prfx, ref); err != nil {
+		return
+	}
+	rjr.secrets = append(rjr.secrets, value)
+	if strings.Contains(value, `"`) { // the reader does not handle escaped quotes
+		return fmt.Errorf("unsupported character in secret <%s>", prfx+ref)
+	}
+	var jsnVal []byte
+	if jsnVal, err = json.Marshal(value); err != nil { // escape the new lines out of keys
+		return
+	}
+	jsnVal = jsnVal[1 : len(jsnVal)-1] // without quotes
+	rjr.buf = append(rjr.buf[:startRef], append(jsnVal, rjr.buf[endRef:]...)...) // replace the reference with the secret
+	return
+}
+
 // warning: needs to read file again
 func (rjr *rjReader) HandleJSONError(err error) error {
 	var offset int64
@@ -424,8 +466,12 @@ func (rjr *rjReader) getJsonOffsetLine(offset int64) (line, character int64) {
 
 // Loads the json config out of rjReader
 func (rjr *rjReader) Decode(cfg interface{}) (err error) {
+	orig := append([]byte(nil), rjr.buf...) // the references are replaced in the buffer
 	if err = json.NewDecoder(rjr).Decode(cfg); err != nil {
 		return rjr.HandleJSONError(err)
 	}
+	if len(rjr.secrets) != 0 && !rjr.markSecrets {
+		recordSecrets(orig, rjr.secrets)
+	}
 	return
 }
@@ -0,0 +1,165 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// RPCAuthCfg is the configuration of the RPC authorization
+type RPCAuthCfg struct {
+	Enabled      bool
+	APIKeys      map[string]string // API key: role
+	Users        map[string]string // basic auth user: role
+	Certificates map[string]string // client certificate common name: role
+	GuestRole    string            // role of the unidentified callers, empty to reject them
+	Roles        map[string]*utils.RPCRole
+}
+
+func (ra *RPCAuthCfg) loadFromJsonCfg(jsnCfg *RPCAuthJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		ra.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Api_keys != nil {
+		ra.APIKeys = make(map[string]string)
+		for key, role := range *jsnCfg.Api_keys {
+			ra.APIKeys[key] = role
+		}
+	}
+	if jsnCfg.Users != nil {
+		ra.Users = make(map[string]string)
+		for user, role := range *jsnCfg.Users {
+			ra.Users[user] = role
+		}
+	}
+	if jsnCfg.Certificates != nil {
+		ra.Certificates = make(map[string]string)
+		for cn, role := range *jsnCfg.Certificates {
+			ra.Certificates[cn] = role
+		}
+	}
+	if jsnCfg.Guest_role != nil {
+		ra.GuestRole = *jsnCfg.Guest_role
+	}
+	if jsnCfg.Roles != nil {
+		if ra.Roles == nil {
+			ra.Roles = make(map[string]*utils.RPCRole)
+		}
+		for name, jsnRole := range *jsnCfg.Roles {
+			role, has := ra.Roles[name]
+			if !has {
+				role = new(utils.RPCRole)
+			}
+			if jsnRole.Methods != nil {
+				role.Methods = make([]string, len(*jsnRole.Methods))
+				copy(role.Methods, *jsnRole.Methods)
+			}
+			if jsnRole.Tenants != nil {
+				role.Tenants = make([]string, len(*jsnRole.Tenants))
+				copy(role.Tenants, *jsnRole.Tenants)
+			}
+			ra.Roles[name] = role
+		}
+	}
+	return
+}
+
+// callerRole returns the role of the caller
+// the identity detected on connection is preferred to the API key sent in arguments
+func (ra *RPCAuthCfg) callerRole(caller *utils.RPCCaller, args interface{}) (role string, err error) {
+	if caller != nil {
+		if caller.CertCN != utils.EmptyString {
+			if role, has := ra.Certificates[caller.CertCN]; has {
+				return role, nil
+			}
+		}
+		if caller.User != utils.EmptyString {
+			if role, has := ra.Users[caller.User]; has {
+				return role, nil
+			}
+		}
+	}
+	apiKey := utils.RPCArgsAPIKey(args)
+	if caller != nil && caller.APIKey != utils.EmptyString {
+		apiKey = caller.APIKey
+	}
+	if apiKey != utils.EmptyString {
+		if role, has := ra.APIKeys[apiKey]; has {
+			return role, nil
+		}
+		return utils.EmptyString, utils.ErrUnknownApiKey
+	}
+	if ra.GuestRole == utils.EmptyString {
+		return utils.EmptyString, utils.ErrUnauthorizedApi
+	}
+	return ra.GuestRole, nil
+}
+
+// AuthorizeRPC checks the method and the tenant of the call against the role of the caller
+func (ra *RPCAuthCfg) AuthorizeRPC(caller *utils.RPCCaller, serviceMethod string,
+	args interface{}, dfltTnt string) (err error) {
+	if !ra.Enabled {
+		return
+	}
+	var roleName string
+	if roleName, err = ra.callerRole(caller, args); err != nil {
+		return
+	}
+	role, has := ra.Roles[roleName]
+	if !has || !role.AllowsMethod(serviceMethod) {
+		return utils.ErrUnauthorizedApi
+	}
+	if role.AllowsAnyTenant() {
+		return
+	}
+	tnts, has := utils.RPCArgsTenants(serviceMethod, args)
+	if !has { // cannot tell which tenants are reached so only the roles with all tenants pass
+		return utils.ErrUnauthorizedTenant
+	}
+	for _, tnt := range tnts {
+		if tnt == utils.EmptyString {
+			tnt = dfltTnt
+		}
+		if !role.AllowsTenant(tnt) {
+			return utils.ErrUnauthorizedTenant
+		}
+	}
+	return
+}
+
+func (ra *RPCAuthCfg) AsMapInterface() map[string]interface{} {
+	roles := make(map[string]interface{}, len(ra.Roles))
+	for name, role := range ra.Roles {
+		roles[name] = map[string]interface{}{
+			utils.MethodsCfg: role.Methods,
+			utils.TenantsCfg: role.Tenants,
+		}
+	}
+	return map[string]interface{}{
+		utils.EnabledCfg:      ra.Enabled,
+		utils.APIKeysCfg:      ra.APIKeys,
+		utils.UsersCfg:        ra.Users,
+		utils.CertificatesCfg: ra.Certificates,
+		utils.GuestRoleCfg:    ra.GuestRole,
+		utils.RolesCfg:        roles,
+	}
+}
@@ -0,0 +1,164 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestRPCAuthCfgloadFromJsonCfg(t *testing.T) {
+	var ra, expected RPCAuthCfg
+	if err := ra.loadFromJsonCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(ra, expected) {
+		t.Errorf("Expected: %+v ,recived: %+v", expected, ra)
+	}
+	cfgJSONStr := `{
+		"rpc_auth": {
+			"enabled": true,
+			"api_keys": {"key1": "readonly"},
+			"users": {"admin": "admin"},
+			"certificates": {"billing.cgrates.org": "admin"},
+			"guest_role": "",
+			"roles": {
+				"admin": {"methods": ["*"], "tenants": ["*any"]},
+				"readonly": {"methods": ["APIerSv1.Get*"], "tenants": ["cgrates.org"]},
+			},
+		},
+}`
+	expected = RPCAuthCfg{
+		Enabled:      true,
+		APIKeys:      map[string]string{"key1": "readonly"},
+		Users:        map[string]string{"admin": "admin"},
+		Certificates: map[string]string{"billing.cgrates.org": "admin"},
+		Roles: map[string]*utils.RPCRole{
+			"admin":    {Methods: []string{"*"}, Tenants: []string{utils.META_ANY}},
+			"readonly": {Methods: []string{"APIerSv1.Get*"}, Tenants: []string{"cgrates.org"}},
+		},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnRa, err := jsnCfg.RPCAuthJsonCfg(); err != nil {
+		t.Error(err)
+	} else if err = ra.loadFromJsonCfg(jsnRa); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, ra) {
+		t.Errorf("Expected: %+v , recived: %+v", utils.ToJSON(expected), utils.ToJSON(ra))
+	}
+}
+
+func TestRPCAuthCfgAuthorizeRPC(t *testing.T) {
+	ra := &RPCAuthCfg{
+		Enabled:      true,
+		APIKeys:      map[string]string{"key1": "readonly"},
+		Users:        map[string]string{"admin": "admin"},
+		Certificates: map[string]string{"billing.cgrates.org": "admin"},
+		Roles: map[string]*utils.RPCRole{
+			"admin":    {Methods: []string{"*"}, Tenants: []string{utils.META_ANY}},
+			"readonly": {Methods: []string{"APIerSv1.Get*"}, Tenants: []string{"cgrates.org"}},
+		},
+	}
+	rmAcnt := &utils.AttrRemoveAccount{Tenant: "cgrates.org", Account: "1001"}
+	getAcnt := &utils.AttrGetAccount{Tenant: "cgrates.org", Account: "1001"}
+	testCases := []struct {
+		caller *utils.RPCCaller
+		method string
+		args   interface{}
+		err    error
+	}{
+		{&utils.RPCCaller{CertCN: "billing.cgrates.org"}, utils.APIerSv1RemoveAccount, rmAcnt, nil},
+		{&utils.RPCCaller{User: "admin"}, utils.APIerSv1RemoveAccount, &utils.AttrRemoveAccount{Tenant: "itsyscom.com"}, nil},
+		{&utils.RPCCaller{APIKey: "key1"}, utils.APIerSv1GetAccount, getAcnt, nil},
+		{&utils.RPCCaller{APIKey: "key1"}, utils.APIerSv1RemoveAccount, rmAcnt, utils.ErrUnauthorizedApi},
+		{&utils.RPCCaller{APIKey: "key1"}, utils.APIerSv1GetAccount, &utils.AttrGetAccount{Tenant: "itsyscom.com"}, utils.ErrUnauthorizedTenant},
+		{&utils.RPCCaller{APIKey: "key1"}, utils.APIerSv1GetAccount, &utils.AttrGetAccount{}, nil}, // default tenant
+		{&utils.RPCCaller{APIKey: "key2"}, utils.APIerSv1GetAccount, getAcnt, utils.ErrUnknownApiKey},
+		{new(utils.RPCCaller), utils.APIerSv1GetAccount, getAcnt, utils.ErrUnauthorizedApi},
+		{new(utils.RPCCaller), utils.APIerSv1GetAccount, &utils.TenantWithArgDispatcher{
+			TenantArg:     &utils.TenantArg{Tenant: "cgrates.org"},
+			ArgDispatcher: &utils.ArgDispatcher{APIKey: utils.StringPointer("key1")}}, nil},
+	}
+	for i, tc := range testCases {
+		if err := ra.AuthorizeRPC(tc.caller, tc.method, tc.args, "cgrates.org"); err != tc.err {
+			t.Errorf("Case %d: expected error %v, received %v", i, tc.err, err)
+		}
+	}
+	ra.GuestRole = "readonly"
+	if err := ra.AuthorizeRPC(new(utils.RPCCaller), utils.CoreSv1Status, utils.StringPointer("ign"), "cgrates.org"); err != utils.ErrUnauthorizedApi {
+		t.Errorf("Expected error %v, received %v", utils.ErrUnauthorizedApi, err)
+	}
+	if err := ra.AuthorizeRPC(new(utils.RPCCaller), utils.APIerSv1GetAccount, getAcnt, "cgrates.org"); err != nil {
+		t.Error(err)
+	}
+	ra.Enabled = false
+	if err := ra.AuthorizeRPC(nil, utils.APIerSv1RemoveAccount, rmAcnt, "cgrates.org"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRPCAuthCfgAuthorizeRPCTenantScope(t *testing.T) {
+	ra := &RPCAuthCfg{
+		Enabled: true,
+		APIKeys: map[string]string{"key1": "tenant", "key2": "admin"},
+		Roles: map[string]*utils.RPCRole{
+			"admin":  {Methods: []string{"*"}, Tenants: []string{utils.META_ANY}},
+			"tenant": {Methods: []string{"CDRsV1.*", "SessionSv1.*", "CoreSv1.*"}, Tenants: []string{"cgrates.org"}},
+		},
+	}
+	tntCaller := &utils.RPCCaller{APIKey: "key1"}
+	adminCaller := &utils.RPCCaller{APIKey: "key2"}
+	testCases := []struct {
+		caller *utils.RPCCaller
+		method string
+		args   interface{}
+		err    error
+	}{
+		{tntCaller, utils.CDRsV1GetCDRs, &utils.RPCCDRsFilterWithArgDispatcher{
+			RPCCDRsFilter: &utils.RPCCDRsFilter{Tenants: []string{"cgrates.org"}}}, nil},
+		{tntCaller, utils.CDRsV1GetCDRs, &utils.RPCCDRsFilterWithArgDispatcher{
+			RPCCDRsFilter: &utils.RPCCDRsFilter{Tenants: []string{"cgrates.org"}},
+			TenantArg:     &utils.TenantArg{Tenant: "cgrates.org"}}, nil},
+		{tntCaller, utils.CDRsV1GetCDRs, &utils.RPCCDRsFilterWithArgDispatcher{
+			RPCCDRsFilter: &utils.RPCCDRsFilter{Tenants: []string{"cgrates.org", "itsyscom.com"}}}, utils.ErrUnauthorizedTenant},
+		{tntCaller, utils.CDRsV1GetCDRs, &utils.RPCCDRsFilterWithArgDispatcher{ // all tenants
+			RPCCDRsFilter: &utils.RPCCDRsFilter{},
+			TenantArg:     &utils.TenantArg{Tenant: "cgrates.org"}}, utils.ErrUnauthorizedTenant},
+		{tntCaller, utils.CDRsV1GetCDRs, &utils.RPCCDRsFilterWithArgDispatcher{}, utils.ErrUnauthorizedTenant},
+		{adminCaller, utils.CDRsV1GetCDRs, &utils.RPCCDRsFilterWithArgDispatcher{RPCCDRsFilter: &utils.RPCCDRsFilter{}}, nil},
+		// the tenant of the session filters does not restrict the sessions returned
+		{tntCaller, utils.SessionSv1GetActiveSessions, &utils.SessionFilter{Tenant: "cgrates.org"}, utils.ErrUnauthorizedTenant},
+		{tntCaller, utils.SessionSv1ForceDisconnect, &utils.SessionFilter{Tenant: "cgrates.org"}, utils.ErrUnauthorizedTenant},
+		{adminCaller, utils.SessionSv1GetActiveSessions, &utils.SessionFilter{}, nil},
+		{tntCaller, utils.SessionSv1ProcessCDR, &utils.CGREventWithArgDispatcher{
+			CGREvent: &utils.CGREvent{Tenant: "cgrates.org"}}, nil},
+		{tntCaller, utils.SessionSv1ProcessCDR, &utils.CGREventWithArgDispatcher{
+			CGREvent: &utils.CGREvent{Tenant: "itsyscom.com"}}, utils.ErrUnauthorizedTenant},
+		// methods without tenant
+		{tntCaller, utils.CoreSv1Status, utils.StringPointer("ign"), utils.ErrUnauthorizedTenant},
+		{adminCaller, utils.CoreSv1Status, utils.StringPointer("ign"), nil},
+	}
+	for i, tc := range testCases {
+		if err := ra.AuthorizeRPC(tc.caller, tc.method, tc.args, "cgrates.org"); err != tc.err {
+			t.Errorf("Case %d: expected error %v, received %v", i, tc.err, err)
+		}
+	}
+}
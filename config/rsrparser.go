@@ -202,7 +202,18 @@ func (prsr *RSRParser) Compile() (err error) {
 				parserRules)
 		}
 		convertersStr := parserRules[idxConverters+1 : len(parserRules)-1] // strip also {}
-		convsSplt := strings.Split(convertersStr, utils.ANDSep)
+		var exprStr string
+		if idxExpr := strings.Index(convertersStr, utils.MetaExpr+utils.InInFieldSep); idxExpr != -1 { // the expression can contain & so it is always the last converter
+			exprStr = convertersStr[idxExpr:]
+			convertersStr = strings.TrimSuffix(convertersStr[:idxExpr], utils.ANDSep)
+		}
+		var convsSplt []string
+		if convertersStr != utils.EmptyString || exprStr == utils.EmptyString {
+			convsSplt = strings.Split(convertersStr, utils.ANDSep)
+		}
+		if exprStr != utils.EmptyString {
+			convsSplt = append(convsSplt, exprStr)
+		}
 		prsr.converters = make(utils.DataConverters, len(convsSplt))
 		for i, convStr := range convsSplt {
 			var conv utils.DataConverter
@@ -253,11 +264,12 @@ func (prsr *RSRParser) RegexpMatched() bool {
 }
 
 // parseValue the field value from a string
-func (prsr *RSRParser) parseValue(value string) (out string, err error) {
+// the DataProvider is passed to the converters reading other fields(ie: *expr)
+func (prsr *RSRParser) parseValue(value string, dP utils.DataProvider) (out string, err error) {
 	for _, rsRule := range prsr.rsrRules {
 		value = rsRule.Process(value)
 	}
-	return prsr.converters.ConvertString(value)
+	return prsr.converters.ConvertStringWithDataProvider(value, dP)
 }
 
 // ParseValue will parse the value out considering converters
@@ -267,7 +279,7 @@ func (prsr *RSRParser) ParseValue(value interface{}) (out string, err error) {
 		strings.HasPrefix(out, utils.DynamicDataPrefix) { // Enforce parsing of static values
 		out = utils.IfaceAsString(value)
 	}
-	return prsr.parseValue(out)
+	return prsr.parseValue(out, nil)
 }
 
 func (prsr *RSRParser) ParseDataProvider(dP utils.DataProvider) (out string, err error) {
@@ -275,7 +287,7 @@ func (prsr *RSRParser) ParseDataProvider(dP utils.DataProvider) (out string, err
 	if outStr, err = utils.DPDynamicString(prsr.path, dP); err != nil {
 		return
 	}
-	return prsr.parseValue(outStr)
+	return prsr.parseValue(outStr, dP)
 }
 
 func (prsr *RSRParser) ParseDataProviderWithInterfaces(dP utils.DataProvider) (out string, err error) {
@@ -283,5 +295,5 @@ func (prsr *RSRParser) ParseDataProviderWithInterfaces(dP utils.DataProvider) (o
 	if outIface, err = utils.DPDynamicInterface(prsr.path, dP); err != nil {
 		return
 	}
-	return prsr.parseValue(utils.IfaceAsString(outIface))
+	return prsr.parseValue(utils.IfaceAsString(outIface), dP)
 }
@@ -293,7 +293,7 @@ func TestRSRParserAttrName(t *testing.T) {
 func TestRSRParserRegexpMatched(t *testing.T) {
 	rsr := NewRSRParserMustCompile("~*req.Time:s/(.*)/${1}s/")
 	expected := "1ss"
-	if val, err := rsr.parseValue("1s"); err != nil {
+	if val, err := rsr.parseValue("1s", nil); err != nil {
 		t.Error(err)
 	} else if val != expected {
 		t.Errorf("Expected: %q received: %q", expected, val)
@@ -303,7 +303,7 @@ func TestRSRParserRegexpMatched(t *testing.T) {
 	}
 	rsr = NewRSRParserMustCompile("~*req.Time:s/(a+)/${1}s/")
 	expected = "1s"
-	if val, err := rsr.parseValue("1s"); err != nil {
+	if val, err := rsr.parseValue("1s", nil); err != nil {
 		t.Error(err)
 	} else if val != expected {
 		t.Errorf("Expected: %q received: %q", expected, val)
@@ -324,3 +324,44 @@ func TestRSRParserCompile3(t *testing.T) {
 		t.Error("Expected error received:", err)
 	}
 }
+
+func TestRSRParserExprConverter(t *testing.T) {
+	prsrs, err := NewRSRParsers("{*expr:if(~*req.Usage > 60s && ~*req.Cost > 0, ~*req.Cost*1.2, ~*req.Cost)}", utils.INFIELD_SEP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dP := utils.MapStorage{
+		utils.MetaReq: utils.MapStorage{
+			utils.Usage: "2m",
+			utils.Cost:  10,
+		},
+	}
+	if out, err := prsrs.ParseDataProvider(dP); err != nil {
+		t.Error(err)
+	} else if out != "12" {
+		t.Errorf("Expected: %q received: %q", "12", out)
+	}
+	dP[utils.MetaReq].(utils.MapStorage)[utils.Usage] = "30s"
+	if out, err := prsrs.ParseDataProvider(dP); err != nil {
+		t.Error(err)
+	} else if out != "10" {
+		t.Errorf("Expected: %q received: %q", "10", out)
+	}
+	// converters before the expression are applied on the value of the rule
+	prsr, err := NewRSRParser("~*req.Usage{*duration_seconds&*expr:upper(~*req.Account)}")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prsr.converters) != 2 {
+		t.Errorf("Expected 2 converters received: %+v", prsr.converters)
+	}
+	dP[utils.MetaReq].(utils.MapStorage)[utils.Account] = "acc1"
+	if out, err := prsr.ParseDataProvider(dP); err != nil {
+		t.Error(err)
+	} else if out != "ACC1" {
+		t.Errorf("Expected: %q received: %q", "ACC1", out)
+	}
+	if _, err := NewRSRParser("{*expr:unknown(1)}"); err == nil {
+		t.Error("Expected error for unknown function")
+	}
+}
@@ -0,0 +1,275 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// SecretResolver returns the secret out of its reference (the part following the prefix)
+type SecretResolver func(ref string) (string, error)
+
+// HTTPSecretsTTL is the interval a secret fetched over HTTP is reused without fetching it again
+var HTTPSecretsTTL = 5 * time.Minute
+
+var (
+	secretResolvers = map[string]SecretResolver{
+		utils.MetaFileSecret: readFileSecret,
+		utils.MetaHTTPSecret: readHTTPSecret,
+	}
+	secretsMux  sync.RWMutex
+	secretPaths = make(map[string]map[string]string) // section, path in the API reply and the secret resolved there
+	httpSecrets = make(map[string]*cachedSecret)
+)
+
+// cachedSecret is a secret fetched over HTTP
+type cachedSecret struct {
+	value   string
+	fetched time.Time
+}
+
+// RegisterSecretResolver adds a resolver for the references starting with prefix (eg: *vault:)
+func RegisterSecretResolver(prefix string, rslvr SecretResolver) {
+	secretsMux.Lock()
+	secretResolvers[prefix] = rslvr
+	secretsMux.Unlock()
+}
+
+// secretPrefix returns the resolver prefix the buffer starts with
+func secretPrefix(buf []byte) (prfx string, has bool) {
+	secretsMux.RLock()
+	defer secretsMux.RUnlock()
+	for prfx = range secretResolvers {
+		if len(buf) > len(prfx) && string(buf[:len(prfx)]) == prfx {
+			return prfx, true
+		}
+	}
+	return utils.EmptyString, false
+}
+
+// resolveSecret returns the secret for the reference
+func resolveSecret(prfx, ref string) (value string, err error) {
+	secretsMux.RLock()
+	rslvr := secretResolvers[prfx]
+	secretsMux.RUnlock()
+	if value, err = rslvr(ref); err != nil {
+		return
+	}
+	if value == utils.EmptyString {
+		return utils.EmptyString, utils.ErrSecretNotFound(prfx + ref)
+	}
+	return
+}
+
+// readFileSecret reads the secret out of a file, ie: mounted by the orchestrator
+func readFileSecret(path string) (value string, err error) {
+	var content []byte
+	if content, err = ioutil.ReadFile(path); err != nil {
+		return
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+// readHTTPSecret fetches the secret out of the body of a GET request,
+// reusing it for HTTPSecretsTTL and falling back on the previous value if the endpoint fails
+func readHTTPSecret(url string) (value string, err error) {
+	secretsMux.RLock()
+	cached, has := httpSecrets[url]
+	secretsMux.RUnlock()
+	if has && time.Since(cached.fetched) < HTTPSecretsTTL {
+		return cached.value, nil
+	}
+	if value, err = fetchHTTPSecret(url); err != nil {
+		if !has {
+			return
+		}
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed refreshing secret from <%s>, using the previous value, error: <%s>",
+			utils.ConfigSv1, url, err.Error()))
+		return cached.value, nil
+	}
+	secretsMux.Lock()
+	httpSecrets[url] = &cachedSecret{value: value, fetched: time.Now()}
+	secretsMux.Unlock()
+	return
+}
+
+func fetchHTTPSecret(url string) (value string, err error) {
+	client := &http.Client{Timeout: CgrConfig().GeneralCfg().ReplyTimeout}
+	var rply *http.Response
+	if rply, err = client.Get(url); err != nil {
+		return
+	}
+	defer rply.Body.Close()
+	if rply.StatusCode != http.StatusOK {
+		return utils.EmptyString, fmt.Errorf("unexpected status code <%d>", rply.StatusCode)
+	}
+	var body []byte
+	if body, err = ioutil.ReadAll(rply.Body); err != nil {
+		return
+	}
+	return strings.TrimRight(string(body), "\r\n"), nil
+}
+
+// expireHTTPSecrets forces fetching again the secrets on the next resolve,
+// the previous values are kept as fallback
+func expireHTTPSecrets() {
+	secretsMux.Lock()
+	for _, cached := range httpSecrets {
+		cached.fetched = time.Time{}
+	}
+	secretsMux.Unlock()
+}
+
+// secretMarker replaces the secret reference when looking for its path in the API replies
+func secretMarker(idx int) string {
+	return utils.MaskedSecret + strconv.Itoa(idx)
+}
+
+// recordSecrets remembers the paths in the API replies of the secrets resolved out of the
+// configuration buffer, found by loading the sections again with markers instead of secrets
+func recordSecrets(buf []byte, secrets []string) {
+	mskRdr := NewRjReaderFromBytes(buf)
+	mskRdr.markSecrets = true
+	mskJsnCfg := new(CgrJsonCfg)
+	if err := mskRdr.Decode(mskJsnCfg); err != nil ||
+		len(mskRdr.secrets) != len(secrets) {
+		return
+	}
+	markers := make(map[string]string, len(secrets))
+	for i, secret := range secrets {
+		markers[secretMarker(i)] = secret
+	}
+	for section, rawSection := range *mskJsnCfg {
+		if !bytes.Contains(*rawSection, []byte(utils.MaskedSecret)) {
+			continue
+		}
+		mskCfg, _ := NewDefaultCGRConfig()
+		loadFunc, has := mskCfg.getLoadFunctions()[section]
+		if !has {
+			continue
+		}
+		if err := loadFunc(&CgrJsonCfg{section: rawSection}); err != nil {
+			continue
+		}
+		jsnSection, err := mskCfg.sectionAsJSON(section)
+		if err != nil {
+			continue
+		}
+		var rply interface{}
+		if err = json.Unmarshal([]byte(jsnSection), &rply); err != nil {
+			continue
+		}
+		paths := make(map[string]string)
+		walkSecrets(rply, nil, func(path []string, val string) {
+			if secret, has := markers[val]; has {
+				paths[strings.Join(path, utils.NestingSep)] = secret
+			}
+		})
+		secretsMux.Lock()
+		if _, has := secretPaths[section]; !has {
+			secretPaths[section] = make(map[string]string)
+		}
+		for path, secret := range paths {
+			secretPaths[section][path] = secret
+		}
+		secretsMux.Unlock()
+	}
+}
+
+// walkSecrets calls the function for each string in the decoded JSON
+func walkSecrets(val interface{}, path []string, f func(path []string, val string)) {
+	switch v := val.(type) {
+	case string:
+		f(path, v)
+	case map[string]interface{}:
+		for k, itm := range v {
+			walkSecrets(itm, append(path[:len(path):len(path)], k), f)
+		}
+	case []interface{}:
+		for i, itm := range v {
+			walkSecrets(itm, append(path[:len(path):len(path)], strconv.Itoa(i)), f)
+		}
+	}
+}
+
+// maskSecrets replaces in place the secrets found in the decoded JSON of the section,
+// the paths not holding anymore the resolved secret are dropped
+func maskSecrets(section string, val interface{}) {
+	var stale []string
+	secretsMux.RLock()
+	paths := secretPaths[section]
+	walkSecrets(val, nil, func(path []string, v string) {
+		pathStr := strings.Join(path, utils.NestingSep)
+		secret, has := paths[pathStr]
+		if !has {
+			return
+		}
+		if v != secret { // changed on reload
+			stale = append(stale, pathStr)
+			return
+		}
+		setSecretMask(val, path)
+	})
+	secretsMux.RUnlock()
+	if len(stale) == 0 {
+		return
+	}
+	secretsMux.Lock()
+	for _, pathStr := range stale {
+		delete(secretPaths[section], pathStr)
+	}
+	secretsMux.Unlock()
+}
+
+// setSecretMask replaces the string at path in the decoded JSON with the mask
+func setSecretMask(val interface{}, path []string) {
+	for i, key := range path {
+		last := i == len(path)-1
+		switch v := val.(type) {
+		case map[string]interface{}:
+			if last {
+				v[key] = utils.MaskedSecret
+				return
+			}
+			val = v[key]
+		case []interface{}:
+			idx, err := strconv.Atoi(key)
+			if err != nil || idx >= len(v) {
+				return
+			}
+			if last {
+				v[idx] = utils.MaskedSecret
+				return
+			}
+			val = v[idx]
+		default:
+			return
+		}
+	}
+}
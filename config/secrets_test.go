@@ -0,0 +1,173 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSecretsFileReference(t *testing.T) {
+	secretsDir, err := ioutil.TempDir("", "secrets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(secretsDir)
+	if err = ioutil.WriteFile(path.Join(secretsDir, "db_password"), []byte("CGRateS.org\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfgJSONStr := fmt.Sprintf(`{
+"data_db": {"db_password": "*file:%s"},
+}`, path.Join(secretsDir, "db_password"))
+	cgrCfg, err := NewCGRConfigFromJsonStringWithDefaults(cfgJSONStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cgrCfg.DataDbCfg().DataDbPass != "CGRateS.org" {
+		t.Errorf("Expected: CGRateS.org, received: %s", cgrCfg.DataDbCfg().DataDbPass)
+	}
+	var reply map[string]interface{}
+	if err = cgrCfg.V1GetConfigSection(&StringWithArgDispatcher{Section: DATADB_JSN}, &reply); err != nil {
+		t.Error(err)
+	} else if reply["DataDbPass"] != utils.MaskedSecret {
+		t.Errorf("Expected masked password, received: %v", reply["DataDbPass"])
+	}
+	if _, err = NewCGRConfigFromJsonStringWithDefaults(`{"data_db": {"db_password": "*file:/not/existing"}}`); err == nil {
+		t.Error("Expected error for missing secret file")
+	}
+}
+
+func TestSecretsMaskByPath(t *testing.T) {
+	secretsDir, err := ioutil.TempDir("", "secrets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(secretsDir)
+	if err = ioutil.WriteFile(path.Join(secretsDir, "db_password"), []byte("cgrates"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	cfgJSONStr := fmt.Sprintf(`{
+"data_db": {"db_name": "cgrates", "db_user": "user*file:%s", "db_password": "*file:%s"},
+}`, path.Join(secretsDir, "db_password"), path.Join(secretsDir, "db_password"))
+	cgrCfg, err := NewCGRConfigFromJsonStringWithDefaults(cfgJSONStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var reply map[string]interface{}
+	if err = cgrCfg.V1GetConfigSection(&StringWithArgDispatcher{Section: DATADB_JSN}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply["DataDbPass"] != utils.MaskedSecret {
+		t.Errorf("Expected masked password, received: %v", reply["DataDbPass"])
+	}
+	if reply["DataDbName"] != "cgrates" { // same value but not a secret
+		t.Errorf("Expected: cgrates, received: %v", reply["DataDbName"])
+	}
+	if exp := "user*file:" + path.Join(secretsDir, "db_password"); reply["DataDbUser"] != exp { // not a reference
+		t.Errorf("Expected: %s, received: %v", exp, reply["DataDbUser"])
+	}
+	var rply string
+	if err = cgrCfg.V1ReloadConfigFromJSON(&JSONReloadWithArgDispatcher{
+		JSON: map[string]interface{}{DATADB_JSN: map[string]interface{}{"db_password": "plain"}}}, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if err = cgrCfg.V1GetConfigSection(&StringWithArgDispatcher{Section: DATADB_JSN}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply["DataDbPass"] != "plain" { // not a secret anymore
+		t.Errorf("Expected: plain, received: %v", reply["DataDbPass"])
+	}
+	secretsMux.RLock()
+	defer secretsMux.RUnlock()
+	if len(secretPaths[DATADB_JSN]) != 0 {
+		t.Errorf("Expected the stale secret to be dropped, received: %v", secretPaths[DATADB_JSN])
+	}
+}
+
+func TestSecretsHTTPReference(t *testing.T) {
+	secret := "SharedSecret"
+	var fetched int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetched++
+		if secret == utils.EmptyString {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, secret)
+	}))
+	defer srv.Close()
+	cfgJSONStr := fmt.Sprintf(`{
+"radius_agent": {"client_secrets": {"*default": "*http:%s/radius"}},
+}`, srv.URL)
+	cgrCfg, err := NewCGRConfigFromJsonStringWithDefaults(cfgJSONStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv := cgrCfg.RadiusAgentCfg().ClientSecrets[utils.MetaDefault]; rcv != "SharedSecret" {
+		t.Errorf("Expected: SharedSecret, received: %s", rcv)
+	}
+	var reply map[string]interface{}
+	if err = cgrCfg.V1GetConfigSection(&StringWithArgDispatcher{Section: RA_JSN}, &reply); err != nil {
+		t.Error(err)
+	} else if clntScrts, canCast := reply["ClientSecrets"].(map[string]interface{}); !canCast ||
+		clntScrts[utils.MetaDefault] != utils.MaskedSecret {
+		t.Errorf("Expected masked secret, received: %v", reply["ClientSecrets"])
+	}
+	secret = "NewSecret"
+	if cgrCfg, err = NewCGRConfigFromJsonStringWithDefaults(cfgJSONStr); err != nil { // cached
+		t.Fatal(err)
+	} else if rcv := cgrCfg.RadiusAgentCfg().ClientSecrets[utils.MetaDefault]; rcv != "SharedSecret" {
+		t.Errorf("Expected: SharedSecret, received: %s", rcv)
+	}
+	expireHTTPSecrets()
+	if cgrCfg, err = NewCGRConfigFromJsonStringWithDefaults(cfgJSONStr); err != nil {
+		t.Fatal(err)
+	} else if rcv := cgrCfg.RadiusAgentCfg().ClientSecrets[utils.MetaDefault]; rcv != "NewSecret" {
+		t.Errorf("Expected: NewSecret, received: %s", rcv)
+	}
+	secret = utils.EmptyString // endpoint failing, previous value used
+	expireHTTPSecrets()
+	if cgrCfg, err = NewCGRConfigFromJsonStringWithDefaults(cfgJSONStr); err != nil {
+		t.Fatal(err)
+	} else if rcv := cgrCfg.RadiusAgentCfg().ClientSecrets[utils.MetaDefault]; rcv != "NewSecret" {
+		t.Errorf("Expected: NewSecret, received: %s", rcv)
+	}
+	if fetched != 3 {
+		t.Errorf("Expected 3 requests, received: %d", fetched)
+	}
+}
+
+func TestSecretsRegisterResolver(t *testing.T) {
+	RegisterSecretResolver("*test:", func(ref string) (string, error) {
+		return "key\nwith new line", nil
+	})
+	cgrCfg, err := NewCGRConfigFromJsonStringWithDefaults(`{"general": {"default_tenant": "*test:tenant"}}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cgrCfg.GeneralCfg().DefaultTenant != "key\nwith new line" {
+		t.Errorf("Expected resolved secret, received: %q", cgrCfg.GeneralCfg().DefaultTenant)
+	}
+}
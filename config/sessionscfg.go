@@ -97,10 +97,13 @@ type SessionSCfg struct {
 	SessionIndexes      utils.StringMap
 	ClientProtocol      float64
 	ChannelSyncInterval time.Duration
+	StoreInterval       time.Duration // checkpoint the active sessions into DataDB, 0 to disable
+	RestoreGracePeriod  time.Duration // time the restored sessions wait to be reported by the agents
 	TerminateAttempts   int
 	AlterableFields     utils.StringSet
 	MinDurLowBalance    time.Duration
 	SchedulerConns      []string
+	PubSubSConns        []string
 	STIRCfg             *STIRcfg
 }
 
@@ -274,6 +277,16 @@ func (scfg *SessionSCfg) loadFromJsonCfg(jsnCfg *SessionSJsonCfg) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.Store_interval != nil {
+		if scfg.StoreInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Store_interval); err != nil {
+			return err
+		}
+	}
+	if jsnCfg.Restore_grace_period != nil {
+		if scfg.RestoreGracePeriod, err = utils.ParseDurationWithNanosecs(*jsnCfg.Restore_grace_period); err != nil {
+			return err
+		}
+	}
 	if jsnCfg.Terminate_attempts != nil {
 		scfg.TerminateAttempts = *jsnCfg.Terminate_attempts
 	}
@@ -296,6 +309,17 @@ func (scfg *SessionSCfg) loadFromJsonCfg(jsnCfg *SessionSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Pubsubs_conns != nil {
+		scfg.PubSubSConns = make([]string, len(*jsnCfg.Pubsubs_conns))
+		for idx, connID := range *jsnCfg.Pubsubs_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			if connID == utils.MetaInternal {
+				scfg.PubSubSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs)
+			} else {
+				scfg.PubSubSConns[idx] = connID
+			}
+		}
+	}
 	return scfg.STIRCfg.loadFromJSONCfg(jsnCfg.Stir)
 }
 
@@ -336,6 +360,14 @@ func (scfg *SessionSCfg) AsMapInterface() map[string]interface{} {
 	if scfg.ChannelSyncInterval != 0 {
 		channelSyncInterval = scfg.ChannelSyncInterval.String()
 	}
+	var storeInterval string = "0"
+	if scfg.StoreInterval != 0 {
+		storeInterval = scfg.StoreInterval.String()
+	}
+	var restoreGracePeriod string = "0"
+	if scfg.RestoreGracePeriod != 0 {
+		restoreGracePeriod = scfg.RestoreGracePeriod.String()
+	}
 	var minDurLowBalance string = "0"
 	if scfg.MinDurLowBalance != 0 {
 		minDurLowBalance = scfg.MinDurLowBalance.String()
@@ -423,6 +455,15 @@ func (scfg *SessionSCfg) AsMapInterface() map[string]interface{} {
 			schedulerConns[i] = item
 		}
 	}
+	pubSubSConns := make([]string, len(scfg.PubSubSConns))
+	for i, item := range scfg.PubSubSConns {
+		buf := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs)
+		if item == buf {
+			pubSubSConns[i] = strings.ReplaceAll(item, utils.CONCATENATED_KEY_SEP+utils.MetaPubSubs, utils.EmptyString)
+		} else {
+			pubSubSConns[i] = item
+		}
+	}
 	return map[string]interface{}{
 		utils.EnabledCfg:             scfg.Enabled,
 		utils.ListenBijsonCfg:        scfg.ListenBijson,
@@ -447,10 +488,13 @@ func (scfg *SessionSCfg) AsMapInterface() map[string]interface{} {
 		utils.SessionIndexesCfg:      scfg.SessionIndexes.Slice(),
 		utils.ClientProtocolCfg:      scfg.ClientProtocol,
 		utils.ChannelSyncIntervalCfg: channelSyncInterval,
+		utils.StoreIntervalCfg:       storeInterval,
+		utils.RestoreGracePeriodCfg:  restoreGracePeriod,
 		utils.TerminateAttemptsCfg:   scfg.TerminateAttempts,
 		utils.AlterableFieldsCfg:     scfg.AlterableFields.AsSlice(),
 		utils.MinDurLowBalanceCfg:    minDurLowBalance,
 		utils.SchedulerConnsCfg:      schedulerConns,
+		utils.PubSubSConnsCfg:        pubSubSConns,
 		utils.STIRCfg:                scfg.STIRCfg.AsMapInterface(),
 	}
 }
@@ -631,10 +675,12 @@ func (aConnCfg *AsteriskConnCfg) AsMapInterface() map[string]interface{} {
 }
 
 type AsteriskAgentCfg struct {
-	Enabled       bool
-	SessionSConns []string
-	CreateCDR     bool
-	AsteriskConns []*AsteriskConnCfg
+	Enabled           bool
+	SessionSConns     []string
+	CreateCDR         bool
+	UpdateInterval    time.Duration // interval for sending SessionSv1.UpdateSession on answered channels, 0 to disable
+	LowBalanceAnnFile string        // sound played by DisconnectWarning
+	AsteriskConns     []*AsteriskConnCfg
 }
 
 func (aCfg *AsteriskAgentCfg) loadFromJsonCfg(jsnCfg *AsteriskAgentJsonCfg) (err error) {
@@ -658,6 +704,14 @@ func (aCfg *AsteriskAgentCfg) loadFromJsonCfg(jsnCfg *AsteriskAgentJsonCfg) (err
 	if jsnCfg.Create_cdr != nil {
 		aCfg.CreateCDR = *jsnCfg.Create_cdr
 	}
+	if jsnCfg.Update_interval != nil {
+		if aCfg.UpdateInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Update_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Low_balance_ann_file != nil {
+		aCfg.LowBalanceAnnFile = *jsnCfg.Low_balance_ann_file
+	}
 	if jsnCfg.Asterisk_conns != nil {
 		aCfg.AsteriskConns = make([]*AsteriskConnCfg, len(*jsnCfg.Asterisk_conns))
 		for i, jsnAConn := range *jsnCfg.Asterisk_conns {
@@ -684,11 +738,18 @@ func (aCfg *AsteriskAgentCfg) AsMapInterface() map[string]interface{} {
 		}
 	}
 
+	var updateInterval string = "0"
+	if aCfg.UpdateInterval != 0 {
+		updateInterval = aCfg.UpdateInterval.String()
+	}
+
 	return map[string]interface{}{
-		utils.EnabledCfg:       aCfg.Enabled,
-		utils.SessionSConnsCfg: sessionSConns,
-		utils.CreateCDRCfg:     aCfg.CreateCDR,
-		utils.AsteriskConnsCfg: conns,
+		utils.EnabledCfg:           aCfg.Enabled,
+		utils.SessionSConnsCfg:     sessionSConns,
+		utils.CreateCDRCfg:         aCfg.CreateCDR,
+		utils.UpdateIntervalCfg:    updateInterval,
+		utils.LowBalanceAnnFileCfg: aCfg.LowBalanceAnnFile,
+		utils.AsteriskConnsCfg:     conns,
 	}
 }
 
@@ -699,6 +760,8 @@ type STIRcfg struct {
 	DefaultAttest      string
 	PublicKeyPath      string
 	PrivateKeyPath     string
+	TrustAnchors       []string // paths to the STI-CA root certificates used to validate the x5u chain
+	CRLs               []string // paths to the certificate revocation lists checked against the x5u chain
 }
 
 func (stirCfg *STIRcfg) loadFromJSONCfg(jsnCfg *STIRJsonCfg) (err error) {
@@ -722,6 +785,14 @@ func (stirCfg *STIRcfg) loadFromJSONCfg(jsnCfg *STIRJsonCfg) (err error) {
 	if jsnCfg.Privatekey_path != nil {
 		stirCfg.PrivateKeyPath = *jsnCfg.Privatekey_path
 	}
+	if jsnCfg.Trust_anchors != nil {
+		stirCfg.TrustAnchors = make([]string, len(*jsnCfg.Trust_anchors))
+		copy(stirCfg.TrustAnchors, *jsnCfg.Trust_anchors)
+	}
+	if jsnCfg.Crls != nil {
+		stirCfg.CRLs = make([]string, len(*jsnCfg.Crls))
+		copy(stirCfg.CRLs, *jsnCfg.Crls)
+	}
 	return nil
 }
 
@@ -734,6 +805,10 @@ func (stirCfg *STIRcfg) AsMapInterface() map[string]interface{} {
 	} else {
 		payloadMaxduration = stirCfg.PayloadMaxduration.String()
 	}
+	trustAnchors := make([]string, len(stirCfg.TrustAnchors))
+	copy(trustAnchors, stirCfg.TrustAnchors)
+	crls := make([]string, len(stirCfg.CRLs))
+	copy(crls, stirCfg.CRLs)
 
 	return map[string]interface{}{
 		utils.DefaultAttestCfg:      stirCfg.DefaultAttest,
@@ -741,5 +816,7 @@ func (stirCfg *STIRcfg) AsMapInterface() map[string]interface{} {
 		utils.PrivateKeyPathCfg:     stirCfg.PrivateKeyPath,
 		utils.AllowedAtestCfg:       stirCfg.AllowedAttest.AsSlice(),
 		utils.PayloadMaxdurationCfg: payloadMaxduration,
+		utils.TrustAnchorsCfg:       trustAnchors,
+		utils.CRLsCfg:               crls,
 	}
 }
@@ -180,6 +180,8 @@ func TestSessionSCfgAsMapInterface(t *testing.T) {
 		"session_indexes":        []string{},
 		"client_protocol":        1.0,
 		"channel_sync_interval":  "0",
+		"store_interval":         "0",
+		"restore_grace_period":   "0",
 		"terminate_attempts":     5,
 		"alterable_fields":       []string{},
 		"session_ttl_last_used":  "0",
@@ -192,8 +194,11 @@ func TestSessionSCfgAsMapInterface(t *testing.T) {
 			"default_attest":      "A",
 			"publickey_path":      "",
 			"privatekey_path":     "",
+			"trust_anchors":       []string{},
+			"crls":                []string{},
 		},
 		"scheduler_conns": []string{},
+		"pubsubs_conns":   []string{},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -225,6 +230,8 @@ func TestSessionSCfgAsMapInterface(t *testing.T) {
 			"session_indexes": [],
 			"client_protocol": 1.0,
 			"channel_sync_interval": "0",
+			"store_interval": "1m",
+			"restore_grace_period": "30s",
 			"terminate_attempts": 5,
 			"alterable_fields": [],
 			"stir": {
@@ -258,6 +265,8 @@ func TestSessionSCfgAsMapInterface(t *testing.T) {
 		"session_indexes":        []string{},
 		"client_protocol":        1.0,
 		"channel_sync_interval":  "0",
+		"store_interval":         "1m0s",
+		"restore_grace_period":   "30s",
 		"terminate_attempts":     5,
 		"alterable_fields":       []string{},
 		"session_ttl_last_used":  "0",
@@ -270,8 +279,11 @@ func TestSessionSCfgAsMapInterface(t *testing.T) {
 			"default_attest":      "A",
 			"publickey_path":      "",
 			"privatekey_path":     "",
+			"trust_anchors":       []string{},
+			"crls":                []string{},
 		},
 		"scheduler_conns": []string{"*internal"},
+		"pubsubs_conns":   []string{},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -494,9 +506,11 @@ func TestAsteriskAgentCfgAsMapInterface(t *testing.T) {
 	},
 }`
 	eMap := map[string]interface{}{
-		"enabled":        true,
-		"sessions_conns": []string{"*internal"},
-		"create_cdr":     false,
+		"enabled":              true,
+		"sessions_conns":       []string{"*internal"},
+		"create_cdr":           false,
+		"update_interval":      "0",
+		"low_balance_ann_file": "",
 		"asterisk_conns": []map[string]interface{}{
 			{"alias": "", "address": "127.0.0.1:8088", "user": "cgrates", "password": "CGRateS.org", "connect_attempts": 3, "reconnects": 5},
 		},
@@ -34,6 +34,7 @@ type StatSCfg struct {
 	StringIndexedFields    *[]string
 	PrefixIndexedFields    *[]string
 	NestedFields           bool
+	PubSubSConns           []string
 }
 
 func (st *StatSCfg) loadFromJsonCfg(jsnCfg *StatServJsonCfg) (err error) {
@@ -82,6 +83,17 @@ func (st *StatSCfg) loadFromJsonCfg(jsnCfg *StatServJsonCfg) (err error) {
 	if jsnCfg.Nested_fields != nil {
 		st.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Pubsubs_conns != nil {
+		st.PubSubSConns = make([]string, len(*jsnCfg.Pubsubs_conns))
+		for idx, connID := range *jsnCfg.Pubsubs_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			if connID == utils.MetaInternal {
+				st.PubSubSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs)
+			} else {
+				st.PubSubSConns[idx] = connID
+			}
+		}
+	}
 	return nil
 }
 
@@ -113,6 +125,15 @@ func (st *StatSCfg) AsMapInterface() map[string]interface{} {
 			thresholdSConns[i] = item
 		}
 	}
+	pubSubSConns := make([]string, len(st.PubSubSConns))
+	for i, item := range st.PubSubSConns {
+		buf := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs)
+		if item == buf {
+			pubSubSConns[i] = strings.ReplaceAll(item, utils.CONCATENATED_KEY_SEP+utils.MetaPubSubs, utils.EmptyString)
+		} else {
+			pubSubSConns[i] = item
+		}
+	}
 
 	return map[string]interface{}{
 		utils.EnabledCfg:                st.Enabled,
@@ -123,6 +144,7 @@ func (st *StatSCfg) AsMapInterface() map[string]interface{} {
 		utils.StringIndexedFieldsCfg:    stringIndexedFields,
 		utils.PrefixIndexedFieldsCfg:    prefixIndexedFields,
 		utils.NestedFieldsCfg:           st.NestedFields,
+		utils.PubSubSConnsCfg:           pubSubSConns,
 	}
 
 }
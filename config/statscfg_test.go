@@ -94,6 +94,7 @@ func TestStatSCfgAsMapInterface(t *testing.T) {
 		"prefix_indexed_fields":    []string{},
 		"nested_fields":            false,
 		"string_indexed_fields":    []string{},
+		"pubsubs_conns":            []string{},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -125,6 +126,7 @@ func TestStatSCfgAsMapInterface(t *testing.T) {
 		"prefix_indexed_fields":    []string{"prefix_indexed_fields1", "prefix_indexed_fields2"},
 		"nested_fields":            false,
 		"string_indexed_fields":    []string{},
+		"pubsubs_conns":            []string{},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
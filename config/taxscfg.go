@@ -0,0 +1,78 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// TaxSCfg is the configuration of the tax service
+type TaxSCfg struct {
+	Enabled             bool
+	IndexedSelects      bool
+	StringIndexedFields *[]string
+	PrefixIndexedFields *[]string
+	NestedFields        bool
+}
+
+func (tS *TaxSCfg) loadFromJsonCfg(jsnCfg *TaxSJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		tS.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Indexed_selects != nil {
+		tS.IndexedSelects = *jsnCfg.Indexed_selects
+	}
+	if jsnCfg.String_indexed_fields != nil {
+		sif := make([]string, len(*jsnCfg.String_indexed_fields))
+		copy(sif, *jsnCfg.String_indexed_fields)
+		tS.StringIndexedFields = &sif
+	}
+	if jsnCfg.Prefix_indexed_fields != nil {
+		pif := make([]string, len(*jsnCfg.Prefix_indexed_fields))
+		copy(pif, *jsnCfg.Prefix_indexed_fields)
+		tS.PrefixIndexedFields = &pif
+	}
+	if jsnCfg.Nested_fields != nil {
+		tS.NestedFields = *jsnCfg.Nested_fields
+	}
+	return
+}
+
+func (tS *TaxSCfg) AsMapInterface() map[string]interface{} {
+	stringIndexedFields := []string{}
+	if tS.StringIndexedFields != nil {
+		stringIndexedFields = make([]string, len(*tS.StringIndexedFields))
+		copy(stringIndexedFields, *tS.StringIndexedFields)
+	}
+	prefixIndexedFields := []string{}
+	if tS.PrefixIndexedFields != nil {
+		prefixIndexedFields = make([]string, len(*tS.PrefixIndexedFields))
+		copy(prefixIndexedFields, *tS.PrefixIndexedFields)
+	}
+	return map[string]interface{}{
+		utils.EnabledCfg:             tS.Enabled,
+		utils.IndexedSelectsCfg:      tS.IndexedSelects,
+		utils.StringIndexedFieldsCfg: stringIndexedFields,
+		utils.PrefixIndexedFieldsCfg: prefixIndexedFields,
+		utils.NestedFieldsCfg:        tS.NestedFields,
+	}
+}
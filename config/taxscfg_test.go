@@ -0,0 +1,69 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestTaxSCfgloadFromJsonCfg(t *testing.T) {
+	var tS, expected TaxSCfg
+	if err := tS.loadFromJsonCfg(nil); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(tS, expected) {
+		t.Errorf("Expected: %+v ,recived: %+v", expected, tS)
+	}
+	cfgJSONStr := `{
+		"taxs": {
+			"enabled": true,
+			"indexed_selects": false,
+			"string_indexed_fields": ["*req.Destination"],
+			"prefix_indexed_fields": ["*req.Country"],
+			"nested_fields": true,
+		},
+}`
+	expected = TaxSCfg{
+		Enabled:             true,
+		IndexedSelects:      false,
+		StringIndexedFields: &[]string{"*req.Destination"},
+		PrefixIndexedFields: &[]string{"*req.Country"},
+		NestedFields:        true,
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnTS, err := jsnCfg.TaxSJsonCfg(); err != nil {
+		t.Error(err)
+	} else if err = tS.loadFromJsonCfg(jsnTS); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, tS) {
+		t.Errorf("Expected: %+v , recived: %+v", utils.ToJSON(expected), utils.ToJSON(tS))
+	}
+	eMap := map[string]interface{}{
+		"enabled":               true,
+		"indexed_selects":       false,
+		"string_indexed_fields": []string{"*req.Destination"},
+		"prefix_indexed_fields": []string{"*req.Country"},
+		"nested_fields":         true,
+	}
+	if rcv := tS.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("\nExpected: %+v\nRecived: %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"strings"
 	"time"
 
 	"github.com/cgrates/cgrates/utils"
@@ -31,6 +32,7 @@ type ThresholdSCfg struct {
 	StringIndexedFields *[]string
 	PrefixIndexedFields *[]string
 	NestedFields        bool
+	PubSubSConns        []string
 }
 
 func (t *ThresholdSCfg) loadFromJsonCfg(jsnCfg *ThresholdSJsonCfg) (err error) {
@@ -65,6 +67,17 @@ func (t *ThresholdSCfg) loadFromJsonCfg(jsnCfg *ThresholdSJsonCfg) (err error) {
 	if jsnCfg.Nested_fields != nil {
 		t.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Pubsubs_conns != nil {
+		t.PubSubSConns = make([]string, len(*jsnCfg.Pubsubs_conns))
+		for idx, connID := range *jsnCfg.Pubsubs_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			if connID == utils.MetaInternal {
+				t.PubSubSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs)
+			} else {
+				t.PubSubSConns[idx] = connID
+			}
+		}
+	}
 	return nil
 }
 
@@ -87,6 +100,15 @@ func (t *ThresholdSCfg) AsMapInterface() map[string]interface{} {
 			prefixIndexedFields[i] = item
 		}
 	}
+	pubSubSConns := make([]string, len(t.PubSubSConns))
+	for i, item := range t.PubSubSConns {
+		buf := utils.ConcatenatedKey(utils.MetaInternal, utils.MetaPubSubs)
+		if item == buf {
+			pubSubSConns[i] = strings.ReplaceAll(item, utils.CONCATENATED_KEY_SEP+utils.MetaPubSubs, utils.EmptyString)
+		} else {
+			pubSubSConns[i] = item
+		}
+	}
 	return map[string]interface{}{
 		utils.EnabledCfg:             t.Enabled,
 		utils.IndexedSelectsCfg:      t.IndexedSelects,
@@ -94,5 +116,6 @@ func (t *ThresholdSCfg) AsMapInterface() map[string]interface{} {
 		utils.StringIndexedFieldsCfg: stringIndexedFields,
 		utils.PrefixIndexedFieldsCfg: prefixIndexedFields,
 		utils.NestedFieldsCfg:        t.NestedFields,
+		utils.PubSubSConnsCfg:        pubSubSConns,
 	}
 }
@@ -79,6 +79,7 @@ func TestThresholdSCfgAsMapInterface(t *testing.T) {
 		"string_indexed_fields": []string{},
 		"prefix_indexed_fields": []string{},
 		"nested_fields":         false,
+		"pubsubs_conns":         []string{},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -107,6 +108,7 @@ func TestThresholdSCfgAsMapInterface(t *testing.T) {
 		"string_indexed_fields": []string{"string", "indexed", "fields"},
 		"prefix_indexed_fields": []string{"prefix_indexed_fields1", "prefix_indexed_fields2"},
 		"nested_fields":         true,
+		"pubsubs_conns":         []string{},
 	}
 	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
 		t.Error(err)
@@ -83,6 +83,8 @@ func (self *CmdApierPing) RpcMethod() string {
 		return utils.EventExporterSv1Ping
 	case utils.RateSLow:
 		return utils.RateSv1Ping
+	case utils.AuditSLow:
+		return utils.AuditSv1Ping
 	default:
 	}
 	return self.rpcMethod
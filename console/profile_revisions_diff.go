@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdGetProfileRevisionsDiff{
+		name:      "profile_revisions_diff",
+		rpcMethod: utils.APIerSv1GetProfileRevisionsDiff,
+		rpcParams: new(utils.ArgsProfileRevisionsDiff),
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// CmdGetProfileRevisionsDiff compares two revisions of a profile
+type CmdGetProfileRevisionsDiff struct {
+	name      string
+	rpcMethod string
+	rpcParams *utils.ArgsProfileRevisionsDiff
+	*CommandExecuter
+}
+
+func (self *CmdGetProfileRevisionsDiff) Name() string {
+	return self.name
+}
+
+func (self *CmdGetProfileRevisionsDiff) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdGetProfileRevisionsDiff) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = new(utils.ArgsProfileRevisionsDiff)
+	}
+	return self.rpcParams
+}
+
+func (self *CmdGetProfileRevisionsDiff) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdGetProfileRevisionsDiff) RpcResult() interface{} {
+	var diff map[string]*engine.AuditChange
+	return &diff
+}
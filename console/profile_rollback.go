@@ -0,0 +1,65 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdProfileRollback{
+		name:      "profile_rollback",
+		rpcMethod: utils.APIerSv1RollbackProfile,
+		rpcParams: new(utils.ArgsProfileRollback),
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// CmdProfileRollback restores a previous revision of a profile
+type CmdProfileRollback struct {
+	name      string
+	rpcMethod string
+	rpcParams *utils.ArgsProfileRollback
+	*CommandExecuter
+}
+
+func (self *CmdProfileRollback) Name() string {
+	return self.name
+}
+
+func (self *CmdProfileRollback) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdProfileRollback) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = new(utils.ArgsProfileRollback)
+	}
+	return self.rpcParams
+}
+
+func (self *CmdProfileRollback) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdProfileRollback) RpcResult() interface{} {
+	var s string
+	return &s
+}
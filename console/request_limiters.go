@@ -0,0 +1,68 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import "github.com/cgrates/cgrates/utils"
+
+func init() {
+	c := &CmdRequestLimiters{
+		name:      "request_limiters",
+		rpcMethod: utils.CoreSv1RequestLimiterMetrics,
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+type CmdRequestLimiters struct {
+	name      string
+	rpcMethod string
+	rpcParams *utils.TenantWithArgDispatcher
+	*CommandExecuter
+}
+
+func (self *CmdRequestLimiters) Name() string {
+	return self.name
+}
+
+func (self *CmdRequestLimiters) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdRequestLimiters) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &utils.TenantWithArgDispatcher{
+			TenantArg:     new(utils.TenantArg),
+			ArgDispatcher: new(utils.ArgDispatcher),
+		}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdRequestLimiters) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdRequestLimiters) RpcResult() interface{} {
+	var s map[string]*utils.RequestLimiterMetrics
+	return &s
+}
+
+func (self *CmdRequestLimiters) ClientArgs() (args []string) {
+	return
+}
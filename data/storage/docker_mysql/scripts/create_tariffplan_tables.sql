@@ -18,6 +18,40 @@ CREATE TABLE `tp_timings` (
   UNIQUE KEY `tpid_tag` (`tpid`,`tag`)
 );
 
+--
+-- Table structure for table `tp_fx_rates`
+--
+
+DROP TABLE IF EXISTS `tp_fx_rates`;
+CREATE TABLE `tp_fx_rates` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `from_currency` varchar(8) NOT NULL,
+  `to_currency` varchar(8) NOT NULL,
+  `rate` decimal(16,8) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  UNIQUE KEY `tpid_from_to` (`tpid`,`from_currency`,`to_currency`)
+);
+
+--
+-- Table structure for table `tp_ported_numbers`
+--
+
+DROP TABLE IF EXISTS `tp_ported_numbers`;
+CREATE TABLE `tp_ported_numbers` (
+  `id` int(11) NOT NULL AUTO_INCREMENT,
+  `tpid` varchar(64) NOT NULL,
+  `number` varchar(64) NOT NULL,
+  `lrn` varchar(32) NOT NULL,
+  `network` varchar(64) NOT NULL,
+  `created_at` TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `tpid` (`tpid`),
+  UNIQUE KEY `tpid_number` (`tpid`,`number`)
+);
+
 --
 -- Table structure for table `tp_destinations`
 --
@@ -71,6 +105,7 @@ CREATE TABLE `tp_destination_rates` (
   `rounding_decimals` tinyint(4) NOT NULL,
   `max_cost` decimal(7,4) NOT NULL,
   `max_cost_strategy` varchar(16) NOT NULL,
+  `currency` varchar(8) NOT NULL DEFAULT '',
   `created_at` TIMESTAMP,
   PRIMARY KEY (`id`),
   KEY `tpid` (`tpid`),
@@ -50,3 +50,25 @@ CREATE TABLE session_costs (
   KEY run_origin_idx (run_id, origin_id),
   KEY deleted_at_idx (deleted_at)
 );
+
+--
+-- Table structure for table `audit_records`
+--
+
+DROP TABLE IF EXISTS audit_records;
+CREATE TABLE audit_records (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  audit_id varchar(40) NOT NULL,
+  tenant varchar(64) NOT NULL,
+  item_type varchar(64) NOT NULL,
+  item_id varchar(128) NOT NULL,
+  method varchar(128) NOT NULL,
+  caller varchar(128) NOT NULL,
+  changes MEDIUMTEXT,
+  error TEXT,
+  created_at TIMESTAMP(6) NULL,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY audit_id (audit_id),
+  KEY tenant_item_idx (tenant, item_type, item_id),
+  KEY created_at_idx (created_at)
+);
@@ -57,3 +57,26 @@ DROP INDEX IF EXISTS run_origin_sessionscost_idx;
 CREATE INDEX run_origin_sessionscost_idx ON session_costs (run_id, origin_id);
 DROP INDEX IF EXISTS deleted_at_sessionscost_idx;
 CREATE INDEX deleted_at_sessionscost_idx ON session_costs (deleted_at);
+
+--
+-- Table structure for table `audit_records`
+--
+
+DROP TABLE IF EXISTS audit_records;
+CREATE TABLE audit_records (
+  id SERIAL PRIMARY KEY,
+  audit_id VARCHAR(40) NOT NULL,
+  tenant VARCHAR(64) NOT NULL,
+  item_type VARCHAR(64) NOT NULL,
+  item_id VARCHAR(128) NOT NULL,
+  method VARCHAR(128) NOT NULL,
+  caller VARCHAR(128) NOT NULL,
+  changes jsonb,
+  error TEXT,
+  created_at TIMESTAMP WITH TIME ZONE,
+  UNIQUE (audit_id)
+);
+DROP INDEX IF EXISTS tenant_item_auditrecords_idx;
+CREATE INDEX tenant_item_auditrecords_idx ON audit_records (tenant, item_type, item_id);
+DROP INDEX IF EXISTS created_at_auditrecords_idx;
+CREATE INDEX created_at_auditrecords_idx ON audit_records (created_at);
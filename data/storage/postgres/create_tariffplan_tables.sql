@@ -17,6 +17,38 @@ CREATE TABLE tp_timings (
 CREATE INDEX tptimings_tpid_idx ON tp_timings (tpid);
 CREATE INDEX tptimings_idx ON tp_timings (tpid,tag);
 
+--
+-- Table structure for table `tp_fx_rates`
+--
+
+DROP TABLE IF EXISTS tp_fx_rates;
+CREATE TABLE tp_fx_rates (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  from_currency VARCHAR(8) NOT NULL,
+  to_currency VARCHAR(8) NOT NULL,
+  rate NUMERIC(16,8) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE,
+  UNIQUE (tpid, from_currency, to_currency)
+);
+CREATE INDEX tpfxrates_tpid_idx ON tp_fx_rates (tpid);
+
+--
+-- Table structure for table `tp_ported_numbers`
+--
+
+DROP TABLE IF EXISTS tp_ported_numbers;
+CREATE TABLE tp_ported_numbers (
+  id SERIAL PRIMARY KEY,
+  tpid VARCHAR(64) NOT NULL,
+  number VARCHAR(64) NOT NULL,
+  lrn VARCHAR(32) NOT NULL,
+  network VARCHAR(64) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE,
+  UNIQUE (tpid, number)
+);
+CREATE INDEX tpportednumbers_tpid_idx ON tp_ported_numbers (tpid);
+
 --
 -- Table structure for table `tp_destinations`
 --
@@ -68,6 +100,7 @@ CREATE TABLE tp_destination_rates (
   rounding_decimals SMALLINT NOT NULL,
   max_cost NUMERIC(7,4) NOT NULL,
   max_cost_strategy VARCHAR(16) NOT NULL,
+  currency VARCHAR(8) NOT NULL DEFAULT '',
   created_at TIMESTAMP WITH TIME ZONE,
   UNIQUE (tpid, tag , destinations_tag)
 );
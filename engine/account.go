@@ -38,6 +38,7 @@ type Account struct {
 	BalanceMap        map[string]Balances
 	UnitCounters      UnitCounters
 	ActionTriggers    ActionTriggers
+	Caps              AccountCaps // spending and usage limits per period
 	AllowNegative     bool
 	Disabled          bool
 	UpdateTime        time.Time
@@ -496,7 +497,9 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 
 		if initialLength == 0 {
 			// this is the first add, debit the connect fee
-			ok, debitedConnectFeeBalance = acc.DebitConnectionFee(cc, usefulMoneyBalances, count, true)
+			if ok, debitedConnectFeeBalance, err = acc.DebitConnectionFee(cc, usefulMoneyBalances, count, true); err != nil {
+				return nil, err
+			}
 		}
 		//log.Printf("Left CC: %+v ", leftCC)
 		// get the default money balanance
@@ -517,9 +520,10 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 					Cost:     ts.RateInterval.Rating.ConnectFee,
 					BalanceInfo: &DebitInfo{
 						Monetary: &MonetaryInfo{
-							UUID:  debitedConnectFeeBalance.Uuid,
-							ID:    debitedConnectFeeBalance.ID,
-							Value: debitedConnectFeeBalance.Value,
+							UUID:   debitedConnectFeeBalance.Uuid,
+							ID:     debitedConnectFeeBalance.ID,
+							Value:  debitedConnectFeeBalance.Value,
+							FxRate: connectFeeFxRate(cc, &debitedConnectFeeBalance),
 						},
 						AccountID: acc.ID,
 					},
@@ -538,7 +542,11 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 
 				cost := increment.Cost
 				defaultBalance := acc.GetDefaultMoneyBalance()
-				defaultBalance.SubstractValue(cost)
+				fxRate, err := fxRateForBalance(ts.RateInterval.Rating.Currency, defaultBalance)
+				if err != nil {
+					return nil, err
+				}
+				defaultBalance.SubstractValue(convertFx(cost, fxRate))
 				//send default balance to thresholdS to be processed
 				if len(config.CgrConfig().RalsCfg().ThresholdSConns) != 0 {
 					acntTnt := utils.NewTenantID(acc.ID)
@@ -563,9 +571,10 @@ func (acc *Account) debitCreditBalance(cd *CallDescriptor, count bool, dryRun bo
 				}
 
 				increment.BalanceInfo.Monetary = &MonetaryInfo{
-					UUID:  defaultBalance.Uuid,
-					ID:    defaultBalance.ID,
-					Value: defaultBalance.Value,
+					UUID:   defaultBalance.Uuid,
+					ID:     defaultBalance.ID,
+					Value:  defaultBalance.Value,
+					FxRate: fxRate,
 				}
 				increment.BalanceInfo.AccountID = acc.ID
 				increment.paid = true
@@ -845,6 +854,7 @@ func (acc *Account) Clone() *Account {
 	newAcc := &Account{
 		ID:            acc.ID,
 		UnitCounters:  acc.UnitCounters.Clone(),
+		Caps:          acc.Caps.Clone(),
 		AllowNegative: acc.AllowNegative,
 		Disabled:      acc.Disabled,
 	}
@@ -864,7 +874,8 @@ func (acc *Account) Clone() *Account {
 }
 
 // DebitConnectionFee debits the connection fee
-func (acc *Account) DebitConnectionFee(cc *CallCost, usefulMoneyBalances Balances, count bool, block bool) (bool, Balance) {
+// it fails, like the rest of the debit, if the fee cannot be converted in the currency of the balance
+func (acc *Account) DebitConnectionFee(cc *CallCost, usefulMoneyBalances Balances, count bool, block bool) (bool, Balance, error) {
 	var debitedBalance Balance
 
 	if cc.deductConnectFee {
@@ -872,18 +883,22 @@ func (acc *Account) DebitConnectionFee(cc *CallCost, usefulMoneyBalances Balance
 		//log.Print("CONNECT FEE: %f", connectFee)
 		connectFeePaid := false
 		for _, b := range usefulMoneyBalances {
-			if b.GetValue() >= connectFee {
-				b.SubstractValue(connectFee)
+			fxRate, err := fxRateForBalance(cc.GetCurrency(), b)
+			if err != nil { // no way to pay from this balance
+				continue
+			}
+			if fee := convertFx(connectFee, fxRate); b.GetValue() >= fee {
+				b.SubstractValue(fee)
 				// the conect fee is not refundable!
 				if count {
-					acc.countUnits(connectFee, utils.MONETARY, cc, b)
+					acc.countUnits(fee, utils.MONETARY, cc, b)
 				}
 				connectFeePaid = true
 				debitedBalance = *b
 				break
 			}
 			if b.Blocker && block { // stop here
-				return false, debitedBalance
+				return false, debitedBalance, nil
 			}
 		}
 		// debit connect fee
@@ -891,15 +906,20 @@ func (acc *Account) DebitConnectionFee(cc *CallCost, usefulMoneyBalances Balance
 			cc.negativeConnectFee = true
 			// there are no money for the connect fee; go negative
 			b := acc.GetDefaultMoneyBalance()
-			b.SubstractValue(connectFee)
+			fxRate, err := fxRateForBalance(cc.GetCurrency(), b)
+			if err != nil {
+				return false, debitedBalance, err
+			}
+			fee := convertFx(connectFee, fxRate)
+			b.SubstractValue(fee)
 			debitedBalance = *b
 			// the conect fee is not refundable!
 			if count {
-				acc.countUnits(connectFee, utils.MONETARY, cc, b)
+				acc.countUnits(fee, utils.MONETARY, cc, b)
 			}
 		}
 	}
-	return true, debitedBalance
+	return true, debitedBalance, nil
 }
 
 func (acc *Account) matchActionFilter(condition string) (bool, error) {
@@ -0,0 +1,176 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AccountCap limits the spending or the usage of an account within a period
+type AccountCap struct {
+	ID          string
+	Type        string          // <*monetary|*units>: cap the spent amount or the used units
+	Limit       float64         // units are in the usage resolution of the ToR, ie: nanoseconds for *voice
+	Period      string          // <*daily|*weekly|*monthly|*yearly>
+	ToRs        utils.StringMap // count only these ToRs, all if empty
+	Categories  utils.StringMap // count only these categories, all if empty
+	Consumed    float64         // spent amount or used units within the current period
+	PeriodStart time.Time       // start of the current period
+}
+
+// Validate checks the definition of the cap
+func (aCap *AccountCap) Validate() error {
+	if aCap.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	if aCap.Type != utils.MONETARY && aCap.Type != utils.MetaUnits {
+		return fmt.Errorf("unsupported type: <%s> for cap: <%s>", aCap.Type, aCap.ID)
+	}
+	switch aCap.Period {
+	case utils.MetaDaily, utils.MetaWeekly, utils.MetaMonthly, utils.MetaYearly:
+	default:
+		return fmt.Errorf("unsupported period: <%s> for cap: <%s>", aCap.Period, aCap.ID)
+	}
+	if aCap.Limit < 0 {
+		return fmt.Errorf("negative limit for cap: <%s>", aCap.ID)
+	}
+	return nil
+}
+
+// Clone returns a copy of the cap
+func (aCap *AccountCap) Clone() *AccountCap {
+	cln := *aCap
+	cln.ToRs = aCap.ToRs.Clone()
+	cln.Categories = aCap.Categories.Clone()
+	return &cln
+}
+
+// matches returns true if the usage of this ToR and category is counted by the cap
+func (aCap *AccountCap) matches(tor, category string) bool {
+	return (len(aCap.ToRs) == 0 || aCap.ToRs[tor]) &&
+		(len(aCap.Categories) == 0 || aCap.Categories[category])
+}
+
+// periodStart returns the start of the period containing t
+func (aCap *AccountCap) periodStart(t time.Time) time.Time {
+	y, m, d := t.Date()
+	switch aCap.Period {
+	case utils.MetaDaily:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	case utils.MetaWeekly: // weeks start on Monday
+		return time.Date(y, m, d-(int(t.Weekday())+6)%7, 0, 0, 0, 0, t.Location())
+	case utils.MetaMonthly:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case utils.MetaYearly:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, t.Location())
+	}
+	return time.Time{}
+}
+
+// left returns what can still be consumed within the period containing t
+func (aCap *AccountCap) left(t time.Time) float64 {
+	if aCap.periodStart(t).After(aCap.PeriodStart) { // new period
+		return aCap.Limit
+	}
+	if aCap.Consumed >= aCap.Limit {
+		return 0
+	}
+	return aCap.Limit - aCap.Consumed
+}
+
+// count adds the value consumed at t, negative values are refunds
+func (aCap *AccountCap) count(t time.Time, value float64) {
+	pStart := aCap.periodStart(t)
+	if pStart.Before(aCap.PeriodStart) { // belongs to a previous period
+		return
+	}
+	if pStart.After(aCap.PeriodStart) {
+		aCap.PeriodStart = pStart
+		aCap.Consumed = 0
+	}
+	if aCap.Consumed += value; aCap.Consumed < 0 {
+		aCap.Consumed = 0
+	}
+}
+
+// AccountCaps is the list of caps of an account
+type AccountCaps []*AccountCap
+
+// Clone returns a copy of the caps
+func (caps AccountCaps) Clone() (cln AccountCaps) {
+	if caps == nil {
+		return
+	}
+	cln = make(AccountCaps, len(caps))
+	for i, aCap := range caps {
+		cln[i] = aCap.Clone()
+	}
+	return
+}
+
+// Refresh resets the consumption of the caps whose period ended before t
+func (caps AccountCaps) Refresh(t time.Time) {
+	for _, aCap := range caps {
+		if pStart := aCap.periodStart(t); pStart.After(aCap.PeriodStart) {
+			aCap.PeriodStart = pStart
+			aCap.Consumed = 0
+		}
+	}
+}
+
+// left returns the units and the amount which can still be consumed at t
+// for the ToR and category, -1 meaning there is no cap
+func (caps AccountCaps) left(tor, category string, t time.Time) (units, amount float64) {
+	units, amount = -1, -1
+	for _, aCap := range caps {
+		if !aCap.matches(tor, category) {
+			continue
+		}
+		left := aCap.left(t)
+		switch aCap.Type {
+		case utils.MetaUnits:
+			if units == -1 || left < units {
+				units = left
+			}
+		case utils.MONETARY:
+			if amount == -1 || left < amount {
+				amount = left
+			}
+		}
+	}
+	return
+}
+
+// count adds the usage and the cost consumed at t to the matching caps
+func (caps AccountCaps) count(tor, category string, t time.Time, usage time.Duration, cost float64) {
+	for _, aCap := range caps {
+		if !aCap.matches(tor, category) {
+			continue
+		}
+		switch aCap.Type {
+		case utils.MetaUnits:
+			aCap.count(t, float64(usage.Nanoseconds()))
+		case utils.MONETARY:
+			aCap.count(t, cost)
+		}
+	}
+}
@@ -0,0 +1,258 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAccountCapValidate(t *testing.T) {
+	aCap := &AccountCap{ID: "CAP1", Type: utils.MetaUnits, Period: utils.MetaMonthly, Limit: 500}
+	if err := aCap.Validate(); err != nil {
+		t.Error(err)
+	}
+	aCap.Type = utils.VOICE
+	if err := aCap.Validate(); err == nil || err.Error() != "unsupported type: <*voice> for cap: <CAP1>" {
+		t.Errorf("received: %v", err)
+	}
+	aCap.Type = utils.MONETARY
+	aCap.Period = "*hourly"
+	if err := aCap.Validate(); err == nil || err.Error() != "unsupported period: <*hourly> for cap: <CAP1>" {
+		t.Errorf("received: %v", err)
+	}
+}
+
+func TestAccountCapPeriodStart(t *testing.T) {
+	tm := time.Date(2020, 5, 14, 13, 37, 0, 0, time.UTC) // Thursday
+	for period, exp := range map[string]time.Time{
+		utils.MetaDaily:   time.Date(2020, 5, 14, 0, 0, 0, 0, time.UTC),
+		utils.MetaWeekly:  time.Date(2020, 5, 11, 0, 0, 0, 0, time.UTC),
+		utils.MetaMonthly: time.Date(2020, 5, 1, 0, 0, 0, 0, time.UTC),
+		utils.MetaYearly:  time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if rcv := (&AccountCap{Period: period}).periodStart(tm); !rcv.Equal(exp) {
+			t.Errorf("period: %s, expecting: %v, received: %v", period, exp, rcv)
+		}
+	}
+	// Sunday belongs to the week started on Monday
+	if rcv := (&AccountCap{Period: utils.MetaWeekly}).periodStart(
+		time.Date(2020, 5, 17, 23, 0, 0, 0, time.UTC)); !rcv.Equal(time.Date(2020, 5, 11, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("received: %v", rcv)
+	}
+}
+
+func TestAccountCapsCount(t *testing.T) {
+	day1 := time.Date(2020, 5, 14, 13, 37, 0, 0, time.UTC)
+	day2 := day1.Add(24 * time.Hour)
+	caps := AccountCaps{
+		{ID: "SPEND", Type: utils.MONETARY, Period: utils.MetaDaily, Limit: 50},
+		{ID: "SMS", Type: utils.MetaUnits, Period: utils.MetaMonthly, Limit: 500,
+			ToRs: utils.StringMap{utils.SMS: true}},
+	}
+	if units, amount := caps.left(utils.SMS, "sms", day1); units != 500 || amount != 50 {
+		t.Errorf("received units: %v, amount: %v", units, amount)
+	}
+	caps.count(utils.SMS, "sms", day1, 10, 2)
+	caps.count(utils.VOICE, "call", day1, time.Minute, 30)
+	if units, amount := caps.left(utils.SMS, "sms", day1); units != 490 || amount != 18 {
+		t.Errorf("received units: %v, amount: %v", units, amount)
+	}
+	if units, amount := caps.left(utils.VOICE, "call", day1); units != -1 || amount != 18 {
+		t.Errorf("received units: %v, amount: %v", units, amount)
+	}
+	// new day resets the spending but not the monthly units
+	caps.count(utils.SMS, "sms", day2, 5, 1)
+	if units, amount := caps.left(utils.SMS, "sms", day2); units != 485 || amount != 49 {
+		t.Errorf("received units: %v, amount: %v", units, amount)
+	}
+	// refunds for the previous day are ignored
+	caps.count(utils.VOICE, "call", day1, -time.Minute, -30)
+	if caps[0].Consumed != 1 {
+		t.Errorf("received: %v", caps[0].Consumed)
+	}
+	caps.count(utils.SMS, "sms", day2, -20, -5)
+	if caps[0].Consumed != 0 || caps[1].Consumed != 0 {
+		t.Errorf("received: %s", utils.ToJSON(caps))
+	}
+	caps[0].Consumed = 60
+	if _, amount := caps.left(utils.SMS, "sms", day2); amount != 0 {
+		t.Errorf("received: %v", amount)
+	}
+	caps.Refresh(day2.Add(24 * time.Hour))
+	if caps[0].Consumed != 0 || !caps[0].PeriodStart.Equal(time.Date(2020, 5, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("received: %s", utils.ToJSON(caps[0]))
+	}
+}
+
+func TestMaxSessionTimeWithCaps(t *testing.T) {
+	cd := &CallDescriptor{
+		TimeStart:   time.Date(2015, 07, 24, 13, 37, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2015, 07, 24, 15, 37, 0, 0, time.UTC),
+		Category:    "call",
+		Tenant:      "cgrates.org",
+		Subject:     "money",
+		Destination: "0723",
+	}
+	acc, _ := dm.GetAccount("cgrates.org:money")
+	acc = acc.Clone()
+	acc.Caps = AccountCaps{{ID: "VOICE", Type: utils.MetaUnits, Period: utils.MetaDaily,
+		Limit: float64(10 * time.Minute), Consumed: float64(4 * time.Minute),
+		PeriodStart: time.Date(2015, 07, 24, 0, 0, 0, 0, time.UTC)}}
+	if allowedTime, err := cd.getMaxSessionDuration(acc); err != nil {
+		t.Error(err)
+	} else if allowedTime != 6*time.Minute {
+		t.Errorf("expecting: %v, received: %v", 6*time.Minute, allowedTime)
+	}
+	// consumption of the previous day is not counted
+	acc.Caps[0].PeriodStart = time.Date(2015, 07, 23, 0, 0, 0, 0, time.UTC)
+	if allowedTime, err := cd.getMaxSessionDuration(acc); err != nil {
+		t.Error(err)
+	} else if allowedTime != 10*time.Minute {
+		t.Errorf("expecting: %v, received: %v", 10*time.Minute, allowedTime)
+	}
+	acc.Caps = AccountCaps{{ID: "SPEND", Type: utils.MONETARY, Period: utils.MetaDaily, Limit: 100}}
+	if allowedTime, err := cd.getMaxSessionDuration(acc); err != nil {
+		t.Error(err)
+	} else if allowedTime != 99*time.Second { // 1 is the connect fee
+		t.Errorf("expecting: %v, received: %v", 99*time.Second, allowedTime)
+	}
+	// the caps are enforced on postpaid accounts too
+	acc.AllowNegative = true
+	if allowedTime, err := cd.getMaxSessionDuration(acc); err != nil {
+		t.Error(err)
+	} else if allowedTime != 99*time.Second {
+		t.Errorf("expecting: %v, received: %v", 99*time.Second, allowedTime)
+	}
+	acc.Caps = nil
+	if allowedTime, err := cd.getMaxSessionDuration(acc); err != nil {
+		t.Error(err)
+	} else if allowedTime != -1 {
+		t.Errorf("expecting: -1, received: %v", allowedTime)
+	}
+}
+
+func TestAccountCapsDebitRefund(t *testing.T) {
+	acc := &Account{
+		ID: "cgrates.org:caps",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: {&Balance{Uuid: "moneya", Value: 100}}},
+		Caps: AccountCaps{
+			{ID: "SPEND", Type: utils.MONETARY, Period: utils.MetaDaily, Limit: 50},
+			{ID: "VOICE", Type: utils.MetaUnits, Period: utils.MetaDaily, Limit: float64(time.Hour),
+				ToRs: utils.StringMap{utils.VOICE: true}},
+		},
+	}
+	if err := dm.SetAccount(acc); err != nil {
+		t.Fatal(err)
+	}
+	defer dm.RemoveAccount(acc.ID)
+	cd := &CallDescriptor{
+		TimeStart:   time.Date(2015, 07, 24, 13, 37, 0, 0, time.UTC),
+		TimeEnd:     time.Date(2015, 07, 24, 13, 37, 10, 0, time.UTC),
+		Category:    "call",
+		Tenant:      "cgrates.org",
+		Subject:     "money",
+		Account:     "caps",
+		Destination: "0723",
+	}
+	cc, err := cd.Debit()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc, err = dm.GetAccount(acc.ID); err != nil {
+		t.Fatal(err)
+	}
+	if acc.Caps[0].Consumed != cc.Cost ||
+		acc.Caps[1].Consumed != float64(10*time.Second) ||
+		!acc.Caps[0].PeriodStart.Equal(time.Date(2015, 07, 24, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("cost: %v, received: %s", cc.Cost, utils.ToJSON(acc.Caps))
+	}
+	cc.Timespans.Decompress()
+	rcd := cc.CreateCallDescriptor()
+	rcd.TimeStart = cc.GetStartTime()
+	rcd.Increments = cc.Timespans[0].Increments
+	for _, ts := range cc.Timespans[1:] {
+		rcd.Increments = append(rcd.Increments, ts.Increments...)
+	}
+	if _, err = rcd.RefundIncrements(); err != nil {
+		t.Fatal(err)
+	}
+	if acc, err = dm.GetAccount(acc.ID); err != nil {
+		t.Fatal(err)
+	}
+	if acc.Caps[0].Consumed > 1 || // the connect fee is not refunded
+		acc.Caps[1].Consumed != 0 {
+		t.Errorf("received: %s", utils.ToJSON(acc.Caps))
+	}
+}
+
+func TestAccountCapsRefundOnlyRefunded(t *testing.T) {
+	acc := &Account{
+		ID: "cgrates.org:capsrefund",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: {&Balance{Uuid: "moneyb", Value: 10}}},
+		Caps: AccountCaps{
+			{ID: "SPEND", Type: utils.MONETARY, Period: utils.MetaDaily, Limit: 50, Consumed: 10,
+				PeriodStart: time.Date(2015, 07, 24, 0, 0, 0, 0, time.UTC)},
+			{ID: "VOICE", Type: utils.MetaUnits, Period: utils.MetaDaily, Limit: float64(time.Hour),
+				Consumed: float64(time.Minute), PeriodStart: time.Date(2015, 07, 24, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	if err := dm.SetAccount(acc); err != nil {
+		t.Fatal(err)
+	}
+	defer dm.RemoveAccount(acc.ID)
+	cd := &CallDescriptor{
+		TimeStart: time.Date(2015, 07, 24, 13, 37, 0, 0, time.UTC),
+		Category:  "call",
+		Tenant:    "cgrates.org",
+		Subject:   "capsrefund",
+		Account:   "capsrefund",
+		ToR:       utils.VOICE,
+		Increments: Increments{
+			{Duration: 10 * time.Second, Cost: 1, BalanceInfo: &DebitInfo{
+				Monetary: &MonetaryInfo{UUID: "moneyb"}, AccountID: acc.ID}},
+			// the account is missing so nothing is refunded
+			{Duration: 20 * time.Second, Cost: 2, BalanceInfo: &DebitInfo{
+				Monetary: &MonetaryInfo{UUID: "moneyc"}, AccountID: "cgrates.org:capsmissing"}},
+			// the balance is missing so the refund stops here
+			{Duration: 30 * time.Second, Cost: 3, BalanceInfo: &DebitInfo{
+				Monetary: &MonetaryInfo{UUID: "moneyd"}, AccountID: acc.ID}},
+			{Duration: 40 * time.Second, Cost: 4, BalanceInfo: &DebitInfo{
+				Monetary: &MonetaryInfo{UUID: "moneyb"}, AccountID: acc.ID}},
+		},
+	}
+	if _, err := cd.RefundIncrements(); err != nil {
+		t.Fatal(err)
+	}
+	var err error
+	if acc, err = dm.GetAccount(acc.ID); err != nil {
+		t.Fatal(err)
+	}
+	if acc.BalanceMap[utils.MONETARY][0].Value != 11 {
+		t.Errorf("received: %s", utils.ToJSON(acc.BalanceMap))
+	}
+	if acc.Caps[0].Consumed != 9 ||
+		acc.Caps[1].Consumed != float64(50*time.Second) {
+		t.Errorf("received: %s", utils.ToJSON(acc.Caps))
+	}
+}
@@ -309,6 +309,33 @@ func (alS *AttributeService) processEvent(args *AttrArgsProcessEvent) (
 				return nil, err
 			}
 			substitute = strconv.Itoa(int(t.Unix()))
+		case utils.MetaExpr:
+			expr := attribute.expr
+			if expr == nil { // profile not compiled
+				if expr, err = utils.NewExpression(attribute.Value.GetRule()); err != nil {
+					return nil, err
+				}
+			}
+			var out interface{}
+			if out, err = expr.Evaluate(evNm); err != nil {
+				return nil, err
+			}
+			substitute = utils.IfaceAsString(out)
+		case utils.MetaMNP:
+			var number string
+			if number, err = attribute.Value.ParseDataProvider(evNm); err != nil {
+				return nil, err
+			}
+			substitute = number // not ported numbers are kept as they are
+			var pn *PortedNumber
+			if pn, err = alS.dm.LookupPortedNumber(number); err != nil {
+				if err != utils.ErrNotFound {
+					return nil, err
+				}
+				err = nil
+			} else {
+				substitute = pn.LRN
+			}
 		default: // backwards compatible in case that Type is empty
 			substitute, err = attribute.Value.ParseDataProvider(evNm)
 		}
@@ -0,0 +1,337 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AuditChange holds the JSON encoded values of a changed field, empty if the field is missing
+type AuditChange struct {
+	Before string
+	After  string
+}
+
+// AuditRecord is the trace of an administrative API call
+type AuditRecord struct {
+	ID       string
+	Tenant   string
+	ItemType string // eg: *attribute_profiles, *accounts
+	ItemID   string
+	Method   string
+	Caller   string
+	Changes  map[string]*AuditChange // changed fields of the item, indexed by field name
+	Error    string
+	Time     time.Time
+}
+
+// AsCGREvent converts the record into an event which can be exported by EEs
+func (ar *AuditRecord) AsCGREvent() *utils.CGREventWithOpts {
+	return &utils.CGREventWithOpts{
+		CGREvent: &utils.CGREvent{
+			Tenant: ar.Tenant,
+			ID:     ar.ID,
+			Time:   utils.TimePointer(ar.Time),
+			Event: map[string]interface{}{
+				utils.ItemType: ar.ItemType,
+				utils.ItemID:   ar.ItemID,
+				utils.Method:   ar.Method,
+				utils.Caller:   ar.Caller,
+				utils.Changes:  utils.ToJSON(ar.Changes),
+				utils.Error:    ar.Error,
+			},
+		},
+	}
+}
+
+// auditItem describes how to retrieve an item changed by the API methods
+type auditItem struct {
+	itemType string
+	idField  string // field of the API arguments holding the ID of the item
+	getItem  func(dm *DataManager, tnt, id string) (interface{}, error)
+}
+
+// auditItems are the items with before/after changes, indexed by their name in the API methods
+var auditItems = map[string]*auditItem{
+	"AttributeProfile": {utils.CacheAttributeProfiles, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetAttributeProfile(tnt, id, false, false, utils.NonTransactional)
+		}},
+	"ChargerProfile": {utils.CacheChargerProfiles, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetChargerProfile(tnt, id, false, false, utils.NonTransactional)
+		}},
+	"Filter": {utils.CacheFilters, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetFilter(tnt, id, false, false, utils.NonTransactional)
+		}},
+	"ResourceProfile": {utils.CacheResourceProfiles, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetResourceProfile(tnt, id, false, false, utils.NonTransactional)
+		}},
+	"StatQueueProfile": {utils.CacheStatQueueProfiles, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetStatQueueProfile(tnt, id, false, false, utils.NonTransactional)
+		}},
+	"ThresholdProfile": {utils.CacheThresholdProfiles, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetThresholdProfile(tnt, id, false, false, utils.NonTransactional)
+		}},
+	"RouteProfile": {utils.CacheRouteProfiles, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetRouteProfile(tnt, id, false, false, utils.NonTransactional)
+		}},
+	"DispatcherProfile": {utils.CacheDispatcherProfiles, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetDispatcherProfile(tnt, id, false, false, utils.NonTransactional)
+		}},
+	"DispatcherHost": {utils.CacheDispatcherHosts, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetDispatcherHost(tnt, id, false, false, utils.NonTransactional)
+		}},
+	"RateProfile": {utils.CacheRateProfiles, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetRateProfile(tnt, id, false, false, utils.NonTransactional)
+		}},
+	"TaxProfile": {utils.CacheTaxProfiles, utils.ID,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetTaxProfile(tnt, id, false, false, utils.NonTransactional)
+		}},
+	utils.Account: {utils.CacheAccounts, utils.Account,
+		func(dm *DataManager, tnt, id string) (interface{}, error) {
+			return dm.GetAccount(utils.ConcatenatedKey(tnt, id))
+		}},
+}
+
+// auditMethodItem returns the item changed by the API method, nil if unknown
+func auditMethodItem(serviceMethod string) (itmName string, itm *auditItem) {
+	itmName = serviceMethod[strings.Index(serviceMethod, utils.NestingSep)+1:]
+	for _, prfx := range []string{"Set", "Remove", "Add", "Debit"} {
+		if strings.HasPrefix(itmName, prfx) {
+			itmName = itmName[len(prfx):]
+			break
+		}
+	}
+	if strings.HasPrefix(itmName, utils.Account) || // account action triggers
+		strings.HasPrefix(itmName, "Balance") {
+		itmName = utils.Account
+	}
+	return itmName, auditItems[itmName]
+}
+
+// auditChanges returns the fields which differ between the two versions of an item
+func auditChanges(before, after interface{}) (changes map[string]*AuditChange, err error) {
+	bFlds, err := auditFields(before)
+	if err != nil {
+		return
+	}
+	aFlds, err := auditFields(after)
+	if err != nil {
+		return
+	}
+	changes = make(map[string]*AuditChange)
+	for fld, bVal := range bFlds {
+		if aVal, has := aFlds[fld]; !has || !bytes.Equal(bVal, aVal) {
+			changes[fld] = &AuditChange{Before: string(bVal), After: string(aVal)}
+		}
+	}
+	for fld, aVal := range aFlds {
+		if _, has := bFlds[fld]; !has {
+			changes[fld] = &AuditChange{After: string(aVal)}
+		}
+	}
+	return
+}
+
+// auditFields returns the JSON encoded fields of an item
+func auditFields(itm interface{}) (flds map[string]json.RawMessage, err error) {
+	if itm == nil {
+		return
+	}
+	var b []byte
+	if b, err = json.Marshal(itm); err != nil {
+		return
+	}
+	err = json.Unmarshal(b, &flds)
+	return
+}
+
+// NewAuditS returns a new AuditS
+func NewAuditS(cgrcfg *config.CGRConfig, storDBChan chan StorDB, dm *DataManager,
+	connMgr *ConnManager) *AuditS {
+	storDB := <-storDBChan
+	return &AuditS{
+		cgrcfg:     cgrcfg,
+		storDB:     storDB,
+		storDBChan: storDBChan,
+		dm:         dm,
+		connMgr:    connMgr,
+	}
+}
+
+// AuditS records the administrative changes done over the API
+type AuditS struct {
+	sync.RWMutex
+	cgrcfg     *config.CGRConfig
+	storDB     AuditStorage
+	storDBChan chan StorDB
+	dm         *DataManager
+	connMgr    *ConnManager
+}
+
+// ListenAndServe listens for storDB reload
+func (aS *AuditS) ListenAndServe(stopChan chan struct{}) (err error) {
+	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.AuditS))
+	for {
+		select {
+		case <-stopChan:
+			return
+		case storDB, ok := <-aS.storDBChan:
+			if !ok { // the chanel was closed by the shutdown of stordbService
+				return
+			}
+			aS.Lock()
+			aS.storDB = storDB
+			aS.Unlock()
+		}
+	}
+}
+
+// Shutdown is called to shutdown the service
+func (aS *AuditS) Shutdown() {
+	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown initialized", utils.AuditS))
+	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown complete", utils.AuditS))
+}
+
+// audited checks if the method is configured to be audited
+func (aS *AuditS) audited(serviceMethod string) bool {
+	for _, pattern := range aS.cgrcfg.AuditSCfg().Methods {
+		if matched, err := path.Match(pattern, serviceMethod); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// AuditRPC implements utils.RPCAuditor, recording the changes of the item after the API call
+func (aS *AuditS) AuditRPC(caller *utils.RPCCaller, serviceMethod string, args interface{}) func(error) {
+	if !aS.audited(serviceMethod) {
+		return nil
+	}
+	rec := &AuditRecord{
+		ID:     utils.GenUUID(),
+		Method: serviceMethod,
+		Caller: caller.Identity(),
+		Time:   time.Now(),
+	}
+	var has bool
+	if rec.Tenant, has = utils.RPCArgsTenant(args); !has || rec.Tenant == utils.EmptyString {
+		rec.Tenant = aS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	var itm *auditItem
+	rec.ItemType, itm = auditMethodItem(serviceMethod)
+	var before interface{}
+	if itm != nil {
+		rec.ItemType = itm.itemType
+		rec.ItemID, _ = utils.RPCArgsString(args, itm.idField)
+		before = aS.getAuditItem(itm, rec.Tenant, rec.ItemID)
+	}
+	return func(err error) {
+		var after interface{}
+		if err != nil {
+			rec.Error = err.Error()
+			after = before
+		} else if itm != nil {
+			after = aS.getAuditItem(itm, rec.Tenant, rec.ItemID)
+		}
+		var errChng error
+		if rec.Changes, errChng = auditChanges(before, after); errChng != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed computing the changes of %s, error: %s",
+				utils.AuditS, utils.ToJSON(rec), errChng.Error()))
+		}
+		if err := aS.processAuditRecord(rec); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed processing audit record %s, error: %s",
+				utils.AuditS, utils.ToJSON(rec), err.Error()))
+		}
+	}
+}
+
+// getAuditItem returns the current version of the item, nil if missing
+func (aS *AuditS) getAuditItem(itm *auditItem, tnt, id string) interface{} {
+	if id == utils.EmptyString {
+		return nil
+	}
+	item, err := itm.getItem(aS.dm, tnt, id)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed retrieving %s with ID: %s, error: %s",
+				utils.AuditS, itm.itemType, utils.ConcatenatedKey(tnt, id), err.Error()))
+		}
+		return nil
+	}
+	return item
+}
+
+// processAuditRecord stores the record and exports it over EEs
+func (aS *AuditS) processAuditRecord(rec *AuditRecord) (err error) {
+	aS.RLock()
+	err = aS.storDB.SetAuditRecord(rec)
+	aS.RUnlock()
+	if err != nil {
+		return
+	}
+	if len(aS.cgrcfg.AuditSCfg().EEsConns) == 0 {
+		return
+	}
+	var reply map[string]map[string]interface{}
+	if err = aS.connMgr.Call(aS.cgrcfg.AuditSCfg().EEsConns, nil,
+		utils.EventExporterSv1ProcessEvent,
+		rec.AsCGREvent(), &reply); err != nil &&
+		err.Error() == utils.ErrNotFound.Error() {
+		err = nil // NotFound is not considered error
+	}
+	return
+}
+
+// V1GetAuditRecords queries the audit records of a tenant
+func (aS *AuditS) V1GetAuditRecords(args *utils.AuditRecordsFilter, reply *[]*AuditRecord) (err error) {
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = aS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	aS.RLock()
+	recs, err := aS.storDB.GetAuditRecords(args)
+	aS.RUnlock()
+	if err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	*reply = recs
+	return
+}
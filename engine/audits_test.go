@@ -0,0 +1,131 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAuditMethodItem(t *testing.T) {
+	for mthd, expItm := range map[string]string{
+		utils.APIerSv1SetAttributeProfile:      "AttributeProfile",
+		utils.APIerSv1RemoveAttributeProfile:   "AttributeProfile",
+		utils.APIerSv1AddBalance:               utils.Account,
+		utils.APIerSv1DebitBalance:             utils.Account,
+		"APIerSv1.SetBalance":                  utils.Account,
+		"APIerSv1.RemoveAccountActionTriggers": utils.Account,
+	} {
+		if itmName, itm := auditMethodItem(mthd); itmName != expItm {
+			t.Errorf("Expected %q for %s, received %q", expItm, mthd, itmName)
+		} else if itm == nil {
+			t.Errorf("Expected item for %s", mthd)
+		}
+	}
+	if itmName, itm := auditMethodItem("APIerSv1.SetTPRate"); itm != nil {
+		t.Errorf("Unexpected item %q", itmName)
+	} else if itmName != "TPRate" {
+		t.Errorf("Expected %q, received %q", "TPRate", itmName)
+	}
+}
+
+func TestAuditChanges(t *testing.T) {
+	before := &AttributeProfile{Tenant: "cgrates.org", ID: "ATTR1", Weight: 10}
+	after := &AttributeProfile{Tenant: "cgrates.org", ID: "ATTR1", Weight: 20,
+		Contexts: []string{utils.META_ANY}}
+	exp := map[string]*AuditChange{
+		"Weight":   {Before: "10", After: "20"},
+		"Contexts": {Before: "null", After: `["*any"]`},
+	}
+	if rcv, err := auditChanges(before, after); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv, err := auditChanges(nil, before); err != nil {
+		t.Error(err)
+	} else if rcv["ID"] == nil || rcv["ID"].After != `"ATTR1"` || rcv["ID"].Before != utils.EmptyString {
+		t.Errorf("Unexpected changes: %s", utils.ToJSON(rcv))
+	}
+	if rcv, err := auditChanges(before, before); err != nil {
+		t.Error(err)
+	} else if len(rcv) != 0 {
+		t.Errorf("Unexpected changes: %s", utils.ToJSON(rcv))
+	}
+}
+
+func TestAuditSAuditRPC(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.AuditSCfg().Enabled = true
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	storDBChan := make(chan StorDB, 1)
+	storDBChan <- NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	aS := NewAuditS(cfg, storDBChan, dm, nil)
+
+	if done := aS.AuditRPC(nil, utils.APIerSv1GetAttributeProfile, &utils.TenantID{ID: "ATTR1"}); done != nil {
+		t.Error("Expected the method to not be audited")
+	}
+	caller := &utils.RPCCaller{User: "admin"}
+	attr := &AttributeProfile{Tenant: "cgrates.org", ID: "ATTR_AUDIT", Weight: 10}
+	done := aS.AuditRPC(caller, utils.APIerSv1SetAttributeProfile,
+		&AttributeProfileWithArgDispatcher{AttributeProfile: attr})
+	if done == nil {
+		t.Fatal("Expected the method to be audited")
+	}
+	if err := dm.SetAttributeProfile(attr, false); err != nil {
+		t.Fatal(err)
+	}
+	done(nil)
+	done = aS.AuditRPC(caller, utils.APIerSv1RemoveAttributeProfile,
+		&utils.TenantIDWithCache{Tenant: "cgrates.org", ID: "ATTR_AUDIT"})
+	done(errors.New("SERVER_ERROR"))
+
+	var recs []*AuditRecord
+	if err := aS.V1GetAuditRecords(&utils.AuditRecordsFilter{
+		ItemIDs: []string{"ATTR_AUDIT"}}, &recs); err != nil {
+		t.Fatal(err)
+	} else if len(recs) != 2 {
+		t.Fatalf("Expected 2 records, received %s", utils.ToJSON(recs))
+	}
+	if recs[0].Tenant != "cgrates.org" ||
+		recs[0].ItemType != utils.CacheAttributeProfiles ||
+		recs[0].Method != utils.APIerSv1SetAttributeProfile ||
+		recs[0].Caller != "*user:admin" ||
+		recs[0].Changes["Weight"] == nil || recs[0].Changes["Weight"].After != "10" ||
+		recs[0].Error != utils.EmptyString {
+		t.Errorf("Unexpected record: %s", utils.ToJSON(recs[0]))
+	}
+	if recs[1].Error != "SERVER_ERROR" || len(recs[1].Changes) != 0 {
+		t.Errorf("Unexpected record: %s", utils.ToJSON(recs[1]))
+	}
+	if err := aS.V1GetAuditRecords(&utils.AuditRecordsFilter{
+		Methods: []string{utils.APIerSv1AddBalance}}, &recs); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err := aS.V1GetAuditRecords(&utils.AuditRecordsFilter{
+		Paginator: utils.Paginator{Limit: utils.IntPointer(1), Offset: utils.IntPointer(1)}}, &recs); err != nil {
+		t.Error(err)
+	} else if len(recs) != 1 || recs[0].Method != utils.APIerSv1RemoveAttributeProfile {
+		t.Errorf("Unexpected records: %s", utils.ToJSON(recs))
+	}
+}
@@ -42,6 +42,7 @@ type BalanceFilter struct {
 	Disabled       *bool
 	Factor         *ValueFactor
 	Blocker        *bool
+	Currency       *string
 }
 
 // NewBalanceFilter creates a new BalanceFilter based on given filter
@@ -106,6 +107,9 @@ func NewBalanceFilter(filter map[string]interface{}, defaultTimezone string) (bf
 		}
 		bf.Blocker = utils.BoolPointer(value)
 	}
+	if cur, has := filter[utils.Currency]; has {
+		bf.Currency = utils.StringPointer(utils.IfaceAsString(cur))
+	}
 	return
 }
 
@@ -125,6 +129,7 @@ func (bp *BalanceFilter) CreateBalance() *Balance {
 		Disabled:       bp.GetDisabled(),
 		Factor:         bp.GetFactor(),
 		Blocker:        bp.GetBlocker(),
+		Currency:       bp.GetCurrency(),
 	}
 	return b.Clone()
 }
@@ -192,6 +197,10 @@ func (bf *BalanceFilter) Clone() *BalanceFilter {
 		result.Blocker = new(bool)
 		*result.Blocker = *bf.Blocker
 	}
+	if bf.Currency != nil {
+		result.Currency = new(string)
+		*result.Currency = *bf.Currency
+	}
 	return result
 }
 
@@ -241,6 +250,9 @@ func (bf *BalanceFilter) LoadFromBalance(b *Balance) *BalanceFilter {
 	if b.Blocker {
 		bf.Blocker = &b.Blocker
 	}
+	if b.Currency != "" {
+		bf.Currency = &b.Currency
+	}
 	bf.Timings = b.Timings
 	return bf
 }
@@ -351,6 +363,13 @@ func (bp *BalanceFilter) GetBlocker() bool {
 	return *bp.Blocker
 }
 
+func (bp *BalanceFilter) GetCurrency() string {
+	if bp == nil || bp.Currency == nil {
+		return ""
+	}
+	return *bp.Currency
+}
+
 func (bp *BalanceFilter) GetExpirationDate() time.Time {
 	if bp == nil || bp.ExpirationDate == nil {
 		return time.Time{}
@@ -415,5 +434,8 @@ func (bf *BalanceFilter) ModifyBalance(b *Balance) {
 	if bf.Disabled != nil {
 		b.Disabled = *bf.Disabled
 	}
+	if bf.Currency != nil {
+		b.Currency = *bf.Currency
+	}
 	b.SetDirty() // Mark the balance as dirty since we have modified and it should be checked by action triggers
 }
@@ -45,6 +45,7 @@ type Balance struct {
 	Disabled       bool
 	Factor         ValueFactor
 	Blocker        bool
+	Currency       string // optional, the monetary balances are debited in this currency
 	precision      int
 	account        *Account // used to store ub reference for shared balances
 	dirty          bool
@@ -66,7 +67,8 @@ func (b *Balance) Equal(o *Balance) bool {
 		b.Categories.Equal(o.Categories) &&
 		b.SharedGroups.Equal(o.SharedGroups) &&
 		b.Disabled == o.Disabled &&
-		b.Blocker == o.Blocker
+		b.Blocker == o.Blocker &&
+		b.Currency == o.Currency
 }
 
 func (b *Balance) MatchFilter(o *BalanceFilter, skipIds, skipExpiry bool) bool {
@@ -91,7 +93,8 @@ func (b *Balance) MatchFilter(o *BalanceFilter, skipIds, skipExpiry bool) bool {
 		(o.Categories == nil || b.Categories.Includes(*o.Categories)) &&
 		(o.TimingIDs == nil || b.TimingIDs.Includes(*o.TimingIDs)) &&
 		(o.SharedGroups == nil || b.SharedGroups.Includes(*o.SharedGroups)) &&
-		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject)
+		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject) &&
+		(o.Currency == nil || b.Currency == *o.Currency)
 }
 
 func (b *Balance) HardMatchFilter(o *BalanceFilter, skipIds bool) bool {
@@ -112,7 +115,8 @@ func (b *Balance) HardMatchFilter(o *BalanceFilter, skipIds bool) bool {
 		(o.Categories == nil || b.Categories.Equal(*o.Categories)) &&
 		(o.TimingIDs == nil || b.TimingIDs.Equal(*o.TimingIDs)) &&
 		(o.SharedGroups == nil || b.SharedGroups.Equal(*o.SharedGroups)) &&
-		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject)
+		(o.RatingSubject == nil || b.RatingSubject == *o.RatingSubject) &&
+		(o.Currency == nil || b.Currency == *o.Currency)
 }
 
 // the default balance has standard Id
@@ -177,6 +181,7 @@ func (b *Balance) Clone() *Balance {
 		Timings:        b.Timings, // should not be a problem with aliasing
 		Blocker:        b.Blocker,
 		Disabled:       b.Disabled,
+		Currency:       b.Currency,
 		dirty:          b.dirty,
 	}
 	if b.DestinationIDs != nil {
@@ -389,7 +394,9 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 		}
 		if debitConnectFee {
 			// this is the first add, debit the connect fee
-			if ok, debitedConnectFeeBalance = ub.DebitConnectionFee(cc, moneyBalances, count, true); !ok {
+			if ok, debitedConnectFeeBalance, err = ub.DebitConnectionFee(cc, moneyBalances, count, true); err != nil {
+				return nil, err
+			} else if !ok {
 				// found blocker balance
 				return nil, nil
 			}
@@ -413,9 +420,10 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 					Cost:     ts.RateInterval.Rating.ConnectFee,
 					BalanceInfo: &DebitInfo{
 						Monetary: &MonetaryInfo{
-							UUID:  debitedConnectFeeBalance.Uuid,
-							ID:    debitedConnectFeeBalance.ID,
-							Value: debitedConnectFeeBalance.Value,
+							UUID:   debitedConnectFeeBalance.Uuid,
+							ID:     debitedConnectFeeBalance.ID,
+							Value:  debitedConnectFeeBalance.Value,
+							FxRate: connectFeeFxRate(cc, &debitedConnectFeeBalance),
 						},
 						AccountID: ub.ID,
 					},
@@ -470,8 +478,12 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 					continue
 				}
 				var moneyBal *Balance
+				var fxRate float64
 				for _, mb := range moneyBalances {
-					if mb.GetValue() >= cost {
+					if fxRate, err = fxRateForBalance(ts.RateInterval.Rating.Currency, mb); err != nil {
+						return nil, err
+					}
+					if mb.GetValue() >= convertFx(cost, fxRate) {
 						moneyBal = mb
 						break
 					}
@@ -479,6 +491,9 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 				if cost != 0 && moneyBal == nil && (!dryRun || ub.AllowNegative) { // Fix for issue #685
 					utils.Logger.Warning(fmt.Sprintf("<RALs> Going negative on account %s with AllowNegative: false", cd.GetAccountKey()))
 					moneyBal = ub.GetDefaultMoneyBalance()
+					if fxRate, err = fxRateForBalance(ts.RateInterval.Rating.Currency, moneyBal); err != nil {
+						return nil, err
+					}
 				}
 				if b.GetValue() >= amount && (moneyBal != nil || cost == 0) {
 					b.SubstractValue(amount)
@@ -493,11 +508,12 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 					}
 					inc.BalanceInfo.AccountID = ub.ID
 					if cost != 0 {
-						moneyBal.SubstractValue(cost)
+						moneyBal.SubstractValue(convertFx(cost, fxRate))
 						inc.BalanceInfo.Monetary = &MonetaryInfo{
-							UUID:  moneyBal.Uuid,
-							ID:    moneyBal.ID,
-							Value: moneyBal.Value,
+							UUID:   moneyBal.Uuid,
+							ID:     moneyBal.ID,
+							Value:  moneyBal.Value,
+							FxRate: fxRate,
 						}
 						cd.MaxCostSoFar += cost
 					}
@@ -505,7 +521,7 @@ func (b *Balance) debitUnits(cd *CallDescriptor, ub *Account, moneyBalances Bala
 					if count {
 						ub.countUnits(amount, cc.ToR, cc, b)
 						if cost != 0 {
-							ub.countUnits(cost, utils.MONETARY, cc, moneyBal)
+							ub.countUnits(convertFx(cost, fxRate), utils.MONETARY, cc, moneyBal)
 						}
 					}
 				} else {
@@ -546,7 +562,9 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 	if debitConnectFee {
 
 		// this is the first add, debit the connect fee
-		if ok, debitedConnectFeeBalance = ub.DebitConnectionFee(cc, moneyBalances, count, true); !ok {
+		if ok, debitedConnectFeeBalance, err = ub.DebitConnectionFee(cc, moneyBalances, count, true); err != nil {
+			return nil, err
+		} else if !ok {
 			// balance is blocker
 			return nil, nil
 		}
@@ -574,9 +592,10 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 				Cost:     ts.RateInterval.Rating.ConnectFee,
 				BalanceInfo: &DebitInfo{
 					Monetary: &MonetaryInfo{
-						UUID:  debitedConnectFeeBalance.Uuid,
-						ID:    debitedConnectFeeBalance.ID,
-						Value: debitedConnectFeeBalance.Value,
+						UUID:   debitedConnectFeeBalance.Uuid,
+						ID:     debitedConnectFeeBalance.ID,
+						Value:  debitedConnectFeeBalance.Value,
+						FxRate: connectFeeFxRate(cc, &debitedConnectFeeBalance),
 					},
 					AccountID: ub.ID,
 				},
@@ -587,6 +606,10 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 		}
 
 		maxCost, strategy := ts.RateInterval.GetMaxCost()
+		var fxRate float64
+		if fxRate, err = fxRateForBalance(ts.RateInterval.Rating.Currency, b); err != nil {
+			return nil, err
+		}
 		//log.Printf("Timing: %+v", ts.RateInterval.Timing)
 		//log.Printf("RGRate: %+v", ts.RateInterval.Rating)
 		for incIndex, inc := range ts.Increments {
@@ -599,6 +622,7 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 			}
 
 			amount := inc.Cost
+			debit := convertFx(amount, fxRate) // amount in the currency of the balance
 			inc.paid = false
 			if strategy == utils.MAX_COST_DISCONNECT && cd.MaxCostSoFar >= maxCost {
 				// cut the entire current timespan
@@ -635,13 +659,14 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 				continue
 			}
 
-			if b.GetValue() >= amount {
-				b.SubstractValue(amount)
+			if b.GetValue() >= debit {
+				b.SubstractValue(debit)
 				cd.MaxCostSoFar += amount
 				inc.BalanceInfo.Monetary = &MonetaryInfo{
-					UUID:  b.Uuid,
-					ID:    b.ID,
-					Value: b.Value,
+					UUID:   b.Uuid,
+					ID:     b.ID,
+					Value:  b.Value,
+					FxRate: fxRate,
 				}
 				inc.BalanceInfo.AccountID = ub.ID
 				if b.RatingSubject != "" {
@@ -649,7 +674,7 @@ func (b *Balance) debitMoney(cd *CallDescriptor, ub *Account, moneyBalances Bala
 				}
 				inc.paid = true
 				if count {
-					ub.countUnits(amount, utils.MONETARY, cc, b)
+					ub.countUnits(debit, utils.MONETARY, cc, b)
 				}
 			} else {
 				inc.paid = false
@@ -726,6 +751,20 @@ func (b *Balance) Publish() {
 			}
 		}()
 	}
+	if len(config.CgrConfig().RalsCfg().PubSubSConns) != 0 {
+		go publishBalanceEvent(cgrEv)
+	}
+}
+
+// publishBalanceEvent sends the balance event to PubSubS
+func publishBalanceEvent(cgrEv *utils.CGREvent) {
+	var reply string
+	if err := connMgr.Call(config.CgrConfig().RalsCfg().PubSubSConns, nil,
+		utils.PubSubSv1Publish, &utils.CGREventWithArgDispatcher{CGREvent: cgrEv}, &reply); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<AccountS> error: %s publishing balance event %+v with PubSubS.",
+				err.Error(), cgrEv))
+	}
 }
 
 /*
@@ -833,6 +872,9 @@ func (bc Balances) SaveDirtyBalances(acc *Account) {
 							err.Error(), thEv))
 				}
 			}
+			if len(config.CgrConfig().RalsCfg().PubSubSConns) != 0 {
+				go publishBalanceEvent(thEv.CGREvent)
+			}
 		}
 		if b.account != nil && b.account != acc && b.dirty && savedAccounts[b.account.ID] == nil {
 			dm.SetAccount(b.account)
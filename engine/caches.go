@@ -267,6 +267,11 @@ func (chS *CacheS) V1GetItemExpiryTime(args *utils.ArgsGetCacheItemWithArgDispat
 func (chS *CacheS) V1RemoveItem(args *utils.ArgsGetCacheItemWithArgDispatcher,
 	reply *string) (err error) {
 	chS.tCache.Remove(args.CacheID, args.ItemID, true, utils.NonTransactional)
+	if args.CacheID == utils.CachePortedNumbers { // keep the lookup index in sync with dataDB
+		if err = chS.dm.reloadPortedNumber(args.ItemID); err != nil {
+			return
+		}
+	}
 	*reply = utils.OK
 	return
 }
@@ -274,6 +279,10 @@ func (chS *CacheS) V1RemoveItem(args *utils.ArgsGetCacheItemWithArgDispatcher,
 func (chS *CacheS) V1Clear(args *utils.AttrCacheIDsWithArgDispatcher,
 	reply *string) (err error) {
 	chS.tCache.Clear(args.CacheIDs)
+	if len(args.CacheIDs) == 0 ||
+		utils.IsSliceMember(args.CacheIDs, utils.CachePortedNumbers) { // rebuilt on next lookup
+		chS.dm.clearPortabilityTrie()
+	}
 	*reply = utils.OK
 	return
 }
@@ -82,6 +82,16 @@ func (cc *CallCost) GetConnectFee() float64 {
 	return cc.Timespans[0].RateInterval.Rating.ConnectFee
 }
 
+// GetCurrency returns the currency of the first rate, used for the connect fee
+func (cc *CallCost) GetCurrency() string {
+	if len(cc.Timespans) == 0 ||
+		cc.Timespans[0].RateInterval == nil ||
+		cc.Timespans[0].RateInterval.Rating == nil {
+		return ""
+	}
+	return cc.Timespans[0].RateInterval.Rating.Currency
+}
+
 // Creates a CallDescriptor structure copying related data from CallCost
 func (cc *CallCost) CreateCallDescriptor() *CallDescriptor {
 	return &CallDescriptor{
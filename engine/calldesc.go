@@ -651,7 +651,12 @@ If the user has postpayed plan it returns -1.
 func (origCD *CallDescriptor) getMaxSessionDuration(origAcc *Account) (time.Duration, error) {
 	// clone the account for discarding chenges on debit dry run
 	account := origAcc.Clone()
-	if account.AllowNegative {
+	tor := origCD.ToR
+	if tor == "" {
+		tor = utils.VOICE
+	}
+	unitsLeft, amountLeft := account.Caps.left(tor, origCD.Category, origCD.TimeStart)
+	if account.AllowNegative && unitsLeft == -1 && amountLeft == -1 {
 		return -1, nil
 	}
 	// for zero duration index
@@ -663,12 +668,18 @@ func (origCD *CallDescriptor) getMaxSessionDuration(origAcc *Account) (time.Dura
 	}
 	cd := origCD.Clone()
 	initialDuration := cd.TimeEnd.Sub(cd.TimeStart)
+	if unitsLeft != -1 && time.Duration(unitsLeft) < initialDuration {
+		initialDuration = time.Duration(unitsLeft)
+		if initialDuration == 0 {
+			return 0, nil
+		}
+	}
 	defaultBalance := account.GetDefaultMoneyBalance()
 
 	//use this to check what increment was payed with debt
 	initialDefaultBalanceValue := defaultBalance.GetValue()
 
-	cc, err := cd.debit(account, true, false)
+	cc, err := cd.debit(account, true, account.AllowNegative)
 	if err != nil {
 		return 0, err
 	}
@@ -693,8 +704,13 @@ func (origCD *CallDescriptor) getMaxSessionDuration(origAcc *Account) (time.Dura
 		}
 		for _, incr := range ts.Increments {
 			totalCost += incr.Cost
-			if incr.BalanceInfo.Monetary != nil && incr.BalanceInfo.Monetary.UUID == defaultBalance.Uuid {
-				initialDefaultBalanceValue -= incr.Cost
+			if amountLeft != -1 && totalCost > amountLeft {
+				// this increment would cross the spending cap
+				return utils.MinDuration(initialDuration, totalDuration), nil
+			}
+			if !account.AllowNegative &&
+				incr.BalanceInfo.Monetary != nil && incr.BalanceInfo.Monetary.UUID == defaultBalance.Uuid {
+				initialDefaultBalanceValue -= convertFx(incr.Cost, incr.BalanceInfo.Monetary.FxRate) // in the currency of the balance
 				if initialDefaultBalanceValue < 0 {
 					// this increment was payed with debt
 					// TODO: improve this check
@@ -758,6 +774,7 @@ func (cd *CallDescriptor) debit(account *Account, dryRun bool, goNegative bool)
 	if cd.ToR == "" {
 		cd.ToR = utils.VOICE
 	}
+	tStart := cd.TimeStart // debitCreditBalance moves it
 	//log.Printf("Debit CD: %+v", cd)
 	cc, err = account.debitCreditBalance(cd, !dryRun, dryRun, goNegative)
 	//log.Printf("HERE: %+v %v", cc, err)
@@ -769,6 +786,7 @@ func (cd *CallDescriptor) debit(account *Account, dryRun bool, goNegative bool)
 	cc.UpdateRatedUsage()
 	cc.Timespans.Compress()
 	if !dryRun {
+		account.Caps.count(cd.ToR, cd.Category, tStart, cc.GetDuration(), cc.Cost)
 		dm.SetAccount(account)
 	}
 	if cd.PerformRounding {
@@ -882,6 +900,9 @@ func (cd *CallDescriptor) MaxDebit() (cc *CallCost, err error) {
 // returns the updated account referenced by the CallDescriptor
 func (cd *CallDescriptor) refundIncrements() (acnt *Account, err error) {
 	accountsCache := make(map[string]*Account)
+	var refundUsage time.Duration
+	var refundCost float64
+REFUND:
 	for _, increment := range cd.Increments {
 		account, found := accountsCache[increment.BalanceInfo.AccountID]
 		if !found {
@@ -902,7 +923,7 @@ func (cd *CallDescriptor) refundIncrements() (acnt *Account, err error) {
 		cc := cd.CreateCallCost()
 		if increment.BalanceInfo.Unit != nil && increment.BalanceInfo.Unit.UUID != "" {
 			if balance = account.BalanceMap[unitType].GetBalance(increment.BalanceInfo.Unit.UUID); balance == nil {
-				return
+				break REFUND
 			}
 			balance.AddValue(float64(increment.Duration.Nanoseconds()))
 			account.countUnits(-float64(increment.Duration.Nanoseconds()), unitType, cc, balance)
@@ -910,13 +931,29 @@ func (cd *CallDescriptor) refundIncrements() (acnt *Account, err error) {
 		// check money too
 		if increment.BalanceInfo.Monetary != nil && increment.BalanceInfo.Monetary.UUID != "" {
 			if balance = account.BalanceMap[utils.MONETARY].GetBalance(increment.BalanceInfo.Monetary.UUID); balance == nil {
-				return
+				break REFUND
 			}
-			balance.AddValue(increment.Cost)
-			account.countUnits(-increment.Cost, utils.MONETARY, cc, balance)
+			refund := convertFx(increment.Cost, increment.BalanceInfo.Monetary.FxRate)
+			balance.AddValue(refund)
+			account.countUnits(-refund, utils.MONETARY, cc, balance)
 		}
+		// only the increments refunded are taken out of the caps
+		refundUsage += increment.Duration
+		refundCost += increment.Cost
 	}
 	acnt = accountsCache[utils.ConcatenatedKey(cd.Tenant, cd.Account)]
+	// the caps are counted on the account of the session
+	tStart := cd.TimeStart
+	if tStart.IsZero() {
+		tStart = time.Now()
+	}
+	if acnt != nil {
+		acnt.Caps.count(cd.ToR, cd.Category, tStart, -refundUsage, -refundCost)
+	} else if acc, errAcc := dm.GetAccount(cd.GetAccountKey()); errAcc == nil && len(acc.Caps) != 0 {
+		// the usage was paid by the other members of the shared group
+		acc.Caps.count(cd.ToR, cd.Category, tStart, -refundUsage, -refundCost)
+		dm.SetAccount(acc)
+	}
 	return
 
 }
@@ -934,6 +971,8 @@ func (cd *CallDescriptor) RefundIncrements() (acnt *Account, err error) {
 			accMap[utils.ACCOUNT_PREFIX+increment.BalanceInfo.AccountID] = true
 		}
 	}
+	// the caps of the session account are refunded even if other accounts paid
+	accMap[utils.ACCOUNT_PREFIX+cd.GetAccountKey()] = true
 	_, err = guardian.Guardian.Guard(func() (iface interface{}, err error) {
 		acnt, err = cd.refundIncrements()
 		return
@@ -965,8 +1004,9 @@ func (cd *CallDescriptor) refundRounding() (err error) {
 			if balance = account.BalanceMap[utils.MONETARY].GetBalance(increment.BalanceInfo.Monetary.UUID); balance == nil {
 				return
 			}
-			balance.AddValue(-increment.Cost)
-			account.countUnits(increment.Cost, utils.MONETARY, cc, balance)
+			rounding := convertFx(increment.Cost, increment.BalanceInfo.Monetary.FxRate)
+			balance.AddValue(-rounding)
+			account.countUnits(rounding, utils.MONETARY, cc, balance)
 		}
 	}
 	return
@@ -399,6 +399,62 @@ func (cdrS *CDRServer) statSProcessEvent(cgrEv *utils.CGREventWithArgDispatcher)
 	return
 }
 
+// pubSubSProcessEvent publishes the processed CDR towards PubSubS
+// the event is sent in background so PubSubS does not delay or fail the processing
+func (cdrS *CDRServer) pubSubSProcessEvent(cgrEv *utils.CGREventWithArgDispatcher) {
+	ev := MapEvent(cgrEv.Event).Clone()
+	ev[utils.EventType] = utils.CDRProcessed
+	pubEv := &utils.CGREventWithArgDispatcher{
+		CGREvent: &utils.CGREvent{
+			Tenant: cgrEv.Tenant,
+			ID:     utils.GenUUID(),
+			Time:   utils.TimePointer(time.Now()),
+			Event:  ev,
+		},
+		ArgDispatcher: cgrEv.ArgDispatcher,
+	}
+	go func() {
+		var reply string
+		if err := cdrS.connMgr.Call(cdrS.cgrCfg.CdrsCfg().PubSubSConns, nil,
+			utils.PubSubSv1Publish, pubEv, &reply); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> publishing event %+v with %s",
+					utils.CDRs, err.Error(), pubEv.CGREvent, utils.PubSubS))
+		}
+	}()
+}
+
+// taxSProcessCDR attaches the taxes computed by TaxS to the cost details of a rated CDR
+func (cdrS *CDRServer) taxSProcessCDR(cdr *CDR, argDisp *utils.ArgDispatcher) (err error) {
+	if cdr.Cost == -1 { // not rated
+		return
+	}
+	var evTx EventTaxes
+	if err = cdrS.connMgr.Call(cdrS.cgrCfg.CdrsCfg().TaxSConns, nil,
+		utils.TaxSv1ComputeTaxes,
+		&utils.CGREventWithArgDispatcher{
+			CGREvent:      cdr.AsCGREvent(),
+			ArgDispatcher: argDisp,
+		}, &evTx); err != nil {
+		if err.Error() == utils.ErrNotFound.Error() { // no taxes for this CDR
+			err = nil
+		}
+		return
+	}
+	if cdr.CostDetails == nil { // pre-rated CDR, keep the taxes within a summary EventCost
+		cdr.CostDetails = &EventCost{
+			CGRID:     cdr.CGRID,
+			RunID:     cdr.RunID,
+			StartTime: cdr.AnswerTime,
+			Usage:     utils.DurationPointer(cdr.Usage),
+			Cost:      utils.Float64Pointer(cdr.Cost),
+		}
+		cdr.CostDetails.initCache()
+	}
+	cdr.CostDetails.Taxes = &evTx
+	return
+}
+
 // exportCDRs will export the CDRs received
 func (cdrS *CDRServer) exportCDRs(cdrs []*CDR) (err error) {
 	for _, exportID := range cdrS.cgrCfg.CdrsCfg().OnlineCDRExports {
@@ -435,7 +491,7 @@ func (cdrS *CDRServer) eeSProcessEvent(cgrEv *utils.CGREventWithOpts) (err error
 // processEvent processes a CGREvent based on arguments
 // in case of partially executed, both error and evs will be returned
 func (cdrS *CDRServer) processEvent(ev *utils.CGREventWithOpts,
-	chrgS, attrS, refund, ralS, store, reRate, export, thdS, stS bool) (evs []*utils.EventWithFlags, err error) {
+	chrgS, attrS, refund, ralS, taxS, store, reRate, export, thdS, stS bool) (evs []*utils.EventWithFlags, err error) {
 	if attrS {
 		if err = cdrS.attrSProcessEvent(ev); err != nil {
 			utils.Logger.Warning(
@@ -488,7 +544,7 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREventWithOpts,
 	}
 	// Populate CDR list out of events
 	cdrs := make([]*CDR, len(cgrEvs))
-	if refund || ralS || store || reRate || export {
+	if refund || ralS || taxS || store || reRate || export {
 		for i, cgrEv := range cgrEvs {
 			if cdrs[i], err = NewMapEvent(cgrEv.Event).AsCDR(cdrS.cgrCfg,
 				cgrEv.Tenant, cdrS.cgrCfg.GeneralCfg().DefaultTimezone); err != nil {
@@ -536,6 +592,22 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREventWithOpts,
 			}
 		}
 	}
+	var taxSFailed bool // the CDRs already charged are still stored and exported, without taxes
+	if taxS {
+		for i, cdr := range cdrs {
+			if errTx := cdrS.taxSProcessCDR(cdr, ev.ArgDispatcher); errTx != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> processing CDR %+v with %s",
+						utils.CDRs, errTx.Error(), cdr, utils.TaxS))
+				taxSFailed = true
+				continue
+			}
+			cgrEvs[i] = &utils.CGREventWithArgDispatcher{
+				CGREvent:      cdr.AsCGREvent(),
+				ArgDispatcher: cgrEvs[i].ArgDispatcher,
+			}
+		}
+	}
 	if store {
 		refundCDRCosts := func() { // will be used to refund all CDRs on errors
 			for _, cdr := range cdrs { // refund what we have charged since duplicates are not allowed
@@ -581,7 +653,7 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREventWithOpts,
 			}
 		}
 	}
-	var partiallyExecuted bool // from here actions are optional and a general error is returned
+	partiallyExecuted := taxSFailed // from here actions are optional and a general error is returned
 	if export {
 		if len(cdrS.cgrCfg.CdrsCfg().OnlineCDRExports) != 0 {
 			if err = cdrS.exportCDRs(cdrs); err != nil {
@@ -625,6 +697,11 @@ func (cdrS *CDRServer) processEvent(ev *utils.CGREventWithOpts,
 			}
 		}
 	}
+	if len(cdrS.cgrCfg.CdrsCfg().PubSubSConns) != 0 {
+		for _, cgrEv := range cgrEvs {
+			cdrS.pubSubSProcessEvent(cgrEv)
+		}
+	}
 	if partiallyExecuted {
 		err = utils.ErrPartiallyExecuted
 	}
@@ -715,6 +792,7 @@ func (cdrS *CDRServer) V1ProcessCDR(cdr *CDRWithOpts, reply *string) (err error)
 		len(cdrS.cgrCfg.CdrsCfg().AttributeSConns) != 0,
 		false,
 		!cdr.PreRated, // rate the CDR if is not PreRated
+		len(cdrS.cgrCfg.CdrsCfg().TaxSConns) != 0,
 		cdrS.cgrCfg.CdrsCfg().StoreCdrs,
 		false, // no rerate
 		(len(cdrS.cgrCfg.CdrsCfg().OnlineCDRExports) != 0 || len(cdrS.cgrCfg.CdrsCfg().EEsConns) != 0),
@@ -803,6 +881,10 @@ func (cdrS *CDRServer) V1ProcessEvent(arg *ArgV1ProcessEvent, reply *string) (er
 	if flgs.HasKey(utils.MetaRefund) {
 		refund = flgs.GetBool(utils.MetaRefund)
 	}
+	taxS := len(cdrS.cgrCfg.CdrsCfg().TaxSConns) != 0
+	if flgs.HasKey(utils.MetaTaxes) {
+		taxS = flgs.GetBool(utils.MetaTaxes)
+	}
 	// end of processing options
 
 	cgrEv := &utils.CGREventWithOpts{
@@ -811,7 +893,7 @@ func (cdrS *CDRServer) V1ProcessEvent(arg *ArgV1ProcessEvent, reply *string) (er
 		Opts:          arg.Opts,
 	}
 	if _, err = cdrS.processEvent(cgrEv, chrgS, attrS, refund,
-		ralS, store, reRate, export, thdS, stS); err != nil {
+		ralS, taxS, store, reRate, export, thdS, stS); err != nil {
 		return
 	}
 	*reply = utils.OK
@@ -887,6 +969,10 @@ func (cdrS *CDRServer) V2ProcessEvent(arg *ArgV1ProcessEvent, evs *[]*utils.Even
 	if flgs.HasKey(utils.MetaRefund) {
 		refund = flgs.GetBool(utils.MetaRefund)
 	}
+	taxS := len(cdrS.cgrCfg.CdrsCfg().TaxSConns) != 0
+	if flgs.HasKey(utils.MetaTaxes) {
+		taxS = flgs.GetBool(utils.MetaTaxes)
+	}
 	// end of processing options
 
 	cgrEv := &utils.CGREventWithOpts{
@@ -896,7 +982,7 @@ func (cdrS *CDRServer) V2ProcessEvent(arg *ArgV1ProcessEvent, evs *[]*utils.Even
 	}
 	var procEvs []*utils.EventWithFlags
 	if procEvs, err = cdrS.processEvent(cgrEv, chrgS, attrS, refund,
-		ralS, store, reRate, export, thdS, stS); err != nil {
+		ralS, taxS, store, reRate, export, thdS, stS); err != nil {
 		return
 	} else {
 		*evs = procEvs
@@ -1047,6 +1133,10 @@ func (cdrS *CDRServer) V1RateCDRs(arg *ArgRateCDRs, reply *string) (err error) {
 	if flgs.HasKey(utils.MetaAttributes) {
 		attrS = flgs.GetBool(utils.MetaAttributes)
 	}
+	taxS := len(cdrS.cgrCfg.CdrsCfg().TaxSConns) != 0
+	if flgs.HasKey(utils.MetaTaxes) {
+		taxS = flgs.GetBool(utils.MetaTaxes)
+	}
 
 	if chrgS && len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) == 0 {
 		return utils.NewErrNotConnected(utils.ChargerS)
@@ -1059,7 +1149,7 @@ func (cdrS *CDRServer) V1RateCDRs(arg *ArgRateCDRs, reply *string) (err error) {
 			Opts:          arg.Opts,
 		}
 		if _, err = cdrS.processEvent(cgrEv, chrgS, attrS, false,
-			true, store, true, export, thdS, statS); err != nil {
+			true, taxS, store, true, export, thdS, statS); err != nil {
 			return utils.NewErrServerError(err)
 		}
 	}
@@ -0,0 +1,262 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ConfigSection is a configuration section shared by the engines over DataDB
+type ConfigSection struct {
+	Section   string
+	Version   int64  // increased on each change of the section
+	Config    string // JSON content of the section
+	UpdatedAt time.Time
+}
+
+// GetConfigSections returns all the configuration sections stored in DataDB
+func (dm *DataManager) GetConfigSections() (css []*ConfigSection, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().GetConfigSectionsDrv()
+}
+
+// GetConfigSection returns the configuration section stored in DataDB
+func (dm *DataManager) GetConfigSection(section string) (cs *ConfigSection, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().GetConfigSectionDrv(section)
+}
+
+// SetConfigSection stores the configuration section, increasing its version
+// If expVersion is not 0 the stored section needs to have the same version
+func (dm *DataManager) SetConfigSection(section, jsnCfg string, expVersion int64) (cs *ConfigSection, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	_, err = guardian.Guardian.Guard(func() (_ interface{}, err error) {
+		var version int64
+		var oldCs *ConfigSection
+		if oldCs, err = dm.DataDB().GetConfigSectionDrv(section); err != nil {
+			if err != utils.ErrNotFound {
+				return
+			}
+			err = nil
+		} else {
+			version = oldCs.Version
+		}
+		if expVersion != 0 && expVersion != version {
+			return nil, fmt.Errorf("%s: stored version %d, expected %d",
+				utils.ErrReqUnsynchronized, version, expVersion)
+		}
+		cs = &ConfigSection{
+			Section:   section,
+			Version:   version + 1,
+			Config:    jsnCfg,
+			UpdatedAt: time.Now(),
+		}
+		return nil, dm.DataDB().SetConfigSectionDrv(cs)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout,
+		utils.ConfigSectionPrefix+section)
+	return
+}
+
+// RemoveConfigSection removes the configuration section out of DataDB
+func (dm *DataManager) RemoveConfigSection(section string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().RemoveConfigSectionDrv(section)
+}
+
+// NewConfigDBS returns the service keeping the configuration in sync with DataDB
+func NewConfigDBS(cfg *config.CGRConfig, dm *DataManager) *ConfigDBS {
+	return &ConfigDBS{
+		cfg:     cfg,
+		dm:      dm,
+		applied: make(map[string]*ConfigSection),
+	}
+}
+
+// ConfigDBS loads the configuration sections shared over DataDB and follows their changes
+type ConfigDBS struct {
+	sync.Mutex
+	cfg      *config.CGRConfig
+	dm       *DataManager
+	applied  map[string]*ConfigSection // sections loaded in the running configuration
+	rejected map[string]*ConfigSection // changes failing the sanity check, not reported again
+}
+
+// LoadSections applies the sections changed in DataDB since the previous call,
+// on reload the affected subsystems are signaled
+func (cS *ConfigDBS) LoadSections(reload bool) (err error) {
+	cS.Lock()
+	defer cS.Unlock()
+	var css []*ConfigSection
+	if css, err = cS.dm.GetConfigSections(); err != nil {
+		return
+	}
+	dbSections := make(map[string]string)
+	changed := make(map[string]*ConfigSection)
+	for _, cs := range css {
+		dbSections[cs.Section] = cs.Config
+		if old, has := cS.applied[cs.Section]; !has ||
+			old.Version != cs.Version || !old.UpdatedAt.Equal(cs.UpdatedAt) {
+			changed[cs.Section] = cs
+		}
+	}
+	for section := range cS.applied {
+		if _, has := dbSections[section]; !has { // removed, the values stay until restart
+			delete(cS.applied, section)
+		}
+	}
+	if len(changed) == 0 ||
+		reflect.DeepEqual(changed, cS.rejected) {
+		return
+	}
+	if err = cS.cfg.CheckDBSections(dbSections); err != nil {
+		cS.rejected = changed
+		return
+	}
+	cS.rejected = nil
+	chngSections := make(map[string]string)
+	for section, cs := range changed {
+		chngSections[section] = cs.Config
+	}
+	if err = cS.cfg.LoadDBSections(chngSections, reload); err != nil {
+		return
+	}
+	for section, cs := range changed {
+		cS.applied[section] = cs
+		utils.Logger.Info(fmt.Sprintf("<%s> loaded section <%s> with version %d",
+			utils.ConfigDBS, section, cs.Version))
+	}
+	return
+}
+
+// ListenAndServe checks periodically DataDB for changed sections
+func (cS *ConfigDBS) ListenAndServe(stopChan chan struct{}) {
+	for {
+		select {
+		case <-stopChan:
+			return
+		case <-time.After(cS.cfg.ConfigDBCfg().SyncInterval):
+			if err := cS.LoadSections(true); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> failed loading the sections out of DataDB, error: <%s>",
+					utils.ConfigDBS, err.Error()))
+			}
+		}
+	}
+}
+
+// V1GetSection returns the configuration section stored in DataDB
+func (cS *ConfigDBS) V1GetSection(args *config.StringWithArgDispatcher, reply *ConfigSection) (err error) {
+	if missing := utils.MissingStructFields(args, []string{"Section"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	var cs *ConfigSection
+	if cs, err = cS.dm.GetConfigSection(args.Section); err != nil {
+		return
+	}
+	*reply = *cs
+	return
+}
+
+// V1GetSectionVersions returns the versions of the sections stored in DataDB
+func (cS *ConfigDBS) V1GetSectionVersions(args *config.StringWithArgDispatcher, reply *map[string]int64) (err error) {
+	var css []*ConfigSection
+	if css, err = cS.dm.GetConfigSections(); err != nil {
+		return
+	}
+	if len(css) == 0 {
+		return utils.ErrNotFound
+	}
+	vers := make(map[string]int64)
+	for _, cs := range css {
+		vers[cs.Section] = cs.Version
+	}
+	*reply = vers
+	return
+}
+
+// V1SetSection validates and stores the configuration section in DataDB,
+// applying it locally right away, only with config_db enabled
+func (cS *ConfigDBS) V1SetSection(args *config.DBSectionWithArgDispatcher, reply *string) (err error) {
+	if !cS.cfg.ConfigDBCfg().Enabled { // would change only the local configuration
+		return fmt.Errorf("<%s> not enabled", utils.ConfigDBS)
+	}
+	if missing := utils.MissingStructFields(args, []string{"Section"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err = config.CheckDBSection(args.Section); err != nil {
+		return
+	}
+	var jsnCfg []byte
+	if jsnCfg, err = json.Marshal(args.Config); err != nil {
+		return
+	}
+	var css []*ConfigSection
+	if css, err = cS.dm.GetConfigSections(); err != nil {
+		return
+	}
+	dbSections := map[string]string{args.Section: string(jsnCfg)}
+	for _, cs := range css {
+		if cs.Section != args.Section {
+			dbSections[cs.Section] = cs.Config
+		}
+	}
+	if err = cS.cfg.CheckDBSections(dbSections); err != nil {
+		return
+	}
+	if _, err = cS.dm.SetConfigSection(args.Section, string(jsnCfg), args.Version); err != nil {
+		return
+	}
+	if err = cS.LoadSections(true); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1RemoveSection removes the configuration section out of DataDB,
+// the engines keep the loaded values until restart
+func (cS *ConfigDBS) V1RemoveSection(args *config.StringWithArgDispatcher, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{"Section"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err = cS.dm.RemoveConfigSection(args.Section); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
@@ -0,0 +1,104 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestConfigDBSSyncSections(t *testing.T) {
+	cfg1, _ := config.NewDefaultCGRConfig()
+	cfg2, _ := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg1.DataDbCfg().Items), cfg1.CacheCfg(), nil)
+	cS1 := NewConfigDBS(cfg1, dm)
+	cS2 := NewConfigDBS(cfg2, dm)
+
+	var reply string
+	expErr := "<ConfigDBS> not enabled"
+	if err := cS1.V1SetSection(&config.DBSectionWithArgDispatcher{Section: config.GENERAL_JSN,
+		Config: map[string]interface{}{"default_tenant": "cgrates.net"}}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+	cfg1.ConfigDBCfg().Enabled = true
+	cfg2.ConfigDBCfg().Enabled = true
+	expErr = "section <data_db> can not be stored in DataDB"
+	if err := cS1.V1SetSection(&config.DBSectionWithArgDispatcher{Section: config.DATADB_JSN,
+		Config: map[string]interface{}{"db_type": "*internal"}}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+	expErr = "<ChargerS> not enabled but requested by <SessionS> component."
+	if err := cS1.V1SetSection(&config.DBSectionWithArgDispatcher{Section: config.SessionSJson,
+		Config: map[string]interface{}{"enabled": true, "chargers_conns": []string{utils.MetaInternal}}},
+		&reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+	if err := cS1.V1SetSection(&config.DBSectionWithArgDispatcher{Section: config.GENERAL_JSN,
+		Config: map[string]interface{}{"default_tenant": "cgrates.net"}}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Unexpected reply: %s", reply)
+	}
+	if cfg1.GeneralCfg().DefaultTenant != "cgrates.net" {
+		t.Errorf("Expected default tenant cgrates.net, received: %s", cfg1.GeneralCfg().DefaultTenant)
+	}
+	if err := cS2.LoadSections(false); err != nil {
+		t.Fatal(err)
+	}
+	if cfg2.GeneralCfg().DefaultTenant != "cgrates.net" {
+		t.Errorf("Expected default tenant cgrates.net, received: %s", cfg2.GeneralCfg().DefaultTenant)
+	}
+
+	var cs ConfigSection
+	if err := cS1.V1GetSection(&config.StringWithArgDispatcher{Section: config.GENERAL_JSN}, &cs); err != nil {
+		t.Error(err)
+	} else if cs.Version != 1 || cs.Config != `{"default_tenant":"cgrates.net"}` {
+		t.Errorf("Unexpected section: %s", utils.ToJSON(cs))
+	}
+	expErr = "REQ_UNSYNCHRONIZED: stored version 1, expected 2"
+	if err := cS2.V1SetSection(&config.DBSectionWithArgDispatcher{Section: config.GENERAL_JSN,
+		Config: map[string]interface{}{"default_tenant": "cgrates.com"}, Version: 2}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %s, received: %v", expErr, err)
+	}
+	if err := cS2.V1SetSection(&config.DBSectionWithArgDispatcher{Section: config.GENERAL_JSN,
+		Config: map[string]interface{}{"default_tenant": "cgrates.com"}, Version: 1}, &reply); err != nil {
+		t.Error(err)
+	}
+	var vers map[string]int64
+	if err := cS1.V1GetSectionVersions(new(config.StringWithArgDispatcher), &vers); err != nil {
+		t.Error(err)
+	} else if vers[config.GENERAL_JSN] != 2 {
+		t.Errorf("Unexpected versions: %+v", vers)
+	}
+	if err := cS1.LoadSections(false); err != nil {
+		t.Fatal(err)
+	}
+	if cfg1.GeneralCfg().DefaultTenant != "cgrates.com" {
+		t.Errorf("Expected default tenant cgrates.com, received: %s", cfg1.GeneralCfg().DefaultTenant)
+	}
+
+	if err := cS1.V1RemoveSection(&config.StringWithArgDispatcher{Section: config.GENERAL_JSN}, &reply); err != nil {
+		t.Error(err)
+	}
+	if err := cS1.V1GetSection(&config.StringWithArgDispatcher{Section: config.GENERAL_JSN}, &cs); err != utils.ErrNotFound {
+		t.Errorf("Expected error: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
@@ -64,3 +64,10 @@ func (cS *CoreService) Status(arg *utils.TenantWithArgDispatcher, reply *map[str
 	*reply = response
 	return
 }
+
+// RequestLimiterMetrics returns the counters of the request limiters, indexed by limiter ID and tenant for the per_tenant ones
+func (cS *CoreService) RequestLimiterMetrics(arg *utils.TenantWithArgDispatcher,
+	reply *map[string]*utils.RequestLimiterMetrics) (err error) {
+	*reply = utils.ReqLimiters.Metrics()
+	return
+}
@@ -18,6 +18,7 @@ package engine
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
@@ -35,6 +36,7 @@ var (
 		utils.DispatcherFilterIndexes:     struct{}{},
 		utils.RateProfilesFilterIndexPrfx: struct{}{},
 		utils.RateFilterIndexPrfx:         struct{}{},
+		utils.TaxFilterIndexes:            struct{}{},
 	}
 	cachePrefixMap = utils.StringSet{
 		utils.DESTINATION_PREFIX:          struct{}{},
@@ -48,6 +50,8 @@ var (
 		utils.SHARED_GROUP_PREFIX:         struct{}{},
 		utils.ResourceProfilesPrefix:      struct{}{},
 		utils.TimingsPrefix:               struct{}{},
+		utils.FxRatesPrefix:               struct{}{},
+		utils.PortedNumbersPrefix:         struct{}{},
 		utils.ResourcesPrefix:             struct{}{},
 		utils.StatQueuePrefix:             struct{}{},
 		utils.StatQueueProfilePrefix:      struct{}{},
@@ -60,6 +64,7 @@ var (
 		utils.DispatcherProfilePrefix:     struct{}{},
 		utils.DispatcherHostPrefix:        struct{}{},
 		utils.RateProfilePrefix:           struct{}{},
+		utils.TaxProfilePrefix:            struct{}{},
 		utils.AttributeFilterIndexes:      struct{}{},
 		utils.ResourceFilterIndexes:       struct{}{},
 		utils.StatFilterIndexes:           struct{}{},
@@ -69,6 +74,7 @@ var (
 		utils.DispatcherFilterIndexes:     struct{}{},
 		utils.RateProfilesFilterIndexPrfx: struct{}{},
 		utils.RateFilterIndexPrfx:         struct{}{},
+		utils.TaxFilterIndexes:            struct{}{},
 	}
 )
 
@@ -90,6 +96,9 @@ type DataManager struct {
 	cacheCfg *config.CacheCfg
 	connMgr  *ConnManager
 	ms       Marshaler
+
+	mnp    *portabilityTrie // index of the ported numbers, built on first lookup
+	mnpMux sync.Mutex
 }
 
 // DataDB exports access to dataDB
@@ -217,6 +226,10 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 			_, err = dm.GetStatQueue(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 		case utils.TimingsPrefix:
 			_, err = dm.GetTiming(dataID, true, utils.NonTransactional)
+		case utils.FxRatesPrefix:
+			_, err = dm.GetFxRate(dataID, true, utils.NonTransactional)
+		case utils.PortedNumbersPrefix:
+			_, err = dm.GetPortedNumber(dataID, true, utils.NonTransactional)
 		case utils.ThresholdProfilePrefix:
 			tntID := utils.NewTenantID(dataID)
 			_, err = dm.GetThresholdProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
@@ -244,6 +257,9 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 		case utils.RateProfilePrefix:
 			tntID := utils.NewTenantID(dataID)
 			_, err = dm.GetRateProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
+		case utils.TaxProfilePrefix:
+			tntID := utils.NewTenantID(dataID)
+			_, err = dm.GetTaxProfile(tntID.Tenant, tntID.ID, false, true, utils.NonTransactional)
 		case utils.AttributeFilterIndexes:
 			var tntCtx, idxKey string
 			if tntCtx, idxKey, err = splitFilterIndex(dataID); err != nil {
@@ -298,6 +314,12 @@ func (dm *DataManager) CacheDataFromDB(prfx string, ids []string, mustBeCached b
 				return
 			}
 			_, err = dm.GetIndexes(utils.CacheRateFilterIndexes, tntCtx, idxKey, false, true)
+		case utils.TaxFilterIndexes:
+			var tntCtx, idxKey string
+			if tntCtx, idxKey, err = splitFilterIndex(dataID); err != nil {
+				return
+			}
+			_, err = dm.GetIndexes(utils.CacheTaxFilterIndexes, tntCtx, idxKey, false, true)
 		case utils.LoadIDPrefix:
 			_, err = dm.GetItemLoadIDs(utils.EmptyString, true)
 		}
@@ -715,6 +737,7 @@ func (dm *DataManager) SetFilter(fltr *Filter, withIndex bool) (err error) {
 	if err = dm.DataDB().SetFilterDrv(fltr); err != nil {
 		return
 	}
+	dm.setProfileRevision(utils.CacheFilters, fltr.Tenant, fltr.ID, fltr)
 	if withIndex {
 		if err = UpdateFilterIndex(dm, oldFlt, fltr); err != nil {
 			return
@@ -768,6 +791,7 @@ func (dm *DataManager) RemoveFilter(tenant, id, transactionID string, withIndex
 	if oldFlt == nil {
 		return utils.ErrNotFound
 	}
+	dm.setProfileRevision(utils.CacheFilters, tenant, id, nil)
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaFilters]; itm.Replicate {
 		var reply string
 		dm.connMgr.Call(config.CgrConfig().DataDbCfg().RplConns, nil,
@@ -938,6 +962,7 @@ func (dm *DataManager) SetThresholdProfile(th *ThresholdProfile, withIndex bool)
 	if err = dm.DataDB().SetThresholdProfileDrv(th); err != nil {
 		return err
 	}
+	dm.setProfileRevision(utils.CacheThresholdProfiles, th.Tenant, th.ID, th)
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldTh != nil {
@@ -981,6 +1006,7 @@ func (dm *DataManager) RemoveThresholdProfile(tenant, id,
 	if oldTh == nil {
 		return utils.ErrNotFound
 	}
+	dm.setProfileRevision(utils.CacheThresholdProfiles, tenant, id, nil)
 	if withIndex {
 		if err = removeIndexFiltersItem(dm, utils.CacheThresholdFilterIndexes, tenant, id, oldTh.FilterIDs); err != nil {
 			return
@@ -1065,6 +1091,7 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 	if err = dm.DataDB().SetStatQueueProfileDrv(sqp); err != nil {
 		return err
 	}
+	dm.setProfileRevision(utils.CacheStatQueueProfiles, sqp.Tenant, sqp.ID, sqp)
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldSts != nil {
@@ -1108,6 +1135,7 @@ func (dm *DataManager) RemoveStatQueueProfile(tenant, id,
 	if oldSts == nil {
 		return utils.ErrNotFound
 	}
+	dm.setProfileRevision(utils.CacheStatQueueProfiles, tenant, id, nil)
 	if withIndex {
 		if err = removeIndexFiltersItem(dm, utils.CacheStatFilterIndexes, tenant, id, oldSts.FilterIDs); err != nil {
 			return
@@ -1226,6 +1254,127 @@ func (dm *DataManager) RemoveTiming(id, transactionID string) (err error) {
 	return
 }
 
+// GetFxRate returns the FxRate with the FROM:TO id from dataDB or cache
+func (dm *DataManager) GetFxRate(id string, skipCache bool,
+	transactionID string) (fx *FxRate, err error) {
+	if !skipCache {
+		if x, ok := Cache.Get(utils.CacheFxRates, id); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*FxRate), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if fx, err = dm.dataDB.GetFxRateDrv(id); err != nil {
+		if err == utils.ErrNotFound {
+			if errCh := Cache.Set(utils.CacheFxRates, id, nil, nil,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if errCh := Cache.Set(utils.CacheFxRates, id, fx, nil,
+		cacheCommit(transactionID), transactionID); errCh != nil {
+		return nil, errCh
+	}
+	return
+}
+
+// SetFxRate stores the FxRate in dataDB and updates the cached one
+func (dm *DataManager) SetFxRate(fx *FxRate) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if err = dm.DataDB().SetFxRateDrv(fx); err != nil {
+		return
+	}
+	return dm.CacheDataFromDB(utils.FxRatesPrefix, []string{fx.ID()}, true)
+}
+
+// RemoveFxRate removes the FxRate from dataDB and cache
+func (dm *DataManager) RemoveFxRate(id, transactionID string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if err = dm.DataDB().RemoveFxRateDrv(id); err != nil {
+		return
+	}
+	return Cache.Remove(utils.CacheFxRates, id,
+		cacheCommit(transactionID), transactionID)
+}
+
+// GetPortedNumber returns the PortedNumber with the number or range id from dataDB or cache
+func (dm *DataManager) GetPortedNumber(id string, skipCache bool,
+	transactionID string) (pn *PortedNumber, err error) {
+	if !skipCache {
+		if x, ok := Cache.Get(utils.CachePortedNumbers, id); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*PortedNumber), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if pn, err = dm.dataDB.GetPortedNumberDrv(id); err != nil {
+		if err == utils.ErrNotFound {
+			if errCh := Cache.Set(utils.CachePortedNumbers, id, nil, nil,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if errCh := Cache.Set(utils.CachePortedNumbers, id, pn, nil,
+		cacheCommit(transactionID), transactionID); errCh != nil {
+		return nil, errCh
+	}
+	return
+}
+
+// SetPortedNumber stores the PortedNumber in dataDB and updates the lookup index
+func (dm *DataManager) SetPortedNumber(pn *PortedNumber) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if _, _, err = portabilityPrefixes(pn.Number); err != nil {
+		return
+	}
+	if err = dm.DataDB().SetPortedNumberDrv(pn); err != nil {
+		return
+	}
+	if err = dm.CacheDataFromDB(utils.PortedNumbersPrefix, []string{pn.ID()}, true); err != nil {
+		return
+	}
+	return dm.updatePortabilityTrie(pn.ID(), pn)
+}
+
+// RemovePortedNumber removes the PortedNumber from dataDB, cache and lookup index
+func (dm *DataManager) RemovePortedNumber(id, transactionID string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if err = dm.DataDB().RemovePortedNumberDrv(id); err != nil {
+		return
+	}
+	if err = Cache.Remove(utils.CachePortedNumbers, id,
+		cacheCommit(transactionID), transactionID); err != nil {
+		return
+	}
+	return dm.updatePortabilityTrie(id, nil)
+}
+
 func (dm *DataManager) GetResource(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (rs *Resource, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
@@ -1384,6 +1533,7 @@ func (dm *DataManager) SetResourceProfile(rp *ResourceProfile, withIndex bool) (
 	if err = dm.DataDB().SetResourceProfileDrv(rp); err != nil {
 		return err
 	}
+	dm.setProfileRevision(utils.CacheResourceProfiles, rp.Tenant, rp.ID, rp)
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldRes != nil {
@@ -1427,6 +1577,7 @@ func (dm *DataManager) RemoveResourceProfile(tenant, id, transactionID string, w
 	if oldRes == nil {
 		return utils.ErrNotFound
 	}
+	dm.setProfileRevision(utils.CacheResourceProfiles, tenant, id, nil)
 	if withIndex {
 		if err = removeIndexFiltersItem(dm, utils.CacheResourceFilterIndexes, tenant, id, oldRes.FilterIDs); err != nil {
 			return
@@ -2267,6 +2418,7 @@ func (dm *DataManager) SetRouteProfile(rpp *RouteProfile, withIndex bool) (err e
 	if err = dm.DataDB().SetRouteProfileDrv(rpp); err != nil {
 		return err
 	}
+	dm.setProfileRevision(utils.CacheRouteProfiles, rpp.Tenant, rpp.ID, rpp)
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldRpp != nil {
@@ -2309,6 +2461,7 @@ func (dm *DataManager) RemoveRouteProfile(tenant, id, transactionID string, with
 	if oldRpp == nil {
 		return utils.ErrNotFound
 	}
+	dm.setProfileRevision(utils.CacheRouteProfiles, tenant, id, nil)
 	if withIndex {
 		if err = removeIndexFiltersItem(dm, utils.CacheRouteFilterIndexes, tenant, id, oldRpp.FilterIDs); err != nil {
 			return
@@ -2409,6 +2562,7 @@ func (dm *DataManager) SetAttributeProfile(ap *AttributeProfile, withIndex bool)
 	if err = dm.DataDB().SetAttributeProfileDrv(ap); err != nil {
 		return err
 	}
+	dm.setProfileRevision(utils.CacheAttributeProfiles, ap.Tenant, ap.ID, ap)
 	if withIndex {
 		var oldContexes *[]string
 		var oldFiltersIDs *[]string
@@ -2453,6 +2607,7 @@ func (dm *DataManager) RemoveAttributeProfile(tenant, id string, transactionID s
 	if oldAttr == nil {
 		return utils.ErrNotFound
 	}
+	dm.setProfileRevision(utils.CacheAttributeProfiles, tenant, id, nil)
 	if withIndex {
 		if err = removeIndexFiltersItem(dm, utils.CacheAttributeFilterIndexes, tenant, id, oldAttr.FilterIDs); err != nil {
 			return
@@ -2540,6 +2695,7 @@ func (dm *DataManager) SetChargerProfile(cpp *ChargerProfile, withIndex bool) (e
 	if err = dm.DataDB().SetChargerProfileDrv(cpp); err != nil {
 		return err
 	}
+	dm.setProfileRevision(utils.CacheChargerProfiles, cpp.Tenant, cpp.ID, cpp)
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldCpp != nil {
@@ -2583,6 +2739,7 @@ func (dm *DataManager) RemoveChargerProfile(tenant, id string,
 	if oldCpp == nil {
 		return utils.ErrNotFound
 	}
+	dm.setProfileRevision(utils.CacheChargerProfiles, tenant, id, nil)
 	if withIndex {
 		if err = removeIndexFiltersItem(dm, utils.CacheChargerFilterIndexes, tenant, id, oldCpp.FilterIDs); err != nil {
 			return
@@ -2606,6 +2763,97 @@ func (dm *DataManager) RemoveChargerProfile(tenant, id string,
 	return
 }
 
+// GetTaxProfile returns the TaxProfile from dataDB or cache
+func (dm *DataManager) GetTaxProfile(tenant, id string, cacheRead, cacheWrite bool,
+	transactionID string) (txp *TaxProfile, err error) {
+	tntID := utils.ConcatenatedKey(tenant, id)
+	if cacheRead {
+		if x, ok := Cache.Get(utils.CacheTaxProfiles, tntID); ok {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x.(*TaxProfile), nil
+		}
+	}
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	if txp, err = dm.dataDB.GetTaxProfileDrv(tenant, id); err != nil {
+		if err == utils.ErrNotFound && cacheWrite {
+			if errCh := Cache.Set(utils.CacheTaxProfiles, tntID, nil, nil,
+				cacheCommit(transactionID), transactionID); errCh != nil {
+				return nil, errCh
+			}
+		}
+		return nil, err
+	}
+	if cacheWrite {
+		if errCh := Cache.Set(utils.CacheTaxProfiles, tntID, txp, nil,
+			cacheCommit(transactionID), transactionID); errCh != nil {
+			return nil, errCh
+		}
+	}
+	return
+}
+
+// SetTaxProfile stores the TaxProfile in dataDB, updating the filter indexes if requested
+func (dm *DataManager) SetTaxProfile(txp *TaxProfile, withIndex bool) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	oldTxp, err := dm.GetTaxProfile(txp.Tenant, txp.ID, true, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().SetTaxProfileDrv(txp); err != nil {
+		return err
+	}
+	dm.setProfileRevision(utils.CacheTaxProfiles, txp.Tenant, txp.ID, txp)
+	if withIndex {
+		var oldFiltersIDs *[]string
+		if oldTxp != nil {
+			oldFiltersIDs = &oldTxp.FilterIDs
+		}
+		if err = updatedIndexes(dm, utils.CacheTaxFilterIndexes, txp.Tenant,
+			utils.EmptyString, txp.ID, oldFiltersIDs, txp.FilterIDs); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// RemoveTaxProfile removes the TaxProfile from dataDB together with its filter indexes
+func (dm *DataManager) RemoveTaxProfile(tenant, id string,
+	transactionID string, withIndex bool) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	oldTxp, err := dm.GetTaxProfile(tenant, id, true, false, utils.NonTransactional)
+	if err != nil && err != utils.ErrNotFound {
+		return err
+	}
+	if err = dm.DataDB().RemoveTaxProfileDrv(tenant, id); err != nil {
+		return
+	}
+	if oldTxp == nil {
+		return utils.ErrNotFound
+	}
+	dm.setProfileRevision(utils.CacheTaxProfiles, tenant, id, nil)
+	if withIndex {
+		if err = removeIndexFiltersItem(dm, utils.CacheTaxFilterIndexes, tenant, id, oldTxp.FilterIDs); err != nil {
+			return
+		}
+		if err = removeItemFromFilterIndex(dm, utils.CacheTaxFilterIndexes,
+			tenant, utils.EmptyString, id, oldTxp.FilterIDs); err != nil {
+			return
+		}
+	}
+	return
+}
+
 func (dm *DataManager) GetDispatcherProfile(tenant, id string, cacheRead, cacheWrite bool,
 	transactionID string) (dpp *DispatcherProfile, err error) {
 	tntID := utils.ConcatenatedKey(tenant, id)
@@ -2668,6 +2916,7 @@ func (dm *DataManager) SetDispatcherProfile(dpp *DispatcherProfile, withIndex bo
 	if err = dm.DataDB().SetDispatcherProfileDrv(dpp); err != nil {
 		return err
 	}
+	dm.setProfileRevision(utils.CacheDispatcherProfiles, dpp.Tenant, dpp.ID, dpp)
 	if withIndex {
 		var oldContexes *[]string
 		var oldFiltersIDs *[]string
@@ -2713,6 +2962,7 @@ func (dm *DataManager) RemoveDispatcherProfile(tenant, id string,
 	if oldDpp == nil {
 		return utils.ErrNotFound
 	}
+	dm.setProfileRevision(utils.CacheDispatcherProfiles, tenant, id, nil)
 	if withIndex {
 		if err = removeIndexFiltersItem(dm, utils.CacheDispatcherFilterIndexes, tenant, id, oldDpp.FilterIDs); err != nil {
 			return
@@ -2977,6 +3227,7 @@ func (dm *DataManager) SetRateProfile(rpp *RateProfile, withIndex bool) (err err
 	if err = dm.DataDB().SetRateProfileDrv(rpp); err != nil {
 		return err
 	}
+	dm.setProfileRevision(utils.CacheRateProfiles, rpp.Tenant, rpp.ID, rpp)
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldRpp != nil {
@@ -3047,6 +3298,7 @@ func (dm *DataManager) RemoveRateProfile(tenant, id string,
 	if oldRpp == nil {
 		return utils.ErrNotFound
 	}
+	dm.setProfileRevision(utils.CacheRateProfiles, tenant, id, nil)
 	if withIndex {
 		for key, rate := range oldRpp.Rates {
 			if err = removeItemFromFilterIndex(dm, utils.CacheRateFilterIndexes,
@@ -3110,6 +3362,7 @@ func (dm *DataManager) RemoveRateProfileRates(tenant, id string, rateIDs []strin
 	if err = dm.DataDB().SetRateProfileDrv(oldRpp); err != nil {
 		return err
 	}
+	dm.setProfileRevision(utils.CacheRateProfiles, oldRpp.Tenant, oldRpp.ID, oldRpp)
 
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRateProfiles]; itm.Replicate {
 		var reply string
@@ -3156,6 +3409,7 @@ func (dm *DataManager) SetRateProfileRates(rpp *RateProfile, withIndex bool) (er
 	if err = dm.DataDB().SetRateProfileDrv(oldRpp); err != nil {
 		return err
 	}
+	dm.setProfileRevision(utils.CacheRateProfiles, oldRpp.Tenant, oldRpp.ID, oldRpp)
 
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRateProfiles]; itm.Replicate {
 		var reply string
@@ -77,6 +77,7 @@ func NewEventCostFromCallCost(cc *CallCost, cgrID, runID string) (ec *EventCost)
 							BalanceUUID: incr.BalanceInfo.Monetary.UUID,
 							Units:       incr.Cost,
 							RatingID:    ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf),
+							FxRate:      incr.BalanceInfo.Monetary.FxRate,
 						}); uuid != "" {
 						ecUUID = uuid
 					}
@@ -94,7 +95,8 @@ func NewEventCostFromCallCost(cc *CallCost, cgrID, runID string) (ec *EventCost)
 						AccountID:   incr.BalanceInfo.AccountID,
 						BalanceUUID: incr.BalanceInfo.Monetary.UUID,
 						Units:       incr.Cost,
-						RatingID:    ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf)})
+						RatingID:    ec.ratingIDForRateInterval(incr.BalanceInfo.Monetary.RateInterval, rf),
+						FxRate:      incr.BalanceInfo.Monetary.FxRate})
 			}
 			cIl.Increments[j] = cIt
 		}
@@ -117,6 +119,7 @@ type EventCost struct {
 	RatingFilters  RatingFilters
 	Rates          ChargedRates
 	Timings        ChargedTimings
+	Taxes          *EventTaxes `json:",omitempty"` // populated by TaxS after rating
 
 	cache utils.MapStorage
 }
@@ -158,7 +161,8 @@ func (ec *EventCost) ratingIDForRateInterval(ri *RateInterval, rf RatingMatchedF
 			MaxCostStrategy:  ri.Rating.MaxCostStrategy,
 			TimingID:         tmID,
 			RatesID:          rtUUID,
-			RatingFiltersID:  rfUUID})
+			RatingFiltersID:  rfUUID,
+			Currency:         ri.Rating.Currency})
 }
 
 func (ec *EventCost) rateIntervalForRatingID(ratingID string) (ri *RateInterval) {
@@ -170,7 +174,8 @@ func (ec *EventCost) rateIntervalForRatingID(ratingID string) (ri *RateInterval)
 	ri.Rating = &RIRate{ConnectFee: cIlRU.ConnectFee,
 		RoundingMethod:   cIlRU.RoundingMethod,
 		RoundingDecimals: cIlRU.RoundingDecimals,
-		MaxCost:          cIlRU.MaxCost, MaxCostStrategy: cIlRU.MaxCostStrategy}
+		MaxCost:          cIlRU.MaxCost, MaxCostStrategy: cIlRU.MaxCostStrategy,
+		Currency: cIlRU.Currency}
 	if cIlRU.RatesID != "" {
 		ri.Rating.Rates = ec.Rates[cIlRU.RatesID]
 	}
@@ -222,6 +227,7 @@ func (ec *EventCost) Clone() (cln *EventCost) {
 	if ec.Timings != nil {
 		cln.Timings = ec.Timings.Clone()
 	}
+	cln.Taxes = ec.Taxes.Clone()
 	return
 }
 
@@ -312,7 +318,8 @@ func (ec *EventCost) AsRefundIncrements(tor string) (cd *CallDescriptor) {
 					}
 					blncSmry := ec.AccountSummary.BalanceSummaries.BalanceSummaryWithUUD(ec.Accounting[cIcrm.AccountingID].BalanceUUID)
 					if blncSmry.Type == utils.MONETARY {
-						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: blncSmry.UUID}
+						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: blncSmry.UUID,
+							FxRate: ec.Accounting[cIcrm.AccountingID].FxRate}
 					} else if utils.NonMonetaryBalances.Has(blncSmry.Type) {
 						cd.Increments[iIdx].BalanceInfo.Unit = &UnitInfo{UUID: blncSmry.UUID}
 					}
@@ -325,7 +332,8 @@ func (ec *EventCost) AsRefundIncrements(tor string) (cd *CallDescriptor) {
 					extraSmry := ec.AccountSummary.BalanceSummaries.BalanceSummaryWithUUD(
 						ec.Accounting[ec.Accounting[cIcrm.AccountingID].ExtraChargeID].BalanceUUID)
 					if extraSmry.Type == utils.MONETARY {
-						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: extraSmry.UUID}
+						cd.Increments[iIdx].BalanceInfo.Monetary = &MonetaryInfo{UUID: extraSmry.UUID,
+							FxRate: ec.Accounting[ec.Accounting[cIcrm.AccountingID].ExtraChargeID].FxRate}
 					} else if utils.NonMonetaryBalances.Has(blncSmry.Type) {
 						cd.Increments[iIdx].BalanceInfo.Unit = &UnitInfo{UUID: extraSmry.UUID}
 					}
@@ -397,7 +405,7 @@ func (ec *EventCost) AsCallCost(tor string) *CallCost {
 					}
 				}
 				if cBC.ExtraChargeID != utils.META_NONE {
-					incr.BalanceInfo.Monetary = &MonetaryInfo{UUID: cBC.BalanceUUID}
+					incr.BalanceInfo.Monetary = &MonetaryInfo{UUID: cBC.BalanceUUID, FxRate: cBC.FxRate}
 					incr.BalanceInfo.Monetary.RateInterval = ec.rateIntervalForRatingID(cBC.RatingID)
 				}
 			}
@@ -947,6 +955,14 @@ func (ec *EventCost) fieldAsInterface(fldPath []string) (val interface{}, err er
 			return ec.Rating, nil
 		}
 		return ec.Rating.FieldAsInterface(fldPath[1:])
+	case utils.Taxes:
+		if ec.Taxes == nil {
+			return nil, utils.ErrNotFound
+		}
+		if len(fldPath) == 1 {
+			return ec.Taxes, nil
+		}
+		return ec.Taxes.FieldAsInterface(fldPath[1:])
 	}
 	return nil, fmt.Errorf("unsupported field prefix: <%s>", fldPath[0])
 }
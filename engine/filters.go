@@ -204,15 +204,15 @@ var supportedFiltersType utils.StringSet = utils.NewStringSet([]string{utils.Met
 	utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations,
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessThan, utils.MetaLessOrEqual,
 	utils.MetaGreaterThan, utils.MetaGreaterOrEqual, utils.MetaEqual,
-	utils.MetaNotEqual})
+	utils.MetaNotEqual, utils.MetaMNP})
 var needsFieldName utils.StringSet = utils.NewStringSet([]string{utils.MetaString, utils.MetaPrefix,
 	utils.MetaSuffix, utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations, utils.MetaLessThan,
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessOrEqual, utils.MetaGreaterThan,
-	utils.MetaGreaterOrEqual, utils.MetaEqual, utils.MetaNotEqual})
+	utils.MetaGreaterOrEqual, utils.MetaEqual, utils.MetaNotEqual, utils.MetaMNP})
 var needsValues utils.StringSet = utils.NewStringSet([]string{utils.MetaString, utils.MetaPrefix,
 	utils.MetaSuffix, utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations,
 	utils.MetaLessThan, utils.MetaLessOrEqual, utils.MetaGreaterThan, utils.MetaGreaterOrEqual,
-	utils.MetaEqual, utils.MetaNotEqual})
+	utils.MetaEqual, utils.MetaNotEqual, utils.MetaMNP})
 
 // NewFilterRule returns a new filter
 func NewFilterRule(rfType, fieldName string, vals []string) (*FilterRule, error) {
@@ -298,6 +298,8 @@ func (fltr *FilterRule) Pass(dDP utils.DataProvider) (result bool, err error) {
 		result, err = fltr.passGreaterThan(dDP)
 	case utils.MetaEqual, utils.MetaNotEqual:
 		result, err = fltr.passEqualTo(dDP)
+	case utils.MetaMNP, utils.MetaNotMNP:
+		result, err = fltr.passMNP(dDP)
 	default:
 		err = utils.ErrPrefixNotErrNotImplemented(fltr.Type)
 	}
@@ -447,6 +449,35 @@ func (fltr *FilterRule) passDestinations(dDP utils.DataProvider) (bool, error) {
 	return false, nil
 }
 
+// passMNP checks if the number was ported into one of the networks in Values
+func (fltr *FilterRule) passMNP(dDP utils.DataProvider) (bool, error) {
+	number, err := utils.DPDynamicString(fltr.Element, dDP)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	var pn PortedNumber
+	if err = connMgr.Call(config.CgrConfig().FilterSCfg().ApierSConns, nil, utils.APIerSv1LookupPortedNumber,
+		&number, &pn); err != nil {
+		if err.Error() == utils.ErrNotFound.Error() { // not ported
+			return false, nil
+		}
+		return false, err
+	}
+	for _, val := range fltr.Values {
+		network, err := utils.DPDynamicString(val, dDP)
+		if err != nil {
+			continue
+		}
+		if network == pn.Network {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (fltr *FilterRule) passRSR(dDP utils.DataProvider) (bool, error) {
 	fld, err := utils.DPDynamicString(fltr.Element, dDP)
 	if err != nil {
@@ -563,6 +594,9 @@ func (dDP *dynamicDP) FieldAsInterface(fldPath []string) (val interface{}, err e
 	if initialDPPrefixes.Has(fldPath[0]) {
 		return dDP.initialDP.FieldAsInterface(fldPath)
 	}
+	if len(fldPath) == 2 && fldPath[0] == utils.MetaMNP { // ~*mnp.<number> defaults to the routing number
+		fldPath = []string{utils.MetaMNP, fldPath[1], utils.LRN}
+	}
 	val, err = dDP.cache.FieldAsInterface(fldPath)
 	if err == utils.ErrNotFound { // in case not found in cache try to populate it
 		return dDP.fieldAsInterface(fldPath)
@@ -612,6 +646,16 @@ func (dDP *dynamicDP) fieldAsInterface(fldPath []string) (val interface{}, err e
 			dDP.cache.Set([]string{utils.MetaStats, fldPath[1], k}, v)
 		}
 		return dDP.cache.FieldAsInterface(fldPath)
+	case utils.MetaMNP:
+		// sample of fieldName : ~*mnp.40721000123.Network
+		var pn PortedNumber
+		if err = dDP.connMgr.Call(dDP.cfg.FilterSCfg().ApierSConns, nil, utils.APIerSv1LookupPortedNumber,
+			&fldPath[1], &pn); err != nil {
+			return
+		}
+		dp := config.NewObjectDP(pn)
+		dDP.cache.Set(fldPath[:2], dp)
+		return dp.FieldAsInterface(fldPath[2:])
 	default: // in case of constant we give an empty DataProvider ( empty navigable map )
 	}
 	return nil, utils.ErrNotFound
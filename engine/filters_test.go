@@ -21,6 +21,7 @@ import (
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
 )
 
 func TestFilterPassString(t *testing.T) {
@@ -1215,3 +1216,54 @@ func TestPassPartial(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", 0, len(ruleList))
 	}
 }
+
+type mnpRPCMock map[string]*PortedNumber
+
+func (m mnpRPCMock) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	if serviceMethod != utils.APIerSv1LookupPortedNumber {
+		return rpcclient.ErrUnsupporteServiceMethod
+	}
+	pn, has := m[*args.(*string)]
+	if !has {
+		return utils.ErrNotFound
+	}
+	*reply.(*PortedNumber) = *pn
+	return nil
+}
+
+func TestFilterPassMNP(t *testing.T) {
+	cfg := config.CgrConfig()
+	defer func(connIDs []string, cM *ConnManager) {
+		cfg.FilterSCfg().ApierSConns = connIDs
+		connMgr = cM
+	}(cfg.FilterSCfg().ApierSConns, connMgr)
+	cfg.FilterSCfg().ApierSConns = []string{"mnpConn"}
+	NewConnManager(cfg, nil)
+	if err := Cache.Set(utils.CacheRPCConnections, "mnpConn", mnpRPCMock{
+		"40721000123": {Number: "40721000123", LRN: "D01", Network: "NET_A"},
+	}, nil, true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	defer Cache.Remove(utils.CacheRPCConnections, "mnpConn", true, utils.NonTransactional)
+	for _, tst := range []struct {
+		fltrType string
+		number   string
+		exp      bool
+	}{
+		{utils.MetaMNP, "40721000123", true},
+		{utils.MetaMNP, "40722000123", false}, // not ported
+		{utils.MetaNotMNP, "40721000123", false},
+		{utils.MetaNotMNP, "40722000123", true},
+	} {
+		rf, err := NewFilterRule(tst.fltrType, "~*req.Destination", []string{"NET_A"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		dDP := utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.Destination: tst.number}}
+		if pass, err := rf.Pass(dDP); err != nil {
+			t.Errorf("%s %s: %v", tst.fltrType, tst.number, err)
+		} else if pass != tst.exp {
+			t.Errorf("%s %s expecting: %v, received: %v", tst.fltrType, tst.number, tst.exp, pass)
+		}
+	}
+}
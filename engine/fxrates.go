@@ -0,0 +1,90 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// FxRate is the exchange rate between two currencies
+type FxRate struct {
+	FromCurrency string
+	ToCurrency   string
+	Rate         float64 // units of ToCurrency for one unit of FromCurrency
+}
+
+// ID returns the key of the FxRate
+func (fx *FxRate) ID() string {
+	return utils.ConcatenatedKey(fx.FromCurrency, fx.ToCurrency)
+}
+
+// NewFxRateFromTPFxRate converts the TP representation into FxRate
+func NewFxRateFromTPFxRate(tpFx *utils.TPFxRate) *FxRate {
+	return &FxRate{
+		FromCurrency: tpFx.FromCurrency,
+		ToCurrency:   tpFx.ToCurrency,
+		Rate:         tpFx.Rate,
+	}
+}
+
+// GetFxRateValue returns the rate to convert from one currency into the other
+// the inverse pair is used if the direct one is not defined
+func GetFxRateValue(fromCurrency, toCurrency string) (rate float64, err error) {
+	if fromCurrency == toCurrency {
+		return 1, nil
+	}
+	var fx *FxRate
+	if fx, err = dm.GetFxRate(utils.ConcatenatedKey(fromCurrency, toCurrency),
+		false, utils.NonTransactional); err == nil && fx.Rate != 0 {
+		return fx.Rate, nil
+	} else if err != nil && err != utils.ErrNotFound {
+		return
+	}
+	if fx, err = dm.GetFxRate(utils.ConcatenatedKey(toCurrency, fromCurrency),
+		false, utils.NonTransactional); err == nil && fx.Rate != 0 {
+		return 1 / fx.Rate, nil
+	} else if err != nil && err != utils.ErrNotFound {
+		return
+	}
+	return 0, utils.ErrFxRateNotFound
+}
+
+// fxRateForBalance returns the rate converting the costs in rateCurrency into the currency of the balance
+// 0 is returned if there is nothing to convert
+func fxRateForBalance(rateCurrency string, b *Balance) (float64, error) {
+	if rateCurrency == utils.EmptyString || b == nil ||
+		b.Currency == utils.EmptyString || b.Currency == rateCurrency {
+		return 0, nil
+	}
+	return GetFxRateValue(rateCurrency, b.Currency)
+}
+
+// connectFeeFxRate returns the rate used to debit the connect fee out of the balance
+func connectFeeFxRate(cc *CallCost, b *Balance) (fxRate float64) {
+	fxRate, _ = fxRateForBalance(cc.GetCurrency(), b)
+	return
+}
+
+// convertFx applies the fxRate on the cost, 0 meaning no conversion
+func convertFx(cost, fxRate float64) float64 {
+	if fxRate == 0 {
+		return cost
+	}
+	return utils.Round(cost*fxRate, globalRoundingDecimals, utils.ROUNDING_MIDDLE)
+}
@@ -0,0 +1,183 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestGetFxRateValue(t *testing.T) {
+	if err := dm.SetFxRate(&FxRate{FromCurrency: "GBP", ToCurrency: "CHF", Rate: 1.25}); err != nil {
+		t.Fatal(err)
+	}
+	if rate, err := GetFxRateValue("GBP", "CHF"); err != nil {
+		t.Error(err)
+	} else if rate != 1.25 {
+		t.Errorf("Expecting: 1.25, received: %v", rate)
+	}
+	if rate, err := GetFxRateValue("CHF", "GBP"); err != nil {
+		t.Error(err)
+	} else if rate != 0.8 {
+		t.Errorf("Expecting: 0.8, received: %v", rate)
+	}
+	if rate, err := GetFxRateValue("CHF", "CHF"); err != nil {
+		t.Error(err)
+	} else if rate != 1 {
+		t.Errorf("Expecting: 1, received: %v", rate)
+	}
+	if _, err := GetFxRateValue("GBP", "JPY"); err != utils.ErrFxRateNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrFxRateNotFound, err)
+	}
+	if err := dm.RemoveFxRate("GBP:CHF", utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := GetFxRateValue("GBP", "CHF"); err != utils.ErrFxRateNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrFxRateNotFound, err)
+	}
+}
+
+func TestFxRateForBalance(t *testing.T) {
+	if err := dm.SetFxRate(&FxRate{FromCurrency: "USD", ToCurrency: "EUR", Rate: 0.85}); err != nil {
+		t.Fatal(err)
+	}
+	if fx, err := fxRateForBalance(utils.EmptyString, &Balance{Currency: "EUR"}); err != nil {
+		t.Error(err)
+	} else if fx != 0 {
+		t.Errorf("Expecting no conversion, received: %v", fx)
+	}
+	if fx, err := fxRateForBalance("USD", &Balance{}); err != nil {
+		t.Error(err)
+	} else if fx != 0 {
+		t.Errorf("Expecting no conversion, received: %v", fx)
+	}
+	if fx, err := fxRateForBalance("USD", &Balance{Currency: "EUR"}); err != nil {
+		t.Error(err)
+	} else if fx != 0.85 {
+		t.Errorf("Expecting: 0.85, received: %v", fx)
+	}
+	if _, err := fxRateForBalance("USD", &Balance{Currency: "JPY"}); err != utils.ErrFxRateNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrFxRateNotFound, err)
+	}
+}
+
+func TestDebitCreditMoneyFx(t *testing.T) {
+	if err := dm.SetFxRate(&FxRate{FromCurrency: "USD", ToCurrency: "EUR", Rate: 0.85}); err != nil {
+		t.Fatal(err)
+	}
+	cc := &CallCost{
+		Destination: "0723045326",
+		Timespans: []*TimeSpan{
+			{
+				TimeStart:     time.Date(2013, 9, 24, 10, 48, 0, 0, time.UTC),
+				TimeEnd:       time.Date(2013, 9, 24, 10, 48, 20, 0, time.UTC),
+				DurationIndex: 0,
+				RateInterval: &RateInterval{
+					Rating: &RIRate{
+						Currency: "USD",
+						Rates: RateGroups{
+							&RGRate{GroupIntervalStart: 0,
+								Value:         1,
+								RateIncrement: 10 * time.Second,
+								RateUnit:      time.Second}}}},
+			},
+		},
+		ToR: utils.VOICE,
+	}
+	cd := &CallDescriptor{
+		TimeStart:     cc.Timespans[0].TimeStart,
+		TimeEnd:       cc.Timespans[0].TimeEnd,
+		Destination:   cc.Destination,
+		ToR:           cc.ToR,
+		DurationIndex: cc.GetDuration(),
+		testCallcost:  cc,
+	}
+	acnt := &Account{ID: "cgrates.org:fx",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: {&Balance{Uuid: "euros", Value: 100, Currency: "EUR"}},
+		}}
+	var err error
+	if cc, err = acnt.debitCreditBalance(cd, false, false, true); err != nil {
+		t.Fatal(err)
+	}
+	if val := acnt.BalanceMap[utils.MONETARY][0].GetValue(); val != 83 {
+		t.Errorf("Expecting balance value: 83, received: %v", val)
+	}
+	if mi := cc.Timespans[0].Increments[0].BalanceInfo.Monetary; mi == nil ||
+		mi.UUID != "euros" || mi.FxRate != 0.85 {
+		t.Errorf("Unexpected MonetaryInfo: %s", utils.ToJSON(mi))
+	}
+	ec := NewEventCostFromCallCost(cc, "TestDebitCreditMoneyFx", utils.MetaDefault)
+	ec.AccountSummary = acnt.AsAccountSummary()
+	for _, bc := range ec.Accounting {
+		if bc.BalanceUUID == "euros" && bc.FxRate != 0.85 {
+			t.Errorf("Expecting FxRate: 0.85, received: %s", utils.ToJSON(bc))
+		}
+	}
+	for _, ru := range ec.Rating {
+		if ru.Currency != "USD" {
+			t.Errorf("Expecting Currency: USD, received: %s", utils.ToJSON(ru))
+		}
+	}
+	// refund converts back into the currency of the balance
+	refundCD := ec.AsRefundIncrements(utils.VOICE)
+	if refundCD.Increments[0].BalanceInfo.Monetary.FxRate != 0.85 {
+		t.Errorf("Expecting FxRate: 0.85, received: %s",
+			utils.ToJSON(refundCD.Increments[0].BalanceInfo))
+	}
+}
+
+func TestDebitConnectionFeeFx(t *testing.T) {
+	if err := dm.SetFxRate(&FxRate{FromCurrency: "USD", ToCurrency: "EUR", Rate: 0.85}); err != nil {
+		t.Fatal(err)
+	}
+	cc := &CallCost{
+		Timespans: []*TimeSpan{
+			{
+				RateInterval: &RateInterval{
+					Rating: &RIRate{
+						Currency:   "USD",
+						ConnectFee: 10}},
+			},
+		},
+		deductConnectFee: true,
+	}
+	acnt := &Account{ID: "cgrates.org:fx",
+		BalanceMap: map[string]Balances{
+			utils.MONETARY: {&Balance{ID: utils.MetaDefault, Currency: "EUR"}},
+		}}
+	if ok, _, err := acnt.DebitConnectionFee(cc, nil, false, true); err != nil {
+		t.Fatal(err)
+	} else if !ok {
+		t.Error("Expecting the connect fee to be debited")
+	}
+	if val := acnt.BalanceMap[utils.MONETARY][0].GetValue(); val != -8.5 {
+		t.Errorf("Expecting balance value: -8.5, received: %v", val)
+	}
+	// no way to convert into the currency of the default balance
+	acnt.BalanceMap[utils.MONETARY][0] = &Balance{ID: utils.MetaDefault, Currency: "JPY"}
+	if _, _, err := acnt.DebitConnectionFee(cc, nil, false, true); err != utils.ErrFxRateNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrFxRateNotFound, err)
+	}
+	if val := acnt.BalanceMap[utils.MONETARY][0].GetValue(); val != 0 {
+		t.Errorf("Expecting balance value: 0, received: %v", val)
+	}
+}
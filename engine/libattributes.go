@@ -33,6 +33,8 @@ type Attribute struct {
 	Path      string
 	Type      string
 	Value     config.RSRParsers
+
+	expr *utils.Expression // compiled Value for *expr type
 }
 
 // AttributeProfile the profile definition for the attributes
@@ -58,6 +60,11 @@ func (ap *AttributeProfile) compileSubstitutes() (err error) {
 		if err = attr.Value.Compile(); err != nil {
 			return
 		}
+		if attr.Type == utils.MetaExpr {
+			if attr.expr, err = utils.NewExpression(attr.Value.GetRule()); err != nil {
+				return
+			}
+		}
 	}
 	return
 }
@@ -32,7 +32,7 @@ import (
 func NewRPCPool(dispatchStrategy string, keyPath, certPath, caPath string, connAttempts, reconnects int,
 	connectTimeout, replyTimeout time.Duration, rpcConnCfgs []*config.RemoteHost,
 	internalConnChan chan rpcclient.ClientConnector, lazyConnect bool) (*rpcclient.RPCPool, error) {
-	var rpcClient *rpcclient.RPCClient
+	var rpcClient rpcclient.ClientConnector
 	var err error
 	rpcPool := rpcclient.NewRPCPool(dispatchStrategy, replyTimeout)
 	atLestOneConnected := false // If one connected we don't longer return errors
@@ -47,6 +47,9 @@ func NewRPCPool(dispatchStrategy string, keyPath, certPath, caPath string, connA
 			}
 			rpcClient, err = rpcclient.NewRPCClient(utils.TCP, rpcConnCfg.Address, rpcConnCfg.TLS, keyPath, certPath, caPath,
 				connAttempts, reconnects, connectTimeout, replyTimeout, codec, nil, lazyConnect)
+		} else if rpcConnCfg.Transport == utils.MetaGRPC {
+			rpcClient, err = utils.NewGRPCClient(rpcConnCfg.Address, rpcConnCfg.TLS, keyPath, certPath, caPath,
+				connectTimeout, replyTimeout, lazyConnect)
 		} else {
 			return nil, fmt.Errorf("Unsupported transport: <%s>", rpcConnCfg.Transport)
 		}
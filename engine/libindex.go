@@ -605,6 +605,26 @@ func UpdateFilterIndex(dm *DataManager, oldFlt, newFlt *Filter) (err error) {
 				}); err != nil && err != utils.ErrNotFound {
 				return utils.APIErrorHandler(err)
 			}
+		case utils.CacheTaxFilterIndexes:
+			if err = removeFilterIndexesForFilrer(dm, idxItmType, newFlt.Tenant, // remove the indexes for the filter
+				removeIndexKeys, indx); err != nil {
+				return
+			}
+			idxSlice := indx.AsSlice()
+			if _, err = ComputeIndexes(dm, newFlt.Tenant, utils.EmptyString, idxItmType, // compute all the indexes for afected items
+				&idxSlice, utils.NonTransactional, func(tnt, id, ctx string) (*[]string, error) {
+					txp, e := dm.GetTaxProfile(tnt, id, true, false, utils.NonTransactional)
+					if e != nil {
+						return nil, e
+					}
+					fltrIDs := make([]string, len(txp.FilterIDs))
+					for i, fltrID := range txp.FilterIDs {
+						fltrIDs[i] = fltrID
+					}
+					return &fltrIDs, nil
+				}); err != nil && err != utils.ErrNotFound {
+				return utils.APIErrorHandler(err)
+			}
 		case utils.CacheAttributeFilterIndexes:
 			for itemID := range indx {
 				var ap *AttributeProfile
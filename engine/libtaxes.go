@@ -0,0 +1,228 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// TaxProfile groups the taxes of one jurisdiction
+type TaxProfile struct {
+	Tenant             string
+	ID                 string
+	FilterIDs          []string // select the jurisdiction
+	ActivationInterval *utils.ActivationInterval
+	Weight             float64
+	Taxes              []*Tax
+}
+
+// TenantID returns the concatenated key between tenant and ID
+func (txp *TaxProfile) TenantID() string {
+	return utils.ConcatenatedKey(txp.Tenant, txp.ID)
+}
+
+// Tax is one tax of the TaxProfile
+type Tax struct {
+	ID         string
+	FilterIDs  []string
+	Rate       float64  // as fraction of the base, ie: 0.19 for 19%
+	Inclusive  bool     // the tax is already contained within the rated cost
+	Compound   bool     // the tax applies on top of the previous taxes
+	ExemptToRs []string // the ToRs which are not taxed
+}
+
+// IsExempt returns true if the tax does not apply for the ToR
+func (tx *Tax) IsExempt(tor string) bool {
+	for _, exTor := range tx.ExemptToRs {
+		if exTor == tor {
+			return true
+		}
+	}
+	return false
+}
+
+// TaxProfiles is a sortable list of TaxProfiles
+type TaxProfiles []*TaxProfile
+
+// Sort is part of sort interface, sort based on Weight
+func (txps TaxProfiles) Sort() {
+	sort.Slice(txps, func(i, j int) bool { return txps[i].Weight > txps[j].Weight })
+}
+
+// TaxCharge is one tax applied on the cost of an event
+type TaxCharge struct {
+	TaxProfileID string
+	TaxID        string
+	Rate         float64
+	Inclusive    bool
+	Base         float64 // the amount the rate was applied on
+	Amount       float64
+}
+
+// FieldAsInterface is part of utils.DataProvider
+func (txc *TaxCharge) FieldAsInterface(fldPath []string) (val interface{}, err error) {
+	if txc == nil || len(fldPath) != 1 {
+		return nil, utils.ErrNotFound
+	}
+	switch fldPath[0] {
+	default:
+		return nil, fmt.Errorf("unsupported field prefix: <%s>", fldPath[0])
+	case utils.TaxProfileID:
+		return txc.TaxProfileID, nil
+	case utils.TaxID:
+		return txc.TaxID, nil
+	case utils.Rate:
+		return txc.Rate, nil
+	case utils.Inclusive:
+		return txc.Inclusive, nil
+	case utils.Base:
+		return txc.Base, nil
+	case utils.Amount:
+		return txc.Amount, nil
+	}
+}
+
+// EventTaxes is the tax breakdown for the cost of an event
+type EventTaxes struct {
+	NetCost   float64 // cost without taxes
+	TaxAmount float64
+	TotalCost float64 // cost with all the taxes
+	Taxes     []*TaxCharge
+}
+
+// Clone returns a copy of EventTaxes
+func (evTx *EventTaxes) Clone() (cln *EventTaxes) {
+	if evTx == nil {
+		return
+	}
+	cln = &EventTaxes{
+		NetCost:   evTx.NetCost,
+		TaxAmount: evTx.TaxAmount,
+		TotalCost: evTx.TotalCost,
+	}
+	if evTx.Taxes != nil {
+		cln.Taxes = make([]*TaxCharge, len(evTx.Taxes))
+		for i, txc := range evTx.Taxes {
+			cpy := *txc
+			cln.Taxes[i] = &cpy
+		}
+	}
+	return
+}
+
+// FieldAsInterface is part of utils.DataProvider
+func (evTx *EventTaxes) FieldAsInterface(fldPath []string) (val interface{}, err error) {
+	if evTx == nil || len(fldPath) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	switch fldPath[0] {
+	default: // "Taxes[1]"
+		opath, indx := utils.GetPathIndex(fldPath[0])
+		if opath != utils.Taxes || indx == nil {
+			return nil, fmt.Errorf("unsupported field prefix: <%s>", fldPath[0])
+		}
+		if len(evTx.Taxes) <= *indx {
+			return nil, utils.ErrNotFound
+		}
+		if len(fldPath) == 1 {
+			return evTx.Taxes[*indx], nil
+		}
+		return evTx.Taxes[*indx].FieldAsInterface(fldPath[1:])
+	case utils.Taxes:
+		if len(fldPath) != 1 {
+			return nil, utils.ErrNotFound
+		}
+		return evTx.Taxes, nil
+	case utils.NetCost:
+		if len(fldPath) != 1 {
+			return nil, utils.ErrNotFound
+		}
+		return evTx.NetCost, nil
+	case utils.TaxAmount:
+		if len(fldPath) != 1 {
+			return nil, utils.ErrNotFound
+		}
+		return evTx.TaxAmount, nil
+	case utils.TotalCost:
+		if len(fldPath) != 1 {
+			return nil, utils.ErrNotFound
+		}
+		return evTx.TotalCost, nil
+	}
+}
+
+// profileTax links a Tax with the TaxProfile it belongs to
+type profileTax struct {
+	prfID string
+	*Tax
+}
+
+// computeEventTaxes applies the taxes in order on the cost
+// inclusive taxes are extracted out of the cost while the exclusive ones are added on top
+func computeEventTaxes(cost float64, taxes []*profileTax,
+	roundingDecimals int) (evTx *EventTaxes) {
+	// all amounts are linear with the net cost so we compute them for a net cost of 1
+	factors := make([]float64, len(taxes))
+	var compounded, inclusive float64
+	for i, tx := range taxes {
+		base := 1.0
+		if tx.Compound {
+			base += compounded
+		}
+		factors[i] = tx.Rate * base
+		compounded += factors[i]
+		if tx.Inclusive {
+			inclusive += factors[i]
+		}
+	}
+	netCost := cost / (1 + inclusive)
+	evTx = &EventTaxes{
+		NetCost:   cost,
+		TotalCost: cost,
+		Taxes:     make([]*TaxCharge, len(taxes)),
+	}
+	for i, tx := range taxes {
+		amount := utils.Round(netCost*factors[i], roundingDecimals, utils.ROUNDING_MIDDLE)
+		base := netCost
+		if tx.Rate != 0 {
+			base = netCost * factors[i] / tx.Rate
+		}
+		evTx.Taxes[i] = &TaxCharge{
+			TaxProfileID: tx.prfID,
+			TaxID:        tx.ID,
+			Rate:         tx.Rate,
+			Inclusive:    tx.Inclusive,
+			Base:         utils.Round(base, roundingDecimals, utils.ROUNDING_MIDDLE),
+			Amount:       amount,
+		}
+		evTx.TaxAmount += amount
+		if tx.Inclusive {
+			evTx.NetCost -= amount
+		} else {
+			evTx.TotalCost += amount
+		}
+	}
+	evTx.NetCost = utils.Round(evTx.NetCost, roundingDecimals, utils.ROUNDING_MIDDLE)
+	evTx.TaxAmount = utils.Round(evTx.TaxAmount, roundingDecimals, utils.ROUNDING_MIDDLE)
+	evTx.TotalCost = utils.Round(evTx.TotalCost, roundingDecimals, utils.ROUNDING_MIDDLE)
+	return
+}
@@ -287,6 +287,16 @@ cgrates.org,RP1,*string:~*req.Subject:1001,,0,0.1,*up,4,0.1,0.6,*free,RT_WEEK,,"
 cgrates.org,RP1,,,,,,,,,,RT_WEEK,,,,,1m,0.06,1m,1s
 cgrates.org,RP1,,,,,,,,,,RT_WEEKEND,,"* * * * 0,6",10,false,0s,0.06,1m,1s
 cgrates.org,RP1,,,,,,,,,,RT_CHRISTMAS,,* * 24 12 *,30,false,0s,0.06,1m,1s
+`
+	FxRatesCSVContent = `
+#FromCurrency[0],ToCurrency[1],Rate[2]
+USD,EUR,0.85
+EUR,RON,4.87
+`
+	PortedNumbersCSVContent = `
+#Number[0],LRN[1],Network[2]
+40721000000-40721009999,40299,NET_B
+40721000123,40288,NET_C
 `
 )
 
@@ -482,6 +492,8 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheAttributeProfiles:         {},
 		utils.CacheChargerFilterIndexes:      {},
 		utils.CacheChargerProfiles:           {},
+		utils.CacheTaxFilterIndexes:          {},
+		utils.CacheTaxProfiles:               {},
 		utils.CacheDispatcherFilterIndexes:   {},
 		utils.CacheDispatcherProfiles:        {},
 		utils.CacheDispatcherHosts:           {},
@@ -512,6 +524,8 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheRateProfilesFilterIndexes: {},
 		utils.CacheRateFilterIndexes:         {},
 		utils.CacheTimings:                   {},
+		utils.CacheFxRates:                   {},
+		utils.CachePortedNumbers:             {},
 		utils.CacheDiameterMessages:          {},
 		utils.CacheClosedSessions:            {},
 		utils.CacheLoadIDs:                   {},
@@ -523,6 +537,9 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheReverseFilterIndexes:      {},
 
 		utils.CacheAccounts:              {},
+		utils.CacheProfileRevisions:      {},
+		utils.CacheStoredSessions:        {},
+		utils.CacheConfigSections:        {},
 		utils.CacheVersions:              {},
 		utils.CacheTBLTPTimings:          {},
 		utils.CacheTBLTPDestinations:     {},
@@ -541,11 +558,14 @@ func GetDefaultEmptyCacheStats() map[string]*ltcache.CacheStats {
 		utils.CacheTBLTPFilters:          {},
 		utils.CacheSessionCostsTBL:       {},
 		utils.CacheCDRsTBL:               {},
+		utils.CacheAuditRecordsTBL:       {},
 		utils.CacheTBLTPRoutes:           {},
 		utils.CacheTBLTPAttributes:       {},
 		utils.CacheTBLTPChargers:         {},
 		utils.CacheTBLTPDispatchers:      {},
 		utils.CacheTBLTPDispatcherHosts:  {},
 		utils.CacheTBLTPRateProfiles:     {},
+		utils.CacheTBLTPFxRates:          {},
+		utils.CacheTBLTPPortedNumbers:    {},
 	}
 }
@@ -42,7 +42,8 @@ func init() {
 		ActionsCSVContent, ActionPlansCSVContent, ActionTriggersCSVContent, AccountActionsCSVContent,
 		ResourcesCSVContent, StatsCSVContent, ThresholdsCSVContent, FiltersCSVContent,
 		RoutesCSVContent, AttributesCSVContent, ChargersCSVContent, DispatcherCSVContent,
-		DispatcherHostCSVContent, RateProfileCSVContent), testTPID, "", nil, nil, false)
+		DispatcherHostCSVContent, RateProfileCSVContent, FxRatesCSVContent,
+		PortedNumbersCSVContent), testTPID, "", nil, nil, false)
 	if err != nil {
 		log.Print("error when creating TpReader:", err)
 	}
@@ -52,6 +53,12 @@ func init() {
 	if err := csvr.LoadTimings(); err != nil {
 		log.Print("error in LoadTimings:", err)
 	}
+	if err := csvr.LoadFxRates(); err != nil {
+		log.Print("error in LoadFxRates:", err)
+	}
+	if err := csvr.LoadPortedNumbers(); err != nil {
+		log.Print("error in LoadPortedNumbers:", err)
+	}
 	if err := csvr.LoadRates(); err != nil {
 		log.Print("error in LoadRates:", err)
 	}
@@ -234,6 +241,26 @@ func TestLoadTimimgs(t *testing.T) {
 	}
 }
 
+func TestLoadFxRates(t *testing.T) {
+	eFxRates := map[string]*FxRate{
+		"USD:EUR": {FromCurrency: "USD", ToCurrency: "EUR", Rate: 0.85},
+		"EUR:RON": {FromCurrency: "EUR", ToCurrency: "RON", Rate: 4.87},
+	}
+	if !reflect.DeepEqual(eFxRates, csvr.fxRates) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(eFxRates), utils.ToJSON(csvr.fxRates))
+	}
+}
+
+func TestLoadPortedNumbers(t *testing.T) {
+	ePortedNumbers := map[string]*PortedNumber{
+		"40721000000-40721009999": {Number: "40721000000-40721009999", LRN: "40299", Network: "NET_B"},
+		"40721000123":             {Number: "40721000123", LRN: "40288", Network: "NET_C"},
+	}
+	if !reflect.DeepEqual(ePortedNumbers, csvr.portedNumbers) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(ePortedNumbers), utils.ToJSON(csvr.portedNumbers))
+	}
+}
+
 func TestLoadRates(t *testing.T) {
 	if len(csvr.rates) != 15 {
 		t.Error("Failed to load rates: ", len(csvr.rates))
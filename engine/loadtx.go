@@ -0,0 +1,514 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewLoadTransaction constructs a LoadTransaction over the DataManager
+func NewLoadTransaction(dm *DataManager) *LoadTransaction {
+	return &LoadTransaction{
+		dm:      dm,
+		keys:    make(utils.StringSet),
+		rebuilt: make(utils.StringSet),
+	}
+}
+
+// LoadTransaction groups the writes of one load so they can be undone on failure
+// since the DataDBs do not offer transactions, the state of each item before the load
+// is kept in an undo log which is replayed backwards on Rollback
+type LoadTransaction struct {
+	dm      *DataManager
+	undos   []*loadUndo     // in the order of writing
+	keys    utils.StringSet // items with the previous state already kept
+	applied []string        // items written, as DataDB keys
+	tasks   []*Task         // pushed only on Commit since they can not be undone
+	rebuilt utils.StringSet // reverse prefixes rebuilt during the load
+	lkRefs  []string        // guardian references of the accounts locked till the end of the load
+}
+
+// loadUndo restores one item to the state before the load
+type loadUndo struct {
+	key  string
+	undo func() error // nil if the item did not exist and was not created
+}
+
+// apply writes the item and keeps the previous state in the undo log
+// getErr is the error received when reading the previous state
+func (tx *LoadTransaction) apply(key string, getErr error,
+	restore, remove, write func() error) (err error) {
+	if getErr != nil && getErr != utils.ErrNotFound {
+		return getErr
+	}
+	if !tx.keys.Has(key) { // only the state before the load is interesting
+		undo := restore
+		if getErr == utils.ErrNotFound {
+			undo = remove
+		}
+		tx.keys.Add(key)
+		tx.undos = append(tx.undos, &loadUndo{key: key, undo: undo})
+	}
+	if err = write(); err != nil {
+		return
+	}
+	tx.applied = append(tx.applied, key)
+	return
+}
+
+// Applied returns the keys of the items written so far
+func (tx *LoadTransaction) Applied() []string {
+	return tx.applied
+}
+
+// PushTask queues the task to be pushed on Commit
+func (tx *LoadTransaction) PushTask(t *Task) {
+	tx.tasks = append(tx.tasks, t)
+}
+
+// RebuildReverseForPrefix rebuilds the reverse indexes, they are rebuilt again on Rollback
+func (tx *LoadTransaction) RebuildReverseForPrefix(prefix string) (err error) {
+	tx.rebuilt.Add(prefix)
+	return tx.dm.DataDB().RebuildReverseForPrefix(prefix)
+}
+
+// Commit pushes the queued tasks, the items are already written
+// the tasks pushed can not be undone so on failure they are listed in the error
+// and the transaction is kept for Rollback
+func (tx *LoadTransaction) Commit() (err error) {
+	var pushed []string
+	for len(tx.tasks) != 0 {
+		if err = tx.dm.DataDB().PushTask(tx.tasks[0]); err != nil {
+			if len(pushed) != 0 {
+				err = fmt.Errorf("%s, pushed tasks: %s", err.Error(), strings.Join(pushed, utils.FIELDS_SEP))
+			}
+			return
+		}
+		pushed = append(pushed, tx.tasks[0].Uuid)
+		tx.applied = append(tx.applied, utils.TASKS_KEY+utils.CONCATENATED_KEY_SEP+tx.tasks[0].Uuid)
+		tx.tasks = tx.tasks[1:]
+	}
+	tx.undos = nil
+	tx.keys = make(utils.StringSet)
+	tx.rebuilt = make(utils.StringSet)
+	tx.unlockAccounts()
+	return
+}
+
+// unlockAccounts releases the accounts locked by SetAccount
+func (tx *LoadTransaction) unlockAccounts() {
+	for _, refID := range tx.lkRefs {
+		guardian.Guardian.UnguardIDs(refID)
+	}
+	tx.lkRefs = nil
+}
+
+// Rollback restores the items written in reverse order
+// all the items are restored even if some of them fail, the failed ones are returned in the error
+func (tx *LoadTransaction) Rollback() (err error) {
+	var failed []string
+	for i := len(tx.undos) - 1; i >= 0; i-- {
+		if tx.undos[i].undo == nil {
+			continue
+		}
+		if errUndo := tx.undos[i].undo(); errUndo != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed restoring <%s> on rollback, error: %s",
+					utils.LoaderS, tx.undos[i].key, errUndo.Error()))
+			failed = append(failed, tx.undos[i].key)
+		}
+	}
+	for prefix := range tx.rebuilt {
+		if errRbld := tx.dm.DataDB().RebuildReverseForPrefix(prefix); errRbld != nil {
+			failed = append(failed, prefix)
+		}
+	}
+	tx.undos = nil
+	tx.keys = make(utils.StringSet)
+	tx.applied = nil
+	tx.tasks = nil
+	tx.rebuilt = make(utils.StringSet)
+	tx.unlockAccounts()
+	if len(failed) != 0 {
+		return fmt.Errorf("failed to restore: %s", strings.Join(failed, utils.FIELDS_SEP))
+	}
+	return
+}
+
+// SetDestination writes the destination keeping the previous one for rollback
+func (tx *LoadTransaction) SetDestination(dest *Destination) (err error) {
+	prev, err := tx.dm.GetDestination(dest.Id, true, utils.NonTransactional)
+	return tx.apply(utils.DESTINATION_PREFIX+dest.Id, err,
+		func() error { return tx.dm.SetDestination(prev, utils.NonTransactional) },
+		func() error { return tx.dm.RemoveDestination(dest.Id, utils.NonTransactional) },
+		func() error { return tx.dm.SetDestination(dest, utils.NonTransactional) })
+}
+
+// SetRatingPlan writes the rating plan keeping the previous one for rollback
+func (tx *LoadTransaction) SetRatingPlan(rp *RatingPlan) (err error) {
+	prev, err := tx.dm.GetRatingPlan(rp.Id, true, utils.NonTransactional)
+	return tx.apply(utils.RATING_PLAN_PREFIX+rp.Id, err,
+		func() error { return tx.dm.SetRatingPlan(prev, utils.NonTransactional) },
+		func() error { return tx.dm.RemoveRatingPlan(rp.Id, utils.NonTransactional) },
+		func() error { return tx.dm.SetRatingPlan(rp, utils.NonTransactional) })
+}
+
+// SetRatingProfile writes the rating profile keeping the previous one for rollback
+func (tx *LoadTransaction) SetRatingProfile(rpf *RatingProfile) (err error) {
+	prev, err := tx.dm.GetRatingProfile(rpf.Id, true, utils.NonTransactional)
+	return tx.apply(utils.RATING_PROFILE_PREFIX+rpf.Id, err,
+		func() error { return tx.dm.SetRatingProfile(prev, utils.NonTransactional) },
+		func() error { return tx.dm.RemoveRatingProfile(rpf.Id, utils.NonTransactional) },
+		func() error { return tx.dm.SetRatingProfile(rpf, utils.NonTransactional) })
+}
+
+// SetActionPlan writes the action plan keeping the previous one for rollback
+func (tx *LoadTransaction) SetActionPlan(key string, ap *ActionPlan, overwrite bool) (err error) {
+	prev, err := tx.dm.GetActionPlan(key, true, utils.NonTransactional)
+	return tx.apply(utils.ACTION_PLAN_PREFIX+key, err,
+		func() error { return tx.dm.SetActionPlan(key, prev, true, utils.NonTransactional) },
+		func() error { return tx.dm.RemoveActionPlan(key, utils.NonTransactional) },
+		func() error { return tx.dm.SetActionPlan(key, ap, overwrite, utils.NonTransactional) })
+}
+
+// SetActionTriggers writes the action triggers keeping the previous ones for rollback
+func (tx *LoadTransaction) SetActionTriggers(key string, atrs ActionTriggers) (err error) {
+	prev, err := tx.dm.GetActionTriggers(key, true, utils.NonTransactional)
+	return tx.apply(utils.ACTION_TRIGGER_PREFIX+key, err,
+		func() error { return tx.dm.SetActionTriggers(key, prev, utils.NonTransactional) },
+		func() error { return tx.dm.RemoveActionTriggers(key, utils.NonTransactional) },
+		func() error { return tx.dm.SetActionTriggers(key, atrs, utils.NonTransactional) })
+}
+
+// SetSharedGroup writes the shared group keeping the previous one for rollback
+func (tx *LoadTransaction) SetSharedGroup(sg *SharedGroup) (err error) {
+	prev, err := tx.dm.GetSharedGroup(sg.Id, true, utils.NonTransactional)
+	return tx.apply(utils.SHARED_GROUP_PREFIX+sg.Id, err,
+		func() error { return tx.dm.SetSharedGroup(prev, utils.NonTransactional) },
+		func() error { return tx.dm.RemoveSharedGroup(sg.Id, utils.NonTransactional) },
+		func() error { return tx.dm.SetSharedGroup(sg, utils.NonTransactional) })
+}
+
+// SetActions writes the actions keeping the previous ones for rollback
+func (tx *LoadTransaction) SetActions(key string, as Actions) (err error) {
+	prev, err := tx.dm.GetActions(key, true, utils.NonTransactional)
+	return tx.apply(utils.ACTION_PREFIX+key, err,
+		func() error { return tx.dm.SetActions(key, prev, utils.NonTransactional) },
+		func() error { return tx.dm.RemoveActions(key, utils.NonTransactional) },
+		func() error { return tx.dm.SetActions(key, as, utils.NonTransactional) })
+}
+
+// SetAccount writes the account keeping the previous one for rollback
+// the account stays locked till Commit or Rollback so the debits are not overwritten by the restore
+func (tx *LoadTransaction) SetAccount(acc *Account) (err error) {
+	key := utils.ACCOUNT_PREFIX + acc.ID
+	var refID string
+	if !tx.keys.Has(key) {
+		refID = guardian.Guardian.GuardIDs(utils.EmptyString,
+			config.CgrConfig().GeneralCfg().LockingTimeout, key)
+	}
+	prev, err := tx.dm.GetAccount(acc.ID)
+	if err != nil && err != utils.ErrNotFound {
+		guardian.Guardian.UnguardIDs(refID)
+		return
+	}
+	if refID != utils.EmptyString {
+		tx.lkRefs = append(tx.lkRefs, refID)
+	}
+	return tx.apply(key, err,
+		func() error { return tx.dm.SetAccount(prev) },
+		func() error { return tx.dm.RemoveAccount(acc.ID) },
+		func() error { return tx.dm.SetAccount(acc) })
+}
+
+// SetTiming writes the timing keeping the previous one for rollback
+func (tx *LoadTransaction) SetTiming(t *utils.TPTiming) (err error) {
+	prev, err := tx.dm.GetTiming(t.ID, true, utils.NonTransactional)
+	return tx.apply(utils.TimingsPrefix+t.ID, err,
+		func() error { return tx.dm.SetTiming(prev) },
+		func() error { return tx.dm.RemoveTiming(t.ID, utils.NonTransactional) },
+		func() error { return tx.dm.SetTiming(t) })
+}
+
+// SetFxRate writes the exchange rate keeping the previous one for rollback
+func (tx *LoadTransaction) SetFxRate(fx *FxRate) (err error) {
+	prev, err := tx.dm.GetFxRate(fx.ID(), true, utils.NonTransactional)
+	return tx.apply(utils.FxRatesPrefix+fx.ID(), err,
+		func() error { return tx.dm.SetFxRate(prev) },
+		func() error { return tx.dm.RemoveFxRate(fx.ID(), utils.NonTransactional) },
+		func() error { return tx.dm.SetFxRate(fx) })
+}
+
+// SetPortedNumber writes the portability entry keeping the previous one for rollback
+func (tx *LoadTransaction) SetPortedNumber(pn *PortedNumber) (err error) {
+	prev, err := tx.dm.GetPortedNumber(pn.ID(), true, utils.NonTransactional)
+	return tx.apply(utils.PortedNumbersPrefix+pn.ID(), err,
+		func() error { return tx.dm.SetPortedNumber(prev) },
+		func() error { return tx.dm.RemovePortedNumber(pn.ID(), utils.NonTransactional) },
+		func() error { return tx.dm.SetPortedNumber(pn) })
+}
+
+// SetFilter writes the filter keeping the previous one for rollback
+func (tx *LoadTransaction) SetFilter(fltr *Filter, withIndex bool) (err error) {
+	prev, err := tx.dm.GetFilter(fltr.Tenant, fltr.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.FilterPrefix+fltr.TenantID(), err,
+		func() error { return tx.dm.SetFilter(prev, withIndex) },
+		func() error { return tx.dm.RemoveFilter(fltr.Tenant, fltr.ID, utils.NonTransactional, withIndex) },
+		func() error { return tx.dm.SetFilter(fltr, withIndex) })
+}
+
+// RemoveFilter removes the filter keeping it for rollback
+func (tx *LoadTransaction) RemoveFilter(tenant, id string, withIndex bool) (err error) {
+	prev, err := tx.dm.GetFilter(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.FilterPrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetFilter(prev, withIndex) }, nil,
+		func() error { return tx.dm.RemoveFilter(tenant, id, utils.NonTransactional, withIndex) })
+}
+
+// SetResourceProfile writes the profile keeping the previous one for rollback
+func (tx *LoadTransaction) SetResourceProfile(rp *ResourceProfile, withIndex bool) (err error) {
+	prev, err := tx.dm.GetResourceProfile(rp.Tenant, rp.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.ResourceProfilesPrefix+rp.TenantID(), err,
+		func() error { return tx.dm.SetResourceProfile(prev, withIndex) },
+		func() error { return tx.dm.RemoveResourceProfile(rp.Tenant, rp.ID, utils.NonTransactional, withIndex) },
+		func() error { return tx.dm.SetResourceProfile(rp, withIndex) })
+}
+
+// RemoveResourceProfile removes the profile keeping it for rollback
+func (tx *LoadTransaction) RemoveResourceProfile(tenant, id string, withIndex bool) (err error) {
+	prev, err := tx.dm.GetResourceProfile(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.ResourceProfilesPrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetResourceProfile(prev, withIndex) }, nil,
+		func() error { return tx.dm.RemoveResourceProfile(tenant, id, utils.NonTransactional, withIndex) })
+}
+
+// SetResource writes the resource keeping the previous one for rollback
+func (tx *LoadTransaction) SetResource(rs *Resource) (err error) {
+	prev, err := tx.dm.GetResource(rs.Tenant, rs.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.ResourcesPrefix+rs.TenantID(), err,
+		func() error { return tx.dm.SetResource(prev) },
+		func() error { return tx.dm.RemoveResource(rs.Tenant, rs.ID, utils.NonTransactional) },
+		func() error { return tx.dm.SetResource(rs) })
+}
+
+// RemoveResource removes the resource keeping it for rollback
+func (tx *LoadTransaction) RemoveResource(tenant, id string) (err error) {
+	prev, err := tx.dm.GetResource(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.ResourcesPrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetResource(prev) }, nil,
+		func() error { return tx.dm.RemoveResource(tenant, id, utils.NonTransactional) })
+}
+
+// SetStatQueueProfile writes the profile keeping the previous one for rollback
+func (tx *LoadTransaction) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool) (err error) {
+	prev, err := tx.dm.GetStatQueueProfile(sqp.Tenant, sqp.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.StatQueueProfilePrefix+sqp.TenantID(), err,
+		func() error { return tx.dm.SetStatQueueProfile(prev, withIndex) },
+		func() error {
+			return tx.dm.RemoveStatQueueProfile(sqp.Tenant, sqp.ID, utils.NonTransactional, withIndex)
+		},
+		func() error { return tx.dm.SetStatQueueProfile(sqp, withIndex) })
+}
+
+// RemoveStatQueueProfile removes the profile keeping it for rollback
+func (tx *LoadTransaction) RemoveStatQueueProfile(tenant, id string, withIndex bool) (err error) {
+	prev, err := tx.dm.GetStatQueueProfile(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.StatQueueProfilePrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetStatQueueProfile(prev, withIndex) }, nil,
+		func() error { return tx.dm.RemoveStatQueueProfile(tenant, id, utils.NonTransactional, withIndex) })
+}
+
+// SetStatQueue writes the queue keeping the previous one for rollback
+func (tx *LoadTransaction) SetStatQueue(sq *StatQueue) (err error) {
+	prev, err := tx.dm.GetStatQueue(sq.Tenant, sq.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.StatQueuePrefix+sq.TenantID(), err,
+		func() error { return tx.dm.SetStatQueue(prev) },
+		func() error { return tx.dm.RemoveStatQueue(sq.Tenant, sq.ID, utils.NonTransactional) },
+		func() error { return tx.dm.SetStatQueue(sq) })
+}
+
+// RemoveStatQueue removes the queue keeping it for rollback
+func (tx *LoadTransaction) RemoveStatQueue(tenant, id string) (err error) {
+	prev, err := tx.dm.GetStatQueue(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.StatQueuePrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetStatQueue(prev) }, nil,
+		func() error { return tx.dm.RemoveStatQueue(tenant, id, utils.NonTransactional) })
+}
+
+// SetThresholdProfile writes the profile keeping the previous one for rollback
+func (tx *LoadTransaction) SetThresholdProfile(th *ThresholdProfile, withIndex bool) (err error) {
+	prev, err := tx.dm.GetThresholdProfile(th.Tenant, th.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.ThresholdProfilePrefix+th.TenantID(), err,
+		func() error { return tx.dm.SetThresholdProfile(prev, withIndex) },
+		func() error { return tx.dm.RemoveThresholdProfile(th.Tenant, th.ID, utils.NonTransactional, withIndex) },
+		func() error { return tx.dm.SetThresholdProfile(th, withIndex) })
+}
+
+// RemoveThresholdProfile removes the profile keeping it for rollback
+func (tx *LoadTransaction) RemoveThresholdProfile(tenant, id string, withIndex bool) (err error) {
+	prev, err := tx.dm.GetThresholdProfile(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.ThresholdProfilePrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetThresholdProfile(prev, withIndex) }, nil,
+		func() error { return tx.dm.RemoveThresholdProfile(tenant, id, utils.NonTransactional, withIndex) })
+}
+
+// SetThreshold writes the threshold keeping the previous one for rollback
+func (tx *LoadTransaction) SetThreshold(th *Threshold) (err error) {
+	prev, err := tx.dm.GetThreshold(th.Tenant, th.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.ThresholdPrefix+th.TenantID(), err,
+		func() error { return tx.dm.SetThreshold(prev) },
+		func() error { return tx.dm.RemoveThreshold(th.Tenant, th.ID, utils.NonTransactional) },
+		func() error { return tx.dm.SetThreshold(th) })
+}
+
+// RemoveThreshold removes the threshold keeping it for rollback
+func (tx *LoadTransaction) RemoveThreshold(tenant, id string) (err error) {
+	prev, err := tx.dm.GetThreshold(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.ThresholdPrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetThreshold(prev) }, nil,
+		func() error { return tx.dm.RemoveThreshold(tenant, id, utils.NonTransactional) })
+}
+
+// SetRouteProfile writes the profile keeping the previous one for rollback
+func (tx *LoadTransaction) SetRouteProfile(rpp *RouteProfile, withIndex bool) (err error) {
+	prev, err := tx.dm.GetRouteProfile(rpp.Tenant, rpp.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.RouteProfilePrefix+rpp.TenantID(), err,
+		func() error { return tx.dm.SetRouteProfile(prev, withIndex) },
+		func() error { return tx.dm.RemoveRouteProfile(rpp.Tenant, rpp.ID, utils.NonTransactional, withIndex) },
+		func() error { return tx.dm.SetRouteProfile(rpp, withIndex) })
+}
+
+// RemoveRouteProfile removes the profile keeping it for rollback
+func (tx *LoadTransaction) RemoveRouteProfile(tenant, id string, withIndex bool) (err error) {
+	prev, err := tx.dm.GetRouteProfile(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.RouteProfilePrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetRouteProfile(prev, withIndex) }, nil,
+		func() error { return tx.dm.RemoveRouteProfile(tenant, id, utils.NonTransactional, withIndex) })
+}
+
+// SetAttributeProfile writes the profile keeping the previous one for rollback
+func (tx *LoadTransaction) SetAttributeProfile(ap *AttributeProfile, withIndex bool) (err error) {
+	prev, err := tx.dm.GetAttributeProfile(ap.Tenant, ap.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.AttributeProfilePrefix+ap.TenantID(), err,
+		func() error { return tx.dm.SetAttributeProfile(prev, withIndex) },
+		func() error { return tx.dm.RemoveAttributeProfile(ap.Tenant, ap.ID, utils.NonTransactional, withIndex) },
+		func() error { return tx.dm.SetAttributeProfile(ap, withIndex) })
+}
+
+// RemoveAttributeProfile removes the profile keeping it for rollback
+func (tx *LoadTransaction) RemoveAttributeProfile(tenant, id string, withIndex bool) (err error) {
+	prev, err := tx.dm.GetAttributeProfile(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.AttributeProfilePrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetAttributeProfile(prev, withIndex) }, nil,
+		func() error { return tx.dm.RemoveAttributeProfile(tenant, id, utils.NonTransactional, withIndex) })
+}
+
+// SetChargerProfile writes the profile keeping the previous one for rollback
+func (tx *LoadTransaction) SetChargerProfile(cpp *ChargerProfile, withIndex bool) (err error) {
+	prev, err := tx.dm.GetChargerProfile(cpp.Tenant, cpp.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.ChargerProfilePrefix+cpp.TenantID(), err,
+		func() error { return tx.dm.SetChargerProfile(prev, withIndex) },
+		func() error { return tx.dm.RemoveChargerProfile(cpp.Tenant, cpp.ID, utils.NonTransactional, withIndex) },
+		func() error { return tx.dm.SetChargerProfile(cpp, withIndex) })
+}
+
+// RemoveChargerProfile removes the profile keeping it for rollback
+func (tx *LoadTransaction) RemoveChargerProfile(tenant, id string, withIndex bool) (err error) {
+	prev, err := tx.dm.GetChargerProfile(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.ChargerProfilePrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetChargerProfile(prev, withIndex) }, nil,
+		func() error { return tx.dm.RemoveChargerProfile(tenant, id, utils.NonTransactional, withIndex) })
+}
+
+// SetDispatcherProfile writes the profile keeping the previous one for rollback
+func (tx *LoadTransaction) SetDispatcherProfile(dpp *DispatcherProfile, withIndex bool) (err error) {
+	prev, err := tx.dm.GetDispatcherProfile(dpp.Tenant, dpp.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.DispatcherProfilePrefix+dpp.TenantID(), err,
+		func() error { return tx.dm.SetDispatcherProfile(prev, withIndex) },
+		func() error {
+			return tx.dm.RemoveDispatcherProfile(dpp.Tenant, dpp.ID, utils.NonTransactional, withIndex)
+		},
+		func() error { return tx.dm.SetDispatcherProfile(dpp, withIndex) })
+}
+
+// RemoveDispatcherProfile removes the profile keeping it for rollback
+func (tx *LoadTransaction) RemoveDispatcherProfile(tenant, id string, withIndex bool) (err error) {
+	prev, err := tx.dm.GetDispatcherProfile(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.DispatcherProfilePrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetDispatcherProfile(prev, withIndex) }, nil,
+		func() error { return tx.dm.RemoveDispatcherProfile(tenant, id, utils.NonTransactional, withIndex) })
+}
+
+// SetDispatcherHost writes the host keeping the previous one for rollback
+func (tx *LoadTransaction) SetDispatcherHost(dpp *DispatcherHost) (err error) {
+	prev, err := tx.dm.GetDispatcherHost(dpp.Tenant, dpp.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.DispatcherHostPrefix+dpp.TenantID(), err,
+		func() error { return tx.dm.SetDispatcherHost(prev) },
+		func() error { return tx.dm.RemoveDispatcherHost(dpp.Tenant, dpp.ID, utils.NonTransactional) },
+		func() error { return tx.dm.SetDispatcherHost(dpp) })
+}
+
+// RemoveDispatcherHost removes the host keeping it for rollback
+func (tx *LoadTransaction) RemoveDispatcherHost(tenant, id string) (err error) {
+	prev, err := tx.dm.GetDispatcherHost(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.DispatcherHostPrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetDispatcherHost(prev) }, nil,
+		func() error { return tx.dm.RemoveDispatcherHost(tenant, id, utils.NonTransactional) })
+}
+
+// SetRateProfile writes the profile keeping the previous one for rollback
+func (tx *LoadTransaction) SetRateProfile(rpp *RateProfile, withIndex bool) (err error) {
+	return tx.setRateProfile(rpp, withIndex,
+		func() error { return tx.dm.SetRateProfile(rpp, withIndex) })
+}
+
+// SetRateProfileRates merges the rates into the profile keeping the previous one for rollback
+func (tx *LoadTransaction) SetRateProfileRates(rpp *RateProfile, withIndex bool) (err error) {
+	return tx.setRateProfile(rpp, withIndex,
+		func() error { return tx.dm.SetRateProfileRates(rpp, withIndex) })
+}
+
+func (tx *LoadTransaction) setRateProfile(rpp *RateProfile, withIndex bool, write func() error) (err error) {
+	prev, err := tx.dm.GetRateProfile(rpp.Tenant, rpp.ID, false, false, utils.NonTransactional)
+	return tx.apply(utils.RateProfilePrefix+rpp.TenantID(), err,
+		func() error { return tx.dm.SetRateProfile(prev, withIndex) },
+		func() error { return tx.dm.RemoveRateProfile(rpp.Tenant, rpp.ID, utils.NonTransactional, withIndex) },
+		write)
+}
+
+// RemoveRateProfile removes the profile keeping it for rollback
+func (tx *LoadTransaction) RemoveRateProfile(tenant, id string, withIndex bool) (err error) {
+	prev, err := tx.dm.GetRateProfile(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.RateProfilePrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetRateProfile(prev, withIndex) }, nil,
+		func() error { return tx.dm.RemoveRateProfile(tenant, id, utils.NonTransactional, withIndex) })
+}
+
+// RemoveRateProfileRates removes the rates out of the profile keeping it for rollback
+func (tx *LoadTransaction) RemoveRateProfileRates(tenant, id string, rateIDs []string, withIndex bool) (err error) {
+	prev, err := tx.dm.GetRateProfile(tenant, id, false, false, utils.NonTransactional)
+	return tx.apply(utils.RateProfilePrefix+utils.ConcatenatedKey(tenant, id), err,
+		func() error { return tx.dm.SetRateProfile(prev, withIndex) }, nil,
+		func() error { return tx.dm.RemoveRateProfileRates(tenant, id, rateIDs, withIndex) })
+}
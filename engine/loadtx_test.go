@@ -0,0 +1,166 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestLoadTransactionRollback(t *testing.T) {
+	dm := NewDataManager(NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items),
+		config.CgrConfig().CacheCfg(), nil)
+	prevAttr := &AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ATTR_TX",
+		Contexts:  []string{utils.META_ANY},
+		FilterIDs: []string{},
+		Attributes: []*Attribute{{
+			Path:  utils.MetaReq + utils.NestingSep + "Field1",
+			Value: config.NewRSRParsersMustCompile("Initial", utils.INFIELD_SEP),
+		}},
+		Weight: 10,
+	}
+	if err := dm.SetAttributeProfile(prevAttr, true); err != nil {
+		t.Fatal(err)
+	}
+	tx := NewLoadTransaction(dm)
+	if err := tx.SetAttributeProfile(&AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ATTR_TX",
+		Contexts:  []string{utils.META_ANY},
+		FilterIDs: []string{},
+		Attributes: []*Attribute{{
+			Path:  utils.MetaReq + utils.NestingSep + "Field1",
+			Value: config.NewRSRParsersMustCompile("Changed", utils.INFIELD_SEP),
+		}},
+		Weight: 20,
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.SetDestination(&Destination{Id: "DST_TX", Prefixes: []string{"+49"}}); err != nil {
+		t.Fatal(err)
+	}
+	tx.PushTask(&Task{Uuid: "TASK_TX", ActionsID: "ACT_TX"})
+	eApplied := []string{
+		utils.AttributeProfilePrefix + "cgrates.org:ATTR_TX",
+		utils.DESTINATION_PREFIX + "DST_TX",
+	}
+	if rcv := tx.Applied(); !reflect.DeepEqual(eApplied, rcv) {
+		t.Errorf("expecting: %+v, received: %+v", eApplied, rcv)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.GetAttributeProfile("cgrates.org", "ATTR_TX",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if rcv.Weight != 10 {
+		t.Errorf("expecting the previous profile, received: %s", utils.ToJSON(rcv))
+	}
+	if _, err := dm.GetDestination("DST_TX", true, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if rcv := tx.Applied(); len(rcv) != 0 {
+		t.Errorf("unexpected applied items: %+v", rcv)
+	}
+	// the tasks are pushed only on commit
+	if task, err := dm.DataDB().PopTask(); err != utils.ErrNotFound {
+		t.Errorf("unexpected task: %+v, err: %v", task, err)
+	}
+	// the profile matches any event so do not leak it to the other tests
+	if err := dm.RemoveAttributeProfile("cgrates.org", "ATTR_TX",
+		utils.NonTransactional, true); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoadTransactionCommit(t *testing.T) {
+	dm := NewDataManager(NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items),
+		config.CgrConfig().CacheCfg(), nil)
+	tx := NewLoadTransaction(dm)
+	if err := tx.SetDestination(&Destination{Id: "DST_TX", Prefixes: []string{"+49"}}); err != nil {
+		t.Fatal(err)
+	}
+	tx.PushTask(&Task{Uuid: "TASK_TX", ActionsID: "ACT_TX"})
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	eApplied := []string{
+		utils.DESTINATION_PREFIX + "DST_TX",
+		utils.TASKS_KEY + utils.CONCATENATED_KEY_SEP + "TASK_TX",
+	}
+	if rcv := tx.Applied(); !reflect.DeepEqual(eApplied, rcv) {
+		t.Errorf("expecting: %+v, received: %+v", eApplied, rcv)
+	}
+	if task, err := dm.DataDB().PopTask(); err != nil {
+		t.Error(err)
+	} else if task.Uuid != "TASK_TX" {
+		t.Errorf("unexpected task: %+v", task)
+	}
+	// nothing left to undo after commit
+	if err := tx.Rollback(); err != nil {
+		t.Error(err)
+	}
+	if _, err := dm.GetDestination("DST_TX", true, utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+	if err := dm.RemoveDestination("DST_TX", utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestLoadTransactionAccountLock(t *testing.T) {
+	dm := NewDataManager(NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items),
+		config.CgrConfig().CacheCfg(), nil)
+	tx := NewLoadTransaction(dm)
+	if err := tx.SetAccount(&Account{ID: "cgrates.org:ACC_TX"}); err != nil {
+		t.Fatal(err)
+	}
+	// writing the same account again does not lock it twice
+	if err := tx.SetAccount(&Account{ID: "cgrates.org:ACC_TX", Disabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	locked := make(chan struct{})
+	go guardian.Guardian.Guard(func() (interface{}, error) {
+		close(locked)
+		return nil, nil
+	}, 0, utils.ACCOUNT_PREFIX+"cgrates.org:ACC_TX")
+	select {
+	case <-locked:
+		t.Fatal("the account should be locked till the end of the load")
+	case <-time.After(20 * time.Millisecond):
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-locked:
+	case <-time.After(time.Second):
+		t.Fatal("the account was not unlocked on rollback")
+	}
+	if _, err := dm.GetAccount("cgrates.org:ACC_TX"); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
@@ -261,6 +261,70 @@ func APItoModelTimings(ts []*utils.ApierTPTiming) (result TpTimings) {
 	return result
 }
 
+type TpFxRates []TpFxRate
+
+func (tps TpFxRates) AsTPFxRates() (result []*utils.TPFxRate) {
+	for _, tp := range tps {
+		result = append(result, &utils.TPFxRate{
+			TPid:         tp.Tpid,
+			FromCurrency: tp.FromCurrency,
+			ToCurrency:   tp.ToCurrency,
+			Rate:         tp.Rate,
+		})
+	}
+	return
+}
+
+func APItoModelFxRate(fx *utils.TPFxRate) TpFxRate {
+	return TpFxRate{
+		Tpid:         fx.TPid,
+		FromCurrency: fx.FromCurrency,
+		ToCurrency:   fx.ToCurrency,
+		Rate:         fx.Rate,
+	}
+}
+
+func APItoModelFxRates(fxs []*utils.TPFxRate) (result TpFxRates) {
+	for _, fx := range fxs {
+		if fx != nil {
+			result = append(result, APItoModelFxRate(fx))
+		}
+	}
+	return
+}
+
+type TpPortedNumbers []TpPortedNumber
+
+func (tps TpPortedNumbers) AsTPPortedNumbers() (result []*utils.TPPortedNumber) {
+	for _, tp := range tps {
+		result = append(result, &utils.TPPortedNumber{
+			TPid:    tp.Tpid,
+			Number:  tp.Number,
+			LRN:     tp.Lrn,
+			Network: tp.Network,
+		})
+	}
+	return
+}
+
+func APItoModelPortedNumber(pn *utils.TPPortedNumber) TpPortedNumber {
+	return TpPortedNumber{
+		Tpid:    pn.TPid,
+		Number:  pn.Number,
+		Lrn:     pn.LRN,
+		Network: pn.Network,
+	}
+}
+
+func APItoModelPortedNumbers(pns []*utils.TPPortedNumber) (result TpPortedNumbers) {
+	for _, pn := range pns {
+		if pn != nil {
+			result = append(result, APItoModelPortedNumber(pn))
+		}
+	}
+	return
+}
+
 type TpRates []TpRate
 
 func (tps TpRates) AsMapRates() (map[string]*utils.TPRateRALs, error) {
@@ -361,6 +425,7 @@ func (tps TpDestinationRates) AsMapDestinationRates() (map[string]*utils.TPDesti
 					RoundingDecimals: tp.RoundingDecimals,
 					MaxCost:          tp.MaxCost,
 					MaxCostStrategy:  tp.MaxCostStrategy,
+					Currency:         tp.Currency,
 				},
 			},
 		}
@@ -410,6 +475,7 @@ func APItoModelDestinationRate(d *utils.TPDestinationRate) (result TpDestination
 				RoundingDecimals: dr.RoundingDecimals,
 				MaxCost:          dr.MaxCost,
 				MaxCostStrategy:  dr.MaxCostStrategy,
+				Currency:         dr.Currency,
 			})
 		}
 		if len(d.DestinationRates) == 0 {
@@ -485,6 +551,7 @@ func GetRateInterval(rpl *utils.TPRatingPlanBinding, dr *utils.DestinationRate)
 			RoundingDecimals: dr.RoundingDecimals,
 			MaxCost:          dr.MaxCost,
 			MaxCostStrategy:  dr.MaxCostStrategy,
+			Currency:         dr.Currency,
 			tag:              dr.Rate.ID,
 		},
 	}
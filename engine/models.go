@@ -41,6 +41,24 @@ type TpTiming struct {
 	CreatedAt time.Time
 }
 
+type TpFxRate struct {
+	Id           int64
+	Tpid         string
+	FromCurrency string  `index:"0" re:"\w+"`
+	ToCurrency   string  `index:"1" re:"\w+"`
+	Rate         float64 `index:"2" re:"\d+\.*\d*"`
+	CreatedAt    time.Time
+}
+
+type TpPortedNumber struct {
+	Id        int64
+	Tpid      string
+	Number    string `index:"0" re:"\+?\d+(-\+?\d+)?"`
+	Lrn       string `index:"1" re:"\+?\d+"`
+	Network   string `index:"2" re:".*"`
+	CreatedAt time.Time
+}
+
 type TpDestination struct {
 	Id        int64
 	Tpid      string
@@ -71,6 +89,7 @@ type TpDestinationRate struct {
 	RoundingDecimals int     `index:"4" re:"\d+"`
 	MaxCost          float64 `index:"5" re:"\d+\.*\d*s*"`
 	MaxCostStrategy  string  `index:"6" re:"\*free|\*disconnect"`
+	Currency         string
 	CreatedAt        time.Time
 }
 
@@ -338,6 +357,23 @@ func (t SessionCostsSQL) TableName() string {
 	return utils.SessionCostsTBL
 }
 
+type AuditRecordSQL struct {
+	ID        int64
+	AuditID   string
+	Tenant    string
+	ItemType  string
+	ItemID    string
+	Method    string
+	Caller    string
+	Changes   string
+	Error     string
+	CreatedAt time.Time
+}
+
+func (AuditRecordSQL) TableName() string {
+	return utils.AuditRecordsTBL
+}
+
 type TBLVersion struct {
 	ID      uint
 	Item    string
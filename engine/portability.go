@@ -0,0 +1,310 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+const portabilityRangeSep = '-' // separates the edges of the number range
+
+func init() {
+	utils.PortedNumberLookup = lookupPortedLRN
+}
+
+// lookupPortedLRN returns the routing number for the *mnp converter
+// the number is looked up on the apiers_conns of FilterS, same as the ~*mnp dynamic fields
+func lookupPortedLRN(number string) (lrn string, err error) {
+	var pn PortedNumber
+	if err = connMgr.Call(config.CgrConfig().FilterSCfg().ApierSConns, nil,
+		utils.APIerSv1LookupPortedNumber, &number, &pn); err != nil {
+		return
+	}
+	return pn.LRN, nil
+}
+
+// PortedNumber maps a ported number or a range of numbers to the routing number(LRN) of the owner network
+type PortedNumber struct {
+	Number  string // single number or range as 40721000000-40721009999
+	LRN     string // routing number
+	Network string // owner network
+}
+
+// ID returns the key of the PortedNumber
+func (pn *PortedNumber) ID() string {
+	return pn.Number
+}
+
+// NewPortedNumberFromTPPortedNumber converts the TP representation into PortedNumber
+func NewPortedNumberFromTPPortedNumber(tpPn *utils.TPPortedNumber) (pn *PortedNumber, err error) {
+	pn = &PortedNumber{
+		Number:  tpPn.Number,
+		LRN:     tpPn.LRN,
+		Network: tpPn.Network,
+	}
+	if _, _, err = portabilityPrefixes(pn.Number); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// portabilityPrefixes returns the prefixes covering the number or range together with the length of the matched numbers
+func portabilityPrefixes(number string) (prfxs []string, length int, err error) {
+	from, to := number, number
+	if idx := strings.IndexByte(number, portabilityRangeSep); idx != -1 {
+		from, to = number[:idx], number[idx+1:]
+	}
+	if !isPortableNumber(from) || !isPortableNumber(to) {
+		return nil, 0, fmt.Errorf("invalid number <%s>", number)
+	}
+	if len(from) != len(to) || from > to {
+		return nil, 0, fmt.Errorf("invalid number range <%s>", number)
+	}
+	return rangePrefixes(from, to), len(from), nil
+}
+
+// isPortableNumber checks the number contains only digits with an optional + in front
+func isPortableNumber(number string) bool {
+	number = strings.TrimPrefix(number, "+")
+	if len(number) == 0 {
+		return false
+	}
+	for i := 0; i < len(number); i++ {
+		if number[i] < '0' || number[i] > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// rangePrefixes returns the minimal set of prefixes covering all the numbers between from and to
+// eg: 40721000000-40721009999 gives 4072100
+func rangePrefixes(from, to string) (prfxs []string) {
+	var common int
+	for common < len(from) && from[common] == to[common] {
+		common++
+	}
+	if common == len(from) { // single number
+		return []string{from}
+	}
+	if strings.Trim(from[common:], "0") == "" &&
+		strings.Trim(to[common:], "9") == "" { // the whole range under the common prefix
+		return []string{from[:common]}
+	}
+	width := len(from) - common - 1
+	prfxs = rangePrefixes(from, from[:common+1]+strings.Repeat("9", width))
+	for d := from[common] + 1; d < to[common]; d++ {
+		prfxs = append(prfxs, from[:common]+string(d))
+	}
+	return append(prfxs, rangePrefixes(to[:common+1]+strings.Repeat("0", width), to)...)
+}
+
+// portabilityNode is a node of the portabilityTrie, one digit per level
+type portabilityNode struct {
+	children map[byte]*portabilityNode
+	ported   []*PortedNumber // entries covering the numbers with this prefix
+}
+
+// portabilityTrie indexes the PortedNumbers by prefix for fast lookups
+type portabilityTrie struct {
+	sync.RWMutex
+	root *portabilityNode
+}
+
+func newPortabilityTrie() *portabilityTrie {
+	return &portabilityTrie{root: new(portabilityNode)}
+}
+
+// set adds the PortedNumber replacing the previous one with the same ID
+func (pt *portabilityTrie) set(pn *PortedNumber) (err error) {
+	var prfxs []string
+	if prfxs, _, err = portabilityPrefixes(pn.Number); err != nil {
+		return
+	}
+	pt.Lock()
+	defer pt.Unlock()
+	pt.remove(prfxs, pn.Number)
+	for _, prfx := range prfxs {
+		node := pt.root
+		for i := 0; i < len(prfx); i++ {
+			child, has := node.children[prfx[i]]
+			if !has {
+				if node.children == nil {
+					node.children = make(map[byte]*portabilityNode)
+				}
+				child = new(portabilityNode)
+				node.children[prfx[i]] = child
+			}
+			node = child
+		}
+		node.ported = append(node.ported, pn)
+	}
+	return
+}
+
+// removeID removes the PortedNumber with the given ID
+func (pt *portabilityTrie) removeID(id string) {
+	prfxs, _, err := portabilityPrefixes(id)
+	if err != nil {
+		return
+	}
+	pt.Lock()
+	pt.remove(prfxs, id)
+	pt.Unlock()
+}
+
+// remove deletes the entries with the ID from the prefixes, pruning the empty nodes
+func (pt *portabilityTrie) remove(prfxs []string, id string) {
+	for _, prfx := range prfxs {
+		path := make([]*portabilityNode, 0, len(prfx)+1)
+		node := pt.root
+		for i := 0; node != nil && i < len(prfx); i++ {
+			path = append(path, node)
+			node = node.children[prfx[i]]
+		}
+		if node == nil {
+			continue
+		}
+		for i, pn := range node.ported {
+			if pn.Number == id {
+				node.ported = append(node.ported[:i], node.ported[i+1:]...)
+				break
+			}
+		}
+		for i := len(prfx) - 1; i >= 0 &&
+			len(node.ported) == 0 && len(node.children) == 0; i-- {
+			delete(path[i].children, prfx[i])
+			node = path[i]
+		}
+	}
+}
+
+// lookup returns the most specific PortedNumber covering the number
+func (pt *portabilityTrie) lookup(number string) (pn *PortedNumber) {
+	pt.RLock()
+	defer pt.RUnlock()
+	node := pt.root
+	for i := 0; node != nil && i <= len(number); i++ {
+		for _, ported := range node.ported {
+			if ported.length() == len(number) {
+				pn = ported
+			}
+		}
+		if i == len(number) {
+			break
+		}
+		node = node.children[number[i]]
+	}
+	return
+}
+
+// length returns the length of the numbers covered by the PortedNumber
+func (pn *PortedNumber) length() int {
+	if idx := strings.IndexByte(pn.Number, portabilityRangeSep); idx != -1 {
+		return idx
+	}
+	return len(pn.Number)
+}
+
+// LookupPortedNumber returns the PortedNumber covering the number out of the in-memory index
+func (dm *DataManager) LookupPortedNumber(number string) (pn *PortedNumber, err error) {
+	var pt *portabilityTrie
+	if pt, err = dm.portabilityTrie(); err != nil {
+		return
+	}
+	if pn = pt.lookup(number); pn == nil {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+// portabilityTrie returns the index of the PortedNumbers, building it out of dataDB on first use
+func (dm *DataManager) portabilityTrie() (pt *portabilityTrie, err error) {
+	if dm == nil {
+		return nil, utils.ErrNoDatabaseConn
+	}
+	dm.mnpMux.Lock()
+	defer dm.mnpMux.Unlock()
+	if dm.mnp != nil {
+		return dm.mnp, nil
+	}
+	var keys []string
+	if keys, err = dm.DataDB().GetKeysForPrefix(utils.PortedNumbersPrefix); err != nil {
+		return
+	}
+	pt = newPortabilityTrie()
+	for _, key := range keys {
+		var pn *PortedNumber
+		if pn, err = dm.DataDB().GetPortedNumberDrv(key[len(utils.PortedNumbersPrefix):]); err != nil {
+			if err == utils.ErrNotFound { // removed in the meantime
+				err = nil
+				continue
+			}
+			return nil, err
+		}
+		if err = pt.set(pn); err != nil {
+			return nil, err
+		}
+	}
+	dm.mnp = pt
+	return
+}
+
+// updatePortabilityTrie sets or removes the PortedNumber in the index if this was already built
+func (dm *DataManager) updatePortabilityTrie(id string, pn *PortedNumber) (err error) {
+	dm.mnpMux.Lock()
+	pt := dm.mnp
+	dm.mnpMux.Unlock()
+	if pt == nil { // will be built on first lookup
+		return
+	}
+	if pn == nil {
+		pt.removeID(id)
+		return
+	}
+	return pt.set(pn)
+}
+
+// reloadPortedNumber updates the index with the PortedNumber from dataDB
+// used when the PortedNumber is changed by other engines
+func (dm *DataManager) reloadPortedNumber(id string) (err error) {
+	if dm == nil {
+		return
+	}
+	pn, err := dm.DataDB().GetPortedNumberDrv(id)
+	if err != nil && err != utils.ErrNotFound {
+		return
+	}
+	return dm.updatePortabilityTrie(id, pn)
+}
+
+// clearPortabilityTrie drops the index so it is built again on the next lookup
+func (dm *DataManager) clearPortabilityTrie() {
+	if dm == nil {
+		return
+	}
+	dm.mnpMux.Lock()
+	dm.mnp = nil
+	dm.mnpMux.Unlock()
+}
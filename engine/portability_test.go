@@ -0,0 +1,217 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestPortabilityRangePrefixes(t *testing.T) {
+	if rcv := rangePrefixes("40721000000", "40721009999"); !reflect.DeepEqual([]string{"4072100"}, rcv) {
+		t.Errorf("Received: %+v", rcv)
+	}
+	if rcv := rangePrefixes("40721000123", "40721000123"); !reflect.DeepEqual([]string{"40721000123"}, rcv) {
+		t.Errorf("Received: %+v", rcv)
+	}
+	eOut := []string{"1015", "1016", "1017", "1018", "1019", "102", "1030", "1031", "1032"}
+	if rcv := rangePrefixes("1015", "1032"); !reflect.DeepEqual(eOut, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eOut, rcv)
+	}
+	eOut = []string{"19", "2", "30"}
+	if rcv := rangePrefixes("190", "309"); !reflect.DeepEqual(eOut, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", eOut, rcv)
+	}
+}
+
+func TestPortabilityPrefixesErrors(t *testing.T) {
+	for _, number := range []string{"", "4072a", "40721009999-40721000000", "407210-4072100", "-40721"} {
+		if _, _, err := portabilityPrefixes(number); err == nil {
+			t.Errorf("Expecting error for <%s>", number)
+		}
+	}
+	if prfxs, length, err := portabilityPrefixes("+40721000000-+40721009999"); err != nil {
+		t.Error(err)
+	} else if length != 12 || !reflect.DeepEqual([]string{"+4072100"}, prfxs) {
+		t.Errorf("Received: %+v, %v", prfxs, length)
+	}
+}
+
+func TestPortabilityTrie(t *testing.T) {
+	pt := newPortabilityTrie()
+	rng := &PortedNumber{Number: "40721000000-40721009999", LRN: "40299", Network: "NET_B"}
+	single := &PortedNumber{Number: "40721000123", LRN: "40288", Network: "NET_C"}
+	if err := pt.set(rng); err != nil {
+		t.Fatal(err)
+	}
+	if err := pt.set(single); err != nil {
+		t.Fatal(err)
+	}
+	if err := pt.set(&PortedNumber{Number: "4072a"}); err == nil {
+		t.Error("Expecting error")
+	}
+	if rcv := pt.lookup("40721000123"); rcv != single {
+		t.Errorf("Expecting: %+v, received: %+v", single, rcv)
+	}
+	if rcv := pt.lookup("40721005555"); rcv != rng {
+		t.Errorf("Expecting: %+v, received: %+v", rng, rcv)
+	}
+	for _, number := range []string{"4072100555", "407210055551", "40722005555"} { // length or prefix not matching
+		if rcv := pt.lookup(number); rcv != nil {
+			t.Errorf("Unexpected match for %s: %+v", number, rcv)
+		}
+	}
+	updated := &PortedNumber{Number: "40721000000-40721009999", LRN: "40277", Network: "NET_D"}
+	if err := pt.set(updated); err != nil {
+		t.Fatal(err)
+	}
+	if rcv := pt.lookup("40721005555"); rcv != updated {
+		t.Errorf("Expecting: %+v, received: %+v", updated, rcv)
+	}
+	pt.removeID(single.Number)
+	if rcv := pt.lookup("40721000123"); rcv != updated {
+		t.Errorf("Expecting: %+v, received: %+v", updated, rcv)
+	}
+	pt.removeID(updated.Number)
+	if rcv := pt.lookup("40721005555"); rcv != nil {
+		t.Errorf("Unexpected match: %+v", rcv)
+	}
+	if len(pt.root.children) != 0 {
+		t.Errorf("Expecting empty trie, received: %s", utils.ToJSON(pt.root))
+	}
+}
+
+func TestPortabilityDataManager(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	rng := &PortedNumber{Number: "40731000000-40731009999", LRN: "40399", Network: "NET_B"}
+	if err := dm.SetPortedNumber(rng); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetPortedNumber(&PortedNumber{Number: "407310-40731"}); err == nil {
+		t.Error("Expecting error")
+	}
+	if rcv, err := dm.LookupPortedNumber("40731000123"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(rng, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", rng, rcv)
+	}
+	// trie already built, updated incrementally
+	single := &PortedNumber{Number: "40731000123", LRN: "40388", Network: "NET_C"}
+	if err := dm.SetPortedNumber(single); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.LookupPortedNumber("40731000123"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(single, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", single, rcv)
+	}
+	if err := dm.RemovePortedNumber(single.Number, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.LookupPortedNumber("40731000123"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(rng, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", rng, rcv)
+	}
+	if _, err := dm.LookupPortedNumber("40732000123"); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	// changed directly in dataDB by another engine
+	if err := dm.DataDB().SetPortedNumberDrv(single); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.reloadPortedNumber(single.Number); err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := dm.LookupPortedNumber("40731000123"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(single, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", single, rcv)
+	}
+	if err := dm.DataDB().RemovePortedNumberDrv(single.Number); err != nil {
+		t.Fatal(err)
+	}
+	dm.clearPortabilityTrie()
+	if rcv, err := dm.LookupPortedNumber("40731000123"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(rng, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", rng, rcv)
+	}
+	if err := dm.RemovePortedNumber(rng.Number, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPortabilityAttribute(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.AttributeSCfg().ProcessRuns = 1
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	rng := &PortedNumber{Number: "40741000000-40741009999", LRN: "40499", Network: "NET_B"}
+	if err := dm.SetPortedNumber(rng); err != nil {
+		t.Fatal(err)
+	}
+	alS, _ := NewAttributeService(dm, &FilterS{dm: dm, cfg: cfg}, cfg)
+	attrPrf := &AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ATTR_MNP",
+		Contexts:  []string{utils.META_ANY},
+		FilterIDs: []string{"*string:~*req.EventName:TestPortabilityAttribute"},
+		Attributes: []*Attribute{
+			{
+				Path:  utils.MetaReq + utils.NestingSep + "RoutingNumber",
+				Type:  utils.MetaMNP,
+				Value: config.NewRSRParsersMustCompile("~*req.Destination", utils.INFIELD_SEP),
+			},
+		},
+		Weight: 10,
+	}
+	if err := dm.SetAttributeProfile(attrPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	for number, eRN := range map[string]string{
+		"40741000123": "40499",
+		"40742000123": "40742000123", // not ported
+	} {
+		ev := &AttrArgsProcessEvent{
+			CGREvent: &utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     "TestPortabilityAttribute",
+				Event: map[string]interface{}{
+					"EventName":       "TestPortabilityAttribute",
+					utils.Destination: number,
+				},
+			},
+		}
+		if rcv, err := alS.processEvent(ev); err != nil {
+			t.Fatal(err)
+		} else if rn := rcv.CGREvent.Event["RoutingNumber"]; rn != eRN {
+			t.Errorf("Expecting: %s, received: %+v", eRN, rn)
+		}
+	}
+	if err := dm.RemoveAttributeProfile(attrPrf.Tenant, attrPrf.ID, utils.NonTransactional, true); err != nil {
+		t.Error(err)
+	}
+	if err := dm.RemovePortedNumber(rng.Number, utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+}
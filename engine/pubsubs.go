@@ -0,0 +1,304 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/rpc2"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// PubSubEvent is the event pushed towards the subscribers
+type PubSubEvent struct {
+	Cursor int64 // position of the event in the journal, used to resume the subscription
+	Lost   bool  // events were dropped from the journal before reaching the subscriber
+	*utils.CGREvent
+}
+
+// ArgsPubSubSubscribe is used to subscribe for events
+type ArgsPubSubSubscribe struct {
+	Tenant     string
+	ID         string   // subscription ID, generated if empty
+	EventTypes []string // only events with these EventTypes, all if empty
+	FilterIDs  []string
+	Cursor     int64 // resume after this cursor, 0 to receive only the new events
+}
+
+// newPubSubJournal returns a journal keeping the last size events
+func newPubSubJournal(size int) *pubSubJournal {
+	if size <= 0 {
+		size = 1
+	}
+	last := time.Now().UnixNano() // so the cursors of a previous run are recognized as lost
+	return &pubSubJournal{
+		events: make([]*PubSubEvent, size),
+		first:  last + 1,
+		last:   last,
+	}
+}
+
+// pubSubJournal is a ring buffer with the published events
+type pubSubJournal struct {
+	sync.RWMutex
+	events []*PubSubEvent
+	first  int64 // cursor of the oldest event kept
+	last   int64 // cursor of the newest event
+}
+
+// append adds the event to the journal, overwriting the oldest one when full
+func (j *pubSubJournal) append(cgrEv *utils.CGREvent) {
+	j.Lock()
+	j.last++
+	j.events[j.last%int64(len(j.events))] = &PubSubEvent{Cursor: j.last, CGREvent: cgrEv}
+	if j.last-j.first >= int64(len(j.events)) {
+		j.first = j.last - int64(len(j.events)) + 1
+	}
+	j.Unlock()
+}
+
+// lastCursor returns the cursor of the newest event
+func (j *pubSubJournal) lastCursor() (cursor int64) {
+	j.RLock()
+	cursor = j.last
+	j.RUnlock()
+	return
+}
+
+// next returns the first event after cursor or nil if there is none
+func (j *pubSubJournal) next(cursor int64) (ev *PubSubEvent) {
+	j.RLock()
+	defer j.RUnlock()
+	if cursor >= j.last {
+		return
+	}
+	if cursor < j.first {
+		cursor = j.first - 1
+	}
+	return j.events[(cursor+1)%int64(len(j.events))]
+}
+
+// pubSubscriber is one subscription served by its own goroutine
+type pubSubscriber struct {
+	*ArgsPubSubSubscribe
+	clnt       rpcclient.ClientConnector
+	eventTypes utils.StringSet
+	notify     chan struct{} // new events are available in the journal
+	stop       chan struct{}
+}
+
+// NewPubSubService returns a new PubSubService
+func NewPubSubService(cfg *config.CGRConfig, filterS *FilterS) *PubSubService {
+	return &PubSubService{
+		cfg:     cfg,
+		filterS: filterS,
+		journal: newPubSubJournal(cfg.PubSubSCfg().BufferSize),
+		subs:    make(map[string]*pubSubscriber),
+	}
+}
+
+// PubSubService pushes the published events towards the subscribers
+type PubSubService struct {
+	sync.RWMutex
+	cfg     *config.CGRConfig
+	filterS *FilterS
+	journal *pubSubJournal
+	subs    map[string]*pubSubscriber // indexed on tenant:ID
+}
+
+// ListenAndServe will initialize the service
+func (ps *PubSubService) ListenAndServe(exitChan chan bool) (err error) {
+	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.PubSubS))
+	e := <-exitChan
+	exitChan <- e
+	return
+}
+
+// Shutdown is called to shutdown the service
+func (ps *PubSubService) Shutdown() (err error) {
+	utils.Logger.Info(fmt.Sprintf("<%s> shutdown initialized", utils.PubSubS))
+	ps.Lock()
+	for tntID, sub := range ps.subs {
+		close(sub.stop)
+		delete(ps.subs, tntID)
+	}
+	ps.Unlock()
+	utils.Logger.Info(fmt.Sprintf("<%s> shutdown complete", utils.PubSubS))
+	return
+}
+
+// OnBiJSONDisconnect is called by rpc2.Client on each client disconnection
+func (ps *PubSubService) OnBiJSONDisconnect(c *rpc2.Client) {
+	ps.Lock()
+	for tntID, sub := range ps.subs {
+		if sub.clnt == c {
+			close(sub.stop)
+			delete(ps.subs, tntID)
+		}
+	}
+	ps.Unlock()
+}
+
+// publish adds the event to the journal and wakes up the subscribers
+func (ps *PubSubService) publish(cgrEv *utils.CGREvent) {
+	ps.journal.append(cgrEv)
+	ps.RLock()
+	for _, sub := range ps.subs {
+		select {
+		case sub.notify <- struct{}{}:
+		default: // already notified
+		}
+	}
+	ps.RUnlock()
+}
+
+// subscriberPass checks if the event should be pushed to the subscriber
+func (ps *PubSubService) subscriberPass(sub *pubSubscriber, cgrEv *utils.CGREvent) (pass bool, err error) {
+	if cgrEv.Tenant != sub.Tenant {
+		return
+	}
+	if len(sub.eventTypes) != 0 &&
+		!sub.eventTypes.Has(utils.IfaceAsString(cgrEv.Event[utils.EventType])) {
+		return
+	}
+	return ps.filterS.Pass(sub.Tenant, sub.FilterIDs,
+		utils.MapStorage{utils.MetaReq: cgrEv.Event})
+}
+
+// serveSubscriber pushes the events from the journal to the subscriber
+// waiting for each push to be acknowledged so slow subscribers are not flooded
+func (ps *PubSubService) serveSubscriber(sub *pubSubscriber) {
+	for {
+		for ev := ps.journal.next(sub.Cursor); ev != nil; ev = ps.journal.next(sub.Cursor) {
+			select {
+			case <-sub.stop:
+				return
+			default:
+			}
+			if pass, err := ps.subscriberPass(sub, ev.CGREvent); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> subscription: %s, error: %s checking event: %s",
+						utils.PubSubS, sub.ID, err.Error(), utils.ToJSON(ev.CGREvent)))
+			} else if pass {
+				var reply string
+				if err := sub.clnt.Call(utils.PubSubSv1PushEvent, &PubSubEvent{
+					Cursor:   ev.Cursor,
+					Lost:     ev.Cursor != sub.Cursor+1,
+					CGREvent: ev.CGREvent,
+				}, &reply); err != nil {
+					utils.Logger.Warning(
+						fmt.Sprintf("<%s> subscription: %s, error: %s pushing event with cursor: %d",
+							utils.PubSubS, sub.ID, err.Error(), ev.Cursor))
+				}
+			}
+			sub.Cursor = ev.Cursor
+		}
+		select {
+		case <-sub.stop:
+			return
+		case <-sub.notify:
+		}
+	}
+}
+
+// V1Publish publishes the event towards the subscribers
+func (ps *PubSubService) V1Publish(args *utils.CGREventWithArgDispatcher, reply *string) (err error) {
+	if args.CGREvent == nil ||
+		args.Event == nil {
+		return utils.NewErrMandatoryIeMissing(utils.Event)
+	}
+	if _, has := args.Event[utils.EventType]; !has {
+		return utils.NewErrMandatoryIeMissing(utils.EventType)
+	}
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = ps.cfg.GeneralCfg().DefaultTenant
+	}
+	ps.publish(args.CGREvent)
+	*reply = utils.OK
+	return
+}
+
+// BiRPCv1Subscribe subscribes the client for events, replacing an existing subscription with the same ID
+// the subscriptions of the other clients cannot be replaced
+func (ps *PubSubService) BiRPCv1Subscribe(clnt rpcclient.ClientConnector,
+	args *ArgsPubSubSubscribe, reply *string) (err error) {
+	if clnt == nil {
+		return utils.NewErrMandatoryIeMissing("BiRPCClient")
+	}
+	if args.Tenant == utils.EmptyString {
+		args.Tenant = ps.cfg.GeneralCfg().DefaultTenant
+	}
+	if args.ID == utils.EmptyString {
+		args.ID = utils.UUIDSha1Prefix()
+	}
+	if last := ps.journal.lastCursor(); args.Cursor == 0 || args.Cursor > last {
+		args.Cursor = last
+	}
+	sub := &pubSubscriber{
+		ArgsPubSubSubscribe: args,
+		clnt:                clnt,
+		eventTypes:          utils.NewStringSet(args.EventTypes),
+		notify:              make(chan struct{}, 1),
+		stop:                make(chan struct{}),
+	}
+	tntID := utils.ConcatenatedKey(args.Tenant, args.ID)
+	ps.Lock()
+	if oldSub, has := ps.subs[tntID]; has {
+		if oldSub.clnt != clnt {
+			ps.Unlock()
+			return utils.ErrExists
+		}
+		close(oldSub.stop)
+	}
+	ps.subs[tntID] = sub
+	ps.Unlock()
+	go ps.serveSubscriber(sub)
+	*reply = args.ID
+	return
+}
+
+// BiRPCv1Unsubscribe removes the subscription, only the client owning it can remove it
+func (ps *PubSubService) BiRPCv1Unsubscribe(clnt rpcclient.ClientConnector,
+	args *utils.TenantID, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = ps.cfg.GeneralCfg().DefaultTenant
+	}
+	tntID := utils.ConcatenatedKey(tnt, args.ID)
+	ps.Lock()
+	sub, has := ps.subs[tntID]
+	has = has && sub.clnt == clnt // the subscriptions of the other clients are not visible
+	if has {
+		close(sub.stop)
+		delete(ps.subs, tntID)
+	}
+	ps.Unlock()
+	if !has {
+		return utils.ErrNotFound
+	}
+	*reply = utils.OK
+	return
+}
@@ -0,0 +1,242 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// pubSubClntMock collects the events pushed by PubSubS
+type pubSubClntMock struct {
+	evs chan *PubSubEvent
+}
+
+func (c *pubSubClntMock) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	if serviceMethod != utils.PubSubSv1PushEvent {
+		return utils.ErrNotImplemented
+	}
+	c.evs <- args.(*PubSubEvent)
+	*reply.(*string) = utils.OK
+	return nil
+}
+
+func (c *pubSubClntMock) receive(t *testing.T) (ev *PubSubEvent) {
+	select {
+	case ev = <-c.evs:
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for event")
+	}
+	return
+}
+
+func (c *pubSubClntMock) noEvent(t *testing.T) {
+	select {
+	case ev := <-c.evs:
+		t.Errorf("unexpected event: %s", utils.ToJSON(ev))
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func newTestPubSubService(bufferSize int) *PubSubService {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.PubSubSCfg().BufferSize = bufferSize
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	return NewPubSubService(cfg, NewFilterS(cfg, nil, dm))
+}
+
+func pubSubTestEvent(evType, account string) *utils.CGREventWithArgDispatcher {
+	return &utils.CGREventWithArgDispatcher{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     utils.UUIDSha1Prefix(),
+			Event: map[string]interface{}{
+				utils.EventType: evType,
+				utils.Account:   account,
+			},
+		},
+	}
+}
+
+func TestPubSubJournal(t *testing.T) {
+	j := newPubSubJournal(2)
+	start := j.lastCursor()
+	if ev := j.next(start); ev != nil {
+		t.Errorf("expecting no event, received: %s", utils.ToJSON(ev))
+	}
+	for _, id := range []string{"ev1", "ev2", "ev3"} {
+		j.append(&utils.CGREvent{ID: id})
+	}
+	if rcv := j.lastCursor(); rcv != start+3 {
+		t.Errorf("expecting: %d, received: %d", start+3, rcv)
+	}
+	// ev1 was overwritten so we continue with the oldest event kept
+	if ev := j.next(start); ev == nil || ev.Cursor != start+2 || ev.ID != "ev2" {
+		t.Errorf("received: %s", utils.ToJSON(ev))
+	}
+	if ev := j.next(start + 2); ev == nil || ev.Cursor != start+3 || ev.ID != "ev3" {
+		t.Errorf("received: %s", utils.ToJSON(ev))
+	}
+	if ev := j.next(start + 3); ev != nil {
+		t.Errorf("expecting no event, received: %s", utils.ToJSON(ev))
+	}
+}
+
+func TestPubSubSubscribePublish(t *testing.T) {
+	ps := newTestPubSubService(10)
+	defer ps.Shutdown()
+	clnt := &pubSubClntMock{evs: make(chan *PubSubEvent, 10)}
+	var subID string
+	if err := ps.BiRPCv1Subscribe(clnt, &ArgsPubSubSubscribe{
+		Tenant:     "cgrates.org",
+		EventTypes: []string{utils.BalanceUpdate},
+		FilterIDs:  []string{"*string:~*req.Account:1001"},
+	}, &subID); err != nil {
+		t.Fatal(err)
+	} else if subID == utils.EmptyString {
+		t.Error("expecting a subscription ID")
+	}
+	var reply string
+	if err := ps.V1Publish(&utils.CGREventWithArgDispatcher{
+		CGREvent: &utils.CGREvent{Tenant: "cgrates.org", Event: map[string]interface{}{}},
+	}, &reply); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.EventType).Error() {
+		t.Errorf("received: %v", err)
+	}
+	for _, ev := range []*utils.CGREventWithArgDispatcher{
+		pubSubTestEvent(utils.StatUpdate, "1001"),
+		pubSubTestEvent(utils.BalanceUpdate, "1002"),
+		pubSubTestEvent(utils.BalanceUpdate, "1001"),
+	} {
+		if err := ps.V1Publish(ev, &reply); err != nil {
+			t.Error(err)
+		}
+	}
+	ev := clnt.receive(t)
+	if ev.Lost ||
+		ev.Event[utils.EventType] != utils.BalanceUpdate ||
+		ev.Event[utils.Account] != "1001" {
+		t.Errorf("received: %s", utils.ToJSON(ev))
+	}
+	if ev.Cursor != ps.journal.lastCursor() {
+		t.Errorf("expecting: %d, received: %d", ps.journal.lastCursor(), ev.Cursor)
+	}
+	clnt.noEvent(t)
+
+	if err := ps.BiRPCv1Unsubscribe(clnt, &utils.TenantID{Tenant: "cgrates.org", ID: subID}, &reply); err != nil {
+		t.Error(err)
+	}
+	if err := ps.BiRPCv1Unsubscribe(clnt, &utils.TenantID{Tenant: "cgrates.org", ID: subID}, &reply); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	if err := ps.V1Publish(pubSubTestEvent(utils.BalanceUpdate, "1001"), &reply); err != nil {
+		t.Error(err)
+	}
+	clnt.noEvent(t)
+}
+
+func TestPubSubResumeFromCursor(t *testing.T) {
+	ps := newTestPubSubService(3)
+	defer ps.Shutdown()
+	start := ps.journal.lastCursor()
+	var reply string
+	for _, acnt := range []string{"1001", "1002", "1003", "1004"} {
+		if err := ps.V1Publish(pubSubTestEvent(utils.BalanceUpdate, acnt), &reply); err != nil {
+			t.Error(err)
+		}
+	}
+	clnt := &pubSubClntMock{evs: make(chan *PubSubEvent, 10)}
+	if err := ps.BiRPCv1Subscribe(clnt, &ArgsPubSubSubscribe{
+		Tenant: "cgrates.org",
+		ID:     "SUB1",
+		Cursor: start,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != "SUB1" {
+		t.Errorf("expecting: SUB1, received: %s", reply)
+	}
+	var rcv []string
+	var lost []bool
+	for i := 0; i < 3; i++ {
+		ev := clnt.receive(t)
+		rcv = append(rcv, utils.IfaceAsString(ev.Event[utils.Account]))
+		lost = append(lost, ev.Lost)
+	}
+	// the first event was dropped from the journal
+	if exp := []string{"1002", "1003", "1004"}; !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expecting: %+v, received: %+v", exp, rcv)
+	}
+	if exp := []bool{true, false, false}; !reflect.DeepEqual(exp, lost) {
+		t.Errorf("expecting: %+v, received: %+v", exp, lost)
+	}
+}
+
+func TestPubSubOnBiJSONDisconnect(t *testing.T) {
+	ps := newTestPubSubService(10)
+	defer ps.Shutdown()
+	var reply string
+	if err := ps.BiRPCv1Subscribe(&pubSubClntMock{evs: make(chan *PubSubEvent, 1)},
+		&ArgsPubSubSubscribe{ID: "SUB1"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	ps.OnBiJSONDisconnect(nil) // not the subscriber
+	if len(ps.subs) != 1 {
+		t.Errorf("expecting 1 subscription, received: %d", len(ps.subs))
+	}
+	ps.Shutdown()
+	if len(ps.subs) != 0 {
+		t.Errorf("expecting no subscription, received: %d", len(ps.subs))
+	}
+}
+
+func TestPubSubSubscriptionOwner(t *testing.T) {
+	ps := newTestPubSubService(10)
+	defer ps.Shutdown()
+	clnt1 := &pubSubClntMock{evs: make(chan *PubSubEvent, 10)}
+	clnt2 := &pubSubClntMock{evs: make(chan *PubSubEvent, 10)}
+	var reply string
+	if err := ps.BiRPCv1Subscribe(clnt1, &ArgsPubSubSubscribe{Tenant: "cgrates.org", ID: "SUB1"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := ps.BiRPCv1Subscribe(clnt2, &ArgsPubSubSubscribe{Tenant: "cgrates.org", ID: "SUB1"}, &reply); err != utils.ErrExists {
+		t.Errorf("expecting: %v, received: %v", utils.ErrExists, err)
+	}
+	if err := ps.BiRPCv1Unsubscribe(clnt2, &utils.TenantID{Tenant: "cgrates.org", ID: "SUB1"}, &reply); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	// the stream of the first client is not affected
+	if err := ps.V1Publish(pubSubTestEvent(utils.BalanceUpdate, "1001"), &reply); err != nil {
+		t.Error(err)
+	}
+	if ev := clnt1.receive(t); ev.Event[utils.Account] != "1001" {
+		t.Errorf("received: %s", utils.ToJSON(ev))
+	}
+	clnt2.noEvent(t)
+	// the owner can still replace and remove it
+	if err := ps.BiRPCv1Subscribe(clnt1, &ArgsPubSubSubscribe{Tenant: "cgrates.org", ID: "SUB1",
+		EventTypes: []string{utils.StatUpdate}}, &reply); err != nil {
+		t.Error(err)
+	}
+	if err := ps.BiRPCv1Unsubscribe(clnt1, &utils.TenantID{Tenant: "cgrates.org", ID: "SUB1"}, &reply); err != nil {
+		t.Error(err)
+	}
+}
@@ -214,12 +214,16 @@ type RIRate struct {
 	RoundingDecimals int
 	MaxCost          float64
 	MaxCostStrategy  string
+	Currency         string     // optional, the balances in other currencies will be converted
 	Rates            RateGroups // GroupRateInterval (start time): RGRate
 	tag              string     // loading validation only
 }
 
 func (rir *RIRate) Stringify() string {
 	str := fmt.Sprintf("%v %v %v %v %v", rir.ConnectFee, rir.RoundingMethod, rir.RoundingDecimals, rir.MaxCost, rir.MaxCostStrategy)
+	if rir.Currency != "" { // keep the old IDs for the rates without currency
+		str += " " + rir.Currency
+	}
 	for _, r := range rir.Rates {
 		str += r.Stringify()
 	}
@@ -485,6 +489,7 @@ func (rit *RIRate) Clone() (cln *RIRate) {
 		RoundingDecimals: rit.RoundingDecimals,
 		MaxCost:          rit.MaxCost,
 		MaxCostStrategy:  rit.MaxCostStrategy,
+		Currency:         rit.Currency,
 	}
 	if rit.Rates != nil {
 		cln.Rates = make([]*RGRate, len(rit.Rates))
@@ -0,0 +1,184 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/guardian"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// ProfileRevision is a version of a profile as it was written in DataDB
+type ProfileRevision struct {
+	Revision int
+	Time     time.Time
+	Profile  json.RawMessage // JSON encoded profile, empty if the profile was removed
+}
+
+// Removed returns true if the revision records the removal of the profile
+func (prv *ProfileRevision) Removed() bool {
+	return len(prv.Profile) == 0 || string(prv.Profile) == "null"
+}
+
+// ProfileRevisions is the revision history of a profile, ordered by revision
+type ProfileRevisions struct {
+	ItemType  string // eg: *attribute_profiles
+	Tenant    string
+	ID        string
+	Revisions []*ProfileRevision
+}
+
+// TenantID returns the concatenated key beteen tenant and ID
+func (prvs *ProfileRevisions) TenantID() string {
+	return utils.ConcatenatedKey(prvs.Tenant, prvs.ID)
+}
+
+// Revision returns the revision with the given number
+func (prvs *ProfileRevisions) Revision(rev int) (*ProfileRevision, error) {
+	for _, prv := range prvs.Revisions {
+		if prv.Revision == rev {
+			return prv, nil
+		}
+	}
+	return nil, utils.ErrNotFound
+}
+
+// Diff returns the fields changed between two revisions
+func (prvs *ProfileRevisions) Diff(fromRev, toRev int) (changes map[string]*AuditChange, err error) {
+	var from, to *ProfileRevision
+	if from, err = prvs.Revision(fromRev); err != nil {
+		return
+	}
+	if to, err = prvs.Revision(toRev); err != nil {
+		return
+	}
+	var fromPrf, toPrf interface{}
+	if !from.Removed() {
+		fromPrf = from.Profile
+	}
+	if !to.Removed() {
+		toPrf = to.Profile
+	}
+	return auditChanges(fromPrf, toPrf)
+}
+
+// ProfileWithRevision decodes the profile of the revision into its concrete type
+// returns nil if the revision records the removal of the profile
+func (prvs *ProfileRevisions) ProfileWithRevision(rev int) (prf interface{}, err error) {
+	var prv *ProfileRevision
+	if prv, err = prvs.Revision(rev); err != nil ||
+		prv.Removed() {
+		return
+	}
+	switch prvs.ItemType {
+	case utils.CacheFilters:
+		prf = new(Filter)
+	case utils.CacheAttributeProfiles:
+		prf = new(AttributeProfile)
+	case utils.CacheChargerProfiles:
+		prf = new(ChargerProfile)
+	case utils.CacheRouteProfiles:
+		prf = new(RouteProfile)
+	case utils.CacheResourceProfiles:
+		prf = new(ResourceProfile)
+	case utils.CacheStatQueueProfiles:
+		prf = new(StatQueueProfile)
+	case utils.CacheThresholdProfiles:
+		prf = new(ThresholdProfile)
+	case utils.CacheDispatcherProfiles:
+		prf = new(DispatcherProfile)
+	case utils.CacheRateProfiles:
+		prf = new(RateProfile)
+	case utils.CacheTaxProfiles:
+		prf = new(TaxProfile)
+	default:
+		return nil, utils.ErrPrefixNotErrNotImplemented(prvs.ItemType)
+	}
+	err = json.Unmarshal(prv.Profile, prf)
+	return
+}
+
+// GetProfileRevisions returns the revision history of a profile
+func (dm *DataManager) GetProfileRevisions(itemType, tenant, id string) (prvs *ProfileRevisions, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().GetProfileRevisionsDrv(itemType, tenant, id)
+}
+
+// RemoveProfileRevisions removes the revision history of a profile
+func (dm *DataManager) RemoveProfileRevisions(itemType, tenant, id string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().RemoveProfileRevisionsDrv(itemType, tenant, id)
+}
+
+// setProfileRevision records a new revision of the profile, nil profile for removal
+// failing to record it is only logged since the profile itself was already stored
+func (dm *DataManager) setProfileRevision(itemType, tenant, id string, prf interface{}) {
+	if err := dm.storeProfileRevision(itemType, tenant, id, prf); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed recording the revision of %s profile: %s, error: %s",
+				utils.DataManager, itemType, utils.ConcatenatedKey(tenant, id), err.Error()))
+	}
+}
+
+// storeProfileRevision appends the revision of the profile
+// the oldest revisions are dropped once the configured limit is reached
+func (dm *DataManager) storeProfileRevision(itemType, tenant, id string, prf interface{}) (err error) {
+	limit := config.CgrConfig().DataDbCfg().ProfileRevisions
+	if limit <= 0 {
+		return
+	}
+	prv := &ProfileRevision{Time: time.Now()}
+	if prf != nil {
+		if prv.Profile, err = json.Marshal(prf); err != nil {
+			return
+		}
+	}
+	_, err = guardian.Guardian.Guard(func() (_ interface{}, err error) {
+		var oldPrvs *ProfileRevisions
+		if oldPrvs, err = dm.DataDB().GetProfileRevisionsDrv(itemType, tenant, id); err != nil {
+			if err != utils.ErrNotFound {
+				return
+			}
+			oldPrvs = new(ProfileRevisions)
+		}
+		prv.Revision = 1
+		if len(oldPrvs.Revisions) != 0 {
+			prv.Revision = oldPrvs.Revisions[len(oldPrvs.Revisions)-1].Revision + 1
+		}
+		prvs := &ProfileRevisions{ItemType: itemType, Tenant: tenant, ID: id,
+			Revisions: make([]*ProfileRevision, 0, len(oldPrvs.Revisions)+1)}
+		prvs.Revisions = append(append(prvs.Revisions, oldPrvs.Revisions...), prv)
+		if len(prvs.Revisions) > limit {
+			prvs.Revisions = prvs.Revisions[len(prvs.Revisions)-limit:]
+		}
+		return nil, dm.DataDB().SetProfileRevisionsDrv(prvs)
+	}, config.CgrConfig().GeneralCfg().LockingTimeout,
+		utils.ProfileRevisionsPrefix+utils.ConcatenatedKey(itemType, tenant, id))
+	return
+}
@@ -0,0 +1,105 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDataManagerProfileRevisions(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	cfg.DataDbCfg().ProfileRevisions = 2
+	config.SetCgrConfig(cfg)
+	defer func() {
+		dfltCfg, _ := config.NewDefaultCGRConfig()
+		config.SetCgrConfig(dfltCfg)
+	}()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	attr := &AttributeProfile{
+		Tenant:   "cgrates.org",
+		ID:       "ATTR_REV",
+		Contexts: []string{utils.META_ANY},
+		Weight:   10,
+	}
+	if err := dm.SetAttributeProfile(attr, true); err != nil {
+		t.Fatal(err)
+	}
+	attr2 := &AttributeProfile{
+		Tenant:   "cgrates.org",
+		ID:       "ATTR_REV",
+		Contexts: []string{utils.META_ANY},
+		Weight:   20,
+	}
+	if err := dm.SetAttributeProfile(attr2, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.RemoveAttributeProfile("cgrates.org", "ATTR_REV", utils.NonTransactional, true); err != nil {
+		t.Fatal(err)
+	}
+	prvs, err := dm.GetProfileRevisions(utils.CacheAttributeProfiles, "cgrates.org", "ATTR_REV")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prvs.Revisions) != 2 { // limited to the last 2 revisions
+		t.Fatalf("Unexpected revisions: %s", utils.ToJSON(prvs))
+	}
+	if prvs.Revisions[0].Revision != 2 || prvs.Revisions[1].Revision != 3 ||
+		!prvs.Revisions[1].Removed() {
+		t.Errorf("Unexpected revisions: %s", utils.ToJSON(prvs))
+	}
+	if _, err := prvs.Revision(1); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if prf, err := prvs.ProfileWithRevision(2); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(attr2, prf) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(attr2), utils.ToJSON(prf))
+	}
+	if prf, err := prvs.ProfileWithRevision(3); err != nil {
+		t.Error(err)
+	} else if prf != nil {
+		t.Errorf("Expected nil profile for removal, received %s", utils.ToJSON(prf))
+	}
+	if diff, err := prvs.Diff(2, 3); err != nil {
+		t.Error(err)
+	} else if diff["Weight"] == nil || diff["Weight"].Before != "20" || diff["Weight"].After != utils.EmptyString {
+		t.Errorf("Unexpected diff: %s", utils.ToJSON(diff))
+	}
+	if err := dm.RemoveProfileRevisions(utils.CacheAttributeProfiles, "cgrates.org", "ATTR_REV"); err != nil {
+		t.Error(err)
+	}
+	if _, err := dm.GetProfileRevisions(utils.CacheAttributeProfiles, "cgrates.org", "ATTR_REV"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestDataManagerProfileRevisionsDisabled(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	if err := dm.SetFilter(&Filter{Tenant: "cgrates.org", ID: "FLTR_REV"}, true); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.GetProfileRevisions(utils.CacheFilters, "cgrates.org", "FLTR_REV"); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+}
@@ -228,6 +228,34 @@ type StatsArgsProcessEvent struct {
 	*utils.ArgDispatcher
 }
 
+// publishStatUpdate sends the metric values of the queue to PubSubS
+// the event is sent in background so PubSubS does not delay or fail the processing
+func (sS *StatService) publishStatUpdate(sq *StatQueue, argDisp *utils.ArgDispatcher) {
+	cgrEv := &utils.CGREvent{
+		Tenant: sq.Tenant,
+		ID:     utils.GenUUID(),
+		Time:   utils.TimePointer(time.Now()),
+		Event: map[string]interface{}{
+			utils.EventType: utils.StatUpdate,
+			utils.StatID:    sq.ID,
+		},
+	}
+	for metricID, metric := range sq.SQMetrics {
+		cgrEv.Event[metricID] = metric.GetValue()
+	}
+	go func() {
+		var reply string
+		if err := sS.connMgr.Call(sS.cgrcfg.StatSCfg().PubSubSConns, nil,
+			utils.PubSubSv1Publish, &utils.CGREventWithArgDispatcher{
+				CGREvent:      cgrEv,
+				ArgDispatcher: argDisp,
+			}, &reply); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<StatS> error: %s publishing event %+v with PubSubS.", err.Error(), cgrEv))
+		}
+	}()
+}
+
 // processEvent processes a new event, dispatching to matching queues
 // queues matching are also cached to speed up
 func (sS *StatService) processEvent(args *StatsArgsProcessEvent) (statQueueIDs []string, err error) {
@@ -264,6 +292,9 @@ func (sS *StatService) processEvent(args *StatsArgsProcessEvent) (statQueueIDs [
 				sS.ssqMux.Unlock()
 			}
 		}
+		if len(sS.cgrcfg.StatSCfg().PubSubSConns) != 0 {
+			sS.publishStatUpdate(sq, args.ArgDispatcher)
+		}
 		if len(sS.cgrcfg.StatSCfg().ThresholdSConns) != 0 {
 			var thIDs []string
 			if len(sq.sqPrfl.ThresholdIDs) != 0 {
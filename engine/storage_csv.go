@@ -66,6 +66,8 @@ type CSVStorage struct {
 	dispatcherProfilesFn     []string
 	dispatcherHostsFn        []string
 	rateProfilesFn           []string
+	fxRatesFn                []string
+	portedNumbersFn          []string
 }
 
 // NewCSVStorage creates a CSV storege that takes the data from the paths specified
@@ -75,7 +77,8 @@ func NewCSVStorage(sep rune,
 	actionsFn, actiontimingsFn, actiontriggersFn, accountactionsFn,
 	resProfilesFn, statsFn, thresholdsFn,
 	filterFn, routeProfilesFn, attributeProfilesFn,
-	chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn, rateProfilesFn []string) *CSVStorage {
+	chargerProfilesFn, dispatcherProfilesFn, dispatcherHostsFn, rateProfilesFn,
+	fxRatesFn, portedNumbersFn []string) *CSVStorage {
 	return &CSVStorage{
 		sep:                      sep,
 		generator:                NewCsvFile,
@@ -100,6 +103,8 @@ func NewCSVStorage(sep rune,
 		dispatcherProfilesFn:     dispatcherProfilesFn,
 		dispatcherHostsFn:        dispatcherHostsFn,
 		rateProfilesFn:           rateProfilesFn,
+		fxRatesFn:                fxRatesFn,
+		portedNumbersFn:          portedNumbersFn,
 	}
 }
 
@@ -130,6 +135,8 @@ func NewFileCSVStorage(sep rune, dataPath string) *CSVStorage {
 	dispatcherprofilesPaths := appendName(allFoldersPath, utils.DispatcherProfilesCsv)
 	dispatcherhostsPaths := appendName(allFoldersPath, utils.DispatcherHostsCsv)
 	rateProfilesFn := appendName(allFoldersPath, utils.RateProfilesCsv)
+	fxRatesPaths := appendName(allFoldersPath, utils.FxRatesCsv)
+	portedNumbersPaths := appendName(allFoldersPath, utils.PortedNumbersCsv)
 	return NewCSVStorage(sep,
 		destinationsPaths,
 		timingsPaths,
@@ -152,6 +159,8 @@ func NewFileCSVStorage(sep rune, dataPath string) *CSVStorage {
 		dispatcherprofilesPaths,
 		dispatcherhostsPaths,
 		rateProfilesFn,
+		fxRatesPaths,
+		portedNumbersPaths,
 	)
 }
 
@@ -163,14 +172,16 @@ func NewStringCSVStorage(sep rune,
 	accountactionsFn, resProfilesFn, statsFn,
 	thresholdsFn, filterFn, routeProfilesFn,
 	attributeProfilesFn, chargerProfilesFn,
-	dispatcherProfilesFn, dispatcherHostsFn, rateProfilesFn string) *CSVStorage {
+	dispatcherProfilesFn, dispatcherHostsFn, rateProfilesFn,
+	fxRatesFn, portedNumbersFn string) *CSVStorage {
 	c := NewCSVStorage(sep, []string{destinationsFn}, []string{timingsFn},
 		[]string{ratesFn}, []string{destinationratesFn}, []string{destinationratetimingsFn},
 		[]string{ratingprofilesFn}, []string{sharedgroupsFn}, []string{actionsFn},
 		[]string{actiontimingsFn}, []string{actiontriggersFn}, []string{accountactionsFn},
 		[]string{resProfilesFn}, []string{statsFn}, []string{thresholdsFn}, []string{filterFn},
 		[]string{routeProfilesFn}, []string{attributeProfilesFn}, []string{chargerProfilesFn},
-		[]string{dispatcherProfilesFn}, []string{dispatcherHostsFn}, []string{rateProfilesFn})
+		[]string{dispatcherProfilesFn}, []string{dispatcherHostsFn}, []string{rateProfilesFn},
+		[]string{fxRatesFn}, []string{portedNumbersFn})
 	c.generator = NewCsvString
 	return c
 }
@@ -212,7 +223,9 @@ func NewGoogleCSVStorage(sep rune, spreadsheetID string) (*CSVStorage, error) {
 		getIfExist(utils.Chargers),
 		getIfExist(utils.DispatcherProfiles),
 		getIfExist(utils.DispatcherHosts),
-		getIfExist(utils.RateProfiles))
+		getIfExist(utils.RateProfiles),
+		getIfExist(utils.FxRates),
+		getIfExist(utils.PortedNumbers))
 	c.generator = func() csvReaderCloser {
 		return &csvGoogle{
 			spreadsheetID: spreadsheetID,
@@ -245,6 +258,8 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 	var dispatcherprofilesPaths []string
 	var dispatcherhostsPaths []string
 	var rateProfilesPaths []string
+	var fxRatesPaths []string
+	var portedNumbersPaths []string
 
 	for _, baseURL := range strings.Split(dataPath, utils.INFIELD_SEP) {
 		if !strings.HasSuffix(baseURL, utils.CSVSuffix) {
@@ -269,6 +284,8 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 			dispatcherprofilesPaths = append(dispatcherprofilesPaths, joinURL(baseURL, utils.DispatcherProfilesCsv))
 			dispatcherhostsPaths = append(dispatcherhostsPaths, joinURL(baseURL, utils.DispatcherHostsCsv))
 			rateProfilesPaths = append(rateProfilesPaths, joinURL(baseURL, utils.RateProfilesCsv))
+			fxRatesPaths = append(fxRatesPaths, joinURL(baseURL, utils.FxRatesCsv))
+			portedNumbersPaths = append(portedNumbersPaths, joinURL(baseURL, utils.PortedNumbersCsv))
 			continue
 		}
 		switch {
@@ -314,6 +331,10 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 			dispatcherhostsPaths = append(dispatcherhostsPaths, baseURL)
 		case strings.HasSuffix(baseURL, utils.RateProfilesCsv):
 			rateProfilesPaths = append(rateProfilesPaths, baseURL)
+		case strings.HasSuffix(baseURL, utils.FxRatesCsv):
+			fxRatesPaths = append(fxRatesPaths, baseURL)
+		case strings.HasSuffix(baseURL, utils.PortedNumbersCsv):
+			portedNumbersPaths = append(portedNumbersPaths, baseURL)
 		}
 	}
 
@@ -339,6 +360,8 @@ func NewURLCSVStorage(sep rune, dataPath string) *CSVStorage {
 		dispatcherprofilesPaths,
 		dispatcherhostsPaths,
 		rateProfilesPaths,
+		fxRatesPaths,
+		portedNumbersPaths,
 	)
 	c.generator = func() csvReaderCloser {
 		return &csvURL{}
@@ -407,6 +430,37 @@ func (csvs *CSVStorage) proccesData(listType interface{}, fns []string, process
 	return nil
 }
 
+func (csvs *CSVStorage) GetTPFxRates(tpid, fromCurrency, toCurrency string) ([]*utils.TPFxRate, error) {
+	var tpFxRates TpFxRates
+	if err := csvs.proccesData(TpFxRate{}, csvs.fxRatesFn, func(tp interface{}) {
+		fx := tp.(TpFxRate)
+		if (fromCurrency != "" && fx.FromCurrency != fromCurrency) ||
+			(toCurrency != "" && fx.ToCurrency != toCurrency) {
+			return
+		}
+		fx.Tpid = tpid
+		tpFxRates = append(tpFxRates, fx)
+	}); err != nil {
+		return nil, err
+	}
+	return tpFxRates.AsTPFxRates(), nil
+}
+
+func (csvs *CSVStorage) GetTPPortedNumbers(tpid, number string) ([]*utils.TPPortedNumber, error) {
+	var tpPortedNumbers TpPortedNumbers
+	if err := csvs.proccesData(TpPortedNumber{}, csvs.portedNumbersFn, func(tp interface{}) {
+		pn := tp.(TpPortedNumber)
+		if number != "" && pn.Number != number {
+			return
+		}
+		pn.Tpid = tpid
+		tpPortedNumbers = append(tpPortedNumbers, pn)
+	}); err != nil {
+		return nil, err
+	}
+	return tpPortedNumbers.AsTPPortedNumbers(), nil
+}
+
 func (csvs *CSVStorage) GetTPTimings(tpid, id string) ([]*utils.ApierTPTiming, error) {
 	var tpTimings TpTimings
 	if err := csvs.proccesData(TpTiming{}, csvs.timingsFn, func(tp interface{}) {
@@ -91,6 +91,12 @@ type DataDB interface {
 	GetTimingDrv(string) (*utils.TPTiming, error)
 	SetTimingDrv(*utils.TPTiming) error
 	RemoveTimingDrv(string) error
+	GetFxRateDrv(string) (*FxRate, error)
+	SetFxRateDrv(*FxRate) error
+	RemoveFxRateDrv(string) error
+	GetPortedNumberDrv(string) (*PortedNumber, error)
+	SetPortedNumberDrv(*PortedNumber) error
+	RemovePortedNumberDrv(string) error
 	GetLoadHistory(int, bool, string) ([]*utils.LoadInstance, error)
 	AddLoadHistory(*utils.LoadInstance, int, string) error
 	GetIndexesDrv(idxItmType, tntCtx, idxKey string) (indexes map[string]utils.StringSet, err error)
@@ -133,10 +139,24 @@ type DataDB interface {
 	GetRateProfileDrv(string, string) (*RateProfile, error)
 	SetRateProfileDrv(*RateProfile) error
 	RemoveRateProfileDrv(string, string) error
+	GetTaxProfileDrv(string, string) (*TaxProfile, error)
+	SetTaxProfileDrv(*TaxProfile) error
+	RemoveTaxProfileDrv(string, string) error
+	GetProfileRevisionsDrv(string, string, string) (*ProfileRevisions, error)
+	SetProfileRevisionsDrv(*ProfileRevisions) error
+	RemoveProfileRevisionsDrv(string, string, string) error
+	GetStoredSessionsDrv(string) ([]*StoredSession, error)
+	SetStoredSessionDrv(*StoredSession) error
+	RemoveStoredSessionDrv(string, string) error
+	GetConfigSectionsDrv() ([]*ConfigSection, error)
+	GetConfigSectionDrv(string) (*ConfigSection, error)
+	SetConfigSectionDrv(*ConfigSection) error
+	RemoveConfigSectionDrv(string) error
 }
 
 type StorDB interface {
 	CdrStorage
+	AuditStorage
 	LoadReader
 	LoadWriter
 }
@@ -151,6 +171,12 @@ type CdrStorage interface {
 	GetCDRs(*utils.CDRsFilter, bool) ([]*CDR, int64, error)
 }
 
+// AuditStorage stores the audit records of the administrative API calls
+type AuditStorage interface {
+	SetAuditRecord(*AuditRecord) error
+	GetAuditRecords(*utils.AuditRecordsFilter) ([]*AuditRecord, error)
+}
+
 type LoadStorage interface {
 	Storage
 	LoadReader
@@ -163,6 +189,8 @@ type LoadReader interface {
 	GetTpTableIds(string, string, utils.TPDistinctIds,
 		map[string]string, *utils.PaginatorWithSearch) ([]string, error)
 	GetTPTimings(string, string) ([]*utils.ApierTPTiming, error)
+	GetTPFxRates(string, string, string) ([]*utils.TPFxRate, error)
+	GetTPPortedNumbers(string, string) ([]*utils.TPPortedNumber, error)
 	GetTPDestinations(string, string) ([]*utils.TPDestination, error)
 	GetTPRates(string, string) ([]*utils.TPRateRALs, error)
 	GetTPDestinationRates(string, string, *utils.Paginator) ([]*utils.TPDestinationRate, error)
@@ -188,6 +216,8 @@ type LoadReader interface {
 type LoadWriter interface {
 	RemTpData(string, string, map[string]string) error
 	SetTPTimings([]*utils.ApierTPTiming) error
+	SetTPFxRates([]*utils.TPFxRate) error
+	SetTPPortedNumbers([]*utils.TPPortedNumber) error
 	SetTPDestinations([]*utils.TPDestination) error
 	SetTPRates([]*utils.TPRateRALs) error
 	SetTPDestinationRates([]*utils.TPDestinationRate) error
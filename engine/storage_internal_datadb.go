@@ -244,7 +244,8 @@ func (iDB *InternalDB) HasDataDrv(category, subject, tenant string) (bool, error
 	case utils.ResourcesPrefix, utils.ResourceProfilesPrefix, utils.StatQueuePrefix,
 		utils.StatQueueProfilePrefix, utils.ThresholdPrefix, utils.ThresholdProfilePrefix,
 		utils.FilterPrefix, utils.RouteProfilePrefix, utils.AttributeProfilePrefix,
-		utils.ChargerProfilePrefix, utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix:
+		utils.ChargerProfilePrefix, utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix,
+		utils.TaxProfilePrefix:
 		return Cache.HasItem(utils.CachePrefixToInstance[category], utils.ConcatenatedKey(tenant, subject)), nil
 	}
 	return false, errors.New("Unsupported HasData category")
@@ -698,6 +699,46 @@ func (iDB *InternalDB) RemoveTimingDrv(id string) (err error) {
 	return
 }
 
+func (iDB *InternalDB) GetFxRateDrv(id string) (fx *FxRate, err error) {
+	x, ok := Cache.Get(utils.CacheFxRates, id)
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*FxRate), nil
+}
+
+func (iDB *InternalDB) SetFxRateDrv(fx *FxRate) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheFxRates, fx.ID(), fx, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveFxRateDrv(id string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheFxRates, id,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetPortedNumberDrv(id string) (pn *PortedNumber, err error) {
+	x, ok := Cache.Get(utils.CachePortedNumbers, id)
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*PortedNumber), nil
+}
+
+func (iDB *InternalDB) SetPortedNumberDrv(pn *PortedNumber) (err error) {
+	Cache.SetWithoutReplicate(utils.CachePortedNumbers, pn.ID(), pn, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemovePortedNumberDrv(id string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CachePortedNumbers, id,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
 func (iDB *InternalDB) GetLoadHistory(int, bool, string) ([]*utils.LoadInstance, error) {
 	return nil, nil
 }
@@ -935,6 +976,80 @@ func (iDB *InternalDB) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	return
 }
 
+func (iDB *InternalDB) GetProfileRevisionsDrv(itemType, tenant, id string) (prvs *ProfileRevisions, err error) {
+	x, ok := Cache.Get(utils.CacheProfileRevisions, utils.ConcatenatedKey(itemType, tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*ProfileRevisions), nil
+}
+
+func (iDB *InternalDB) SetProfileRevisionsDrv(prvs *ProfileRevisions) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheProfileRevisions, utils.ConcatenatedKey(prvs.ItemType, prvs.TenantID()), prvs, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveProfileRevisionsDrv(itemType, tenant, id string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheProfileRevisions, utils.ConcatenatedKey(itemType, tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetStoredSessionsDrv(nodeID string) (sss []*StoredSession, err error) {
+	for _, key := range Cache.GetItemIDs(utils.CacheStoredSessions, nodeID+utils.CONCATENATED_KEY_SEP) {
+		x, ok := Cache.Get(utils.CacheStoredSessions, key)
+		if !ok || x == nil {
+			continue
+		}
+		sss = append(sss, x.(*StoredSession))
+	}
+	return
+}
+
+func (iDB *InternalDB) SetStoredSessionDrv(ss *StoredSession) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheStoredSessions, ss.ID(), ss, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveStoredSessionDrv(nodeID, cgrID string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheStoredSessions, utils.ConcatenatedKey(nodeID, cgrID),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) GetConfigSectionsDrv() (css []*ConfigSection, err error) {
+	for _, key := range Cache.GetItemIDs(utils.CacheConfigSections, utils.EmptyString) {
+		x, ok := Cache.Get(utils.CacheConfigSections, key)
+		if !ok || x == nil {
+			continue
+		}
+		css = append(css, x.(*ConfigSection))
+	}
+	return
+}
+
+func (iDB *InternalDB) GetConfigSectionDrv(section string) (cs *ConfigSection, err error) {
+	x, ok := Cache.Get(utils.CacheConfigSections, section)
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*ConfigSection), nil
+}
+
+func (iDB *InternalDB) SetConfigSectionDrv(cs *ConfigSection) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheConfigSections, cs.Section, cs, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveConfigSectionDrv(section string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheConfigSections, section,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
 func (iDB *InternalDB) GetRateProfileDrv(tenant, id string) (rpp *RateProfile, err error) {
 	x, ok := Cache.Get(utils.CacheRateProfiles, utils.ConcatenatedKey(tenant, id))
 	if !ok || x == nil {
@@ -955,6 +1070,26 @@ func (iDB *InternalDB) RemoveRateProfileDrv(tenant, id string) (err error) {
 	return
 }
 
+func (iDB *InternalDB) GetTaxProfileDrv(tenant, id string) (txp *TaxProfile, err error) {
+	x, ok := Cache.Get(utils.CacheTaxProfiles, utils.ConcatenatedKey(tenant, id))
+	if !ok || x == nil {
+		return nil, utils.ErrNotFound
+	}
+	return x.(*TaxProfile), nil
+}
+
+func (iDB *InternalDB) SetTaxProfileDrv(txp *TaxProfile) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheTaxProfiles, txp.TenantID(), txp, nil,
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+func (iDB *InternalDB) RemoveTaxProfileDrv(tenant, id string) (err error) {
+	Cache.RemoveWithoutReplicate(utils.CacheTaxProfiles, utils.ConcatenatedKey(tenant, id),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
 func (iDB *InternalDB) RemoveLoadIDsDrv() (err error) {
 	return utils.ErrNotImplemented
 }
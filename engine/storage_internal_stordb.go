@@ -87,6 +87,50 @@ func (iDB *InternalDB) GetTPTimings(tpid, id string) (timings []*utils.ApierTPTi
 	return
 }
 
+func (iDB *InternalDB) GetTPFxRates(tpid, fromCurrency, toCurrency string) (fxRates []*utils.TPFxRate, err error) {
+	key := tpid
+	if fromCurrency != utils.EmptyString {
+		key += utils.CONCATENATED_KEY_SEP + fromCurrency
+	}
+	for _, id := range Cache.GetItemIDs(utils.CacheTBLTPFxRates, key) {
+		x, ok := Cache.Get(utils.CacheTBLTPFxRates, id)
+		if !ok || x == nil {
+			return nil, utils.ErrNotFound
+		}
+		fx := x.(*utils.TPFxRate)
+		if toCurrency != utils.EmptyString && fx.ToCurrency != toCurrency {
+			continue
+		}
+		fxRates = append(fxRates, fx)
+	}
+	if len(fxRates) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
+func (iDB *InternalDB) GetTPPortedNumbers(tpid, number string) (pns []*utils.TPPortedNumber, err error) {
+	key := tpid
+	if number != utils.EmptyString {
+		key += utils.CONCATENATED_KEY_SEP + number
+	}
+	for _, id := range Cache.GetItemIDs(utils.CacheTBLTPPortedNumbers, key) {
+		x, ok := Cache.Get(utils.CacheTBLTPPortedNumbers, id)
+		if !ok || x == nil {
+			return nil, utils.ErrNotFound
+		}
+		pn := x.(*utils.TPPortedNumber)
+		if number != utils.EmptyString && pn.Number != number {
+			continue
+		}
+		pns = append(pns, pn)
+	}
+	if len(pns) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
+
 func (iDB *InternalDB) GetTPDestinations(tpid, id string) (dsts []*utils.TPDestination, err error) {
 	key := tpid
 	if id != utils.EmptyString {
@@ -614,6 +658,24 @@ func (iDB *InternalDB) SetTPTimings(timings []*utils.ApierTPTiming) (err error)
 	}
 	return
 }
+func (iDB *InternalDB) SetTPFxRates(fxRates []*utils.TPFxRate) (err error) {
+	for _, fx := range fxRates {
+		Cache.SetWithoutReplicate(utils.CacheTBLTPFxRates,
+			utils.ConcatenatedKey(fx.TPid, fx.FromCurrency, fx.ToCurrency), fx, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	}
+	return
+}
+
+func (iDB *InternalDB) SetTPPortedNumbers(pns []*utils.TPPortedNumber) (err error) {
+	for _, pn := range pns {
+		Cache.SetWithoutReplicate(utils.CacheTBLTPPortedNumbers,
+			utils.ConcatenatedKey(pn.TPid, pn.Number), pn, nil,
+			cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	}
+	return
+}
+
 func (iDB *InternalDB) SetTPDestinations(dests []*utils.TPDestination) (err error) {
 	if len(dests) == 0 {
 		return nil
@@ -1794,3 +1856,53 @@ func (iDB *InternalDB) SetSMCost(smCost *SMCost) (err error) {
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return err
 }
+
+// SetAuditRecord stores the audit record, grouped by tenant
+func (iDB *InternalDB) SetAuditRecord(rec *AuditRecord) (err error) {
+	Cache.SetWithoutReplicate(utils.CacheAuditRecordsTBL, utils.ConcatenatedKey(rec.Tenant, rec.ID), rec,
+		[]string{utils.ConcatenatedKey(utils.Tenant, rec.Tenant)},
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+// GetAuditRecords returns the audit records matching the filter, ordered by time
+func (iDB *InternalDB) GetAuditRecords(qryFltr *utils.AuditRecordsFilter) (recs []*AuditRecord, err error) {
+	itemTypes := utils.NewStringSet(qryFltr.ItemTypes)
+	itemIDs := utils.NewStringSet(qryFltr.ItemIDs)
+	methods := utils.NewStringSet(qryFltr.Methods)
+	callers := utils.NewStringSet(qryFltr.Callers)
+	for _, key := range Cache.tCache.GetGroupItemIDs(utils.CacheAuditRecordsTBL,
+		utils.ConcatenatedKey(utils.Tenant, qryFltr.Tenant)) {
+		x, ok := Cache.Get(utils.CacheAuditRecordsTBL, key)
+		if !ok || x == nil {
+			continue
+		}
+		rec := x.(*AuditRecord)
+		if (itemTypes.Size() != 0 && !itemTypes.Has(rec.ItemType)) ||
+			(itemIDs.Size() != 0 && !itemIDs.Has(rec.ItemID)) ||
+			(methods.Size() != 0 && !methods.Has(rec.Method)) ||
+			(callers.Size() != 0 && !callers.Has(rec.Caller)) ||
+			(qryFltr.Time.Begin != nil && rec.Time.Before(*qryFltr.Time.Begin)) ||
+			(qryFltr.Time.End != nil && !rec.Time.Before(*qryFltr.Time.End)) {
+			continue
+		}
+		recs = append(recs, rec)
+	}
+	sort.Slice(recs, func(i, j int) bool {
+		return recs[i].Time.Before(recs[j].Time)
+	})
+	if qryFltr.Paginator.Offset != nil {
+		if *qryFltr.Paginator.Offset >= len(recs) {
+			recs = nil
+		} else {
+			recs = recs[*qryFltr.Paginator.Offset:]
+		}
+	}
+	if qryFltr.Paginator.Limit != nil && *qryFltr.Paginator.Limit < len(recs) {
+		recs = recs[:*qryFltr.Paginator.Limit]
+	}
+	if len(recs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
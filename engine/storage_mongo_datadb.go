@@ -62,6 +62,8 @@ const (
 	ColRsP  = "resource_profiles"
 	ColIndx = "indexes"
 	ColTmg  = "timings"
+	ColFxr  = "fx_rates"
+	ColMnp  = "ported_numbers"
 	ColRes  = "resources"
 	ColSqs  = "statqueues"
 	ColSqp  = "statqueue_profiles"
@@ -75,7 +77,11 @@ const (
 	ColDpp  = "dispatcher_profiles"
 	ColDph  = "dispatcher_hosts"
 	ColRpp  = "rate_profiles"
+	ColTxp  = "tax_profiles"
 	ColLID  = "load_ids"
+	ColPrv  = "profile_revisions"
+	ColSsn  = "stored_sessions"
+	ColCfs  = "config_sections"
 )
 
 var (
@@ -287,7 +293,7 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 		if err = ms.enusureIndex(col, true, "key"); err != nil {
 			return
 		}
-	case ColRsP, ColRes, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph, ColRpp:
+	case ColRsP, ColRes, ColSqs, ColSqp, ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColDph, ColRpp, ColTxp:
 		if err = ms.enusureIndex(col, true, "tenant", "id"); err != nil {
 			return
 		}
@@ -295,6 +301,18 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 		if err = ms.enusureIndex(col, true, "id"); err != nil {
 			return
 		}
+	case ColPrv:
+		if err = ms.enusureIndex(col, true, "itemtype", "tenant", "id"); err != nil {
+			return
+		}
+	case ColSsn:
+		if err = ms.enusureIndex(col, true, "nodeid", "cgrid"); err != nil {
+			return
+		}
+	case ColCfs:
+		if err = ms.enusureIndex(col, true, "section"); err != nil {
+			return
+		}
 		//StorDB
 	case utils.TBLTPTimings, utils.TBLTPDestinations,
 		utils.TBLTPDestinationRates, utils.TBLTPRatingPlans,
@@ -334,6 +352,16 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 			OriginIDLow); err != nil {
 			return
 		}
+	case utils.AuditRecordsTBL:
+		if err = ms.enusureIndex(col, true, "id"); err != nil {
+			return
+		}
+		if err = ms.enusureIndex(col, false, "tenant", "itemtype", "itemid"); err != nil {
+			return
+		}
+		if err = ms.enusureIndex(col, false, "time"); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -351,8 +379,8 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 	if ms.storageType == utils.DataDB {
 		for _, col := range []string{ColAct, ColApl, ColAAp, ColAtr,
 			ColRpl, ColDst, ColRds, ColLht, ColIndx, ColRsP, ColRes, ColSqs, ColSqp,
-			ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColRpp,
-			ColRpf, ColShg, ColAcc} {
+			ColTps, ColThs, ColRts, ColAttr, ColFlt, ColCpp, ColDpp, ColRpp, ColTxp,
+			ColRpf, ColShg, ColAcc, ColPrv, ColSsn, ColCfs} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -364,7 +392,8 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 			utils.TBLTPSharedGroups, utils.TBLTPActions,
 			utils.TBLTPActionPlans, utils.TBLTPActionTriggers,
 			utils.TBLTPStats, utils.TBLTPResources,
-			utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL} {
+			utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL,
+			utils.AuditRecordsTBL} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -389,6 +418,8 @@ func (ms *MongoStorage) getColNameForPrefix(prefix string) (string, bool) {
 		utils.LOADINST_KEY:               ColLht,
 		utils.VERSION_PREFIX:             ColVer,
 		utils.TimingsPrefix:              ColTmg,
+		utils.FxRatesPrefix:              ColFxr,
+		utils.PortedNumbersPrefix:        ColMnp,
 		utils.ResourcesPrefix:            ColRes,
 		utils.ResourceProfilesPrefix:     ColRsP,
 		utils.ThresholdProfilePrefix:     ColTps,
@@ -668,6 +699,10 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err er
 			result, err = ms.getField(sctx, ColAAp, utils.AccountActionPlansPrefix, subject, "key")
 		case utils.TimingsPrefix:
 			result, err = ms.getField(sctx, ColTmg, utils.TimingsPrefix, subject, "id")
+		case utils.FxRatesPrefix:
+			result, err = ms.getField(sctx, ColFxr, utils.FxRatesPrefix, subject, "id")
+		case utils.PortedNumbersPrefix:
+			result, err = ms.getField(sctx, ColMnp, utils.PortedNumbersPrefix, subject, "id")
 		case utils.FilterPrefix:
 			result, err = ms.getField2(sctx, ColFlt, utils.FilterPrefix, subject, tntID)
 		case utils.ThresholdPrefix:
@@ -686,6 +721,8 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err er
 			result, err = ms.getField2(sctx, ColRpp, utils.RateProfilePrefix, subject, tntID)
 		case utils.DispatcherHostPrefix:
 			result, err = ms.getField2(sctx, ColDph, utils.DispatcherHostPrefix, subject, tntID)
+		case utils.TaxProfilePrefix:
+			result, err = ms.getField2(sctx, ColTxp, utils.TaxProfilePrefix, subject, tntID)
 		case utils.AttributeFilterIndexes:
 			result, err = ms.getField3(sctx, ColIndx, utils.AttributeFilterIndexes, "key")
 		case utils.ResourceFilterIndexes:
@@ -700,6 +737,8 @@ func (ms *MongoStorage) GetKeysForPrefix(prefix string) (result []string, err er
 			result, err = ms.getField3(sctx, ColIndx, utils.ChargerFilterIndexes, "key")
 		case utils.DispatcherFilterIndexes:
 			result, err = ms.getField3(sctx, ColIndx, utils.DispatcherFilterIndexes, "key")
+		case utils.TaxFilterIndexes:
+			result, err = ms.getField3(sctx, ColIndx, utils.TaxFilterIndexes, "key")
 		default:
 			err = fmt.Errorf("unsupported prefix in GetKeysForPrefix: %s", prefix)
 		}
@@ -750,6 +789,8 @@ func (ms *MongoStorage) HasDataDrv(category, subject, tenant string) (has bool,
 			count, err = ms.getCol(ColDph).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
 		case utils.RateProfilePrefix:
 			count, err = ms.getCol(ColRpp).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
+		case utils.TaxProfilePrefix:
+			count, err = ms.getCol(ColTxp).CountDocuments(sctx, bson.M{"tenant": tenant, "id": subject})
 		default:
 			err = fmt.Errorf("unsupported category in HasData: %s", category)
 		}
@@ -1741,6 +1782,84 @@ func (ms *MongoStorage) RemoveTimingDrv(id string) (err error) {
 	})
 }
 
+func (ms *MongoStorage) GetFxRateDrv(id string) (fx *FxRate, err error) {
+	fx = new(FxRate)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColFxr).FindOne(sctx, bson.M{"id": id})
+		if err := cur.Decode(fx); err != nil {
+			fx = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetFxRateDrv(fx *FxRate) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColFxr).UpdateOne(sctx, bson.M{"id": fx.ID()},
+			bson.M{"$set": bson.M{"id": fx.ID(),
+				"fromcurrency": fx.FromCurrency,
+				"tocurrency":   fx.ToCurrency,
+				"rate":         fx.Rate}},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveFxRateDrv(id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColFxr).DeleteOne(sctx, bson.M{"id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetPortedNumberDrv(id string) (pn *PortedNumber, err error) {
+	pn = new(PortedNumber)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColMnp).FindOne(sctx, bson.M{"id": id})
+		if err := cur.Decode(pn); err != nil {
+			pn = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetPortedNumberDrv(pn *PortedNumber) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColMnp).UpdateOne(sctx, bson.M{"id": pn.ID()},
+			bson.M{"$set": bson.M{"id": pn.ID(),
+				"number":  pn.Number,
+				"lrn":     pn.LRN,
+				"network": pn.Network}},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemovePortedNumberDrv(id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColMnp).DeleteOne(sctx, bson.M{"id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
 // GetStatQueueProfileDrv retrieves a StatQueueProfile from dataDB
 func (ms *MongoStorage) GetStatQueueProfileDrv(tenant string, id string) (sq *StatQueueProfile, err error) {
 	sq = new(StatQueueProfile)
@@ -2113,6 +2232,128 @@ func (ms *MongoStorage) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	})
 }
 
+func (ms *MongoStorage) GetProfileRevisionsDrv(itemType, tenant, id string) (prvs *ProfileRevisions, err error) {
+	prvs = new(ProfileRevisions)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColPrv).FindOne(sctx, bson.M{"itemtype": itemType, "tenant": tenant, "id": id})
+		if err := cur.Decode(prvs); err != nil {
+			prvs = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetProfileRevisionsDrv(prvs *ProfileRevisions) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColPrv).UpdateOne(sctx, bson.M{"itemtype": prvs.ItemType, "tenant": prvs.Tenant, "id": prvs.ID},
+			bson.M{"$set": prvs},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveProfileRevisionsDrv(itemType, tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColPrv).DeleteOne(sctx, bson.M{"itemtype": itemType, "tenant": tenant, "id": id})
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetStoredSessionsDrv(nodeID string) (sss []*StoredSession, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(ColSsn).Find(sctx, bson.M{"nodeid": nodeID})
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var ss StoredSession
+			if err = cur.Decode(&ss); err != nil {
+				cur.Close(sctx)
+				return err
+			}
+			ss.initEventCosts()
+			sss = append(sss, &ss)
+		}
+		return cur.Close(sctx)
+	})
+	return
+}
+
+func (ms *MongoStorage) SetStoredSessionDrv(ss *StoredSession) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColSsn).UpdateOne(sctx, bson.M{"nodeid": ss.NodeID, "cgrid": ss.CGRID},
+			bson.M{"$set": ss},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveStoredSessionDrv(nodeID, cgrID string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColSsn).DeleteOne(sctx, bson.M{"nodeid": nodeID, "cgrid": cgrID})
+		return err
+	})
+}
+
+func (ms *MongoStorage) GetConfigSectionsDrv() (css []*ConfigSection, err error) {
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(ColCfs).Find(sctx, bson.D{})
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var cs ConfigSection
+			if err = cur.Decode(&cs); err != nil {
+				cur.Close(sctx)
+				return err
+			}
+			css = append(css, &cs)
+		}
+		return cur.Close(sctx)
+	})
+	return
+}
+
+func (ms *MongoStorage) GetConfigSectionDrv(section string) (cs *ConfigSection, err error) {
+	cs = new(ConfigSection)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColCfs).FindOne(sctx, bson.M{"section": section})
+		if err := cur.Decode(cs); err != nil {
+			cs = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetConfigSectionDrv(cs *ConfigSection) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColCfs).UpdateOne(sctx, bson.M{"section": cs.Section},
+			bson.M{"$set": cs},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveConfigSectionDrv(section string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColCfs).DeleteOne(sctx, bson.M{"section": section})
+		return err
+	})
+}
+
 func (ms *MongoStorage) GetItemLoadIDsDrv(itemIDPrefix string) (loadIDs map[string]int64, err error) {
 	fop := options.FindOne()
 	if itemIDPrefix != "" {
@@ -2190,6 +2431,42 @@ func (ms *MongoStorage) RemoveRateProfileDrv(tenant, id string) (err error) {
 	})
 }
 
+func (ms *MongoStorage) GetTaxProfileDrv(tenant, id string) (txp *TaxProfile, err error) {
+	txp = new(TaxProfile)
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur := ms.getCol(ColTxp).FindOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if err := cur.Decode(txp); err != nil {
+			txp = nil
+			if err == mongo.ErrNoDocuments {
+				return utils.ErrNotFound
+			}
+			return err
+		}
+		return nil
+	})
+	return
+}
+
+func (ms *MongoStorage) SetTaxProfileDrv(txp *TaxProfile) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(ColTxp).UpdateOne(sctx, bson.M{"tenant": txp.Tenant, "id": txp.ID},
+			bson.M{"$set": txp},
+			options.Update().SetUpsert(true),
+		)
+		return err
+	})
+}
+
+func (ms *MongoStorage) RemoveTaxProfileDrv(tenant, id string) (err error) {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		dr, err := ms.getCol(ColTxp).DeleteOne(sctx, bson.M{"tenant": tenant, "id": id})
+		if dr.DeletedCount == 0 {
+			return utils.ErrNotFound
+		}
+		return err
+	})
+}
+
 // GetIndexesDrv retrieves Indexes from dataDB
 // the key is the tenant of the item or in case of context dependent profiles is a concatenatedKey between tenant and context
 // id is used as a concatenated key in case of filterIndexes the id will be filterType:fieldName:fieldVal
@@ -182,6 +182,61 @@ func (ms *MongoStorage) GetTPTimings(tpid, id string) ([]*utils.ApierTPTiming, e
 	return results, err
 }
 
+func (ms *MongoStorage) GetTPFxRates(tpid, fromCurrency, toCurrency string) ([]*utils.TPFxRate, error) {
+	filter := bson.M{"tpid": tpid}
+	if fromCurrency != "" {
+		filter["fromcurrency"] = fromCurrency
+	}
+	if toCurrency != "" {
+		filter["tocurrency"] = toCurrency
+	}
+	var results []*utils.TPFxRate
+	err := ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.TBLTPFxRates).Find(sctx, filter)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var el utils.TPFxRate
+			if err := cur.Decode(&el); err != nil {
+				return err
+			}
+			results = append(results, &el)
+		}
+		if len(results) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return results, err
+}
+
+func (ms *MongoStorage) GetTPPortedNumbers(tpid, number string) ([]*utils.TPPortedNumber, error) {
+	filter := bson.M{"tpid": tpid}
+	if number != "" {
+		filter["number"] = number
+	}
+	var results []*utils.TPPortedNumber
+	err := ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.TBLTPPortedNumbers).Find(sctx, filter)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var el utils.TPPortedNumber
+			if err := cur.Decode(&el); err != nil {
+				return err
+			}
+			results = append(results, &el)
+		}
+		if len(results) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return results, err
+}
+
 func (ms *MongoStorage) GetTPDestinations(tpid, id string) ([]*utils.TPDestination, error) {
 	filter := bson.M{"tpid": tpid}
 	if id != "" {
@@ -583,6 +638,13 @@ func (ms *MongoStorage) RemTpData(table, tpid string, args map[string]string) er
 		args["id"] = args["tag"]
 		delete(args, "tag")
 	}
+	for sqlKey, mgoKey := range map[string]string{ // FxRates keys used in SQL models
+		"from_currency": "fromcurrency", "to_currency": "tocurrency"} {
+		if val, has := args[sqlKey]; has {
+			args[mgoKey] = val
+			delete(args, sqlKey)
+		}
+	}
 	if tpid != "" {
 		args["tpid"] = tpid
 	}
@@ -613,6 +675,42 @@ func (ms *MongoStorage) SetTPTimings(tps []*utils.ApierTPTiming) error {
 	})
 }
 
+func (ms *MongoStorage) SetTPFxRates(tps []*utils.TPFxRate) error {
+	if len(tps) == 0 {
+		return nil
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		for _, tp := range tps {
+			if _, err = ms.getCol(utils.TBLTPFxRates).UpdateOne(sctx,
+				bson.M{"tpid": tp.TPid, "fromcurrency": tp.FromCurrency, "tocurrency": tp.ToCurrency},
+				bson.M{"$set": tp},
+				options.Update().SetUpsert(true),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (ms *MongoStorage) SetTPPortedNumbers(tps []*utils.TPPortedNumber) error {
+	if len(tps) == 0 {
+		return nil
+	}
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		for _, tp := range tps {
+			if _, err = ms.getCol(utils.TBLTPPortedNumbers).UpdateOne(sctx,
+				bson.M{"tpid": tp.TPid, "number": tp.Number},
+				bson.M{"$set": tp},
+				options.Update().SetUpsert(true),
+			); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 func (ms *MongoStorage) SetTPDestinations(tpDsts []*utils.TPDestination) (err error) {
 	if len(tpDsts) == 0 {
 		return nil
@@ -1177,6 +1275,52 @@ func (ms *MongoStorage) GetCDRs(qryFltr *utils.CDRsFilter, remove bool) ([]*CDR,
 	return cdrs, 0, err
 }
 
+// SetAuditRecord stores the audit record
+func (ms *MongoStorage) SetAuditRecord(rec *AuditRecord) error {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(utils.AuditRecordsTBL).InsertOne(sctx, rec)
+		return
+	})
+}
+
+// GetAuditRecords returns the audit records matching the filter, ordered by time
+func (ms *MongoStorage) GetAuditRecords(qryFltr *utils.AuditRecordsFilter) (recs []*AuditRecord, err error) {
+	filters := bson.M{
+		"tenant":   qryFltr.Tenant,
+		"itemtype": bson.M{"$in": qryFltr.ItemTypes},
+		"itemid":   bson.M{"$in": qryFltr.ItemIDs},
+		"method":   bson.M{"$in": qryFltr.Methods},
+		"caller":   bson.M{"$in": qryFltr.Callers},
+		"time":     bson.M{"$gte": qryFltr.Time.Begin, "$lt": qryFltr.Time.End},
+	}
+	ms.cleanEmptyFilters(filters)
+	fop := options.Find().SetSort(bson.M{"time": 1})
+	if qryFltr.Paginator.Limit != nil {
+		fop = fop.SetLimit(int64(*qryFltr.Paginator.Limit))
+	}
+	if qryFltr.Paginator.Offset != nil {
+		fop = fop.SetSkip(int64(*qryFltr.Paginator.Offset))
+	}
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.AuditRecordsTBL).Find(sctx, filters, fop)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			var rec AuditRecord
+			if err = cur.Decode(&rec); err != nil {
+				return err
+			}
+			recs = append(recs, &rec)
+		}
+		if len(recs) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return
+}
+
 func (ms *MongoStorage) SetTPStats(tpSTs []*utils.TPStatProfile) (err error) {
 	if len(tpSTs) == 0 {
 		return
@@ -414,7 +414,7 @@ func (rs *RedisStorage) HasDataDrv(category, subject, tenant string) (bool, erro
 		utils.StatQueueProfilePrefix, utils.ThresholdPrefix, utils.ThresholdProfilePrefix,
 		utils.FilterPrefix, utils.RouteProfilePrefix, utils.AttributeProfilePrefix,
 		utils.ChargerProfilePrefix, utils.DispatcherProfilePrefix, utils.DispatcherHostPrefix,
-		utils.RateProfilePrefix:
+		utils.RateProfilePrefix, utils.TaxProfilePrefix:
 		i, err := rs.Cmd(redis_EXISTS, category+utils.ConcatenatedKey(tenant, subject)).Int()
 		return i == 1, err
 	}
@@ -1212,6 +1212,54 @@ func (rs *RedisStorage) RemoveTimingDrv(id string) (err error) {
 	return
 }
 
+func (rs *RedisStorage) GetFxRateDrv(id string) (fx *FxRate, err error) {
+	var values []byte
+	if values, err = rs.Cmd(redis_GET, utils.FxRatesPrefix+id).Bytes(); err != nil {
+		if err == redis.ErrRespNil {
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	err = rs.ms.Unmarshal(values, &fx)
+	return
+}
+
+func (rs *RedisStorage) SetFxRateDrv(fx *FxRate) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(fx); err != nil {
+		return
+	}
+	return rs.Cmd(redis_SET, utils.FxRatesPrefix+fx.ID(), result).Err
+}
+
+func (rs *RedisStorage) RemoveFxRateDrv(id string) (err error) {
+	return rs.Cmd(redis_DEL, utils.FxRatesPrefix+id).Err
+}
+
+func (rs *RedisStorage) GetPortedNumberDrv(id string) (pn *PortedNumber, err error) {
+	var values []byte
+	if values, err = rs.Cmd(redis_GET, utils.PortedNumbersPrefix+id).Bytes(); err != nil {
+		if err == redis.ErrRespNil {
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	err = rs.ms.Unmarshal(values, &pn)
+	return
+}
+
+func (rs *RedisStorage) SetPortedNumberDrv(pn *PortedNumber) (err error) {
+	var result []byte
+	if result, err = rs.ms.Marshal(pn); err != nil {
+		return
+	}
+	return rs.Cmd(redis_SET, utils.PortedNumbersPrefix+pn.ID(), result).Err
+}
+
+func (rs *RedisStorage) RemovePortedNumberDrv(id string) (err error) {
+	return rs.Cmd(redis_DEL, utils.PortedNumbersPrefix+id).Err
+}
+
 func (rs *RedisStorage) GetVersions(itm string) (vrs Versions, err error) {
 	if itm != "" {
 		fldVal, err := rs.Cmd(redis_HGET, utils.TBLVersions, itm).Str()
@@ -1582,6 +1630,110 @@ func (rs *RedisStorage) RemoveDispatcherHostDrv(tenant, id string) (err error) {
 	return
 }
 
+func (rs *RedisStorage) GetProfileRevisionsDrv(itemType, tenant, id string) (prvs *ProfileRevisions, err error) {
+	key := utils.ProfileRevisionsPrefix + utils.ConcatenatedKey(itemType, tenant, id)
+	var values []byte
+	if values, err = rs.Cmd(redis_GET, key).Bytes(); err != nil {
+		if err == redis.ErrRespNil {
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	err = rs.ms.Unmarshal(values, &prvs)
+	return
+}
+
+func (rs *RedisStorage) SetProfileRevisionsDrv(prvs *ProfileRevisions) (err error) {
+	result, err := rs.ms.Marshal(prvs)
+	if err != nil {
+		return err
+	}
+	return rs.Cmd(redis_SET, utils.ProfileRevisionsPrefix+utils.ConcatenatedKey(prvs.ItemType, prvs.TenantID()), result).Err
+}
+
+func (rs *RedisStorage) RemoveProfileRevisionsDrv(itemType, tenant, id string) (err error) {
+	return rs.Cmd(redis_DEL, utils.ProfileRevisionsPrefix+utils.ConcatenatedKey(itemType, tenant, id)).Err
+}
+
+func (rs *RedisStorage) GetStoredSessionsDrv(nodeID string) (sss []*StoredSession, err error) {
+	var keys []string
+	if keys, err = rs.GetKeysForPrefix(utils.StoredSessionPrefix + nodeID + utils.CONCATENATED_KEY_SEP); err != nil {
+		return
+	}
+	for _, key := range keys {
+		var values []byte
+		if values, err = rs.Cmd(redis_GET, key).Bytes(); err != nil {
+			if err == redis.ErrRespNil { // removed in the meantime
+				err = nil
+				continue
+			}
+			return
+		}
+		var ss *StoredSession
+		if err = rs.ms.Unmarshal(values, &ss); err != nil {
+			return
+		}
+		ss.initEventCosts()
+		sss = append(sss, ss)
+	}
+	return
+}
+
+func (rs *RedisStorage) SetStoredSessionDrv(ss *StoredSession) (err error) {
+	result, err := rs.ms.Marshal(ss)
+	if err != nil {
+		return err
+	}
+	return rs.Cmd(redis_SET, utils.StoredSessionPrefix+ss.ID(), result).Err
+}
+
+func (rs *RedisStorage) RemoveStoredSessionDrv(nodeID, cgrID string) (err error) {
+	return rs.Cmd(redis_DEL, utils.StoredSessionPrefix+utils.ConcatenatedKey(nodeID, cgrID)).Err
+}
+
+func (rs *RedisStorage) GetConfigSectionsDrv() (css []*ConfigSection, err error) {
+	var keys []string
+	if keys, err = rs.GetKeysForPrefix(utils.ConfigSectionPrefix); err != nil {
+		return
+	}
+	for _, key := range keys {
+		var cs *ConfigSection
+		if cs, err = rs.GetConfigSectionDrv(key[len(utils.ConfigSectionPrefix):]); err != nil {
+			if err == utils.ErrNotFound { // removed in the meantime
+				err = nil
+				continue
+			}
+			return
+		}
+		css = append(css, cs)
+	}
+	return
+}
+
+func (rs *RedisStorage) GetConfigSectionDrv(section string) (cs *ConfigSection, err error) {
+	var values []byte
+	if values, err = rs.Cmd(redis_GET, utils.ConfigSectionPrefix+section).Bytes(); err != nil {
+		if err == redis.ErrRespNil { // did not find the section
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	err = rs.ms.Unmarshal(values, &cs)
+	return
+}
+
+func (rs *RedisStorage) SetConfigSectionDrv(cs *ConfigSection) (err error) {
+	result, err := rs.ms.Marshal(cs)
+	if err != nil {
+		return err
+	}
+	return rs.Cmd(redis_SET, utils.ConfigSectionPrefix+cs.Section, result).Err
+}
+
+func (rs *RedisStorage) RemoveConfigSectionDrv(section string) (err error) {
+	return rs.Cmd(redis_DEL, utils.ConfigSectionPrefix+section).Err
+}
+
 func (rs *RedisStorage) GetStorageType() string {
 	return utils.REDIS
 }
@@ -1654,6 +1806,32 @@ func (rs *RedisStorage) RemoveRateProfileDrv(tenant, id string) (err error) {
 	return
 }
 
+func (rs *RedisStorage) GetTaxProfileDrv(tenant, id string) (txp *TaxProfile, err error) {
+	key := utils.TaxProfilePrefix + utils.ConcatenatedKey(tenant, id)
+	var values []byte
+	if values, err = rs.Cmd(redis_GET, key).Bytes(); err != nil {
+		if err == redis.ErrRespNil {
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	err = rs.ms.Unmarshal(values, &txp)
+	return
+}
+
+func (rs *RedisStorage) SetTaxProfileDrv(txp *TaxProfile) (err error) {
+	result, err := rs.ms.Marshal(txp)
+	if err != nil {
+		return err
+	}
+	return rs.Cmd(redis_SET, utils.TaxProfilePrefix+utils.ConcatenatedKey(txp.Tenant, txp.ID), result).Err
+}
+
+func (rs *RedisStorage) RemoveTaxProfileDrv(tenant, id string) (err error) {
+	key := utils.TaxProfilePrefix + utils.ConcatenatedKey(tenant, id)
+	return rs.Cmd(redis_DEL, key).Err
+}
+
 // GetIndexesDrv retrieves Indexes from dataDB
 func (rs *RedisStorage) GetIndexesDrv(idxItmType, tntCtx, idxKey string) (indexes map[string]utils.StringSet, err error) {
 	mp := make(map[string]string)
@@ -105,7 +105,8 @@ func (self *SQLStorage) IsDBEmpty() (resp bool, err error) {
 		utils.TBLTPAccountActions, utils.TBLTPResources, utils.TBLTPStats, utils.TBLTPThresholds,
 		utils.TBLTPFilters, utils.SessionCostsTBL, utils.CDRsTBL, utils.TBLTPActionPlans,
 		utils.TBLVersions, utils.TBLTPRoutes, utils.TBLTPAttributes, utils.TBLTPChargers,
-		utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts,
+		utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts, utils.AuditRecordsTBL,
+		utils.TBLTPFxRates, utils.TBLTPPortedNumbers,
 	}
 	for _, tbl := range tbls {
 		if self.db.HasTable(tbl) {
@@ -239,7 +240,8 @@ func (self *SQLStorage) RemTpData(table, tpid string, args map[string]string) er
 			utils.TBLTPActionTriggers, utils.TBLTPAccountActions,
 			utils.TBLTPResources, utils.TBLTPStats, utils.TBLTPFilters,
 			utils.TBLTPRoutes, utils.TBLTPAttributes, utils.TBLTPRateProfiles,
-			utils.TBLTPChargers, utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts} {
+			utils.TBLTPChargers, utils.TBLTPDispatchers, utils.TBLTPDispatcherHosts,
+			utils.TBLTPFxRates, utils.TBLTPPortedNumbers} {
 			if err := tx.Table(tblName).Where("tpid = ?", tpid).Delete(nil).Error; err != nil {
 				tx.Rollback()
 				return err
@@ -283,6 +285,47 @@ func (self *SQLStorage) SetTPTimings(timings []*utils.ApierTPTiming) error {
 	return nil
 }
 
+func (self *SQLStorage) SetTPFxRates(fxRates []*utils.TPFxRate) error {
+	if len(fxRates) == 0 {
+		return nil
+	}
+	tx := self.db.Begin()
+	for _, fxRate := range fxRates {
+		if err := tx.Where(&TpFxRate{Tpid: fxRate.TPid, FromCurrency: fxRate.FromCurrency,
+			ToCurrency: fxRate.ToCurrency}).Delete(TpFxRate{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		mdl := APItoModelFxRate(fxRate)
+		if err := tx.Save(&mdl).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
+func (self *SQLStorage) SetTPPortedNumbers(pns []*utils.TPPortedNumber) error {
+	if len(pns) == 0 {
+		return nil
+	}
+	tx := self.db.Begin()
+	for _, pn := range pns {
+		if err := tx.Where(&TpPortedNumber{Tpid: pn.TPid, Number: pn.Number}).Delete(TpPortedNumber{}).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+		mdl := APItoModelPortedNumber(pn)
+		if err := tx.Save(&mdl).Error; err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	tx.Commit()
+	return nil
+}
+
 func (self *SQLStorage) SetTPDestinations(dests []*utils.TPDestination) error {
 	if len(dests) == 0 {
 		return nil
@@ -894,6 +937,83 @@ func (self *SQLStorage) GetSMCosts(cgrid, runid, originHost, originIDPrefix stri
 	return smCosts, nil
 }
 
+// SetAuditRecord stores the audit record
+func (self *SQLStorage) SetAuditRecord(rec *AuditRecord) error {
+	tx := self.db.Begin()
+	arSQL := &AuditRecordSQL{
+		AuditID:   rec.ID,
+		Tenant:    rec.Tenant,
+		ItemType:  rec.ItemType,
+		ItemID:    rec.ItemID,
+		Method:    rec.Method,
+		Caller:    rec.Caller,
+		Changes:   utils.ToJSON(rec.Changes),
+		Error:     rec.Error,
+		CreatedAt: rec.Time,
+	}
+	if err := tx.Save(arSQL).Error; err != nil {
+		tx.Rollback()
+		return err
+	}
+	tx.Commit()
+	return nil
+}
+
+// GetAuditRecords returns the audit records matching the filter, ordered by time
+func (self *SQLStorage) GetAuditRecords(qryFltr *utils.AuditRecordsFilter) ([]*AuditRecord, error) {
+	q := self.db.Table(utils.AuditRecordsTBL).Select("*").
+		Where("tenant = ?", qryFltr.Tenant)
+	if len(qryFltr.ItemTypes) != 0 {
+		q = q.Where("item_type in (?)", qryFltr.ItemTypes)
+	}
+	if len(qryFltr.ItemIDs) != 0 {
+		q = q.Where("item_id in (?)", qryFltr.ItemIDs)
+	}
+	if len(qryFltr.Methods) != 0 {
+		q = q.Where("method in (?)", qryFltr.Methods)
+	}
+	if len(qryFltr.Callers) != 0 {
+		q = q.Where("caller in (?)", qryFltr.Callers)
+	}
+	if qryFltr.Time.Begin != nil {
+		q = q.Where("created_at >= ?", qryFltr.Time.Begin)
+	}
+	if qryFltr.Time.End != nil {
+		q = q.Where("created_at < ?", qryFltr.Time.End)
+	}
+	q = q.Order("created_at, id")
+	if qryFltr.Paginator.Limit != nil {
+		q = q.Limit(*qryFltr.Paginator.Limit)
+	}
+	if qryFltr.Paginator.Offset != nil {
+		q = q.Offset(*qryFltr.Paginator.Offset)
+	}
+	results := make([]*AuditRecordSQL, 0)
+	if err := q.Find(&results).Error; err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	recs := make([]*AuditRecord, len(results))
+	for i, result := range results {
+		recs[i] = &AuditRecord{
+			ID:       result.AuditID,
+			Tenant:   result.Tenant,
+			ItemType: result.ItemType,
+			ItemID:   result.ItemID,
+			Method:   result.Method,
+			Caller:   result.Caller,
+			Error:    result.Error,
+			Time:     result.CreatedAt,
+		}
+		if err := json.Unmarshal([]byte(result.Changes), &recs[i].Changes); err != nil {
+			return nil, err
+		}
+	}
+	return recs, nil
+}
+
 func (self *SQLStorage) SetCDR(cdr *CDR, allowUpdate bool) error {
 	tx := self.db.Begin()
 	cdrSql := cdr.AsCDRsql()
@@ -1268,6 +1388,41 @@ func (self *SQLStorage) GetTPTimings(tpid, id string) ([]*utils.ApierTPTiming, e
 	return ts, nil
 }
 
+func (self *SQLStorage) GetTPFxRates(tpid, fromCurrency, toCurrency string) ([]*utils.TPFxRate, error) {
+	var tpFxRates TpFxRates
+	q := self.db.Where("tpid = ?", tpid)
+	if len(fromCurrency) != 0 {
+		q = q.Where("from_currency = ?", fromCurrency)
+	}
+	if len(toCurrency) != 0 {
+		q = q.Where("to_currency = ?", toCurrency)
+	}
+	if err := q.Find(&tpFxRates).Error; err != nil {
+		return nil, err
+	}
+	fxs := tpFxRates.AsTPFxRates()
+	if len(fxs) == 0 {
+		return fxs, utils.ErrNotFound
+	}
+	return fxs, nil
+}
+
+func (self *SQLStorage) GetTPPortedNumbers(tpid, number string) ([]*utils.TPPortedNumber, error) {
+	var tpPortedNumbers TpPortedNumbers
+	q := self.db.Where("tpid = ?", tpid)
+	if len(number) != 0 {
+		q = q.Where("number = ?", number)
+	}
+	if err := q.Find(&tpPortedNumbers).Error; err != nil {
+		return nil, err
+	}
+	pns := tpPortedNumbers.AsTPPortedNumbers()
+	if len(pns) == 0 {
+		return pns, utils.ErrNotFound
+	}
+	return pns, nil
+}
+
 func (self *SQLStorage) GetTPRatingPlans(tpid, id string, pagination *utils.Paginator) ([]*utils.TPRatingPlan, error) {
 	var tpRatingPlans TpRatingPlans
 	q := self.db.Where("tpid = ?", tpid)
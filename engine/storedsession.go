@@ -0,0 +1,93 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// StoredSession is the checkpoint of an active session kept in DataDB
+// so it can be restored after an engine restart
+type StoredSession struct {
+	NodeID        string // engine owning the session
+	CGRID         string
+	Tenant        string
+	ResourceID    string
+	ClientConnID  string
+	EventStart    MapEvent
+	DebitInterval time.Duration
+	OptsStart     MapEvent
+	SRuns         []*StoredSRun
+	ArgDispatcher *utils.ArgDispatcher
+	Stored        time.Time // time of the checkpoint
+}
+
+// StoredSRun is the checkpoint of one session run
+type StoredSRun struct {
+	Event         MapEvent
+	CD            *CallDescriptor
+	EventCost     *EventCost
+	ExtraDuration time.Duration
+	LastUsage     time.Duration
+	TotalUsage    time.Duration
+	NextAutoDebit *time.Time
+}
+
+// ID returns the key under which the session is stored
+func (ss *StoredSession) ID() string {
+	return utils.ConcatenatedKey(ss.NodeID, ss.CGRID)
+}
+
+// initEventCosts prepares the EventCosts after being decoded out of DataDB
+func (ss *StoredSession) initEventCosts() {
+	for _, sr := range ss.SRuns {
+		if sr.EventCost != nil {
+			sr.EventCost.initCache()
+		}
+	}
+}
+
+// GetStoredSessions returns the sessions checkpointed by the given node
+func (dm *DataManager) GetStoredSessions(nodeID string) (sss []*StoredSession, err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().GetStoredSessionsDrv(nodeID)
+}
+
+// SetStoredSession checkpoints the session, overwriting the previous one
+func (dm *DataManager) SetStoredSession(ss *StoredSession) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().SetStoredSessionDrv(ss)
+}
+
+// RemoveStoredSession removes the checkpoint of the session
+func (dm *DataManager) RemoveStoredSession(nodeID, cgrID string) (err error) {
+	if dm == nil {
+		err = utils.ErrNoDatabaseConn
+		return
+	}
+	return dm.DataDB().RemoveStoredSessionDrv(nodeID, cgrID)
+}
@@ -0,0 +1,75 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestDataManagerStoredSessions(t *testing.T) {
+	cfg, _ := config.NewDefaultCGRConfig()
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	ss1 := &StoredSession{
+		NodeID:        "NODE1",
+		CGRID:         "CGRID1",
+		Tenant:        "cgrates.org",
+		EventStart:    MapEvent{utils.Account: "1001"},
+		DebitInterval: time.Second,
+		SRuns: []*StoredSRun{{
+			Event:      MapEvent{utils.RunID: utils.MetaDefault},
+			CD:         &CallDescriptor{Account: "1001"},
+			LastUsage:  5 * time.Second,
+			TotalUsage: 10 * time.Second,
+		}},
+	}
+	ss2 := &StoredSession{
+		NodeID: "NODE2",
+		CGRID:  "CGRID2",
+		Tenant: "cgrates.org",
+	}
+	if err := dm.SetStoredSession(ss1); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetStoredSession(ss2); err != nil {
+		t.Fatal(err)
+	}
+	if sss, err := dm.GetStoredSessions("NODE1"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]*StoredSession{ss1}, sss) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(ss1), utils.ToJSON(sss))
+	}
+	if err := dm.RemoveStoredSession("NODE1", "CGRID1"); err != nil {
+		t.Error(err)
+	}
+	if sss, err := dm.GetStoredSessions("NODE1"); err != nil {
+		t.Error(err)
+	} else if len(sss) != 0 {
+		t.Errorf("Expecting no session, received: %s", utils.ToJSON(sss))
+	}
+	if sss, err := dm.GetStoredSessions("NODE2"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]*StoredSession{ss2}, sss) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(ss2), utils.ToJSON(sss))
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewTaxService returns a new TaxService
+func NewTaxService(dm *DataManager, filterS *FilterS,
+	cfg *config.CGRConfig) *TaxService {
+	return &TaxService{dm: dm, filterS: filterS, cfg: cfg}
+}
+
+// TaxService computes the taxes for the cost of the events
+type TaxService struct {
+	dm      *DataManager
+	filterS *FilterS
+	cfg     *config.CGRConfig
+}
+
+// ListenAndServe will initialize the service
+func (tS *TaxService) ListenAndServe(exitChan chan bool) (err error) {
+	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.TaxS))
+	e := <-exitChan
+	exitChan <- e
+	return
+}
+
+// Shutdown is called to shutdown the service
+func (tS *TaxService) Shutdown() (err error) {
+	utils.Logger.Info(fmt.Sprintf("<%s> shutdown initialized", utils.TaxS))
+	utils.Logger.Info(fmt.Sprintf("<%s> shutdown complete", utils.TaxS))
+	return
+}
+
+// matchingTaxProfilesForEvent returns the ordered list of TaxProfiles matching the event
+func (tS *TaxService) matchingTaxProfilesForEvent(cgrEv *utils.CGREvent) (txPs TaxProfiles, err error) {
+	txpIDs, err := MatchingItemIDsForEvent(cgrEv.Event,
+		tS.cfg.TaxSCfg().StringIndexedFields,
+		tS.cfg.TaxSCfg().PrefixIndexedFields,
+		tS.dm, utils.CacheTaxFilterIndexes, cgrEv.Tenant,
+		tS.cfg.TaxSCfg().IndexedSelects,
+		tS.cfg.TaxSCfg().NestedFields,
+	)
+	if err != nil {
+		return nil, err
+	}
+	evNm := utils.MapStorage{utils.MetaReq: cgrEv.Event}
+	for txpID := range txpIDs {
+		txp, err := tS.dm.GetTaxProfile(cgrEv.Tenant, txpID, true, true, utils.NonTransactional)
+		if err != nil {
+			if err == utils.ErrNotFound {
+				continue
+			}
+			return nil, err
+		}
+		if txp.ActivationInterval != nil && cgrEv.Time != nil &&
+			!txp.ActivationInterval.IsActiveAtTime(*cgrEv.Time) { // not active
+			continue
+		}
+		if pass, err := tS.filterS.Pass(cgrEv.Tenant, txp.FilterIDs,
+			evNm); err != nil {
+			return nil, err
+		} else if !pass {
+			continue
+		}
+		txPs = append(txPs, txp)
+	}
+	if len(txPs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	txPs.Sort()
+	return
+}
+
+// computeTaxes applies the taxes of the matching profiles on the Cost of the event
+func (tS *TaxService) computeTaxes(cgrEv *utils.CGREvent) (evTx *EventTaxes, err error) {
+	ev := MapEvent(cgrEv.Event)
+	var cost float64
+	if cost, err = utils.IfaceAsFloat64(ev[utils.Cost]); err != nil {
+		return
+	}
+	var txPs TaxProfiles
+	if txPs, err = tS.matchingTaxProfilesForEvent(cgrEv); err != nil {
+		return
+	}
+	tor := ev.GetStringIgnoreErrors(utils.ToR)
+	evNm := utils.MapStorage{utils.MetaReq: cgrEv.Event}
+	var taxes []*profileTax
+	for _, txp := range txPs {
+		for _, tx := range txp.Taxes {
+			if tx.IsExempt(tor) {
+				continue
+			}
+			var pass bool
+			if pass, err = tS.filterS.Pass(cgrEv.Tenant, tx.FilterIDs,
+				evNm); err != nil {
+				return
+			} else if !pass {
+				continue
+			}
+			taxes = append(taxes, &profileTax{prfID: txp.ID, Tax: tx})
+		}
+	}
+	if len(taxes) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return computeEventTaxes(cost, taxes,
+		tS.cfg.GeneralCfg().RoundingDecimals), nil
+}
+
+// V1ComputeTaxes returns the tax breakdown for the Cost of the event
+func (tS *TaxService) V1ComputeTaxes(args *utils.CGREventWithArgDispatcher,
+	reply *EventTaxes) (err error) {
+	if args.CGREvent == nil ||
+		args.Event == nil {
+		return utils.NewErrMandatoryIeMissing(utils.Event)
+	}
+	if !MapEvent(args.Event).HasField(utils.Cost) {
+		return utils.NewErrMandatoryIeMissing(utils.Cost)
+	}
+	evTx, err := tS.computeTaxes(args.CGREvent)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	*reply = *evTx
+	return
+}
+
+// V1GetTaxProfilesForEvent returns the ordered list of TaxProfiles matching the event
+func (tS *TaxService) V1GetTaxProfilesForEvent(args *utils.CGREventWithArgDispatcher,
+	reply *TaxProfiles) (err error) {
+	if args.CGREvent == nil ||
+		args.Event == nil {
+		return utils.NewErrMandatoryIeMissing(utils.Event)
+	}
+	txPs, err := tS.matchingTaxProfilesForEvent(args.CGREvent)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return err
+	}
+	*reply = txPs
+	return
+}
@@ -0,0 +1,163 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestComputeEventTaxesExclusive(t *testing.T) {
+	taxes := []*profileTax{
+		{prfID: "TAX_DE", Tax: &Tax{ID: "VAT", Rate: 0.19}},
+	}
+	exp := &EventTaxes{
+		NetCost:   10,
+		TaxAmount: 1.9,
+		TotalCost: 11.9,
+		Taxes: []*TaxCharge{
+			{TaxProfileID: "TAX_DE", TaxID: "VAT", Rate: 0.19, Base: 10, Amount: 1.9},
+		},
+	}
+	if rcv := computeEventTaxes(10, taxes, 4); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestComputeEventTaxesInclusive(t *testing.T) {
+	taxes := []*profileTax{
+		{prfID: "TAX_DE", Tax: &Tax{ID: "VAT", Rate: 0.19, Inclusive: true}},
+	}
+	exp := &EventTaxes{
+		NetCost:   10,
+		TaxAmount: 1.9,
+		TotalCost: 11.9,
+		Taxes: []*TaxCharge{
+			{TaxProfileID: "TAX_DE", TaxID: "VAT", Rate: 0.19, Inclusive: true, Base: 10, Amount: 1.9},
+		},
+	}
+	if rcv := computeEventTaxes(11.9, taxes, 4); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestComputeEventTaxesCompound(t *testing.T) {
+	taxes := []*profileTax{
+		{prfID: "TAX_CA", Tax: &Tax{ID: "GST", Rate: 0.05}},
+		{prfID: "TAX_CA", Tax: &Tax{ID: "QST", Rate: 0.1, Compound: true}},
+	}
+	exp := &EventTaxes{
+		NetCost:   100,
+		TaxAmount: 15.5,
+		TotalCost: 115.5,
+		Taxes: []*TaxCharge{
+			{TaxProfileID: "TAX_CA", TaxID: "GST", Rate: 0.05, Base: 100, Amount: 5},
+			{TaxProfileID: "TAX_CA", TaxID: "QST", Rate: 0.1, Base: 105, Amount: 10.5},
+		},
+	}
+	if rcv := computeEventTaxes(100, taxes, 4); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
+
+func TestEventTaxesFieldAsInterface(t *testing.T) {
+	evTx := &EventTaxes{
+		NetCost:   10,
+		TaxAmount: 1.9,
+		TotalCost: 11.9,
+		Taxes: []*TaxCharge{
+			{TaxProfileID: "TAX_DE", TaxID: "VAT", Rate: 0.19, Base: 10, Amount: 1.9},
+		},
+	}
+	if rcv, err := evTx.FieldAsInterface([]string{utils.TotalCost}); err != nil {
+		t.Error(err)
+	} else if rcv != 11.9 {
+		t.Errorf("Expecting: 11.9, received: %+v", rcv)
+	}
+	if rcv, err := evTx.FieldAsInterface([]string{"Taxes[0]", utils.TaxID}); err != nil {
+		t.Error(err)
+	} else if rcv != "VAT" {
+		t.Errorf("Expecting: VAT, received: %+v", rcv)
+	}
+	if _, err := evTx.FieldAsInterface([]string{"Taxes[1]", utils.TaxID}); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestTaxServiceComputeTaxes(t *testing.T) {
+	defaultCfg, _ := config.NewDefaultCGRConfig()
+	data := NewInternalDB(nil, nil, true, defaultCfg.DataDbCfg().Items)
+	dm := NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	txS := NewTaxService(dm, &FilterS{dm: dm, cfg: defaultCfg}, defaultCfg)
+	txp := &TaxProfile{
+		Tenant:    "cgrates.org",
+		ID:        "TAX_DE",
+		FilterIDs: []string{"*string:~*req.Country:DE"},
+		Weight:    10,
+		Taxes: []*Tax{
+			{ID: "VAT", Rate: 0.19, ExemptToRs: []string{utils.SMS}},
+		},
+	}
+	if err := dm.SetTaxProfile(txp, true); err != nil {
+		t.Fatal(err)
+	}
+	args := &utils.CGREventWithArgDispatcher{
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "TestTaxServiceComputeTaxes",
+			Event: map[string]interface{}{
+				"Country":   "DE",
+				utils.ToR:   utils.VOICE,
+				utils.Cost:  10,
+				utils.RunID: utils.MetaDefault,
+			},
+		},
+	}
+	exp := EventTaxes{
+		NetCost:   10,
+		TaxAmount: 1.9,
+		TotalCost: 11.9,
+		Taxes: []*TaxCharge{
+			{TaxProfileID: "TAX_DE", TaxID: "VAT", Rate: 0.19, Base: 10, Amount: 1.9},
+		},
+	}
+	var reply EventTaxes
+	if err := txS.V1ComputeTaxes(args, &reply); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, reply) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(reply))
+	}
+	args.Event[utils.ToR] = utils.SMS
+	if err := txS.V1ComputeTaxes(args, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	args.Event[utils.ToR] = utils.VOICE
+	args.Event["Country"] = "FR"
+	if err := txS.V1ComputeTaxes(args, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	delete(args.Event, utils.Cost)
+	if err := txS.V1ComputeTaxes(args, &reply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.Cost).Error() {
+		t.Errorf("Expecting: %v, received: %v", utils.NewErrMandatoryIeMissing(utils.Cost), err)
+	}
+}
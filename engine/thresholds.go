@@ -79,16 +79,21 @@ func (t *Threshold) TenantID() string {
 	return utils.ConcatenatedKey(t.Tenant, t.ID)
 }
 
-// ProcessEvent processes an ThresholdEvent
-// concurrentActions limits the number of simultaneous action sets executed
-func (t *Threshold) ProcessEvent(args *ArgsProcessEvent, dm *DataManager) (err error) {
+// isActive returns true if the threshold should execute its actions for the current hit
+func (t *Threshold) isActive() bool {
 	if t.Snooze.After(time.Now()) { // snoozed, not executing actions
-		return
+		return false
 	}
 	if t.Hits < t.tPrfl.MinHits { // number of hits was not met, will not execute actions
-		return
+		return false
 	}
-	if t.tPrfl.MaxHits != -1 && t.Hits > t.tPrfl.MaxHits {
+	return t.tPrfl.MaxHits == -1 || t.Hits <= t.tPrfl.MaxHits
+}
+
+// ProcessEvent processes an ThresholdEvent
+// concurrentActions limits the number of simultaneous action sets executed
+func (t *Threshold) ProcessEvent(args *ArgsProcessEvent, dm *DataManager) (err error) {
+	if !t.isActive() {
 		return
 	}
 	acnt, _ := args.FieldAsString(utils.Account)
@@ -300,6 +305,34 @@ type ArgsProcessEvent struct {
 	*utils.ArgDispatcher
 }
 
+// publishThresholdHit sends the hit of the threshold to PubSubS
+// the event is sent in background so PubSubS does not delay or fail the processing
+func (tS *ThresholdService) publishThresholdHit(t *Threshold, args *ArgsProcessEvent) {
+	cgrEv := &utils.CGREvent{
+		Tenant: t.Tenant,
+		ID:     utils.GenUUID(),
+		Time:   utils.TimePointer(time.Now()),
+		Event: map[string]interface{}{
+			utils.EventType:   utils.ThresholdHit,
+			utils.ThresholdID: t.ID,
+			utils.Hits:        t.Hits,
+			utils.EventSource: utils.ThresholdS,
+			utils.Event:       MapEvent(args.Event).Clone(), // the event which hit the threshold
+		},
+	}
+	go func(argDisp *utils.ArgDispatcher) {
+		var reply string
+		if err := connMgr.Call(tS.cgrcfg.ThresholdSCfg().PubSubSConns, nil,
+			utils.PubSubSv1Publish, &utils.CGREventWithArgDispatcher{
+				CGREvent:      cgrEv,
+				ArgDispatcher: argDisp,
+			}, &reply); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<ThresholdService> error: %s publishing event %+v with PubSubS.", err.Error(), cgrEv))
+		}
+	}(args.ArgDispatcher)
+}
+
 // processEvent processes a new event, dispatching to matching thresholds
 func (tS *ThresholdService) processEvent(args *ArgsProcessEvent) (thresholdsIDs []string, err error) {
 	matchTs, err := tS.matchingThresholdsForEvent(args)
@@ -311,6 +344,9 @@ func (tS *ThresholdService) processEvent(args *ArgsProcessEvent) (thresholdsIDs
 	for _, t := range matchTs {
 		tIDs = append(tIDs, t.ID)
 		t.Hits++
+		if len(tS.cgrcfg.ThresholdSCfg().PubSubSConns) != 0 && t.isActive() {
+			tS.publishThresholdHit(t, args)
+		}
 		err = t.ProcessEvent(args, tS.dm)
 		if err != nil {
 			utils.Logger.Warning(
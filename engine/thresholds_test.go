@@ -385,3 +385,35 @@ func TestThresholdsProcessEvent2(t *testing.T) {
 		}
 	}
 }
+
+func TestThresholdIsActive(t *testing.T) {
+	th := &Threshold{
+		Tenant: "cgrates.org",
+		ID:     "TH_ACTIVE",
+		Hits:   1,
+		tPrfl: &ThresholdProfile{
+			MinHits: 2,
+			MaxHits: 3,
+		},
+	}
+	if th.isActive() {
+		t.Error("expecting inactive before MinHits")
+	}
+	th.Hits = 2
+	if !th.isActive() {
+		t.Error("expecting active")
+	}
+	th.Snooze = time.Now().Add(time.Hour)
+	if th.isActive() {
+		t.Error("expecting inactive while snoozed")
+	}
+	th.Snooze = time.Time{}
+	th.Hits = 4
+	if th.isActive() {
+		t.Error("expecting inactive after MaxHits")
+	}
+	th.tPrfl.MaxHits = -1
+	if !th.isActive() {
+		t.Error("expecting active with unlimited hits")
+	}
+}
@@ -81,6 +81,7 @@ type MonetaryInfo struct {
 	ID           string
 	Value        float64
 	RateInterval *RateInterval
+	FxRate       float64 // rate used to convert the cost into the currency of the balance
 }
 
 func (mi *MonetaryInfo) Clone() *MonetaryInfo {
@@ -96,6 +97,7 @@ func (mi *MonetaryInfo) Equal(other *MonetaryInfo) bool {
 		return false
 	}
 	return mi.UUID == other.UUID &&
+		mi.FxRate == other.FxRate &&
 		reflect.DeepEqual(mi.RateInterval, other.RateInterval)
 }
 
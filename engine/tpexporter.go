@@ -98,6 +98,22 @@ func (self *TPExporter) Run() error {
 		toExportMap[utils.TimingsCsv][i] = sd
 	}
 
+	storDataFxRates, err := self.storDb.GetTPFxRates(self.tpID, "", "")
+	if err != nil && err.Error() != utils.ErrNotFound.Error() {
+		return err
+	}
+	for _, sd := range APItoModelFxRates(storDataFxRates) {
+		toExportMap[utils.FxRatesCsv] = append(toExportMap[utils.FxRatesCsv], sd)
+	}
+
+	storDataPortedNumbers, err := self.storDb.GetTPPortedNumbers(self.tpID, "")
+	if err != nil && err.Error() != utils.ErrNotFound.Error() {
+		return err
+	}
+	for _, sd := range APItoModelPortedNumbers(storDataPortedNumbers) {
+		toExportMap[utils.PortedNumbersCsv] = append(toExportMap[utils.PortedNumbersCsv], sd)
+	}
+
 	storDataDestinations, err := self.storDb.GetTPDestinations(self.tpID, "")
 	if err != nil && err.Error() != utils.ErrNotFound.Error() {
 		return err
@@ -61,6 +61,8 @@ var fileHandlers = map[string]func(*TPCSVImporter, string) error{
 	utils.DispatcherProfilesCsv: (*TPCSVImporter).importDispatcherProfiles,
 	utils.DispatcherHostsCsv:    (*TPCSVImporter).importDispatcherHosts,
 	utils.RateProfilesCsv:       (*TPCSVImporter).importRateProfiles,
+	utils.FxRatesCsv:            (*TPCSVImporter).importFxRates,
+	utils.PortedNumbersCsv:      (*TPCSVImporter).importPortedNumbers,
 }
 
 func (self *TPCSVImporter) Run() error {
@@ -99,6 +101,34 @@ func (self *TPCSVImporter) importTimings(fn string) error {
 	return self.StorDb.SetTPTimings(tps)
 }
 
+func (self *TPCSVImporter) importFxRates(fn string) error {
+	if self.Verbose {
+		log.Printf("Processing file: <%s> ", fn)
+	}
+	tps, err := self.csvr.GetTPFxRates(self.TPid, "", "")
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(tps); i++ {
+		tps[i].TPid = self.TPid
+	}
+	return self.StorDb.SetTPFxRates(tps)
+}
+
+func (self *TPCSVImporter) importPortedNumbers(fn string) error {
+	if self.Verbose {
+		log.Printf("Processing file: <%s> ", fn)
+	}
+	tps, err := self.csvr.GetTPPortedNumbers(self.TPid, "")
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(tps); i++ {
+		tps[i].TPid = self.TPid
+	}
+	return self.StorDb.SetTPPortedNumbers(tps)
+}
+
 func (self *TPCSVImporter) importDestinations(fn string) error {
 	if self.Verbose {
 		log.Printf("Processing file: <%s> ", fn)
@@ -42,6 +42,8 @@ type TpReader struct {
 	accountActions     map[string]*Account
 	destinations       map[string]*Destination
 	timings            map[string]*utils.TPTiming
+	fxRates            map[string]*FxRate
+	portedNumbers      map[string]*PortedNumber
 	rates              map[string]*utils.TPRateRALs
 	destinationRates   map[string]*utils.TPDestinationRate
 	ratingPlans        map[string]*RatingPlan
@@ -62,6 +64,7 @@ type TpReader struct {
 	thresholds         []*utils.TenantID // IDs of thresholds which need creation based on thresholdProfiles
 	revDests           map[string][]string
 	acntActionPlans    map[string][]string
+	applied            []string // DataDB keys written by the last WriteToDatabase
 	cacheConns         []string
 	schedulerConns     []string
 	isInternalDB       bool // do not reload cache if we use intarnalDB
@@ -94,6 +97,8 @@ func (tpr *TpReader) Init() {
 	tpr.destinations = make(map[string]*Destination)
 	tpr.destinationRates = make(map[string]*utils.TPDestinationRate)
 	tpr.timings = make(map[string]*utils.TPTiming)
+	tpr.fxRates = make(map[string]*FxRate)
+	tpr.portedNumbers = make(map[string]*PortedNumber)
 	tpr.ratingPlans = make(map[string]*RatingPlan)
 	tpr.ratingProfiles = make(map[string]*RatingProfile)
 	tpr.sharedGroups = make(map[string]*SharedGroup)
@@ -169,6 +174,41 @@ func (tpr *TpReader) LoadTimings() (err error) {
 	return err
 }
 
+// LoadFxRates loads the currency exchange rates
+func (tpr *TpReader) LoadFxRates() (err error) {
+	tps, err := tpr.lr.GetTPFxRates(tpr.tpid, "", "")
+	if err != nil {
+		return err
+	}
+	for _, tp := range tps {
+		fx := NewFxRateFromTPFxRate(tp)
+		if _, has := tpr.fxRates[fx.ID()]; has {
+			return fmt.Errorf("duplicate fx rate: %s", fx.ID())
+		}
+		tpr.fxRates[fx.ID()] = fx
+	}
+	return
+}
+
+// LoadPortedNumbers loads the number portability entries
+func (tpr *TpReader) LoadPortedNumbers() (err error) {
+	tps, err := tpr.lr.GetTPPortedNumbers(tpr.tpid, "")
+	if err != nil {
+		return err
+	}
+	for _, tp := range tps {
+		var pn *PortedNumber
+		if pn, err = NewPortedNumberFromTPPortedNumber(tp); err != nil {
+			return
+		}
+		if _, has := tpr.portedNumbers[pn.ID()]; has {
+			return fmt.Errorf("duplicate ported number: %s", pn.ID())
+		}
+		tpr.portedNumbers[pn.ID()] = pn
+	}
+	return
+}
+
 func (tpr *TpReader) LoadRates() (err error) {
 	tps, err := tpr.lr.GetTPRates(tpr.tpid, "")
 	if err != nil {
@@ -1272,6 +1312,12 @@ func (tpr *TpReader) LoadAll() (err error) {
 	if err = tpr.LoadTimings(); err != nil && err.Error() != utils.NotFoundCaps {
 		return
 	}
+	if err = tpr.LoadFxRates(); err != nil && err.Error() != utils.NotFoundCaps {
+		return
+	}
+	if err = tpr.LoadPortedNumbers(); err != nil && err.Error() != utils.NotFoundCaps {
+		return
+	}
 	if err = tpr.LoadRates(); err != nil && err.Error() != utils.NotFoundCaps {
 		return
 	}
@@ -1355,6 +1401,26 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 	if tpr.dm.dataDB == nil {
 		return errors.New("no database connection")
 	}
+	// the items are restored to the previous state if any of them fails
+	tx := NewLoadTransaction(tpr.dm)
+	defer func() {
+		if err == nil {
+			err = tx.Commit()
+		}
+		if err == nil {
+			tpr.applied = tx.Applied()
+			if verbose {
+				log.Printf("Applied %d items", len(tpr.applied))
+			}
+			return
+		}
+		if verbose {
+			log.Printf("Rolling back %d items", len(tx.Applied()))
+		}
+		if errRb := tx.Rollback(); errRb != nil {
+			err = fmt.Errorf("%s, %s", err.Error(), errRb.Error())
+		}
+	}()
 	//generate a loadID
 	loadID := time.Now().UnixNano()
 	loadIDs := make(map[string]int64)
@@ -1362,7 +1428,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		log.Print("Destinations:")
 	}
 	for _, d := range tpr.destinations {
-		err = tpr.dm.SetDestination(d, utils.NonTransactional)
+		err = tx.SetDestination(d)
 		if err != nil {
 			return err
 		}
@@ -1384,7 +1450,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		log.Print("Rating Plans:")
 	}
 	for _, rp := range tpr.ratingPlans {
-		err = tpr.dm.SetRatingPlan(rp, utils.NonTransactional)
+		err = tx.SetRatingPlan(rp)
 		if err != nil {
 			return err
 		}
@@ -1399,7 +1465,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		log.Print("Rating Profiles:")
 	}
 	for _, rp := range tpr.ratingProfiles {
-		err = tpr.dm.SetRatingProfile(rp, utils.NonTransactional)
+		err = tx.SetRatingProfile(rp)
 		if err != nil {
 			return err
 		}
@@ -1425,9 +1491,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 					if verbose {
 						log.Println("\tTask: ", t)
 					}
-					if err = tpr.dm.DataDB().PushTask(t); err != nil {
-						return err
-					}
+					tx.PushTask(t)
 				}
 				if len(ap.AccountIDs) == 0 {
 					t := &Task{
@@ -1437,13 +1501,11 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 					if verbose {
 						log.Println("\tTask: ", t)
 					}
-					if err = tpr.dm.DataDB().PushTask(t); err != nil {
-						return err
-					}
+					tx.PushTask(t)
 				}
 			}
 		}
-		err = tpr.dm.SetActionPlan(k, ap, false, utils.NonTransactional)
+		err = tx.SetActionPlan(k, ap, false)
 		if err != nil {
 			return err
 		}
@@ -1467,7 +1529,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		log.Print("Action Triggers:")
 	}
 	for k, atrs := range tpr.actionsTriggers {
-		err = tpr.dm.SetActionTriggers(k, atrs, utils.NonTransactional)
+		err = tx.SetActionTriggers(k, atrs)
 		if err != nil {
 			return err
 		}
@@ -1482,7 +1544,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		log.Print("Shared Groups:")
 	}
 	for k, sg := range tpr.sharedGroups {
-		err = tpr.dm.SetSharedGroup(sg, utils.NonTransactional)
+		err = tx.SetSharedGroup(sg)
 		if err != nil {
 			return err
 		}
@@ -1497,7 +1559,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		log.Print("Actions:")
 	}
 	for k, as := range tpr.actions {
-		err = tpr.dm.SetActions(k, as, utils.NonTransactional)
+		err = tx.SetActions(k, as)
 		if err != nil {
 			return err
 		}
@@ -1512,7 +1574,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		log.Print("Account Actions:")
 	}
 	for _, ub := range tpr.accountActions {
-		err = tpr.dm.SetAccount(ub)
+		err = tx.SetAccount(ub)
 		if err != nil {
 			return err
 		}
@@ -1528,7 +1590,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		if err != nil {
 			return err
 		}
-		if err = tpr.dm.SetFilter(th, true); err != nil {
+		if err = tx.SetFilter(th, true); err != nil {
 			return err
 		}
 		if verbose {
@@ -1546,7 +1608,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		if err != nil {
 			return err
 		}
-		if err = tpr.dm.SetResourceProfile(rsp, true); err != nil {
+		if err = tx.SetResourceProfile(rsp, true); err != nil {
 			return err
 		}
 		if verbose {
@@ -1560,7 +1622,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		log.Print("Resources:")
 	}
 	for _, rTid := range tpr.resources {
-		if err = tpr.dm.SetResource(&Resource{Tenant: rTid.Tenant, ID: rTid.ID, Usages: make(map[string]*ResourceUsage)}); err != nil {
+		if err = tx.SetResource(&Resource{Tenant: rTid.Tenant, ID: rTid.ID, Usages: make(map[string]*ResourceUsage)}); err != nil {
 			return
 		}
 		if verbose {
@@ -1578,7 +1640,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		if err != nil {
 			return err
 		}
-		if err = tpr.dm.SetStatQueueProfile(st, true); err != nil {
+		if err = tx.SetStatQueueProfile(st, true); err != nil {
 			return err
 		}
 		if verbose {
@@ -1603,7 +1665,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 			}
 		}
 		sq := &StatQueue{Tenant: sqTntID.Tenant, ID: sqTntID.ID, SQMetrics: metrics}
-		if err = tpr.dm.SetStatQueue(sq); err != nil {
+		if err = tx.SetStatQueue(sq); err != nil {
 			return
 		}
 		if verbose {
@@ -1621,7 +1683,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		if err != nil {
 			return err
 		}
-		if err = tpr.dm.SetThresholdProfile(th, true); err != nil {
+		if err = tx.SetThresholdProfile(th, true); err != nil {
 			return err
 		}
 		if verbose {
@@ -1635,7 +1697,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		log.Print("Thresholds:")
 	}
 	for _, thd := range tpr.thresholds {
-		if err = tpr.dm.SetThreshold(&Threshold{Tenant: thd.Tenant, ID: thd.ID}); err != nil {
+		if err = tx.SetThreshold(&Threshold{Tenant: thd.Tenant, ID: thd.ID}); err != nil {
 			return err
 		}
 		if verbose {
@@ -1653,7 +1715,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		if err != nil {
 			return err
 		}
-		if err = tpr.dm.SetRouteProfile(th, true); err != nil {
+		if err = tx.SetRouteProfile(th, true); err != nil {
 			return err
 		}
 		if verbose {
@@ -1671,7 +1733,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		if err != nil {
 			return err
 		}
-		if err = tpr.dm.SetAttributeProfile(th, true); err != nil {
+		if err = tx.SetAttributeProfile(th, true); err != nil {
 			return err
 		}
 		if verbose {
@@ -1690,7 +1752,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		if err != nil {
 			return err
 		}
-		if err = tpr.dm.SetChargerProfile(th, true); err != nil {
+		if err = tx.SetChargerProfile(th, true); err != nil {
 			return err
 		}
 		if verbose {
@@ -1708,7 +1770,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		if err != nil {
 			return err
 		}
-		if err = tpr.dm.SetDispatcherProfile(th, true); err != nil {
+		if err = tx.SetDispatcherProfile(th, true); err != nil {
 			return err
 		}
 		if verbose {
@@ -1723,7 +1785,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 	}
 	for _, tpTH := range tpr.dispatcherHosts {
 		th := APItoDispatcherHost(tpTH)
-		if err = tpr.dm.SetDispatcherHost(th); err != nil {
+		if err = tx.SetDispatcherHost(th); err != nil {
 			return err
 		}
 		if verbose {
@@ -1742,7 +1804,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		if err != nil {
 			return err
 		}
-		if err = tpr.dm.SetRateProfile(th, true); err != nil {
+		if err = tx.SetRateProfile(th, true); err != nil {
 			return err
 		}
 		if verbose {
@@ -1757,7 +1819,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 		log.Print("Timings:")
 	}
 	for _, t := range tpr.timings {
-		if err = tpr.dm.SetTiming(t); err != nil {
+		if err = tx.SetTiming(t); err != nil {
 			return err
 		}
 		if verbose {
@@ -1767,12 +1829,40 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 	if len(tpr.timings) != 0 {
 		loadIDs[utils.CacheTimings] = loadID
 	}
+	if verbose {
+		log.Print("FxRates:")
+	}
+	for _, fx := range tpr.fxRates {
+		if err = tx.SetFxRate(fx); err != nil {
+			return err
+		}
+		if verbose {
+			log.Print("\t", fx.ID())
+		}
+	}
+	if len(tpr.fxRates) != 0 {
+		loadIDs[utils.CacheFxRates] = loadID
+	}
+	if verbose {
+		log.Print("PortedNumbers:")
+	}
+	for _, pn := range tpr.portedNumbers {
+		if err = tx.SetPortedNumber(pn); err != nil {
+			return err
+		}
+		if verbose {
+			log.Print("\t", pn.ID())
+		}
+	}
+	if len(tpr.portedNumbers) != 0 {
+		loadIDs[utils.CachePortedNumbers] = loadID
+	}
 	if !disable_reverse {
 		if len(tpr.destinations) > 0 {
 			if verbose {
 				log.Print("Rebuilding reverse destinations")
 			}
-			if err = tpr.dm.DataDB().RebuildReverseForPrefix(utils.REVERSE_DESTINATION_PREFIX); err != nil {
+			if err = tx.RebuildReverseForPrefix(utils.REVERSE_DESTINATION_PREFIX); err != nil {
 				return err
 			}
 		}
@@ -1780,7 +1870,7 @@ func (tpr *TpReader) WriteToDatabase(verbose, disable_reverse bool) (err error)
 			if verbose {
 				log.Print("Rebuilding account action plans")
 			}
-			if err = tpr.dm.DataDB().RebuildReverseForPrefix(utils.AccountActionPlansPrefix); err != nil {
+			if err = tx.RebuildReverseForPrefix(utils.AccountActionPlansPrefix); err != nil {
 				return err
 			}
 		}
@@ -1862,6 +1952,15 @@ func (tpr *TpReader) ShowStatistics() {
 	log.Print("DispatcherHosts: ", len(tpr.dispatcherHosts))
 	// Rate profiles
 	log.Print("RateProfiles: ", len(tpr.rateProfiles))
+	// FX rates
+	log.Print("FxRates: ", len(tpr.fxRates))
+	// Ported numbers
+	log.Print("PortedNumbers: ", len(tpr.portedNumbers))
+}
+
+// AppliedItems returns the DataDB keys written by the last WriteToDatabase
+func (tpr *TpReader) AppliedItems() []string {
+	return tpr.applied
 }
 
 // Returns the identities loaded for a specific category, useful for cache reloads
@@ -2021,6 +2120,22 @@ func (tpr *TpReader) GetLoadedIds(categ string) ([]string, error) {
 			i++
 		}
 		return keys, nil
+	case utils.FxRatesPrefix:
+		keys := make([]string, len(tpr.fxRates))
+		i := 0
+		for k := range tpr.fxRates {
+			keys[i] = k
+			i++
+		}
+		return keys, nil
+	case utils.PortedNumbersPrefix:
+		keys := make([]string, len(tpr.portedNumbers))
+		i := 0
+		for k := range tpr.portedNumbers {
+			keys[i] = k
+			i++
+		}
+		return keys, nil
 	}
 	return nil, errors.New("Unsupported load category")
 }
@@ -2287,6 +2402,28 @@ func (tpr *TpReader) RemoveFromDatabase(verbose, disable_reverse bool) (err erro
 			log.Print("\t", t.ID)
 		}
 	}
+	if verbose {
+		log.Print("FxRates:")
+	}
+	for fxID := range tpr.fxRates {
+		if err = tpr.dm.RemoveFxRate(fxID, utils.NonTransactional); err != nil {
+			return err
+		}
+		if verbose {
+			log.Print("\t", fxID)
+		}
+	}
+	if verbose {
+		log.Print("PortedNumbers:")
+	}
+	for pnID := range tpr.portedNumbers {
+		if err = tpr.dm.RemovePortedNumber(pnID, utils.NonTransactional); err != nil {
+			return err
+		}
+		if verbose {
+			log.Print("\t", pnID)
+		}
+	}
 	if !disable_reverse {
 		if len(tpr.destinations) > 0 {
 			if verbose {
@@ -2387,6 +2524,12 @@ func (tpr *TpReader) RemoveFromDatabase(verbose, disable_reverse bool) (err erro
 	if len(tpr.timings) != 0 {
 		loadIDs[utils.CacheTimings] = loadID
 	}
+	if len(tpr.fxRates) != 0 {
+		loadIDs[utils.CacheFxRates] = loadID
+	}
+	if len(tpr.portedNumbers) != 0 {
+		loadIDs[utils.CachePortedNumbers] = loadID
+	}
 	if err = tpr.dm.SetLoadIDs(loadIDs); err != nil {
 		return err
 	}
@@ -2423,6 +2566,8 @@ func (tpr *TpReader) ReloadCache(caching string, verbose bool, argDispatcher *ut
 	dppIDs, _ := tpr.GetLoadedIds(utils.DispatcherProfilePrefix)
 	dphIDs, _ := tpr.GetLoadedIds(utils.DispatcherHostPrefix)
 	ratePrfIDs, _ := tpr.GetLoadedIds(utils.RateProfilePrefix)
+	fxIDs, _ := tpr.GetLoadedIds(utils.FxRatesPrefix)
+	pnIDs, _ := tpr.GetLoadedIds(utils.PortedNumbersPrefix)
 	aps, _ := tpr.GetLoadedIds(utils.ACTION_PLAN_PREFIX)
 
 	//compose Reload Cache argument
@@ -2506,6 +2651,12 @@ func (tpr *TpReader) ReloadCache(caching string, verbose bool, argDispatcher *ut
 		cacheIDs = append(cacheIDs, utils.CacheRateProfilesFilterIndexes)
 		cacheIDs = append(cacheIDs, utils.CacheRateFilterIndexes)
 	}
+	if len(fxIDs) != 0 { // not part of ArgsCache, they will be cached again on first use
+		cacheIDs = append(cacheIDs, utils.CacheFxRates)
+	}
+	if len(pnIDs) != 0 { // clearing them rebuilds the lookup index on first use
+		cacheIDs = append(cacheIDs, utils.CachePortedNumbers)
+	}
 	if verbose {
 		log.Print("Clearing indexes")
 	}
@@ -2387,3 +2387,58 @@ func TestProcessAttributeUnixTimeStamp(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eRply), utils.ToJSON(rcv))
 	}
 }
+
+func TestProcessAttributeExpr(t *testing.T) {
+	defaultCfg, _ := config.NewDefaultCGRConfig()
+	defaultCfg.AttributeSCfg().ProcessRuns = 1
+	data := NewInternalDB(nil, nil, true, defaultCfg.DataDbCfg().Items)
+	dmAtr = NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	Cache.Clear(nil)
+	attrService, _ = NewAttributeService(dmAtr, &FilterS{dm: dmAtr, cfg: defaultCfg}, defaultCfg)
+	attrPrf := &AttributeProfile{
+		Tenant:    config.CgrConfig().GeneralCfg().DefaultTenant,
+		ID:        "ATTR_EXPR",
+		Contexts:  []string{utils.MetaSessionS},
+		FilterIDs: []string{"*string:~*req.Field1:Val1"},
+		Attributes: []*Attribute{
+			{
+				Path:  utils.MetaReq + utils.NestingSep + utils.Cost,
+				Type:  utils.MetaExpr,
+				Value: config.NewRSRParsersMustCompile("if(~*req.Usage > 60s, ~*req.Cost*1.2, ~*req.Cost)", utils.INFIELD_SEP),
+			},
+		},
+		Weight: 10,
+	}
+	if err := attrPrf.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmAtr.SetAttributeProfile(attrPrf, true); err != nil {
+		t.Error(err)
+	}
+	ev := &AttrArgsProcessEvent{
+		Context: utils.StringPointer(utils.MetaSessionS),
+		CGREvent: &utils.CGREvent{
+			Tenant: config.CgrConfig().GeneralCfg().DefaultTenant,
+			ID:     "TestProcessAttributeExpr",
+			Event: map[string]interface{}{
+				"Field1":    "Val1",
+				utils.Usage: 2 * time.Minute,
+				utils.Cost:  10,
+			},
+		},
+	}
+	rcv, err := attrService.processEvent(ev)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clnEv := ev.CGREvent.Clone()
+	clnEv.Event[utils.Cost] = "12"
+	eRply := &AttrSProcessEventReply{
+		MatchedProfiles: []string{"ATTR_EXPR"},
+		AlteredFields:   []string{utils.MetaReq + utils.NestingSep + utils.Cost},
+		CGREvent:        clnEv,
+	}
+	if !reflect.DeepEqual(eRply, rcv) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eRply), utils.ToJSON(rcv))
+	}
+}
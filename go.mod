@@ -60,5 +60,6 @@ require (
 	golang.org/x/net v0.0.0-20190909003024-a7b16738d86b
 	golang.org/x/oauth2 v0.0.0-20190604053449-0f29369cfe45
 	google.golang.org/api v0.10.0
+	google.golang.org/grpc v1.21.1
 	pack.ag/amqp v0.12.2
 )
@@ -58,7 +58,13 @@ func (ld LoaderData) GetRateIDs() ([]string, error) {
 // contained in record and processed with cfgTpl
 func (ld LoaderData) UpdateFromCSV(fileName string, record []string,
 	cfgTpl []*config.FCTemplate, tnt config.RSRParsers, filterS *engine.FilterS) (err error) {
-	csvProvider := newCsvProvider(record, fileName)
+	return ld.UpdateFromDataProvider(newCsvProvider(record, fileName), cfgTpl, tnt, filterS)
+}
+
+// UpdateFromDataProvider will update LoaderData with the record read out of any of the sources,
+// processed with cfgTpl
+func (ld LoaderData) UpdateFromDataProvider(dP utils.DataProvider,
+	cfgTpl []*config.FCTemplate, tnt config.RSRParsers, filterS *engine.FilterS) (err error) {
 	tenant, err := tnt.ParseValue("")
 	if err != nil {
 		return err
@@ -67,13 +73,13 @@ func (ld LoaderData) UpdateFromCSV(fileName string, record []string,
 		// Make sure filters are matching
 		if len(cfgFld.Filters) != 0 {
 			if pass, err := filterS.Pass(tenant,
-				cfgFld.Filters, csvProvider); err != nil {
+				cfgFld.Filters, dP); err != nil {
 				return err
 			} else if !pass {
 				continue // Not passes filters, ignore this CDR
 			}
 		}
-		out, err := cfgFld.Value.ParseDataProvider(csvProvider)
+		out, err := cfgFld.Value.ParseDataProvider(dP)
 		if err != nil {
 			return err
 		}
@@ -123,33 +129,12 @@ func (cP *csvProvider) FieldAsInterface(fldPath []string) (data interface{}, err
 	}
 	err = nil // cancel previous err
 
-	if splt := strings.Split(fldPath[0], utils.MatchLessThan); len(splt) != 1 {
-		var fileName string
-		// check for *req prefix
-		if splt[0] != utils.MetaReq {
-			return nil, fmt.Errorf("invalid prefix for : %s", fldPath)
-		}
-		fileName = splt[1]
-		// find the last > and compute the name of the file
-		hasGrThan := false
-		for _, val := range fldPath[1:] {
-			if grSplt := strings.Split(val, utils.MatchGreaterThan); len(grSplt) == 1 {
-				fileName = fileName + utils.NestingSep + val
-			} else {
-				fileName = fileName + utils.NestingSep + grSplt[0]
-				hasGrThan = true
-				break
-			}
-		}
-		if !hasGrThan {
-			return nil, fmt.Errorf("filter rule <%s> needs to end in >", fldPath)
-		}
-		if cP.fileName != fileName {
-			cP.cache.Set(fldPath, nil)
-			return
-		}
-	} else if fldPath[0] != utils.MetaReq {
-		return nil, fmt.Errorf("invalid prefix for : %s", fldPath)
+	var rcrdPath []string
+	if rcrdPath, err = recordPath(fldPath, cP.fileName); err != nil {
+		return
+	} else if rcrdPath == nil {
+		cP.cache.Set(fldPath, nil)
+		return
 	}
 	if cfgFieldIdx, err := strconv.Atoi(fldPath[len(fldPath)-1]); err != nil || len(cP.req) <= cfgFieldIdx {
 		return nil, fmt.Errorf("Ignoring record: %v with error : %+v", cP.req, err)
@@ -175,3 +160,77 @@ func (cP *csvProvider) FieldAsString(fldPath []string) (data string, err error)
 func (cP *csvProvider) RemoteHost() net.Addr {
 	return utils.LocalAddr()
 }
+
+// recordPath returns the path within the record out of the field path
+// the path is nil if the field is requested from other file (eg: ~*req<File2.csv>.1)
+func recordPath(fldPath []string, fileName string) (rcrdPath []string, err error) {
+	splt := strings.Split(fldPath[0], utils.MatchLessThan)
+	if len(splt) == 1 {
+		if fldPath[0] != utils.MetaReq {
+			return nil, fmt.Errorf("invalid prefix for : %s", fldPath)
+		}
+		return fldPath[1:], nil
+	}
+	// check for *req prefix
+	if splt[0] != utils.MetaReq {
+		return nil, fmt.Errorf("invalid prefix for : %s", fldPath)
+	}
+	reqFileName := splt[1]
+	// find the last > and compute the name of the file
+	for i, val := range fldPath[1:] {
+		if grSplt := strings.Split(val, utils.MatchGreaterThan); len(grSplt) == 1 {
+			reqFileName = reqFileName + utils.NestingSep + val
+		} else {
+			if reqFileName+utils.NestingSep+grSplt[0] != fileName {
+				return
+			}
+			return fldPath[i+2:], nil
+		}
+	}
+	return nil, fmt.Errorf("filter rule <%s> needs to end in >", fldPath)
+}
+
+// newMapProvider constructs a DataProvider out of a JSON or SQL record
+func newMapProvider(record map[string]interface{}, fileName string) (dP utils.DataProvider) {
+	dP = &mapProvider{req: utils.MapStorage(record), fileName: fileName}
+	return
+}
+
+// mapProvider implements utils.DataProvider for the records with named fields
+type mapProvider struct {
+	req      utils.MapStorage
+	fileName string
+}
+
+// String is part of utils.DataProvider interface
+func (mP *mapProvider) String() string {
+	return utils.ToJSON(mP.req)
+}
+
+// FieldAsInterface is part of utils.DataProvider interface
+// the fields missing from the record are considered empty
+func (mP *mapProvider) FieldAsInterface(fldPath []string) (data interface{}, err error) {
+	var rcrdPath []string
+	if rcrdPath, err = recordPath(fldPath, mP.fileName); err != nil ||
+		len(rcrdPath) == 0 {
+		return
+	}
+	if data, err = mP.req.FieldAsInterface(rcrdPath); err == utils.ErrNotFound {
+		return nil, nil
+	}
+	return
+}
+
+// FieldAsString is part of utils.DataProvider interface
+func (mP *mapProvider) FieldAsString(fldPath []string) (data string, err error) {
+	var valIface interface{}
+	if valIface, err = mP.FieldAsInterface(fldPath); err != nil {
+		return
+	}
+	return utils.IfaceAsString(valIface), nil
+}
+
+// RemoteHost is part of utils.DataProvider interface
+func (mP *mapProvider) RemoteHost() net.Addr {
+	return utils.LocalAddr()
+}
@@ -19,25 +19,20 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package loaders
 
 import (
-	"encoding/csv"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"os"
 	"path"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
 
-type openedCSVFile struct {
-	fileName string
-	rdr      io.ReadCloser // keep reference so we can close it when done
-	csvRdr   *csv.Reader
-}
-
 func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 	timezone string, exitChan chan bool, filterS *engine.FilterS,
 	connMgr *engine.ConnManager, cacheConns []string) (ldr *Loader) {
@@ -50,9 +45,14 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 		tpOutDir:      cfg.TpOutDir,
 		lockFilename:  cfg.LockFileName,
 		fieldSep:      cfg.FieldSeparator,
+		runDelay:      cfg.RunDelay,
+		srcType:       cfg.SourceType,
+		srcPath:       cfg.SourcePath,
+		srcTimeout:    cfg.SourceTimeout,
+		srcVersions:   make(map[string]string),
 		dataTpls:      make(map[string][]*config.FCTemplate),
 		flagsTpls:     make(map[string]utils.FlagsWithParams),
-		rdrs:          make(map[string]map[string]*openedCSVFile),
+		rdrs:          make(map[string]map[string]*openedSource),
 		bufLoaderData: make(map[string][]LoaderData),
 		dm:            dm,
 		timezone:      timezone,
@@ -63,7 +63,7 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 	for _, ldrData := range cfg.Data {
 		ldr.dataTpls[ldrData.Type] = ldrData.Fields
 		ldr.flagsTpls[ldrData.Type] = ldrData.Flags
-		ldr.rdrs[ldrData.Type] = make(map[string]*openedCSVFile)
+		ldr.rdrs[ldrData.Type] = make(map[string]*openedSource)
 		if ldrData.Filename != "" {
 			ldr.rdrs[ldrData.Type][ldrData.Filename] = nil
 		}
@@ -88,11 +88,20 @@ type Loader struct {
 	tpOutDir      string
 	lockFilename  string
 	fieldSep      string
-	dataTpls      map[string][]*config.FCTemplate      // map[loaderType]*config.FCTemplate
-	flagsTpls     map[string]utils.FlagsWithParams     //map[loaderType]utils.FlagsWithParams
-	rdrs          map[string]map[string]*openedCSVFile // map[loaderType]map[fileName]*openedCSVFile for common incremental read
-	procRows      int                                  // keep here the last processed row in the file/-s
-	bufLoaderData map[string][]LoaderData              // cache of data read, indexed on tenantID
+	runDelay      time.Duration
+	srcType       string                              // <*file|*http|*s3|*sql>
+	srcPath       string                              // URL of the remote sources
+	srcTimeout    time.Duration                       // timeout of the requests towards the remote sources, 0 for no timeout
+	srcVersions   map[string]string                   // versions of the remote items already loaded, ie: ETag
+	lkMux         sync.Mutex                          // protects locked
+	locked        bool                                // lock of the sources without lock file
+	dataTpls      map[string][]*config.FCTemplate     // map[loaderType]*config.FCTemplate
+	flagsTpls     map[string]utils.FlagsWithParams    //map[loaderType]utils.FlagsWithParams
+	rdrs          map[string]map[string]*openedSource // map[loaderType]map[fileName]*openedSource for common incremental read
+	procRows      int                                 // keep here the last processed row in the file/-s
+	bufLoaderData map[string][]LoaderData             // cache of data read, indexed on tenantID
+	tx            *engine.LoadTransaction             // undo log of the current load
+	cacheBuf      []*loadedItems                      // items to be cached once the load is committed
 	dm            *engine.DataManager
 	timezone      string
 	filterS       *engine.FilterS
@@ -102,40 +111,184 @@ type Loader struct {
 
 func (ldr *Loader) ListenAndServe(exitChan chan struct{}) (err error) {
 	utils.Logger.Info(fmt.Sprintf("Starting <%s-%s>", utils.LoaderS, ldr.ldrID))
+	if ldr.runDelay > 0 && !ldr.isFileSource() {
+		go ldr.pollSource(exitChan)
+	}
 	e := <-exitChan
 	exitChan <- e // put back for the others listening for shutdown request
 	return
 }
 
+// loadedItems are the items of one store or remove, kept to be cached at the end of the load
+type loadedItems struct {
+	caching   string
+	args      utils.ArgsCache
+	partition string
+	ids       []string
+}
+
 // ProcessFolder will process the content in the folder with locking
+// the content is loaded as a transaction, on failure the DataDB is restored to the previous state
 func (ldr *Loader) ProcessFolder(caching, loadOption string) (err error) {
 	if err = ldr.lockFolder(); err != nil {
 		return
 	}
 	defer ldr.unlockFolder()
+	ldr.tx = engine.NewLoadTransaction(ldr.dm)
+	ldr.cacheBuf = nil
+	srcVersions := make(map[string]string, len(ldr.srcVersions)) // restored on rollback
+	for item, version := range ldr.srcVersions {
+		srcVersions[item] = version
+	}
 	for ldrType := range ldr.rdrs {
 		if err = ldr.processFiles(ldrType, caching, loadOption); err != nil {
-			utils.Logger.Warning(fmt.Sprintf("<%s-%s> loaderType: <%s> cannot open files, err: %s",
-				utils.LoaderS, ldr.ldrID, ldrType, err.Error()))
-			continue
+			utils.Logger.Warning(fmt.Sprintf("<%s-%s> loaderType: <%s>, rolling back %d items, err: %s",
+				utils.LoaderS, ldr.ldrID, ldrType, len(ldr.tx.Applied()), err.Error()))
+			ldr.srcVersions = srcVersions
+			ldr.bufLoaderData = make(map[string][]LoaderData)
+			ldr.cacheBuf = nil
+			if errRb := ldr.tx.Rollback(); errRb != nil {
+				err = fmt.Errorf("%s, %s", err.Error(), errRb.Error())
+			}
+			return
+		}
+	}
+	if err = ldr.tx.Commit(); err != nil {
+		ldr.srcVersions = srcVersions
+		ldr.cacheBuf = nil
+		if errRb := ldr.tx.Rollback(); errRb != nil {
+			err = fmt.Errorf("%s, %s", err.Error(), errRb.Error())
+		}
+		return
+	}
+	if applied := ldr.tx.Applied(); len(applied) != 0 {
+		utils.Logger.Info(fmt.Sprintf("<%s-%s> applied %d items: %s",
+			utils.LoaderS, ldr.ldrID, len(applied), strings.Join(applied, utils.FIELDS_SEP)))
+	}
+	cacheErr := ldr.updateCache()
+	if ldr.isFileSource() { // the remote sources are read again on next run
+		err = ldr.moveFiles()
+	}
+	if cacheErr != nil {
+		err = cacheErr
+	}
+	return
+}
+
+// transaction returns the transaction of the current load
+func (ldr *Loader) transaction() *engine.LoadTransaction {
+	if ldr.tx == nil {
+		ldr.tx = engine.NewLoadTransaction(ldr.dm)
+	}
+	return ldr.tx
+}
+
+// updateCache sends the items of the committed load to CacheS with one call
+func (ldr *Loader) updateCache() (err error) {
+	cacheBuf := ldr.cacheBuf
+	ldr.cacheBuf = nil
+	if len(cacheBuf) == 0 {
+		return
+	}
+	var cacheArgs utils.ArgsCache
+	for _, itms := range cacheBuf {
+		cacheArgs.Merge(itms.args)
+	}
+	var reply string
+	switch cacheBuf[0].caching { // same caching for all the items of the load
+	case utils.META_NONE:
+		return
+	case utils.MetaReload:
+		if err = ldr.connMgr.Call(ldr.cacheConns, nil,
+			utils.CacheSv1ReloadCache, utils.AttrReloadCacheWithArgDispatcher{
+				ArgsCache: cacheArgs}, &reply); err != nil {
+			return
+		}
+	case utils.MetaLoad:
+		if err = ldr.connMgr.Call(ldr.cacheConns, nil,
+			utils.CacheSv1LoadCache, utils.AttrReloadCacheWithArgDispatcher{
+				ArgsCache: cacheArgs}, &reply); err != nil {
+			return
+		}
+	case utils.MetaRemove:
+		for _, itms := range cacheBuf {
+			for _, id := range itms.ids {
+				if err = ldr.connMgr.Call(ldr.cacheConns, nil,
+					utils.CacheSv1RemoveItem, &utils.ArgsGetCacheItemWithArgDispatcher{
+						ArgsGetCacheItem: utils.ArgsGetCacheItem{
+							CacheID: itms.partition,
+							ItemID:  id,
+						},
+					}, &reply); err != nil {
+					return
+				}
+			}
+		}
+	case utils.MetaClear:
+		if err = ldr.connMgr.Call(ldr.cacheConns, nil,
+			utils.CacheSv1Clear, new(utils.AttrCacheIDsWithArgDispatcher), &reply); err != nil {
+			return
 		}
 	}
-	return ldr.moveFiles()
+	return
 }
 
-// lockFolder will attempt to lock the folder by creating the lock file
+// pollSource processes periodically the remote source, only the changed items are loaded
+func (ldr *Loader) pollSource(exitChan chan struct{}) {
+	for {
+		select {
+		case <-exitChan:
+			return
+		case <-time.After(ldr.runDelay):
+		}
+		if err := ldr.ProcessFolder(config.CgrConfig().GeneralCfg().DefaultCaching,
+			utils.MetaStore); err != nil && err != errLoaderRunning { // processed over API
+			utils.Logger.Warning(fmt.Sprintf("<%s-%s> processing source, err: %s",
+				utils.LoaderS, ldr.ldrID, err.Error()))
+		}
+	}
+}
+
+// isFileSource returns true if the loader reads the files out of tpInDir
+func (ldr *Loader) isFileSource() bool {
+	return ldr.srcType == utils.EmptyString ||
+		ldr.srcType == utils.MetaFile
+}
+
+// lockFolder will attempt to lock the folder by creating the lock file,
+// the sources without lock file fail if already locked
 func (ldr *Loader) lockFolder() (err error) {
+	if !ldr.isFileSource() {
+		ldr.lkMux.Lock()
+		defer ldr.lkMux.Unlock()
+		if ldr.locked {
+			return errLoaderRunning
+		}
+		ldr.locked = true
+		return
+	}
 	_, err = os.OpenFile(path.Join(ldr.tpInDir, ldr.lockFilename),
 		os.O_RDONLY|os.O_CREATE, 0644)
 	return
 }
 
 func (ldr *Loader) unlockFolder() (err error) {
+	if !ldr.isFileSource() {
+		ldr.lkMux.Lock()
+		ldr.locked = false
+		ldr.lkMux.Unlock()
+		return
+	}
 	return os.Remove(path.Join(ldr.tpInDir,
 		ldr.lockFilename))
 }
 
 func (ldr *Loader) isFolderLocked() (locked bool, err error) {
+	if !ldr.isFileSource() {
+		ldr.lkMux.Lock()
+		defer ldr.lkMux.Unlock()
+		return ldr.locked, nil
+	}
 	if _, err = os.Stat(path.Join(ldr.tpInDir,
 		ldr.lockFilename)); err == nil {
 		return true, nil
@@ -148,9 +301,9 @@ func (ldr *Loader) isFolderLocked() (locked bool, err error) {
 
 // unreferenceFile will cleanup an used file by closing and removing from referece map
 func (ldr *Loader) unreferenceFile(loaderType, fileName string) (err error) {
-	openedCSVFile := ldr.rdrs[loaderType][fileName]
+	openedSource := ldr.rdrs[loaderType][fileName]
 	ldr.rdrs[loaderType][fileName] = nil
-	return openedCSVFile.rdr.Close()
+	return openedSource.rdr.Close()
 }
 
 func (ldr *Loader) moveFiles() (err error) {
@@ -170,32 +323,58 @@ func (ldr *Loader) moveFiles() (err error) {
 }
 
 func (ldr *Loader) processFiles(loaderType, caching, loadOption string) (err error) {
+	var unchanged int
 	for fName := range ldr.rdrs[loaderType] {
-		var rdr *os.File
-		if rdr, err = os.Open(path.Join(ldr.tpInDir, fName)); err != nil {
-			return err
-		}
-		csvReader := csv.NewReader(rdr)
-		csvReader.Comment = '#'
-		ldr.rdrs[loaderType][fName] = &openedCSVFile{
-			fileName: fName, rdr: rdr, csvRdr: csvReader}
+		var oS *openedSource
+		if oS, err = ldr.openSource(fName, loadOption == utils.MetaStore); err == errSourceNotModified {
+			unchanged++
+			continue
+		} else if os.IsNotExist(err) { // nothing to load for this loaderType
+			utils.Logger.Warning(fmt.Sprintf("<%s-%s> loaderType: <%s> cannot open files, err: %s",
+				utils.LoaderS, ldr.ldrID, loaderType, err.Error()))
+			return nil
+		} else if err != nil {
+			return
+		}
+		ldr.rdrs[loaderType][fName] = oS
 		defer ldr.unreferenceFile(loaderType, fName)
-		// based on load option will store or remove the content
-		switch loadOption {
-		case utils.MetaStore:
-			if err = ldr.processContent(loaderType, caching); err != nil {
-				return
+	}
+	err = nil
+	if unchanged == len(ldr.rdrs[loaderType]) { // nothing new since the previous load
+		return
+	}
+	if unchanged != 0 { // the records are composed out of all the items
+		for fName, oS := range ldr.rdrs[loaderType] {
+			if oS != nil {
+				continue
 			}
-		case utils.MetaRemove:
-			if err = ldr.removeContent(loaderType, caching); err != nil {
+			if oS, err = ldr.openSource(fName, false); err != nil {
 				return
 			}
+			ldr.rdrs[loaderType][fName] = oS
+			defer ldr.unreferenceFile(loaderType, fName)
+		}
+	}
+	// based on load option will store or remove the content
+	switch loadOption {
+	case utils.MetaStore:
+		if err = ldr.processContent(loaderType, caching); err != nil {
+			return
+		}
+		for fName, oS := range ldr.rdrs[loaderType] {
+			if oS.version != utils.EmptyString {
+				ldr.srcVersions[fName] = oS.version
+			}
+		}
+	case utils.MetaRemove:
+		if err = ldr.removeContent(loaderType, caching); err != nil {
+			return
 		}
 	}
 	return
 }
 
-//processContent will process the contect and will store it into database
+// processContent will process the contect and will store it into database
 func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 	// start processing lines
 	keepLooping := true // controls looping
@@ -204,9 +383,9 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 		lineNr++
 		var hasErrors bool
 		lData := make(LoaderData) // one row
-		for fName, rdr := range ldr.rdrs[loaderType] {
-			var record []string
-			if record, err = rdr.csvRdr.Read(); err != nil {
+		for _, rdr := range ldr.rdrs[loaderType] {
+			var record utils.DataProvider
+			if record, err = rdr.readRecord(); err != nil {
 				if err == io.EOF {
 					keepLooping = false
 					break
@@ -220,7 +399,7 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 				continue
 			}
 
-			if err := lData.UpdateFromCSV(fName, record,
+			if err := lData.UpdateFromDataProvider(record,
 				ldr.dataTpls[loaderType], ldr.tenant, ldr.filterS); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> line: %d, error: %s",
@@ -264,6 +443,7 @@ func (ldr *Loader) processContent(loaderType, caching string) (err error) {
 
 func (ldr *Loader) storeLoadedData(loaderType string,
 	lds map[string][]LoaderData, caching string) (err error) {
+	tx := ldr.transaction()
 	var ids []string
 	var cacheArgs utils.ArgsCache
 	var cachePartition string
@@ -290,7 +470,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, apf.TenantID())
-				if err := ldr.dm.SetAttributeProfile(apf, true); err != nil {
+				if err := tx.SetAttributeProfile(apf, true); err != nil {
 					return err
 				}
 			}
@@ -320,10 +500,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, res.TenantID())
-				if err := ldr.dm.SetResourceProfile(res, true); err != nil {
+				if err := tx.SetResourceProfile(res, true); err != nil {
 					return err
 				}
-				if err := ldr.dm.SetResource(
+				if err := tx.SetResource(
 					&engine.Resource{Tenant: res.Tenant,
 						ID:     res.ID,
 						Usages: make(map[string]*engine.ResourceUsage)}); err != nil {
@@ -357,7 +537,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, fltrPrf.TenantID())
-				if err := ldr.dm.SetFilter(fltrPrf, true); err != nil {
+				if err := tx.SetFilter(fltrPrf, true); err != nil {
 					return err
 				}
 				cacheArgs.FilterIDs = ids
@@ -386,7 +566,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, stsPrf.TenantID())
-				if err := ldr.dm.SetStatQueueProfile(stsPrf, true); err != nil {
+				if err := tx.SetStatQueueProfile(stsPrf, true); err != nil {
 					return err
 				}
 				metrics := make(map[string]engine.StatMetric)
@@ -397,7 +577,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 					}
 					metrics[metric.MetricID] = stsMetric
 				}
-				if err := ldr.dm.SetStatQueue(&engine.StatQueue{Tenant: stsPrf.Tenant, ID: stsPrf.ID, SQMetrics: metrics}); err != nil {
+				if err := tx.SetStatQueue(&engine.StatQueue{Tenant: stsPrf.Tenant, ID: stsPrf.ID, SQMetrics: metrics}); err != nil {
 					return err
 				}
 				cacheArgs.StatsQueueProfileIDs = ids
@@ -427,10 +607,10 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, thPrf.TenantID())
-				if err := ldr.dm.SetThresholdProfile(thPrf, true); err != nil {
+				if err := tx.SetThresholdProfile(thPrf, true); err != nil {
 					return err
 				}
-				if err := ldr.dm.SetThreshold(&engine.Threshold{Tenant: thPrf.Tenant, ID: thPrf.ID}); err != nil {
+				if err := tx.SetThreshold(&engine.Threshold{Tenant: thPrf.Tenant, ID: thPrf.ID}); err != nil {
 					return err
 				}
 				cacheArgs.ThresholdProfileIDs = ids
@@ -461,7 +641,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, spPrf.TenantID())
-				if err := ldr.dm.SetRouteProfile(spPrf, true); err != nil {
+				if err := tx.SetRouteProfile(spPrf, true); err != nil {
 					return err
 				}
 				cacheArgs.RouteProfileIDs = ids
@@ -491,7 +671,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, cpp.TenantID())
-				if err := ldr.dm.SetChargerProfile(cpp, true); err != nil {
+				if err := tx.SetChargerProfile(cpp, true); err != nil {
 					return err
 				}
 				cacheArgs.ChargerProfileIDs = ids
@@ -520,7 +700,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, dsp.TenantID())
-				if err := ldr.dm.SetDispatcherProfile(dsp, true); err != nil {
+				if err := tx.SetDispatcherProfile(dsp, true); err != nil {
 					return err
 				}
 				cacheArgs.DispatcherProfileIDs = ids
@@ -546,7 +726,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, dsp.TenantID())
-				if err := ldr.dm.SetDispatcherHost(dsp); err != nil {
+				if err := tx.SetDispatcherHost(dsp); err != nil {
 					return err
 				}
 				cacheArgs.DispatcherHostIDs = ids
@@ -576,11 +756,11 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				// get IDs so we can reload in cache
 				ids = append(ids, rpl.TenantID())
 				if ldr.flagsTpls[loaderType].GetBool(utils.MetaPartial) {
-					if err := ldr.dm.SetRateProfileRates(rpl, true); err != nil {
+					if err := tx.SetRateProfileRates(rpl, true); err != nil {
 						return err
 					}
 				} else {
-					if err := ldr.dm.SetRateProfile(rpl, true); err != nil {
+					if err := tx.SetRateProfile(rpl, true); err != nil {
 						return err
 					}
 				}
@@ -590,46 +770,14 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 		}
 	}
 
-	if len(ldr.cacheConns) != 0 {
-		var reply string
-		switch caching {
-		case utils.META_NONE:
-			return
-		case utils.MetaReload:
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-				utils.CacheSv1ReloadCache, utils.AttrReloadCacheWithArgDispatcher{
-					ArgsCache: cacheArgs}, &reply); err != nil {
-				return
-			}
-		case utils.MetaLoad:
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-				utils.CacheSv1LoadCache, utils.AttrReloadCacheWithArgDispatcher{
-					ArgsCache: cacheArgs}, &reply); err != nil {
-				return
-			}
-		case utils.MetaRemove:
-			for _, id := range ids {
-				if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-					utils.CacheSv1RemoveItem, &utils.ArgsGetCacheItemWithArgDispatcher{
-						ArgsGetCacheItem: utils.ArgsGetCacheItem{
-							CacheID: cachePartition,
-							ItemID:  id,
-						},
-					}, &reply); err != nil {
-					return
-				}
-			}
-		case utils.MetaClear:
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-				utils.CacheSv1Clear, new(utils.AttrCacheIDsWithArgDispatcher), &reply); err != nil {
-				return
-			}
-		}
+	if len(ldr.cacheConns) != 0 { // cached once the load is committed
+		ldr.cacheBuf = append(ldr.cacheBuf, &loadedItems{caching: caching,
+			args: cacheArgs, partition: cachePartition, ids: ids})
 	}
 	return
 }
 
-//removeContent will process the content and will remove it from database
+// removeContent will process the content and will remove it from database
 func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 	// start processing lines
 	keepLooping := true // controls looping
@@ -638,9 +786,9 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 		lineNr++
 		var hasErrors bool
 		lData := make(LoaderData) // one row
-		for fName, rdr := range ldr.rdrs[loaderType] {
-			var record []string
-			if record, err = rdr.csvRdr.Read(); err != nil {
+		for _, rdr := range ldr.rdrs[loaderType] {
+			var record utils.DataProvider
+			if record, err = rdr.readRecord(); err != nil {
 				if err == io.EOF {
 					keepLooping = false
 					break
@@ -654,7 +802,7 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 				continue
 			}
 
-			if err := lData.UpdateFromCSV(fName, record,
+			if err := lData.UpdateFromDataProvider(record,
 				ldr.dataTpls[loaderType], ldr.tenant, ldr.filterS); err != nil {
 				utils.Logger.Warning(
 					fmt.Sprintf("<%s> <%s> line: %d, error: %s",
@@ -696,9 +844,10 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 	return
 }
 
-//removeLoadedData will remove the data from database
-//since we remove we don't need to compose the struct we only need the Tenant and the ID of the profile
+// removeLoadedData will remove the data from database
+// since we remove we don't need to compose the struct we only need the Tenant and the ID of the profile
 func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderData, caching string) (err error) {
+	tx := ldr.transaction()
 	var ids []string
 	var cacheArgs utils.ArgsCache
 	var cachePartition string
@@ -713,8 +862,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveAttributeProfile(tntIDStruct.Tenant, tntIDStruct.ID,
-					utils.NonTransactional, true); err != nil {
+				if err := tx.RemoveAttributeProfile(tntIDStruct.Tenant, tntIDStruct.ID, true); err != nil {
 					return err
 				}
 				cacheArgs.AttributeProfileIDs = ids
@@ -733,11 +881,10 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveResourceProfile(tntIDStruct.Tenant,
-					tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+				if err := tx.RemoveResourceProfile(tntIDStruct.Tenant, tntIDStruct.ID, true); err != nil {
 					return err
 				}
-				if err := ldr.dm.RemoveResource(tntIDStruct.Tenant, tntIDStruct.ID, utils.NonTransactional); err != nil {
+				if err := tx.RemoveResource(tntIDStruct.Tenant, tntIDStruct.ID); err != nil {
 					return err
 				}
 				cacheArgs.ResourceProfileIDs = ids
@@ -755,8 +902,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveFilter(tntIDStruct.Tenant, tntIDStruct.ID,
-					utils.NonTransactional, true); err != nil {
+				if err := tx.RemoveFilter(tntIDStruct.Tenant, tntIDStruct.ID, true); err != nil {
 					return err
 				}
 				cacheArgs.FilterIDs = ids
@@ -773,11 +919,10 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveStatQueueProfile(tntIDStruct.Tenant,
-					tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+				if err := tx.RemoveStatQueueProfile(tntIDStruct.Tenant, tntIDStruct.ID, true); err != nil {
 					return err
 				}
-				if err := ldr.dm.RemoveStatQueue(tntIDStruct.Tenant, tntIDStruct.ID, utils.NonTransactional); err != nil {
+				if err := tx.RemoveStatQueue(tntIDStruct.Tenant, tntIDStruct.ID); err != nil {
 					return err
 				}
 				cacheArgs.StatsQueueProfileIDs = ids
@@ -795,11 +940,10 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveThresholdProfile(tntIDStruct.Tenant,
-					tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+				if err := tx.RemoveThresholdProfile(tntIDStruct.Tenant, tntIDStruct.ID, true); err != nil {
 					return err
 				}
-				if err := ldr.dm.RemoveThreshold(tntIDStruct.Tenant, tntIDStruct.ID, utils.NonTransactional); err != nil {
+				if err := tx.RemoveThreshold(tntIDStruct.Tenant, tntIDStruct.ID); err != nil {
 					return err
 				}
 				cacheArgs.ThresholdProfileIDs = ids
@@ -817,8 +961,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveRouteProfile(tntIDStruct.Tenant,
-					tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+				if err := tx.RemoveRouteProfile(tntIDStruct.Tenant, tntIDStruct.ID, true); err != nil {
 					return err
 				}
 				cacheArgs.RouteProfileIDs = ids
@@ -835,8 +978,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveChargerProfile(tntIDStruct.Tenant,
-					tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+				if err := tx.RemoveChargerProfile(tntIDStruct.Tenant, tntIDStruct.ID, true); err != nil {
 					return err
 				}
 				cacheArgs.ChargerProfileIDs = ids
@@ -853,8 +995,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveDispatcherProfile(tntIDStruct.Tenant,
-					tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+				if err := tx.RemoveDispatcherProfile(tntIDStruct.Tenant, tntIDStruct.ID, true); err != nil {
 					return err
 				}
 				cacheArgs.DispatcherProfileIDs = ids
@@ -871,8 +1012,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveDispatcherHost(tntIDStruct.Tenant,
-					tntIDStruct.ID, utils.NonTransactional); err != nil {
+				if err := tx.RemoveDispatcherHost(tntIDStruct.Tenant, tntIDStruct.ID); err != nil {
 					return err
 				}
 				cacheArgs.DispatcherHostIDs = ids
@@ -895,14 +1035,13 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 					if rateIDs, err := ldData[0].GetRateIDs(); err != nil {
 						return err
 					} else {
-						if err := ldr.dm.RemoveRateProfileRates(tntIDStruct.Tenant,
+						if err := tx.RemoveRateProfileRates(tntIDStruct.Tenant,
 							tntIDStruct.ID, rateIDs, true); err != nil {
 							return err
 						}
 					}
 				} else {
-					if err := ldr.dm.RemoveRateProfile(tntIDStruct.Tenant,
-						tntIDStruct.ID, utils.NonTransactional, true); err != nil {
+					if err := tx.RemoveRateProfile(tntIDStruct.Tenant, tntIDStruct.ID, true); err != nil {
 						return err
 					}
 				}
@@ -913,41 +1052,9 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 		}
 	}
 
-	if len(ldr.cacheConns) != 0 {
-		var reply string
-		switch caching {
-		case utils.META_NONE:
-			return
-		case utils.MetaReload:
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-				utils.CacheSv1ReloadCache, utils.AttrReloadCacheWithArgDispatcher{
-					ArgsCache: cacheArgs}, &reply); err != nil {
-				return
-			}
-		case utils.MetaLoad:
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-				utils.CacheSv1LoadCache, utils.AttrReloadCacheWithArgDispatcher{
-					ArgsCache: cacheArgs}, &reply); err != nil {
-				return
-			}
-		case utils.MetaRemove:
-			for tntID, _ := range lds {
-				if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-					utils.CacheSv1RemoveItem, &utils.ArgsGetCacheItemWithArgDispatcher{
-						ArgsGetCacheItem: utils.ArgsGetCacheItem{
-							CacheID: cachePartition,
-							ItemID:  tntID,
-						},
-					}, &reply); err != nil {
-					return
-				}
-			}
-		case utils.MetaClear:
-			if err = ldr.connMgr.Call(ldr.cacheConns, nil,
-				utils.CacheSv1Clear, new(utils.AttrCacheIDsWithArgDispatcher), &reply); err != nil {
-				return
-			}
-		}
+	if len(ldr.cacheConns) != 0 { // cached once the load is committed
+		ldr.cacheBuf = append(ldr.cacheBuf, &loadedItems{caching: caching,
+			args: cacheArgs, partition: cachePartition, ids: ids})
 	}
 	return
 }
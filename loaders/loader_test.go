@@ -21,6 +21,8 @@ package loaders
 import (
 	"encoding/csv"
 	"io/ioutil"
+	"os"
+	"path"
 	"reflect"
 	"sort"
 	"strings"
@@ -93,9 +95,9 @@ func TestLoaderProcessContentSingleFile(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.AttributesCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaAttributes: {
-			"Attributes.csv": &openedCSVFile{fileName: "Attributes.csv",
+			"Attributes.csv": &openedSource{fileName: "Attributes.csv",
 				rdr: rdr, csvRdr: csvRdr}},
 	}
 	if err := ldr.processContent(utils.MetaAttributes, utils.EmptyString); err != nil {
@@ -182,11 +184,11 @@ func TestLoaderProcessContentMultiFiles(t *testing.T) {
 	rdr2 := ioutil.NopCloser(strings.NewReader(file2CSV))
 	csvRdr2 := csv.NewReader(rdr2)
 	csvRdr2.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaAttributes: {
-			"File1.csv": &openedCSVFile{fileName: "File1.csv",
+			"File1.csv": &openedSource{fileName: "File1.csv",
 				rdr: rdr1, csvRdr: csvRdr1},
-			"File2.csv": &openedCSVFile{fileName: "File2.csv",
+			"File2.csv": &openedSource{fileName: "File2.csv",
 				rdr: rdr2, csvRdr: csvRdr2}},
 	}
 	if err := ldr.processContent(utils.MetaAttributes, utils.EmptyString); err != nil {
@@ -277,9 +279,9 @@ func TestLoaderProcessResource(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.ResourcesCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaResources: {
-			"Resources.csv": &openedCSVFile{fileName: "Resources.csv",
+			"Resources.csv": &openedSource{fileName: "Resources.csv",
 				rdr: rdr, csvRdr: csvRdr}},
 	}
 	if err := ldr.processContent(utils.MetaResources, utils.EmptyString); err != nil {
@@ -375,9 +377,9 @@ func TestLoaderProcessFilters(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.FiltersCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaFilters: {
-			"Filters.csv": &openedCSVFile{fileName: "Filters.csv",
+			"Filters.csv": &openedSource{fileName: "Filters.csv",
 				rdr: rdr, csvRdr: csvRdr}},
 	}
 	if err := ldr.processContent(utils.MetaFilters, utils.EmptyString); err != nil {
@@ -515,9 +517,9 @@ func TestLoaderProcessThresholds(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.ThresholdsCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaThresholds: {
-			"Thresholds.csv": &openedCSVFile{fileName: "Thresholds.csv",
+			"Thresholds.csv": &openedSource{fileName: "Thresholds.csv",
 				rdr: rdr, csvRdr: csvRdr}},
 	}
 	if err := ldr.processContent(utils.MetaThresholds, utils.EmptyString); err != nil {
@@ -622,9 +624,9 @@ func TestLoaderProcessStats(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.StatsCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaStats: {
-			"Stats.csv": &openedCSVFile{fileName: "Stats.csv",
+			"Stats.csv": &openedSource{fileName: "Stats.csv",
 				rdr: rdr, csvRdr: csvRdr}},
 	}
 	if err := ldr.processContent(utils.MetaStats, utils.EmptyString); err != nil {
@@ -755,9 +757,9 @@ func TestLoaderProcessRoutes(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.RoutesCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaRoutes: {
-			utils.RoutesCsv: &openedCSVFile{fileName: utils.RoutesCsv,
+			utils.RoutesCsv: &openedSource{fileName: utils.RoutesCsv,
 				rdr: rdr, csvRdr: csvRdr}},
 	}
 	if err := ldr.processContent(utils.MetaRoutes, utils.EmptyString); err != nil {
@@ -876,9 +878,9 @@ func TestLoaderProcessChargers(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.ChargersCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaChargers: {
-			utils.ChargersCsv: &openedCSVFile{fileName: utils.ChargersCsv,
+			utils.ChargersCsv: &openedSource{fileName: utils.ChargersCsv,
 				rdr: rdr, csvRdr: csvRdr}},
 	}
 	if err := ldr.processContent(utils.MetaChargers, utils.EmptyString); err != nil {
@@ -1004,9 +1006,9 @@ func TestLoaderProcessDispatches(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.DispatcherCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaDispatchers: {
-			utils.DispatcherProfilesCsv: &openedCSVFile{
+			utils.DispatcherProfilesCsv: &openedSource{
 				fileName: utils.DispatcherProfilesCsv,
 				rdr:      rdr,
 				csvRdr:   csvRdr,
@@ -1108,9 +1110,9 @@ func TestLoaderProcessDispatcheHosts(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.DispatcherHostCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaDispatcherHosts: {
-			utils.DispatcherProfilesCsv: &openedCSVFile{
+			utils.DispatcherProfilesCsv: &openedSource{
 				fileName: utils.DispatcherProfilesCsv,
 				rdr:      rdr,
 				csvRdr:   csvRdr,
@@ -1174,9 +1176,9 @@ func TestLoaderRemoveContentSingleFile(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.AttributesCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaAttributes: {
-			"Attributes.csv": &openedCSVFile{fileName: "Attributes.csv",
+			"Attributes.csv": &openedSource{fileName: "Attributes.csv",
 				rdr: rdr, csvRdr: csvRdr}},
 	}
 	// Add two attributeProfiles
@@ -1330,9 +1332,9 @@ func TestLoaderProcessRateProfile(t *testing.T) {
 	rdr := ioutil.NopCloser(strings.NewReader(engine.RateProfileCSVContent))
 	csvRdr := csv.NewReader(rdr)
 	csvRdr.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaRateProfiles: {
-			utils.RateProfilesCsv: &openedCSVFile{fileName: utils.RateProfilesCsv,
+			utils.RateProfilesCsv: &openedSource{fileName: utils.RateProfilesCsv,
 				rdr: rdr, csvRdr: csvRdr}},
 	}
 	if err := ldr.processContent(utils.MetaRateProfiles, utils.EmptyString); err != nil {
@@ -1517,9 +1519,9 @@ cgrates.org,RP1,,,,,,,,,,RT_CHRISTMAS,,* * 24 12 *,30,false,0s,0.06,1m,1s
 	rdr1 := ioutil.NopCloser(strings.NewReader(ratePrfCnt1))
 	csvRdr1 := csv.NewReader(rdr1)
 	csvRdr1.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaRateProfiles: {
-			utils.RateProfilesCsv: &openedCSVFile{fileName: utils.RateProfilesCsv,
+			utils.RateProfilesCsv: &openedSource{fileName: utils.RateProfilesCsv,
 				rdr: rdr1, csvRdr: csvRdr1}},
 	}
 	if err := ldr.processContent(utils.MetaRateProfiles, utils.EmptyString); err != nil {
@@ -1571,9 +1573,9 @@ cgrates.org,RP1,,,,,,,,,,RT_CHRISTMAS,,* * 24 12 *,30,false,0s,0.06,1m,1s
 	rdr2 := ioutil.NopCloser(strings.NewReader(ratePrfCnt2))
 	csvRdr2 := csv.NewReader(rdr2)
 	csvRdr2.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaRateProfiles: {
-			utils.RateProfilesCsv: &openedCSVFile{fileName: utils.RateProfilesCsv,
+			utils.RateProfilesCsv: &openedSource{fileName: utils.RateProfilesCsv,
 				rdr: rdr2, csvRdr: csvRdr2}},
 	}
 	if flag, err := utils.FlagsWithParamsFromSlice([]string{utils.MetaPartial}); err != nil {
@@ -1820,9 +1822,9 @@ cgrates.org,RP1,
 	rdr1 := ioutil.NopCloser(strings.NewReader(ratePrfCnt1))
 	csvRdr1 := csv.NewReader(rdr1)
 	csvRdr1.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaRateProfiles: {
-			utils.RateProfilesCsv: &openedCSVFile{fileName: utils.RateProfilesCsv,
+			utils.RateProfilesCsv: &openedSource{fileName: utils.RateProfilesCsv,
 				rdr: rdr1, csvRdr: csvRdr1}},
 	}
 	if flag, err := utils.FlagsWithParamsFromSlice([]string{utils.MetaPartial}); err != nil {
@@ -1893,9 +1895,9 @@ cgrates.org,RP1,
 	rdr2 := ioutil.NopCloser(strings.NewReader(ratePrfCnt2))
 	csvRdr2 := csv.NewReader(rdr2)
 	csvRdr2.Comment = '#'
-	ldr.rdrs = map[string]map[string]*openedCSVFile{
+	ldr.rdrs = map[string]map[string]*openedSource{
 		utils.MetaRateProfiles: {
-			utils.RateProfilesCsv: &openedCSVFile{fileName: utils.RateProfilesCsv,
+			utils.RateProfilesCsv: &openedSource{fileName: utils.RateProfilesCsv,
 				rdr: rdr2, csvRdr: csvRdr2}},
 	}
 	if flag, err := utils.FlagsWithParamsFromSlice([]string{utils.MetaPartial}); err != nil {
@@ -1970,3 +1972,103 @@ cgrates.org,RP1,
 		t.Errorf("expecting: %+v,\n received: %+v", utils.ToJSON(eRatePrf3), utils.ToJSON(rcv))
 	}
 }
+
+func TestLoaderProcessFolderRollback(t *testing.T) {
+	tpInDir, err := ioutil.TempDir(utils.EmptyString, "TestLoaderProcessFolderRollbackIn")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tpInDir)
+	tpOutDir, err := ioutil.TempDir(utils.EmptyString, "TestLoaderProcessFolderRollbackOut")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tpOutDir)
+	data := engine.NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	ldr := &Loader{
+		ldrID:         "TestLoaderProcessFolderRollback",
+		tpInDir:       tpInDir,
+		tpOutDir:      tpOutDir,
+		lockFilename:  ".cgr.lck",
+		bufLoaderData: make(map[string][]LoaderData),
+		dm:            engine.NewDataManager(data, config.CgrConfig().CacheCfg(), nil),
+		timezone:      "UTC",
+		dataTpls: map[string][]*config.FCTemplate{
+			utils.MetaAttributes: {
+				{Tag: "TenantID", Path: "Tenant", Type: utils.META_COMPOSED,
+					Value: config.NewRSRParsersMustCompile("~*req.0", utils.INFIELD_SEP), Mandatory: true},
+				{Tag: "ProfileID", Path: "ID", Type: utils.META_COMPOSED,
+					Value: config.NewRSRParsersMustCompile("~*req.1", utils.INFIELD_SEP), Mandatory: true},
+				{Tag: "Path", Path: "Path", Type: utils.META_COMPOSED,
+					Value: config.NewRSRParsersMustCompile("~*req.2", utils.INFIELD_SEP)},
+				{Tag: "Value", Path: "Value", Type: utils.META_COMPOSED,
+					Value: config.NewRSRParsersMustCompile("~*req.3", utils.INFIELD_SEP)},
+				{Tag: "Weight", Path: "Weight", Type: utils.META_COMPOSED,
+					Value: config.NewRSRParsersMustCompile("~*req.4", utils.INFIELD_SEP)},
+			},
+		},
+		rdrs: map[string]map[string]*openedSource{
+			utils.MetaAttributes: {utils.AttributesCsv: nil},
+		},
+	}
+	prevAttr := &engine.AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ALS1",
+		Contexts:  []string{},
+		FilterIDs: []string{},
+		Attributes: []*engine.Attribute{{
+			FilterIDs: []string{},
+			Path:      utils.MetaReq + utils.NestingSep + "Field1",
+			Value:     config.NewRSRParsersMustCompile("Initial", utils.INFIELD_SEP),
+		}},
+		Weight: 10,
+	}
+	if err := ldr.dm.SetAttributeProfile(prevAttr, true); err != nil {
+		t.Fatal(err)
+	}
+	// second profile can not be converted so the first one should be restored
+	content := `cgrates.org,ALS1,*req.Field1,Changed,20
+cgrates.org,ALS2,*req.Field1,Sub1,not_a_number
+`
+	if err := ioutil.WriteFile(path.Join(tpInDir, utils.AttributesCsv), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore); err == nil {
+		t.Error("expecting error")
+	}
+	if rcv, err := ldr.dm.GetAttributeProfile("cgrates.org", "ALS1",
+		false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(prevAttr, rcv) {
+		t.Errorf("expecting: %s, \n received: %s", utils.ToJSON(prevAttr), utils.ToJSON(rcv))
+	}
+	if _, err := ldr.dm.GetAttributeProfile("cgrates.org", "ALS2",
+		false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expecting: %v, received: %v", utils.ErrNotFound, err)
+	}
+	// the files are kept for a new attempt
+	if _, err := os.Stat(path.Join(tpInDir, utils.AttributesCsv)); err != nil {
+		t.Error(err)
+	}
+
+	content = `cgrates.org,ALS1,*req.Field1,Changed,20
+cgrates.org,ALS2,*req.Field1,Sub1,20
+`
+	if err := ioutil.WriteFile(path.Join(tpInDir, utils.AttributesCsv), []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ldr.ProcessFolder(utils.EmptyString, utils.MetaStore); err != nil {
+		t.Error(err)
+	}
+	for _, id := range []string{"ALS1", "ALS2"} {
+		if rcv, err := ldr.dm.GetAttributeProfile("cgrates.org", id,
+			false, false, utils.NonTransactional); err != nil {
+			t.Error(err)
+		} else if rcv.Weight != 20 {
+			t.Errorf("unexpected profile: %s", utils.ToJSON(rcv))
+		}
+	}
+	if _, err := os.Stat(path.Join(tpOutDir, utils.AttributesCsv)); err != nil {
+		t.Error(err)
+	}
+}
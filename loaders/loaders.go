@@ -28,6 +28,9 @@ import (
 	"github.com/cgrates/cgrates/utils"
 )
 
+// errLoaderRunning is returned when the loader is already processing its source
+var errLoaderRunning = errors.New("ANOTHER_LOADER_RUNNING")
+
 func NewLoaderService(dm *engine.DataManager, ldrsCfg []*config.LoaderSCfg,
 	timezone string, exitChan chan bool, filterS *engine.FilterS,
 	connMgr *engine.ConnManager) (ldrS *LoaderService) {
@@ -97,7 +100,7 @@ func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
 				return utils.NewErrServerError(err)
 			}
 		}
-		return errors.New("ANOTHER_LOADER_RUNNING")
+		return errLoaderRunning
 	}
 	//verify If Caching is present in arguments
 	caching := config.CgrConfig().GeneralCfg().DefaultCaching
@@ -105,6 +108,9 @@ func (ldrS *LoaderService) V1Load(args *ArgsProcessFolder,
 		caching = *args.Caching
 	}
 	if err := ldr.ProcessFolder(caching, utils.MetaStore); err != nil {
+		if err == errLoaderRunning {
+			return err
+		}
 		return utils.NewErrServerError(err)
 	}
 	*rply = utils.OK
@@ -130,7 +136,7 @@ func (ldrS *LoaderService) V1Remove(args *ArgsProcessFolder,
 				return utils.NewErrServerError(err)
 			}
 		}
-		return errors.New("ANOTHER_LOADER_RUNNING")
+		return errLoaderRunning
 	}
 	//verify If Caching is present in arguments
 	caching := config.CgrConfig().GeneralCfg().DefaultCaching
@@ -138,6 +144,9 @@ func (ldrS *LoaderService) V1Remove(args *ArgsProcessFolder,
 		caching = *args.Caching
 	}
 	if err := ldr.ProcessFolder(caching, utils.MetaRemove); err != nil {
+		if err == errLoaderRunning {
+			return err
+		}
 		return utils.NewErrServerError(err)
 	}
 	*rply = utils.OK
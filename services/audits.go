@@ -0,0 +1,121 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewAuditService returns the Audit Service
+func NewAuditService(cfg *config.CGRConfig, dm *DataDBService,
+	storDB *StorDBService, server *utils.Server,
+	connMgr *engine.ConnManager) servmanager.Service {
+	return &AuditService{
+		cfg:     cfg,
+		dm:      dm,
+		storDB:  storDB,
+		server:  server,
+		connMgr: connMgr,
+	}
+}
+
+// AuditService implements Service interface
+type AuditService struct {
+	sync.RWMutex
+	cfg     *config.CGRConfig
+	dm      *DataDBService
+	storDB  *StorDBService
+	server  *utils.Server
+	connMgr *engine.ConnManager
+
+	auditS   *engine.AuditS
+	rpc      *v1.AuditSv1
+	syncStop chan struct{}
+}
+
+// Start should handle the sercive start
+func (aud *AuditService) Start() (err error) {
+	if aud.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+	dbchan := aud.dm.GetDMChan()
+	datadb := <-dbchan
+	dbchan <- datadb
+
+	aud.Lock()
+	defer aud.Unlock()
+
+	storDBChan := make(chan engine.StorDB, 1)
+	aud.syncStop = make(chan struct{})
+	aud.storDB.RegisterSyncChan(storDBChan)
+
+	aud.auditS = engine.NewAuditS(aud.cfg, storDBChan, datadb, aud.connMgr)
+	go func(auditS *engine.AuditS, stopChan chan struct{}) {
+		if err := auditS.ListenAndServe(stopChan); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.AuditS, err.Error()))
+		}
+	}(aud.auditS, aud.syncStop)
+	aud.server.SetRPCAuditor(aud.auditS)
+	aud.rpc = v1.NewAuditSv1(aud.auditS)
+	if !aud.cfg.DispatcherSCfg().Enabled {
+		aud.server.RpcRegister(aud.rpc)
+	}
+	return
+}
+
+// Reload handles the change of config
+func (aud *AuditService) Reload() (err error) {
+	return // the audited methods are read from config on each call
+}
+
+// Shutdown stops the service
+func (aud *AuditService) Shutdown() (err error) {
+	aud.Lock()
+	aud.server.SetRPCAuditor(nil)
+	close(aud.syncStop)
+	aud.auditS.Shutdown()
+	aud.auditS = nil
+	aud.rpc = nil
+	aud.Unlock()
+	return
+}
+
+// IsRunning returns if the service is running
+func (aud *AuditService) IsRunning() bool {
+	aud.RLock()
+	defer aud.RUnlock()
+	return aud != nil && aud.auditS != nil
+}
+
+// ServiceName returns the service name
+func (aud *AuditService) ServiceName() string {
+	return utils.AuditS
+}
+
+// ShouldRun returns if the service should be running
+func (aud *AuditService) ShouldRun() bool {
+	return aud.cfg.AuditSCfg().Enabled
+}
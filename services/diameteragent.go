@@ -23,6 +23,7 @@ import (
 	"sync"
 
 	"github.com/cgrates/cgrates/agents"
+	v1 "github.com/cgrates/cgrates/apier/v1"
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/servmanager"
@@ -31,12 +32,13 @@ import (
 
 // NewDiameterAgent returns the Diameter Agent
 func NewDiameterAgent(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
-	exitChan chan bool, connMgr *engine.ConnManager) servmanager.Service {
+	exitChan chan bool, connMgr *engine.ConnManager, server *utils.Server) servmanager.Service {
 	return &DiameterAgent{
 		cfg:         cfg,
 		filterSChan: filterSChan,
 		exitChan:    exitChan,
 		connMgr:     connMgr,
+		server:      server,
 	}
 }
 
@@ -46,6 +48,7 @@ type DiameterAgent struct {
 	cfg         *config.CGRConfig
 	filterSChan chan *engine.FilterS
 	exitChan    chan bool
+	server      *utils.Server
 
 	da      *agents.DiameterAgent
 	connMgr *engine.ConnManager
@@ -69,6 +72,9 @@ func (da *DiameterAgent) Start() (err error) {
 			utils.DiameterAgent, err))
 		return
 	}
+	if !da.cfg.DispatcherSCfg().Enabled {
+		da.server.RpcRegister(v1.NewDiameterAgentV1(da.da))
+	}
 
 	go func() {
 		if err = da.da.ListenAndServe(); err != nil {
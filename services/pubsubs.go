@@ -0,0 +1,159 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/cenkalti/rpc2"
+	rpc2_jsonrpc "github.com/cenkalti/rpc2/jsonrpc"
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// NewPubSubService returns the PubSub Service
+func NewPubSubService(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
+	server *utils.Server, internalPubSubSChan chan rpcclient.ClientConnector) servmanager.Service {
+	return &PubSubService{
+		connChan:    internalPubSubSChan,
+		cfg:         cfg,
+		filterSChan: filterSChan,
+		server:      server,
+	}
+}
+
+// PubSubService implements Service interface
+type PubSubService struct {
+	sync.RWMutex
+	cfg         *config.CGRConfig
+	filterSChan chan *engine.FilterS
+	server      *utils.Server
+
+	ps       *engine.PubSubService
+	rpc      *v1.PubSubSv1
+	birpcSrv *rpc2.Server // dedicated so the subscribers do not mix with the SessionS ones
+	lBiJSON  net.Listener
+	wsURL    string // the http handlers cannot be removed so we register it only once
+	connChan chan rpcclient.ClientConnector
+}
+
+// Start should handle the sercive start
+func (ps *PubSubService) Start() (err error) {
+	if ps.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+
+	filterS := <-ps.filterSChan
+	ps.filterSChan <- filterS
+
+	ps.Lock()
+	defer ps.Unlock()
+	ps.ps = engine.NewPubSubService(ps.cfg, filterS)
+	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.PubSubS))
+	ps.rpc = v1.NewPubSubSv1(ps.ps)
+	if !ps.cfg.DispatcherSCfg().Enabled {
+		ps.server.RpcRegister(ps.rpc)
+	}
+	ps.birpcSrv = rpc2.NewServer()
+	for method, handler := range ps.rpc.Handlers() { // authorized like the other BiRPC methods
+		ps.birpcSrv.Handle(method, ps.server.BiRPCHandler(method, handler))
+	}
+	ps.birpcSrv.OnDisconnect(ps.ps.OnBiJSONDisconnect)
+	if lstnAddr := ps.cfg.PubSubSCfg().ListenBijson; lstnAddr != utils.EmptyString {
+		if ps.lBiJSON, err = net.Listen(utils.TCP, lstnAddr); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> serve BiRPC error: %s!", utils.PubSubS, err))
+			return
+		}
+		utils.Logger.Info(fmt.Sprintf("<%s> starting BiJSON server at <%s>", utils.PubSubS, lstnAddr))
+		go ps.acceptBiJSON(ps.lBiJSON, ps.birpcSrv)
+	}
+	if wsURL := ps.cfg.PubSubSCfg().WSURL; wsURL != utils.EmptyString && ps.wsURL == utils.EmptyString {
+		ps.wsURL = wsURL
+		ps.server.RegisterHttpHandler(wsURL, utils.BiRPCWSHandler(ps.biRPCServer,
+			ps.cfg.HTTPCfg().HTTPUseBasicAuth, ps.cfg.HTTPCfg().HTTPAuthUsers))
+	}
+	ps.connChan <- ps.rpc
+	return
+}
+
+// acceptBiJSON serves the subscribers connecting over BiJSON
+func (ps *PubSubService) acceptBiJSON(l net.Listener, srv *rpc2.Server) {
+	for {
+		conn, err := l.Accept()
+		if err != nil {
+			return // the listener was closed on shutdown
+		}
+		go func(conn net.Conn) {
+			utils.ServeBiRPCCodec(srv, rpc2_jsonrpc.NewJSONCodec(conn), utils.NewConnRPCCaller(conn))
+		}(conn)
+	}
+}
+
+// biRPCServer returns the server of the subscribers connecting over WebSocket, nil if the service is stopped
+func (ps *PubSubService) biRPCServer() *rpc2.Server {
+	ps.RLock()
+	defer ps.RUnlock()
+	return ps.birpcSrv
+}
+
+// Reload handles the change of config
+func (ps *PubSubService) Reload() (err error) {
+	return
+}
+
+// Shutdown stops the service
+func (ps *PubSubService) Shutdown() (err error) {
+	ps.Lock()
+	defer ps.Unlock()
+	if ps.lBiJSON != nil {
+		ps.lBiJSON.Close()
+		ps.lBiJSON = nil
+	}
+	if err = ps.ps.Shutdown(); err != nil {
+		return
+	}
+	ps.birpcSrv = nil
+	ps.ps = nil
+	ps.rpc = nil
+	<-ps.connChan
+	return
+}
+
+// IsRunning returns if the service is running
+func (ps *PubSubService) IsRunning() bool {
+	ps.RLock()
+	defer ps.RUnlock()
+	return ps != nil && ps.ps != nil
+}
+
+// ServiceName returns the service name
+func (ps *PubSubService) ServiceName() string {
+	return utils.PubSubS
+}
+
+// ShouldRun returns if the service should be running
+func (ps *PubSubService) ShouldRun() bool {
+	return ps.cfg.PubSubSCfg().Enabled
+}
@@ -0,0 +1,121 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// NewTaxService returns the Tax Service
+func NewTaxService(cfg *config.CGRConfig, dm *DataDBService,
+	cacheS *engine.CacheS, filterSChan chan *engine.FilterS, server *utils.Server,
+	internalTaxSChan chan rpcclient.ClientConnector) servmanager.Service {
+	return &TaxService{
+		connChan:    internalTaxSChan,
+		cfg:         cfg,
+		dm:          dm,
+		cacheS:      cacheS,
+		filterSChan: filterSChan,
+		server:      server,
+	}
+}
+
+// TaxService implements Service interface
+type TaxService struct {
+	sync.RWMutex
+	cfg         *config.CGRConfig
+	dm          *DataDBService
+	cacheS      *engine.CacheS
+	filterSChan chan *engine.FilterS
+	server      *utils.Server
+
+	txS      *engine.TaxService
+	rpc      *v1.TaxSv1
+	connChan chan rpcclient.ClientConnector
+}
+
+// Start should handle the sercive start
+func (txS *TaxService) Start() (err error) {
+	if txS.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+
+	<-txS.cacheS.GetPrecacheChannel(utils.CacheTaxProfiles)
+	<-txS.cacheS.GetPrecacheChannel(utils.CacheTaxFilterIndexes)
+
+	filterS := <-txS.filterSChan
+	txS.filterSChan <- filterS
+	dbchan := txS.dm.GetDMChan()
+	datadb := <-dbchan
+	dbchan <- datadb
+
+	txS.Lock()
+	defer txS.Unlock()
+	txS.txS = engine.NewTaxService(datadb, filterS, txS.cfg)
+	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.TaxS))
+	txS.rpc = v1.NewTaxSv1(txS.txS)
+	if !txS.cfg.DispatcherSCfg().Enabled {
+		txS.server.RpcRegister(txS.rpc)
+	}
+	txS.connChan <- txS.rpc
+	return
+}
+
+// Reload handles the change of config
+func (txS *TaxService) Reload() (err error) {
+	return
+}
+
+// Shutdown stops the service
+func (txS *TaxService) Shutdown() (err error) {
+	txS.Lock()
+	defer txS.Unlock()
+	if err = txS.txS.Shutdown(); err != nil {
+		return
+	}
+	txS.txS = nil
+	txS.rpc = nil
+	<-txS.connChan
+	return
+}
+
+// IsRunning returns if the service is running
+func (txS *TaxService) IsRunning() bool {
+	txS.RLock()
+	defer txS.RUnlock()
+	return txS != nil && txS.txS != nil
+}
+
+// ServiceName returns the service name
+func (txS *TaxService) ServiceName() string {
+	return utils.TaxS
+}
+
+// ShouldRun returns if the service should be running
+func (txS *TaxService) ShouldRun() bool {
+	return txS.cfg.TaxSCfg().Enabled
+}
@@ -299,6 +299,18 @@ func (srvMngr *ServiceManager) handleReload() {
 			if err = srvMngr.reloadService(utils.RateS); err != nil {
 				return
 			}
+		case <-srvMngr.GetConfig().GetReloadChan(config.AuditSJson):
+			if err = srvMngr.reloadService(utils.AuditS); err != nil {
+				return
+			}
+		case <-srvMngr.GetConfig().GetReloadChan(config.TaxSJson):
+			if err = srvMngr.reloadService(utils.TaxS); err != nil {
+				return
+			}
+		case <-srvMngr.GetConfig().GetReloadChan(config.PubSubSJson):
+			if err = srvMngr.reloadService(utils.PubSubS); err != nil {
+				return
+			}
 		case <-srvMngr.GetConfig().GetReloadChan(config.RPCConnsJsonName):
 			engine.Cache.Clear([]string{utils.CacheRPCConnections})
 		case <-srvMngr.GetConfig().GetReloadChan(config.SIPAgentJson):
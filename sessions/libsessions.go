@@ -19,7 +19,13 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package sessions
 
 import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
 	"strings"
 	"time"
 
@@ -70,6 +76,9 @@ func getFlagIDs(flag string) []string {
 	return strings.Split(flagWithIDs[1], utils.INFIELD_SEP)
 }
 
+// stirExtensions are the supported PASSporT extensions(ppt)
+var stirExtensions = utils.NewStringSet([]string{utils.STIRPpt, utils.STIRPptDiv, utils.STIRPptRcd})
+
 // ProcessedStirIdentity the structure that keeps all the header information
 type ProcessedStirIdentity struct {
 	Tokens     []string
@@ -77,6 +86,8 @@ type ProcessedStirIdentity struct {
 	Signature  string
 	Header     *utils.PASSporTHeader
 	Payload    *utils.PASSporTPayload
+
+	Certificates []*x509.Certificate // the certificate chain from x5u, empty if x5u holds only the public key
 }
 
 // NewProcessedIdentity creates a proccessed header
@@ -106,6 +117,19 @@ func NewProcessedIdentity(identity string) (pi *ProcessedStirIdentity, err error
 	return
 }
 
+// NewProcessedIdentities creates the proccessed headers from the Identity header fields separated by comma
+func NewProcessedIdentities(identities string) (pis []*ProcessedStirIdentity, err error) {
+	for _, identity := range strings.Split(identities, utils.FIELDS_SEP) {
+		var pi *ProcessedStirIdentity
+		pi, err = NewProcessedIdentity(identity)
+		pis = append(pis, pi)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
 // VerifyHeader returns if the header is corectly populated
 func (pi *ProcessedStirIdentity) VerifyHeader() (isValid bool) {
 	var x5u string
@@ -120,8 +144,8 @@ func (pi *ProcessedStirIdentity) VerifyHeader() (isValid bool) {
 				return false
 			}
 		case utils.STIRPptField:
-			if ptoken[1] != utils.STIRPpt &&
-				ptoken[1] != "\""+utils.STIRPpt+"\"" {
+			if ptoken[1] != pi.Header.Ppt &&
+				ptoken[1] != "\""+pi.Header.Ppt+"\"" {
 				return false
 			}
 		case utils.STIRInfoField:
@@ -137,38 +161,187 @@ func (pi *ProcessedStirIdentity) VerifyHeader() (isValid bool) {
 	}
 
 	return pi.Header.Alg == utils.STIRAlg &&
-		pi.Header.Ppt == utils.STIRPpt &&
+		stirExtensions.Has(pi.Header.Ppt) &&
 		pi.Header.Typ == utils.STIRTyp &&
 		pi.Header.X5u == x5u
 }
 
 // VerifySignature returns if the signature is valid
 func (pi *ProcessedStirIdentity) VerifySignature(timeoutVal time.Duration) (err error) {
-	var pubkey interface{}
-	var ok bool
-	if pubkey, ok = engine.Cache.Get(utils.CacheSTIR, pi.Header.X5u); !ok {
-		if pubkey, err = utils.NewECDSAPubKey(pi.Header.X5u, timeoutVal); err != nil {
-			if errCh := engine.Cache.Set(utils.CacheSTIR, pi.Header.X5u, nil,
-				nil, false, utils.NonTransactional); errCh != nil {
-				return errCh
+	var x5uVal interface{}
+	if x5uVal, err = getSTIRCached(pi.Header.X5u, func() (interface{}, error) {
+		return newSTIRx5u(pi.Header.X5u, timeoutVal)
+	}); err != nil {
+		return
+	}
+	pubkey := x5uVal
+	if certs, isChain := x5uVal.([]*x509.Certificate); isChain {
+		pi.Certificates = certs
+		pubkey = certs[0].PublicKey
+	}
+
+	sigMethod := jwt.GetSigningMethod(pi.Header.Alg)
+	return sigMethod.Verify(pi.SigningStr, pi.Signature, pubkey)
+
+}
+
+// VerifyCertificates validates the x5u certificate chain against the trust anchors and CRLs
+// and checks if the originatorTn is authorized by the TNAuthList of the leaf certificate
+func (pi *ProcessedStirIdentity) VerifyCertificates(originatorTn string, stirCfg *config.STIRcfg,
+	timeoutVal time.Duration) (err error) {
+	if len(pi.Certificates) == 0 {
+		if len(stirCfg.TrustAnchors) != 0 {
+			return errors.New("missing certificate chain")
+		}
+		return // x5u holds only the public key
+	}
+	now := time.Now()
+	leaf := pi.Certificates[0]
+	if now.Before(leaf.NotBefore) || now.After(leaf.NotAfter) {
+		return errors.New("expired certificate")
+	}
+	if len(stirCfg.TrustAnchors) != 0 {
+		roots := x509.NewCertPool()
+		for _, anchorPath := range stirCfg.TrustAnchors {
+			var anchors []*x509.Certificate
+			if anchors, err = getSTIRCertificates(anchorPath, timeoutVal); err != nil {
+				return
 			}
+			for _, anchor := range anchors {
+				roots.AddCert(anchor)
+			}
+		}
+		intermediates := x509.NewCertPool()
+		for _, cert := range pi.Certificates[1:] {
+			intermediates.AddCert(cert)
+		}
+		var chains [][]*x509.Certificate
+		if chains, err = leaf.Verify(x509.VerifyOptions{
+			Roots:         roots,
+			Intermediates: intermediates,
+			CurrentTime:   now,
+			KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}); err != nil {
+			return fmt.Errorf("invalid certificate chain: %s", err.Error())
+		}
+		if err = verifySTIRRevocation(chains[0], stirCfg.CRLs, now, timeoutVal); err != nil {
 			return
 		}
-		if errCh := engine.Cache.Set(utils.CacheSTIR, pi.Header.X5u, pubkey,
-			nil, false, utils.NonTransactional); errCh != nil {
-			return errCh
+	}
+	if originatorTn == utils.EmptyString {
+		return
+	}
+	var tnl *utils.TNAuthList
+	if tnl, err = utils.NewTNAuthList(leaf); err != nil {
+		if err == utils.ErrNotFound {
+			return nil
 		}
+		return fmt.Errorf("invalid TNAuthList: %s", err.Error())
+	}
+	if !tnl.HasTn(originatorTn) {
+		return errors.New("originatorTn not authorized by TNAuthList")
 	}
+	return
+}
 
-	sigMethod := jwt.GetSigningMethod(pi.Header.Alg)
-	return sigMethod.Verify(pi.SigningStr, pi.Signature, pubkey)
+// verifySTIRRevocation checks the verified chain against the certificate revocation lists
+func verifySTIRRevocation(chain []*x509.Certificate, crlPaths []string, now time.Time,
+	timeoutVal time.Duration) (err error) {
+	if len(crlPaths) == 0 {
+		return
+	}
+	crls := make([]*pkix.CertificateList, len(crlPaths))
+	for i, crlPath := range crlPaths {
+		var crl interface{}
+		if crl, err = getSTIRCached(crlPath, func() (interface{}, error) {
+			return utils.NewX509CRL(crlPath, timeoutVal)
+		}); err != nil {
+			return
+		}
+		var canCast bool
+		if crls[i], canCast = crl.(*pkix.CertificateList); !canCast {
+			return fmt.Errorf("invalid CRL <%s>", crlPath)
+		}
+	}
+	for i := 0; i < len(chain)-1; i++ { // the trust anchor is not checked
+		var revoked bool
+		if revoked, err = utils.X509CertificateRevoked(chain[i], chain[i+1], crls, now); err != nil {
+			return
+		}
+		if revoked {
+			return errors.New("revoked certificate")
+		}
+	}
+	return
+}
+
+// getSTIRCertificates returns the cached certificates from the path
+func getSTIRCertificates(certPath string, timeoutVal time.Duration) (certs []*x509.Certificate, err error) {
+	var certsIface interface{}
+	if certsIface, err = getSTIRCached(certPath, func() (interface{}, error) {
+		return utils.NewX509Certificates(certPath, timeoutVal)
+	}); err != nil {
+		return
+	}
+	var canCast bool
+	if certs, canCast = certsIface.([]*x509.Certificate); !canCast {
+		return nil, fmt.Errorf("invalid certificates <%s>", certPath)
+	}
+	return
+}
+
+// newSTIRx5u returns the resource referred by x5u:
+// the certificate chain or the public key if no certificate is found
+func newSTIRx5u(x5u string, timeoutVal time.Duration) (x5uVal interface{}, err error) {
+	var x5uRdr io.ReadCloser
+	if x5uRdr, err = utils.GetReaderFromPath(x5u, timeoutVal); err != nil {
+		return
+	}
+	defer x5uRdr.Close()
+	var x5uBuf []byte
+	if x5uBuf, err = ioutil.ReadAll(x5uRdr); err != nil {
+		return
+	}
+	var certs []*x509.Certificate
+	if certs, err = utils.NewX509CertificatesFromReader(bytes.NewReader(x5uBuf)); err == nil {
+		return certs, nil
+	}
+	return utils.NewECDSAPubKeyFromReader(bytes.NewReader(x5uBuf))
+}
 
+// getSTIRCached returns the value cached for the path, loading it if missing
+// failed loads are cached as nil in order to not query the path again until the cache expires
+func getSTIRCached(path string, load func() (interface{}, error)) (val interface{}, err error) {
+	var ok bool
+	if val, ok = engine.Cache.Get(utils.CacheSTIR, path); ok {
+		return
+	}
+	if val, err = load(); err != nil {
+		if errCh := engine.Cache.Set(utils.CacheSTIR, path, nil,
+			nil, false, utils.NonTransactional); errCh != nil {
+			return nil, errCh
+		}
+		return nil, err
+	}
+	if errCh := engine.Cache.Set(utils.CacheSTIR, path, val,
+		nil, false, utils.NonTransactional); errCh != nil {
+		return nil, errCh
+	}
+	return
 }
 
 // VerifyPayload returns if the payload is corectly populated
 func (pi *ProcessedStirIdentity) VerifyPayload(originatorTn, originatorURI, destinationTn, destinationURI string,
 	hdrMaxDur time.Duration, attest utils.StringSet) (err error) {
-	if !attest.Has(utils.META_ANY) && !attest.Has(pi.Payload.ATTest) {
+	ppt := utils.STIRPpt
+	if pi.Header != nil {
+		ppt = pi.Header.Ppt
+	}
+	if err = checkPASSporTClaims(ppt, pi.Payload); err != nil {
+		return
+	}
+	if !pi.isDiversion() && // the div PASSporT has no attestation
+		!attest.Has(utils.META_ANY) && !attest.Has(pi.Payload.ATTest) {
 		return errors.New("wrong attest level")
 	}
 	if hdrMaxDur >= 0 && time.Now().After(time.Unix(pi.Payload.IAT, 0).Add(hdrMaxDur)) {
@@ -181,18 +354,109 @@ func (pi *ProcessedStirIdentity) VerifyPayload(originatorTn, originatorURI, dest
 	} else if originatorTn != pi.Payload.Orig.Tn {
 		return errors.New("wrong originatorTn")
 	}
-	if destinationURI != utils.EmptyString {
-		if !utils.SliceHasMember(pi.Payload.Dest.URI, destinationURI) {
+	if !pi.hasDestination(destinationTn, destinationURI) {
+		if destinationURI != utils.EmptyString {
 			return errors.New("wrong destinationURI")
 		}
-	} else if !utils.SliceHasMember(pi.Payload.Dest.Tn, destinationTn) {
 		return errors.New("wrong destinationTn")
 	}
 	return
 }
 
+// hasDestination returns if the PASSporT was created for the destination
+// if the destinationURI is populated the destinationTn is ignored
+func (pi *ProcessedStirIdentity) hasDestination(destinationTn, destinationURI string) bool {
+	if destinationURI != utils.EmptyString {
+		return utils.SliceHasMember(pi.Payload.Dest.URI, destinationURI)
+	}
+	return utils.SliceHasMember(pi.Payload.Dest.Tn, destinationTn)
+}
+
+// checkPASSporTClaims checks the claims mandatory for the PASSporT extension
+func checkPASSporTClaims(ppt string, payload *utils.PASSporTPayload) (err error) {
+	switch ppt {
+	case utils.STIRPpt:
+		if payload.ATTest == utils.EmptyString {
+			return errors.New("missing attest")
+		}
+		if payload.OrigID == utils.EmptyString {
+			return errors.New("missing origid")
+		}
+	case utils.STIRPptDiv:
+		if payload.Div == nil {
+			return errors.New("missing diverting identity")
+		}
+	case utils.STIRPptRcd:
+		if payload.Rcd == nil || payload.Rcd.Nam == utils.EmptyString {
+			return errors.New("missing rich call data")
+		}
+	}
+	return
+}
+
+// isDiversion returns if the PASSporT uses the div extension
+func (pi *ProcessedStirIdentity) isDiversion() bool {
+	return pi.Header != nil && pi.Header.Ppt == utils.STIRPptDiv
+}
+
+// VerifyRcdIntegrity checks the integrity digests of the rich call data resources
+func (pi *ProcessedStirIdentity) VerifyRcdIntegrity(timeoutVal time.Duration) (err error) {
+	if len(pi.Payload.Rcdi) == 0 {
+		return
+	}
+	var rsrs map[string]string
+	if pi.Payload.Rcd != nil {
+		rsrs = pi.Payload.Rcd.Resources()
+	}
+	for ptr, digest := range pi.Payload.Rcdi {
+		rsrPath, has := rsrs[ptr]
+		if !has {
+			return fmt.Errorf("unsupported rcdi <%s>", ptr)
+		}
+		var rsrDigest string
+		if rsrDigest, err = getRcdIntegrity(rsrPath, timeoutVal); err != nil {
+			return
+		}
+		if rsrDigest != digest {
+			return fmt.Errorf("wrong rcdi <%s>", ptr)
+		}
+	}
+	return
+}
+
+// getRcdIntegrity returns the cached integrity digest of the rich call data resource
+func getRcdIntegrity(rsrPath string, timeoutVal time.Duration) (digest string, err error) {
+	var digestIface interface{}
+	if digestIface, err = getSTIRCached(utils.ConcatenatedKey(utils.STIRRcdiAlg, rsrPath), func() (interface{}, error) {
+		return utils.NewRcdIntegrity(rsrPath, timeoutVal)
+	}); err != nil {
+		return
+	}
+	var canCast bool
+	if digest, canCast = digestIface.(string); !canCast {
+		return utils.EmptyString, fmt.Errorf("invalid rich call data resource <%s>", rsrPath)
+	}
+	return
+}
+
 // NewSTIRIdentity returns the identiy for stir header
 func NewSTIRIdentity(header *utils.PASSporTHeader, payload *utils.PASSporTPayload, prvkeyPath string, timeout time.Duration) (identity string, err error) {
+	if !stirExtensions.Has(header.Ppt) {
+		return utils.EmptyString, fmt.Errorf("unsupported PASSporT extension <%s>", header.Ppt)
+	}
+	if err = checkPASSporTClaims(header.Ppt, payload); err != nil {
+		return
+	}
+	if payload.Rcd != nil && payload.Rcdi == nil {
+		for ptr, rsrPath := range payload.Rcd.Resources() {
+			if payload.Rcdi == nil {
+				payload.Rcdi = make(map[string]string)
+			}
+			if payload.Rcdi[ptr], err = getRcdIntegrity(rsrPath, timeout); err != nil {
+				return
+			}
+		}
+	}
 	var prvKey interface{}
 	var ok bool
 	if prvKey, ok = engine.Cache.Get(utils.CacheSTIR, prvkeyPath); !ok {
@@ -223,24 +487,143 @@ func NewSTIRIdentity(header *utils.PASSporTHeader, payload *utils.PASSporTPayloa
 		return
 	}
 	identity += utils.NestingSep + signature
-	identity += utils.STIRExtraInfoPrefix + header.X5u + utils.STIRExtraInfoSuffix
+	identity += utils.STIRExtraInfoPrefix + header.X5u + utils.STIRExtraInfoSuffix + header.Ppt
 	return
 }
 
 // AuthStirShaken autentificates the given identity using STIR/SHAKEN
 func AuthStirShaken(identity, originatorTn, originatorURI, destinationTn, destinationURI string,
 	attest utils.StringSet, hdrMaxDur time.Duration) (err error) {
-	var pi *ProcessedStirIdentity
-	if pi, err = NewProcessedIdentity(identity); err != nil {
+	_, err = authStirShaken(identity, originatorTn, originatorURI,
+		destinationTn, destinationURI, attest, hdrMaxDur)
+	return
+}
+
+func authStirShaken(identity, originatorTn, originatorURI, destinationTn, destinationURI string,
+	attest utils.StringSet, hdrMaxDur time.Duration) (pis []*ProcessedStirIdentity, err error) {
+	if pis, err = NewProcessedIdentities(identity); err != nil {
 		return
 	}
-	if !pi.VerifyHeader() {
-		return errors.New("wrong header")
+	timeoutVal := config.CgrConfig().GeneralCfg().ReplyTimeout
+	var divs []*ProcessedStirIdentity
+	origs := make([]*ProcessedStirIdentity, 0, len(pis))
+	for _, pi := range pis {
+		if !pi.VerifyHeader() {
+			return pis, errors.New("wrong header")
+		}
+		if err = pi.VerifySignature(timeoutVal); err != nil {
+			return
+		}
+		if err = pi.VerifyRcdIntegrity(timeoutVal); err != nil {
+			return
+		}
+		if pi.isDiversion() {
+			divs = append(divs, pi)
+		} else {
+			origs = append(origs, pi)
+		}
 	}
-	if err = pi.VerifySignature(config.CgrConfig().GeneralCfg().ReplyTimeout); err != nil {
+	if len(origs) == 0 {
+		return pis, errors.New("missing original PASSporT")
+	}
+	// follow the diversions back to the destination of the original call
+	for len(divs) != 0 {
+		idx := -1
+		for i, div := range divs {
+			if div.hasDestination(destinationTn, destinationURI) {
+				idx = i
+				break
+			}
+		}
+		if idx == -1 {
+			return pis, errors.New("broken diversion chain")
+		}
+		div := divs[idx]
+		if err = div.verifyPayloadAndCertificates(originatorTn, originatorURI,
+			destinationTn, destinationURI, attest, hdrMaxDur, timeoutVal); err != nil {
+			return
+		}
+		if destinationURI != utils.EmptyString {
+			destinationURI = div.Payload.Div.URI
+		} else {
+			destinationTn = div.Payload.Div.Tn
+		}
+		divs = append(divs[:idx], divs[idx+1:]...)
+	}
+	for _, pi := range origs {
+		if err = pi.verifyPayloadAndCertificates(originatorTn, originatorURI,
+			destinationTn, destinationURI, attest, hdrMaxDur, timeoutVal); err != nil {
+			return
+		}
+	}
+	return
+}
+
+func (pi *ProcessedStirIdentity) verifyPayloadAndCertificates(originatorTn, originatorURI, destinationTn, destinationURI string,
+	attest utils.StringSet, hdrMaxDur, timeoutVal time.Duration) (err error) {
+	if err = pi.VerifyPayload(originatorTn, originatorURI, destinationTn, destinationURI, hdrMaxDur, attest); err != nil {
 		return
 	}
-	return pi.VerifyPayload(originatorTn, originatorURI, destinationTn, destinationURI, hdrMaxDur, attest)
+	return pi.VerifyCertificates(originatorTn, config.CgrConfig().SessionSCfg().STIRCfg, timeoutVal)
+}
+
+// STIRVerification is the result of the STIR/SHAKEN verification
+type STIRVerification struct {
+	Verstat string // the verification status <TN-Validation-Passed|TN-Validation-Failed|No-TN-Validation>
+	Attest  string // the attestation level of the PASSporT
+	X5u     string // the URI of the certificate used to sign the PASSporT
+	Reason  string // the reason of the failed validation
+
+	CallerName string // the caller name from the rich call data
+	CallerLogo string // the URI of the caller logo from the rich call data
+	CallReason string // the call reason
+}
+
+// AsNavigableMap returns the verification as NavigableMap2
+func (sv *STIRVerification) AsNavigableMap() utils.NavigableMap2 {
+	return utils.NavigableMap2{
+		utils.Verstat: utils.NewNMData(sv.Verstat),
+		utils.Attest:  utils.NewNMData(sv.Attest),
+		utils.X5u:     utils.NewNMData(sv.X5u),
+		utils.Reason:  utils.NewNMData(sv.Reason),
+
+		utils.CallerName: utils.NewNMData(sv.CallerName),
+		utils.CallerLogo: utils.NewNMData(sv.CallerLogo),
+		utils.CallReason: utils.NewNMData(sv.CallReason),
+	}
+}
+
+// VerifyStirShaken verifies the given identity using STIR/SHAKEN
+// returning the verification status instead of an error
+func VerifyStirShaken(identity, originatorTn, originatorURI, destinationTn, destinationURI string,
+	attest utils.StringSet, hdrMaxDur time.Duration) (sv *STIRVerification) {
+	if identity == utils.EmptyString {
+		return &STIRVerification{Verstat: utils.STIRNoTNValidation}
+	}
+	sv = &STIRVerification{Verstat: utils.STIRTNValidationPassed}
+	pis, err := authStirShaken(identity, originatorTn, originatorURI,
+		destinationTn, destinationURI, attest, hdrMaxDur)
+	for _, pi := range pis {
+		if pi == nil || pi.Header == nil || pi.Payload == nil {
+			continue
+		}
+		if sv.X5u == utils.EmptyString && !pi.isDiversion() { // the original PASSporT
+			sv.X5u = pi.Header.X5u
+			sv.Attest = pi.Payload.ATTest
+		}
+		if sv.CallerName == utils.EmptyString && pi.Payload.Rcd != nil {
+			sv.CallerName = pi.Payload.Rcd.Nam
+			sv.CallerLogo = pi.Payload.Rcd.Icn
+		}
+		if sv.CallReason == utils.EmptyString {
+			sv.CallReason = pi.Payload.Crn
+		}
+	}
+	if err != nil {
+		sv.Verstat = utils.STIRTNValidationFailed
+		sv.Reason = err.Error()
+	}
+	return
 }
 
 // V1STIRAuthenticateArgs are the arguments for STIRAuthenticate API
@@ -248,7 +631,7 @@ type V1STIRAuthenticateArgs struct {
 	Attest             []string // what attest levels are allowed
 	DestinationTn      string   // the expected destination telephone number
 	DestinationURI     string   // the expected destination URI; if this is populated the DestinationTn is ignored
-	Identity           string   // the identity header
+	Identity           string   // the identity header; multiple Identity header fields are separated by comma
 	OriginatorTn       string   // the expected originator telephone number
 	OriginatorURI      string   // the expected originator URI; if this is populated the OriginatorTn is ignored
 	PayloadMaxDuration string   // the duration the payload is valid after it's creation
@@ -261,5 +644,6 @@ type V1STIRIdentityArgs struct {
 	PublicKeyPath  string                 // the path to the public key used in the header
 	PrivateKeyPath string                 // the private key path
 	OverwriteIAT   bool                   // if true the IAT from payload is overwrited with the present unix timestamp
+	PPT            string                 // the PASSporT extension <shaken|div|rcd>; shaken if empty
 	*utils.ArgDispatcher
 }
@@ -19,10 +19,21 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package sessions
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/dgrijalva/jwt-go"
@@ -295,3 +306,226 @@ aa+jqv4dwkr/FLEcN1zC76Y/IniI65fId55hVJvN3ORuzUqYEtzD3irmsw==
 		t.Fatal(err)
 	}
 }
+
+func newTestSTIRCertificate(t *testing.T, tmpl, parent *x509.Certificate,
+	parentKey *ecdsa.PrivateKey) (cert *x509.Certificate, key *ecdsa.PrivateKey) {
+	var err error
+	if key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader); err != nil {
+		t.Fatal(err)
+	}
+	if parent == nil { // self signed
+		parent, parentKey = tmpl, key
+	}
+	var der []byte
+	if der, err = x509.CreateCertificate(rand.Reader, tmpl, parent, &key.PublicKey, parentKey); err != nil {
+		t.Fatal(err)
+	}
+	if cert, err = x509.ParseCertificate(der); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestVerifyStirShakenCertificates(t *testing.T) {
+	now := time.Now()
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "STI-CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	caCert, caKey := newTestSTIRCertificate(t, caTmpl, nil, nil)
+	otherCA, _ := newTestSTIRCertificate(t, caTmpl, nil, nil)
+	tn, err := asn1.MarshalWithParams("1001", "ia5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tnAuthList, err := asn1.Marshal([]asn1.RawValue{
+		{Class: asn1.ClassContextSpecific, Tag: 2, IsCompound: true, Bytes: tn}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafCert, leafKey := newTestSTIRCertificate(t, &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		Subject:         pkix.Name{CommonName: "SHAKEN 1234"},
+		NotBefore:       now.Add(-time.Hour),
+		NotAfter:        now.Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{{Id: utils.OIDTNAuthList, Value: tnAuthList}},
+	}, caCert, caKey)
+	crlDER, err := caCert.CreateCRL(rand.Reader, caKey, []pkix.RevokedCertificate{
+		{SerialNumber: big.NewInt(2), RevocationTime: now}}, now, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	crl, err := x509.ParseCRL(crlDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	x5u := "https://sti.example.org/shaken.pem"
+	for key, val := range map[string]interface{}{
+		x5u:                                    []*x509.Certificate{leafCert},
+		"https://sti.example.org/private.pem":  leafKey,
+		"https://sti-ca.example.org/root.pem":  []*x509.Certificate{caCert},
+		"https://sti-ca.example.org/other.pem": []*x509.Certificate{otherCA},
+		"https://sti-ca.example.org/root.crl":  crl,
+	} {
+		if err := engine.Cache.Set(utils.CacheSTIR, key, val,
+			nil, true, utils.NonTransactional); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dest := utils.NewPASSporTDestinationsIdentity([]string{"1002"}, nil)
+	identity, err := NewSTIRIdentity(utils.NewPASSporTHeader(x5u),
+		utils.NewPASSporTPayload("A", "123456", *dest, *utils.NewPASSporTOriginsIdentity("1001", utils.EmptyString)),
+		"https://sti.example.org/private.pem", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	unauthIdentity, err := NewSTIRIdentity(utils.NewPASSporTHeader(x5u),
+		utils.NewPASSporTPayload("A", "123456", *dest, *utils.NewPASSporTOriginsIdentity("1003", utils.EmptyString)),
+		"https://sti.example.org/private.pem", time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defCfg := config.CgrConfig()
+	defer config.SetCgrConfig(defCfg)
+	cfg, _ := config.NewDefaultCGRConfig()
+	config.SetCgrConfig(cfg)
+	anyAttest := utils.NewStringSet([]string{utils.META_ANY})
+
+	exp := &STIRVerification{Verstat: utils.STIRNoTNValidation}
+	if rcv := VerifyStirShaken(utils.EmptyString, "1001", "", "1002", "", anyAttest, -1); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	cfg.SessionSCfg().STIRCfg.TrustAnchors = []string{"https://sti-ca.example.org/root.pem"}
+	exp = &STIRVerification{Verstat: utils.STIRTNValidationPassed, Attest: "A", X5u: x5u}
+	if rcv := VerifyStirShaken(identity, "1001", "", "1002", "", anyAttest, -1); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	exp = &STIRVerification{Verstat: utils.STIRTNValidationFailed, Attest: "A", X5u: x5u,
+		Reason: "originatorTn not authorized by TNAuthList"}
+	if rcv := VerifyStirShaken(unauthIdentity, "1003", "", "1002", "", anyAttest, -1); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	cfg.SessionSCfg().STIRCfg.CRLs = []string{"https://sti-ca.example.org/root.crl"}
+	exp.Reason = "revoked certificate"
+	if rcv := VerifyStirShaken(identity, "1001", "", "1002", "", anyAttest, -1); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	cfg.SessionSCfg().STIRCfg.CRLs = nil
+	cfg.SessionSCfg().STIRCfg.TrustAnchors = []string{"https://sti-ca.example.org/other.pem"}
+	if err := AuthStirShaken(identity, "1001", "", "1002", "", anyAttest, -1); err == nil {
+		t.Error("Expected untrusted certificate chain")
+	}
+}
+
+func TestAuthStirShakenExtensions(t *testing.T) {
+	prvKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpDir, err := ioutil.TempDir("", "stir")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(tmpDir)
+	logoPath := path.Join(tmpDir, "logo.png")
+	if err := ioutil.WriteFile(logoPath, []byte("logo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	x5u := "https://www.example.org/ext_cert.cer"
+	prvkeyPath := "https://www.example.org/ext_private.pem"
+	for key, val := range map[string]interface{}{
+		x5u:        &prvKey.PublicKey,
+		prvkeyPath: prvKey,
+	} {
+		if err := engine.Cache.Set(utils.CacheSTIR, key, val,
+			nil, true, utils.NonTransactional); err != nil {
+			t.Fatal(err)
+		}
+	}
+	orig := utils.NewPASSporTOriginsIdentity("1001", utils.EmptyString)
+	shakenPayload := utils.NewPASSporTPayload("A", "123456",
+		*utils.NewPASSporTDestinationsIdentity([]string{"1002"}, nil), *orig)
+	shakenPayload.Rcd = &utils.PASSporTRcd{Nam: "ITsysCOM", Icn: logoPath}
+	shaken, err := NewSTIRIdentity(utils.NewPASSporTHeader(x5u), shakenPayload, prvkeyPath, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if digest, err := utils.NewRcdIntegrity(logoPath, time.Second); err != nil {
+		t.Error(err)
+	} else if shakenPayload.Rcdi["/icn"] != digest {
+		t.Errorf("Expected rcdi: %q, received: %q", digest, shakenPayload.Rcdi["/icn"])
+	}
+	divPayload := utils.NewPASSporTPayload(utils.EmptyString, utils.EmptyString,
+		*utils.NewPASSporTDestinationsIdentity([]string{"1003"}, nil), *orig)
+	if _, err := NewSTIRIdentity(utils.NewPASSporTExtensionHeader(x5u, utils.STIRPptDiv),
+		divPayload, prvkeyPath, time.Second); err == nil || err.Error() != "missing diverting identity" {
+		t.Errorf("Expected missing diverting identity, received: %v", err)
+	}
+	divPayload.Div = utils.NewPASSporTDiversionIdentity("1002", utils.EmptyString)
+	div, err := NewSTIRIdentity(utils.NewPASSporTExtensionHeader(x5u, utils.STIRPptDiv), divPayload, prvkeyPath, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewSTIRIdentity(utils.NewPASSporTExtensionHeader(x5u, "unknown"),
+		divPayload, prvkeyPath, time.Second); err == nil {
+		t.Error("Expected unsupported extension")
+	}
+	if _, err := NewSTIRIdentity(utils.NewPASSporTHeader(x5u),
+		divPayload, prvkeyPath, time.Second); err == nil || err.Error() != "missing attest" {
+		t.Errorf("Expected missing attest, received: %v", err)
+	}
+	rcdPayload := utils.NewPASSporTPayload("A", "123456",
+		*utils.NewPASSporTDestinationsIdentity([]string{"1002"}, nil), *orig)
+	if _, err := NewSTIRIdentity(utils.NewPASSporTExtensionHeader(x5u, utils.STIRPptRcd),
+		rcdPayload, prvkeyPath, time.Second); err == nil || err.Error() != "missing rich call data" {
+		t.Errorf("Expected missing rich call data, received: %v", err)
+	}
+	rcdPayload.Rcd = &utils.PASSporTRcd{Nam: "ITsysCOM"}
+	if _, err := NewSTIRIdentity(utils.NewPASSporTExtensionHeader(x5u, utils.STIRPptRcd),
+		rcdPayload, prvkeyPath, time.Second); err != nil {
+		t.Error(err)
+	}
+
+	anyAttest := utils.NewStringSet([]string{utils.META_ANY})
+	exp := &STIRVerification{
+		Verstat:    utils.STIRTNValidationPassed,
+		Attest:     "A",
+		X5u:        x5u,
+		CallerName: "ITsysCOM",
+		CallerLogo: logoPath,
+	}
+	if rcv := VerifyStirShaken(shaken+utils.FIELDS_SEP+div, "1001", "", "1003", "", anyAttest, -1); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if err := AuthStirShaken(shaken, "1001", "", "1002", "", anyAttest, -1); err != nil {
+		t.Error(err)
+	}
+	if err := AuthStirShaken(shaken, "1001", "", "1003", "", anyAttest, -1); err == nil ||
+		err.Error() != "wrong destinationTn" {
+		t.Errorf("Expected wrong destinationTn, received: %v", err)
+	}
+	if err := AuthStirShaken(div, "1001", "", "1003", "", anyAttest, -1); err == nil ||
+		err.Error() != "missing original PASSporT" {
+		t.Errorf("Expected missing original PASSporT, received: %v", err)
+	}
+	if err := AuthStirShaken(shaken+utils.FIELDS_SEP+div, "1001", "", "1004", "", anyAttest, -1); err == nil ||
+		err.Error() != "broken diversion chain" {
+		t.Errorf("Expected broken diversion chain, received: %v", err)
+	}
+
+	shakenPayload.Rcdi["/icn"] = "sha256-wrong"
+	if shaken, err = NewSTIRIdentity(utils.NewPASSporTHeader(x5u), shakenPayload, prvkeyPath, time.Second); err != nil {
+		t.Fatal(err)
+	}
+	if err := AuthStirShaken(shaken, "1001", "", "1002", "", anyAttest, -1); err == nil ||
+		err.Error() != "wrong rcdi </icn>" {
+		t.Errorf("Expected wrong rcdi, received: %v", err)
+	}
+}
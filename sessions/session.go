@@ -80,6 +80,7 @@ type Session struct {
 
 	debitStop   chan struct{}
 	sTerminator *sTerminator // automatic timeout for the session
+	restored    time.Time    // restored after engine restart, not yet reported by the agents
 	*utils.ArgDispatcher
 }
 
@@ -252,6 +253,64 @@ func (s *Session) stopDebitLoops() {
 	}
 }
 
+// asStoredSession converts the session into the checkpoint stored in DataDB
+// not thread safe
+func (s *Session) asStoredSession(nodeID string) (ss *engine.StoredSession) {
+	ss = &engine.StoredSession{
+		NodeID:        nodeID,
+		CGRID:         s.CGRID,
+		Tenant:        s.Tenant,
+		ResourceID:    s.ResourceID,
+		ClientConnID:  s.ClientConnID,
+		EventStart:    s.EventStart.Clone(),
+		DebitInterval: s.DebitInterval,
+		OptsStart:     s.OptsStart.Clone(),
+		SRuns:         make([]*engine.StoredSRun, len(s.SRuns)),
+		ArgDispatcher: s.ArgDispatcher,
+		Stored:        time.Now(),
+	}
+	for i, sr := range s.SRuns {
+		sr = sr.Clone()
+		ss.SRuns[i] = &engine.StoredSRun{
+			Event:         sr.Event,
+			CD:            sr.CD,
+			EventCost:     sr.EventCost,
+			ExtraDuration: sr.ExtraDuration,
+			LastUsage:     sr.LastUsage,
+			TotalUsage:    sr.TotalUsage,
+			NextAutoDebit: sr.NextAutoDebit,
+		}
+	}
+	return
+}
+
+// newSessionFromStored builds back the session out of its checkpoint
+func newSessionFromStored(ss *engine.StoredSession) (s *Session) {
+	s = &Session{
+		CGRID:         ss.CGRID,
+		Tenant:        ss.Tenant,
+		ResourceID:    ss.ResourceID,
+		ClientConnID:  ss.ClientConnID,
+		EventStart:    ss.EventStart,
+		DebitInterval: ss.DebitInterval,
+		OptsStart:     ss.OptsStart,
+		SRuns:         make([]*SRun, len(ss.SRuns)),
+		ArgDispatcher: ss.ArgDispatcher,
+	}
+	for i, sr := range ss.SRuns {
+		s.SRuns[i] = &SRun{
+			Event:         sr.Event,
+			CD:            sr.CD,
+			EventCost:     sr.EventCost,
+			ExtraDuration: sr.ExtraDuration,
+			LastUsage:     sr.LastUsage,
+			TotalUsage:    sr.TotalUsage,
+			NextAutoDebit: sr.NextAutoDebit,
+		}
+	}
+	return
+}
+
 // SRun is one billing run for the Session
 type SRun struct {
 	Event     engine.MapEvent        // Event received from ChargerS
@@ -110,6 +110,20 @@ func (sS *SessionS) ListenAndServe(exitChan chan bool) (err error) {
 
 		}()
 	}
+	if sS.cgrCfg.SessionSCfg().StoreInterval != 0 {
+		sS.restoreSessions()
+		go func() {
+			for { // checkpoint the active sessions repeatedly
+				select {
+				case e := <-exitChan:
+					exitChan <- e
+					return
+				case <-time.After(sS.cgrCfg.SessionSCfg().StoreInterval):
+					sS.storeSessions()
+				}
+			}
+		}()
+	}
 	e := <-exitChan // block here until shutdown request
 	exitChan <- e   // put back for the others listening for shutdown request
 	return
@@ -117,6 +131,16 @@ func (sS *SessionS) ListenAndServe(exitChan chan bool) (err error) {
 
 // Shutdown is called by engine to clear states
 func (sS *SessionS) Shutdown() (err error) {
+	if sS.cgrCfg.SessionSCfg().StoreInterval != 0 { // keep the sessions to be restored on restart
+		for _, s := range sS.getSessions("", false) {
+			s.Lock()
+			s.stopSTerminator()
+			s.stopDebitLoops()
+			s.Unlock()
+			sS.storeSession(s)
+		}
+		return
+	}
 	for _, s := range sS.getSessions("", false) { // Force sessions shutdown
 		sS.terminateSession(s, nil, nil, nil, false)
 	}
@@ -628,6 +652,7 @@ func (sS *SessionS) refundSession(s *Session, sRunIdx int, rUsage time.Duration)
 		Account:     sr.CD.Account,
 		Destination: sr.CD.Destination,
 		ToR:         utils.FirstNonEmpty(sr.CD.ToR, utils.VOICE),
+		TimeStart:   srplsEC.StartTime,
 		Increments:  incrmts,
 	}
 	var acnt engine.Account
@@ -1239,6 +1264,9 @@ func (sS *SessionS) transitSState(cgrID string, psv bool) (s *Session) {
 	} else { // transit from active with possible STerminator and DebitLoops
 		s.stopSTerminator()
 		s.stopDebitLoops()
+		if sS.cgrCfg.SessionSCfg().StoreInterval != 0 {
+			sS.removeStoredSession(cgrID)
+		}
 	}
 	return
 }
@@ -1291,22 +1319,30 @@ func (sS *SessionS) getRelocateSession(cgrID string, initOriginID,
 
 // syncSessions synchronizes the active sessions with the one in the clients
 // it will force-disconnect the one found in SessionS but not in clients
+// the restored sessions are force-disconnected only once their owner can confirm them or the grace period passed
 func (sS *SessionS) syncSessions() {
 	queriedCGRIDs := engine.NewSafEvent(nil) // need this to be
+	syncedConnIDs := utils.NewStringSet(nil) // connections which answered with their sessions
+	var syncedMux sync.Mutex
 	var err error
 	for _, clnt := range sS.biJClients() {
-		errChan := make(chan error)
-		go func() {
+		errChan := make(chan error, 1) // do not block the query finishing after timeout
+		go func(clnt *biJClient) {
 			var queriedSessionIDs []*SessionID
 			if err := clnt.conn.Call(utils.SessionSv1GetActiveSessionIDs,
 				utils.EmptyString, &queriedSessionIDs); err != nil {
 				errChan <- err
+				return
 			}
+			connID := sS.biJClntID(clnt.conn)
 			for _, sessionID := range queriedSessionIDs {
-				queriedCGRIDs.Set(sessionID.CGRID(), struct{}{})
+				queriedCGRIDs.Set(sessionID.CGRID(), connID)
 			}
+			syncedMux.Lock()
+			syncedConnIDs.Add(connID)
+			syncedMux.Unlock()
 			errChan <- nil
-		}()
+		}(clnt)
 		select {
 		case err = <-errChan:
 			if err != nil {
@@ -1320,13 +1356,43 @@ func (sS *SessionS) syncSessions() {
 
 	}
 	var toBeRemoved []string
+	var toBeReattached []*Session
 	sS.aSsMux.RLock()
-	for cgrid := range sS.aSessions {
-		if !queriedCGRIDs.HasField(cgrid) {
+	for cgrid, s := range sS.aSessions {
+		if queriedCGRIDs.HasField(cgrid) {
+			toBeReattached = append(toBeReattached, s)
+			continue
+		}
+		s.RLock()
+		clntConnID, restored := s.ClientConnID, s.restored
+		s.RUnlock()
+		if restored.IsZero() { // not reported by the clients
+			toBeRemoved = append(toBeRemoved, cgrid)
+			continue
+		}
+		// the restored sessions are kept until their owner can confirm them
+		syncedMux.Lock()
+		synced := syncedConnIDs.Has(clntConnID)
+		syncedMux.Unlock()
+		switch {
+		case clntConnID == utils.EmptyString: // not owned by a bidirectional connection
+		case synced: // the owner does not know about it anymore
+			toBeRemoved = append(toBeRemoved, cgrid)
+		case sS.biJClnt(clntConnID) != nil: // the owner did not answer
+		case time.Since(restored) < sS.cgrCfg.SessionSCfg().RestoreGracePeriod: // wait for the agents to reconnect
+		default: // the owner is gone
 			toBeRemoved = append(toBeRemoved, cgrid)
 		}
 	}
 	sS.aSsMux.RUnlock()
+	for _, s := range toBeReattached { // sessions restored after restart point to old connections
+		s.Lock()
+		if sS.biJClnt(s.ClientConnID) == nil {
+			s.ClientConnID = queriedCGRIDs.GetStringIgnoreErrors(s.CGRID)
+		}
+		s.restored = time.Time{}
+		s.Unlock()
+	}
 	for _, cgrID := range toBeRemoved {
 		ss := sS.getSessions(cgrID, false)
 		if len(ss) == 0 {
@@ -1340,6 +1406,69 @@ func (sS *SessionS) syncSessions() {
 	}
 }
 
+// storeSession checkpoints the active session into DataDB
+// thread safe, the session is not stored if it was ended in the meantime
+func (sS *SessionS) storeSession(s *Session) {
+	s.RLock()
+	defer s.RUnlock()
+	if !sS.isIndexed(s, false) {
+		return
+	}
+	if err := sS.dm.SetStoredSession(
+		s.asStoredSession(sS.cgrCfg.GeneralCfg().NodeID)); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed storing session: <%s>, err: <%s>",
+				utils.SessionS, s.CGRID, err.Error()))
+	}
+}
+
+// storeSessions checkpoints all the active sessions into DataDB
+func (sS *SessionS) storeSessions() {
+	for _, s := range sS.getSessions(utils.EmptyString, false) {
+		sS.storeSession(s)
+	}
+}
+
+// removeStoredSession removes the session checkpoint out of DataDB
+func (sS *SessionS) removeStoredSession(cgrID string) {
+	if err := sS.dm.RemoveStoredSession(sS.cgrCfg.GeneralCfg().NodeID,
+		cgrID); err != nil && err != utils.ErrNotFound {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed removing stored session: <%s>, err: <%s>",
+				utils.SessionS, cgrID, err.Error()))
+	}
+}
+
+// restoreSessions activates the sessions checkpointed before the engine restart
+// the ones not reported by the agents within the restore grace period are terminated by the sessions sync
+func (sS *SessionS) restoreSessions() {
+	sss, err := sS.dm.GetStoredSessions(sS.cgrCfg.GeneralCfg().NodeID)
+	if err != nil {
+		if err != utils.ErrNotFound {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> failed restoring sessions, err: <%s>",
+					utils.SessionS, err.Error()))
+		}
+		return
+	}
+	var restored int
+	for _, ss := range sss {
+		s := newSessionFromStored(ss)
+		if sS.isIndexed(s, false) {
+			continue
+		}
+		s.restored = time.Now()
+		s.Lock()
+		sS.unregisterSession(s.CGRID, true) // the passive copy is outdated
+		sS.registerSession(s, false)
+		sS.initSessionDebitLoops(s)
+		sS.setSTerminator(s, nil)
+		s.Unlock()
+		restored++
+	}
+	utils.Logger.Info(fmt.Sprintf("<%s> restored <%d> sessions", utils.SessionS, restored))
+}
+
 // initSessionDebitLoops will init the debit loops for a session
 // not thread-safe, it should be protected in another layer
 func (sS *SessionS) initSessionDebitLoops(s *Session) {
@@ -1405,6 +1534,7 @@ func (sS *SessionS) initSession(cgrEv *utils.CGREventWithOpts, clntConnID,
 	if !isMsg {
 		sS.initSessionDebitLoops(s)
 		sS.registerSession(s, false) // make the session available to the rest of the system
+		sS.publishSessionEvent(s, utils.SessionInitiate)
 	}
 	return
 }
@@ -1413,6 +1543,9 @@ func (sS *SessionS) initSession(cgrEv *utils.CGREventWithOpts, clntConnID,
 func (sS *SessionS) updateSession(s *Session, updtEv, opts engine.MapEvent, isMsg bool) (maxUsage map[string]time.Duration, err error) {
 	if !isMsg {
 		defer sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
+		if sS.cgrCfg.SessionSCfg().StoreInterval != 0 {
+			defer sS.storeSession(s) // checkpoint the debits once the session is unlocked
+		}
 		s.Lock()
 		defer s.Unlock()
 
@@ -1478,6 +1611,10 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 		sS.unregisterSession(s.CGRID, false)
 		s.stopSTerminator()
 		s.stopDebitLoops()
+		if sS.cgrCfg.SessionSCfg().StoreInterval != 0 {
+			sS.removeStoredSession(s.CGRID)
+		}
+		defer sS.publishSessionEvent(s, utils.SessionTerminate) // after the usage was updated
 	}
 	for sRunIdx, sr := range s.SRuns {
 		sUsage := sr.TotalUsage
@@ -3024,14 +3161,15 @@ type V1ProcessEventArgs struct {
 
 // V1ProcessEventReply is the reply for the ProcessEvent API
 type V1ProcessEventReply struct {
-	MaxUsage        map[string]time.Duration
-	Cost            *float64 // Cost is the cost received from Rater, ignoring accounting part
-	ResourceMessage map[string]string
-	Attributes      *engine.AttrSProcessEventReply
-	Routes          *engine.SortedRoutes
-	ThresholdIDs    *[]string
-	StatQueueIDs    *[]string
-	STIRIdentity    map[string]string
+	MaxUsage         map[string]time.Duration
+	Cost             *float64 // Cost is the cost received from Rater, ignoring accounting part
+	ResourceMessage  map[string]string
+	Attributes       *engine.AttrSProcessEventReply
+	Routes           *engine.SortedRoutes
+	ThresholdIDs     *[]string
+	StatQueueIDs     *[]string
+	STIRIdentity     map[string]string
+	STIRVerification *STIRVerification
 }
 
 // AsNavigableMap is part of engine.NavigableMapper interface
@@ -3089,6 +3227,9 @@ func (v1Rply *V1ProcessEventReply) AsNavigableMap() utils.NavigableMap2 {
 			}
 			cgrReply[utils.STIRIdentity] = stir
 		}
+		if v1Rply.STIRVerification != nil {
+			cgrReply[utils.STIRVerification] = v1Rply.STIRVerification.AsNavigableMap()
+		}
 	}
 	return cgrReply
 }
@@ -3209,7 +3350,8 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 		rply.StatQueueIDs = &sIDs
 	}
 
-	if argsFlagsWithParams.HasKey(utils.MetaSTIRAuthenticate) {
+	if argsFlagsWithParams.HasKey(utils.MetaSTIRAuthenticate) ||
+		argsFlagsWithParams.HasKey(utils.MetaSTIRVerify) {
 		attest := sS.cgrCfg.SessionSCfg().STIRCfg.AllowedAttest
 		if uattest := opts.GetStringIgnoreErrors(utils.STIRATest); uattest != utils.EmptyString {
 			attest = utils.NewStringSet(strings.Split(uattest, utils.INFIELD_SEP))
@@ -3218,12 +3360,16 @@ func (sS *SessionS) BiRPCv1ProcessEvent(clnt rpcclient.ClientConnector,
 		if stirMaxDur, err = opts.GetDuration(utils.STIRPayloadMaxDuration); err != nil {
 			stirMaxDur = sS.cgrCfg.SessionSCfg().STIRCfg.PayloadMaxduration
 		}
-		if err = AuthStirShaken(opts.GetStringIgnoreErrors(utils.STIRIdentity),
-			utils.FirstNonEmpty(opts.GetStringIgnoreErrors(utils.STIROriginatorTn), ev.GetStringIgnoreErrors(utils.Account)),
-			opts.GetStringIgnoreErrors(utils.STIROriginatorURI),
-			utils.FirstNonEmpty(opts.GetStringIgnoreErrors(utils.STIRDestinationTn), ev.GetStringIgnoreErrors(utils.Destination)),
-			opts.GetStringIgnoreErrors(utils.STIRDestinationURI),
-			attest, stirMaxDur); err != nil {
+		identity := opts.GetStringIgnoreErrors(utils.STIRIdentity)
+		origTn := utils.FirstNonEmpty(opts.GetStringIgnoreErrors(utils.STIROriginatorTn), ev.GetStringIgnoreErrors(utils.Account))
+		origURI := opts.GetStringIgnoreErrors(utils.STIROriginatorURI)
+		destTn := utils.FirstNonEmpty(opts.GetStringIgnoreErrors(utils.STIRDestinationTn), ev.GetStringIgnoreErrors(utils.Destination))
+		destURI := opts.GetStringIgnoreErrors(utils.STIRDestinationURI)
+		if argsFlagsWithParams.HasKey(utils.MetaSTIRVerify) { // the agent decides based on verstat
+			rply.STIRVerification = VerifyStirShaken(identity, origTn, origURI,
+				destTn, destURI, attest, stirMaxDur)
+		} else if err = AuthStirShaken(identity, origTn, origURI,
+			destTn, destURI, attest, stirMaxDur); err != nil {
 			return utils.NewSTIRError(err.Error())
 		}
 	} else if argsFlagsWithParams.HasKey(utils.MetaSTIRInitiate) {
@@ -3722,6 +3868,35 @@ func (sS *SessionS) processStats(cgrEv *utils.CGREvent, argDisp *utils.ArgDispat
 	return
 }
 
+// publishSessionEvent sends the session event to PubSubS
+// the publish is done asynchronously so the session is not held by slow connections
+func (sS *SessionS) publishSessionEvent(s *Session, evType string) {
+	if len(sS.cgrCfg.SessionSCfg().PubSubSConns) == 0 {
+		return
+	}
+	ev := s.EventStart.Clone()
+	ev[utils.EventType] = evType
+	ev[utils.CGRID] = s.CGRID
+	args := &utils.CGREventWithArgDispatcher{
+		CGREvent: &utils.CGREvent{
+			Tenant: s.Tenant,
+			ID:     utils.GenUUID(),
+			Time:   utils.TimePointer(time.Now()),
+			Event:  ev,
+		},
+		ArgDispatcher: s.ArgDispatcher,
+	}
+	go func() {
+		var reply string
+		if err := sS.connMgr.Call(sS.cgrCfg.SessionSCfg().PubSubSConns, nil,
+			utils.PubSubSv1Publish, args, &reply); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: <%s> publishing %s event for session: <%s>",
+					utils.SessionS, err.Error(), evType, s.CGRID))
+		}
+	}()
+}
+
 // getRoutes will receive the event and send it to SupplierS to find the suppliers
 func (sS *SessionS) getRoutes(cgrEv *utils.CGREvent, argDisp *utils.ArgDispatcher, pag utils.Paginator,
 	ignoreErrors bool, maxCost string, opts map[string]interface{}) (routesReply engine.SortedRoutes, err error) {
@@ -3972,18 +4147,38 @@ func (sS *SessionS) BiRPCv1STIRAuthenticate(clnt rpcclient.ClientConnector,
 	return
 }
 
+// BiRPCv1STIRVerify the API for STIR verification returning the verification status
+func (sS *SessionS) BiRPCv1STIRVerify(clnt rpcclient.ClientConnector,
+	args *V1STIRAuthenticateArgs, reply *STIRVerification) (err error) {
+	attest := sS.cgrCfg.SessionSCfg().STIRCfg.AllowedAttest
+	if len(args.Attest) != 0 {
+		attest = utils.NewStringSet(args.Attest)
+	}
+	stirMaxDur := sS.cgrCfg.SessionSCfg().STIRCfg.PayloadMaxduration
+	if args.PayloadMaxDuration != utils.EmptyString {
+		if stirMaxDur, err = utils.ParseDurationWithNanosecs(args.PayloadMaxDuration); err != nil {
+			return
+		}
+	}
+	*reply = *VerifyStirShaken(args.Identity, args.OriginatorTn, args.OriginatorURI,
+		args.DestinationTn, args.DestinationURI, attest, stirMaxDur)
+	return
+}
+
 // BiRPCv1STIRIdentity the API for STIR header creation
 func (sS *SessionS) BiRPCv1STIRIdentity(clnt rpcclient.ClientConnector,
 	args *V1STIRIdentityArgs, identity *string) (err error) {
-	if args.Payload.ATTest == utils.EmptyString {
+	ppt := utils.FirstNonEmpty(args.PPT, utils.STIRPpt)
+	if args.Payload.ATTest == utils.EmptyString &&
+		ppt != utils.STIRPptDiv { // the div PASSporT has no attestation
 		args.Payload.ATTest = sS.cgrCfg.SessionSCfg().STIRCfg.DefaultAttest
 	}
 	if args.OverwriteIAT {
 		args.Payload.IAT = time.Now().Unix()
 	}
 	if *identity, err = NewSTIRIdentity(
-		utils.NewPASSporTHeader(utils.FirstNonEmpty(args.PublicKeyPath,
-			sS.cgrCfg.SessionSCfg().STIRCfg.PublicKeyPath)),
+		utils.NewPASSporTExtensionHeader(utils.FirstNonEmpty(args.PublicKeyPath,
+			sS.cgrCfg.SessionSCfg().STIRCfg.PublicKeyPath), ppt),
 		args.Payload, utils.FirstNonEmpty(args.PrivateKeyPath,
 			sS.cgrCfg.SessionSCfg().STIRCfg.PrivateKeyPath),
 		sS.cgrCfg.GeneralCfg().ReplyTimeout); err != nil {
@@ -2339,3 +2339,124 @@ aa+jqv4dwkr/FLEcN1zC76Y/IniI65fId55hVJvN3ORuzUqYEtzD3irmsw==
 		t.Fatal(err)
 	}
 }
+
+func TestSessionSStoreRestoreSessions(t *testing.T) {
+	sSCfg, _ := config.NewDefaultCGRConfig()
+	sSCfg.SessionSCfg().StoreInterval = time.Minute
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, sSCfg.DataDbCfg().Items),
+		sSCfg.CacheCfg(), nil)
+	sS := NewSessionS(sSCfg, dm, nil)
+	s := &Session{
+		CGRID:      "session1",
+		Tenant:     "cgrates.org",
+		EventStart: engine.MapEvent{utils.OriginID: "12345", utils.Account: "1001"},
+		SRuns: []*SRun{{
+			Event:      engine.MapEvent{utils.RunID: utils.MetaDefault},
+			CD:         &engine.CallDescriptor{Account: "1001", RunID: utils.MetaDefault},
+			LastUsage:  5 * time.Second,
+			TotalUsage: 10 * time.Second,
+		}},
+	}
+	sS.registerSession(s, false)
+	sS.storeSessions()
+
+	sSRestarted := NewSessionS(sSCfg, dm, nil)
+	sSRestarted.restoreSessions()
+	if ss := sSRestarted.getSessions("session1", false); len(ss) != 1 {
+		t.Fatalf("Expecting one restored session, received: %+v", ss)
+	} else if rcv := ss[0].Clone(); !reflect.DeepEqual(s.Clone(), rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(s), utils.ToJSON(rcv))
+	}
+	if err := sSRestarted.terminateSession(sSRestarted.getSessions("session1", false)[0],
+		nil, nil, nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if sss, err := dm.GetStoredSessions(sSCfg.GeneralCfg().NodeID); err != nil {
+		t.Error(err)
+	} else if len(sss) != 0 {
+		t.Errorf("Expecting no stored session, received: %s", utils.ToJSON(sss))
+	}
+	// ended sessions are not checkpointed anymore
+	sS.unregisterSession("session1", false)
+	sS.storeSession(s)
+	if sss, err := dm.GetStoredSessions(sSCfg.GeneralCfg().NodeID); err != nil {
+		t.Error(err)
+	} else if len(sss) != 0 {
+		t.Errorf("Expecting no stored session, received: %s", utils.ToJSON(sss))
+	}
+}
+
+type testSyncBiJClient struct {
+	sIDs []*SessionID
+	err  error
+}
+
+func (c *testSyncBiJClient) Call(serviceMethod string, args, reply interface{}) error {
+	switch serviceMethod {
+	case utils.SessionSv1GetActiveSessionIDs:
+		if c.err != nil {
+			return c.err
+		}
+		*reply.(*[]*SessionID) = c.sIDs
+	case utils.SessionSv1DisconnectSession:
+		*reply.(*string) = utils.OK
+	}
+	return nil
+}
+
+func TestSessionSSyncSessions(t *testing.T) {
+	sSCfg, _ := config.NewDefaultCGRConfig()
+	sS := NewSessionS(sSCfg, nil, nil)
+	reported := &SessionID{OriginHost: "127.0.0.1", OriginID: "reported"}
+	agent := &testSyncBiJClient{sIDs: []*SessionID{reported}}
+	sS.biJClnts[agent] = "agent"
+	sS.biJIDs["agent"] = &biJClient{conn: agent}
+	mute := &testSyncBiJClient{err: utils.ErrNotImplemented}
+	sS.biJClnts[mute] = "mute"
+	sS.biJIDs["mute"] = &biJClient{conn: mute}
+	for _, s := range []*Session{
+		{CGRID: reported.CGRID(), ClientConnID: "agent"},
+		{CGRID: "stale", ClientConnID: "agent"},
+		{CGRID: "muteAgent", ClientConnID: "mute"},
+		{CGRID: "api"},
+		{CGRID: "agentGone", ClientConnID: "gone"},
+		{CGRID: "restoredStale", ClientConnID: "agent", restored: time.Now()},
+		{CGRID: "restoredMuteAgent", ClientConnID: "mute", restored: time.Now()},
+		{CGRID: "restoredAPI", restored: time.Now()},
+		{CGRID: "restored", ClientConnID: "gone", restored: time.Now()},
+	} {
+		sS.registerSession(s, false)
+	}
+	sS.syncSessions()
+	for cgrID, active := range map[string]bool{
+		reported.CGRID():    true,
+		"stale":             false,
+		"muteAgent":         false,
+		"api":               false,
+		"agentGone":         false,
+		"restoredStale":     false,
+		"restoredMuteAgent": true,
+		"restoredAPI":       true,
+		"restored":          true,
+	} {
+		if ss := sS.getSessions(cgrID, false); active != (len(ss) == 1) {
+			t.Errorf("Expecting session %s active: %v, received: %+v", cgrID, active, ss)
+		}
+	}
+	// once the grace period passed the restored session is terminated
+	restored := sS.getSessions("restored", false)[0]
+	restored.restored = time.Now().Add(-sSCfg.SessionSCfg().RestoreGracePeriod)
+	agent.sIDs = nil
+	sS.syncSessions()
+	if ss := sS.getSessions("restored", false); len(ss) != 0 {
+		t.Errorf("Expecting restored session to be terminated, received: %+v", ss)
+	}
+	// the restored session reported by an agent is reattached to it
+	restored = &Session{CGRID: reported.CGRID(), ClientConnID: "gone", restored: time.Now()}
+	sS.registerSession(restored, false)
+	agent.sIDs = []*SessionID{reported}
+	sS.syncSessions()
+	if restored.ClientConnID != "agent" || !restored.restored.IsZero() {
+		t.Errorf("Expecting session reattached to agent, received: %+v", restored)
+	}
+}
@@ -155,6 +155,7 @@ type DestinationRate struct {
 	RoundingDecimals int
 	MaxCost          float64
 	MaxCostStrategy  string
+	Currency         string // optional, currency of the rate
 }
 
 type ApierTPTiming struct {
@@ -177,6 +178,22 @@ type TPTiming struct {
 	EndTime   string
 }
 
+// TPFxRate is used to exchange one currency into another
+type TPFxRate struct {
+	TPid         string
+	FromCurrency string
+	ToCurrency   string
+	Rate         float64 // units of ToCurrency for one unit of FromCurrency
+}
+
+// TPPortedNumber maps a ported number or a number range to its routing number
+type TPPortedNumber struct {
+	TPid    string
+	Number  string // single number or range as 40721000000-40721009999
+	LRN     string // routing number
+	Network string // owner network
+}
+
 // TPTimingWithArgDispatcher is used in replicatorV1 for dispatcher
 type TPTimingWithArgDispatcher struct {
 	*TPTiming
@@ -464,6 +481,33 @@ type ArgsCache struct {
 	RateProfileIDs        []string
 }
 
+// Merge appends the IDs out of other ArgsCache
+func (ac *ArgsCache) Merge(other ArgsCache) {
+	ac.DestinationIDs = append(ac.DestinationIDs, other.DestinationIDs...)
+	ac.ReverseDestinationIDs = append(ac.ReverseDestinationIDs, other.ReverseDestinationIDs...)
+	ac.RatingPlanIDs = append(ac.RatingPlanIDs, other.RatingPlanIDs...)
+	ac.RatingProfileIDs = append(ac.RatingProfileIDs, other.RatingProfileIDs...)
+	ac.ActionIDs = append(ac.ActionIDs, other.ActionIDs...)
+	ac.ActionPlanIDs = append(ac.ActionPlanIDs, other.ActionPlanIDs...)
+	ac.AccountActionPlanIDs = append(ac.AccountActionPlanIDs, other.AccountActionPlanIDs...)
+	ac.ActionTriggerIDs = append(ac.ActionTriggerIDs, other.ActionTriggerIDs...)
+	ac.SharedGroupIDs = append(ac.SharedGroupIDs, other.SharedGroupIDs...)
+	ac.ResourceProfileIDs = append(ac.ResourceProfileIDs, other.ResourceProfileIDs...)
+	ac.ResourceIDs = append(ac.ResourceIDs, other.ResourceIDs...)
+	ac.StatsQueueIDs = append(ac.StatsQueueIDs, other.StatsQueueIDs...)
+	ac.StatsQueueProfileIDs = append(ac.StatsQueueProfileIDs, other.StatsQueueProfileIDs...)
+	ac.ThresholdIDs = append(ac.ThresholdIDs, other.ThresholdIDs...)
+	ac.ThresholdProfileIDs = append(ac.ThresholdProfileIDs, other.ThresholdProfileIDs...)
+	ac.FilterIDs = append(ac.FilterIDs, other.FilterIDs...)
+	ac.RouteProfileIDs = append(ac.RouteProfileIDs, other.RouteProfileIDs...)
+	ac.AttributeProfileIDs = append(ac.AttributeProfileIDs, other.AttributeProfileIDs...)
+	ac.ChargerProfileIDs = append(ac.ChargerProfileIDs, other.ChargerProfileIDs...)
+	ac.DispatcherProfileIDs = append(ac.DispatcherProfileIDs, other.DispatcherProfileIDs...)
+	ac.DispatcherHostIDs = append(ac.DispatcherHostIDs, other.DispatcherHostIDs...)
+	ac.DispatcherRoutesIDs = append(ac.DispatcherRoutesIDs, other.DispatcherRoutesIDs...)
+	ac.RateProfileIDs = append(ac.RateProfileIDs, other.RateProfileIDs...)
+}
+
 type AttrExpFileCdrs struct {
 	CdrFormat           *string  // Cdr output file format <CdreCdrFormats>
 	FieldSeparator      *string  // Separator used between fields
@@ -1187,6 +1231,38 @@ type SMCostFilter struct { //id cu litere mare
 	CreatedAt      TimeInterval
 }
 
+// AuditRecordsFilter is used to query the audit records of a tenant
+type AuditRecordsFilter struct {
+	Tenant    string
+	ItemTypes []string
+	ItemIDs   []string
+	Methods   []string
+	Callers   []string
+	Time      TimeInterval
+	Paginator
+}
+
+// ArgsProfileRevisions identifies the revision history of a profile
+type ArgsProfileRevisions struct {
+	ItemType string // eg: *attribute_profiles
+	Tenant   string
+	ID       string
+}
+
+// ArgsProfileRevisionsDiff is used to compare two revisions of a profile
+type ArgsProfileRevisionsDiff struct {
+	ArgsProfileRevisions
+	FromRevision int
+	ToRevision   int
+}
+
+// ArgsProfileRollback is used to restore a previous revision of a profile
+type ArgsProfileRollback struct {
+	ArgsProfileRevisions
+	Revision int
+	Cache    *string
+}
+
 func AppendToSMCostFilter(smcFilter *SMCostFilter, fieldType, fieldName string,
 	values []string, timezone string) (smcf *SMCostFilter, err error) {
 	switch fieldName {
@@ -1410,6 +1486,15 @@ type DPRArgs struct {
 	DisconnectCause int
 }
 
+// DiamRequestArgs are the arguments used to originate a Diameter request towards the upstream peers
+type DiamRequestArgs struct {
+	Template      string                 // the template used to build the request
+	ApplicationID uint32                 // Application-Id of the request
+	CommandCode   uint32                 // Command-Code of the request
+	PeerID        string                 // send only to this peer, otherwise route on Destination-Host/Realm
+	Event         map[string]interface{} // data available as ~*req inside the template
+}
+
 type ArgCacheReplicateSet struct {
 	CacheID, ItemID string
 	Value           interface{}
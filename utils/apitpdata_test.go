@@ -973,3 +973,18 @@ func TestAppendToSMCostFilter(t *testing.T) {
 		t.Errorf("Expected: %s ,received: %s ", ToJSON(expected), ToJSON(smfltr))
 	}
 }
+
+func TestArgsCacheMerge(t *testing.T) {
+	args := ArgsCache{AttributeProfileIDs: []string{"cgrates.org:ATTR1"}}
+	args.Merge(ArgsCache{
+		AttributeProfileIDs: []string{"cgrates.org:ATTR2"},
+		FilterIDs:           []string{"cgrates.org:FLTR1"},
+	})
+	exp := ArgsCache{
+		AttributeProfileIDs: []string{"cgrates.org:ATTR1", "cgrates.org:ATTR2"},
+		FilterIDs:           []string{"cgrates.org:FLTR1"},
+	}
+	if !reflect.DeepEqual(exp, args) {
+		t.Errorf("Expected: %s ,received: %s ", ToJSON(exp), ToJSON(args))
+	}
+}
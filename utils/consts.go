@@ -56,15 +56,17 @@ var (
 		CacheDispatcherRoutes, CacheDispatcherLoads, CacheDiameterMessages, CacheRPCResponses,
 		CacheClosedSessions, CacheCDRIDs, CacheLoadIDs, CacheRPCConnections, CacheRatingProfilesTmp,
 		CacheUCH, CacheSTIR, CacheEventCharges, CacheRateProfiles, CacheRateProfilesFilterIndexes,
-		CacheRateFilterIndexes, CacheReverseFilterIndexes,
+		CacheRateFilterIndexes, CacheReverseFilterIndexes, CacheTaxProfiles, CacheTaxFilterIndexes,
+		CacheFxRates, CachePortedNumbers,
 		// only internalDB
-		CacheVersions, CacheAccounts,
+		CacheVersions, CacheAccounts, CacheProfileRevisions, CacheStoredSessions, CacheConfigSections,
 		CacheTBLTPTimings, CacheTBLTPDestinations, CacheTBLTPRates, CacheTBLTPDestinationRates,
 		CacheTBLTPRatingPlans, CacheTBLTPRatingProfiles, CacheTBLTPSharedGroups, CacheTBLTPActions,
 		CacheTBLTPActionPlans, CacheTBLTPActionTriggers, CacheTBLTPAccountActions, CacheTBLTPResources,
 		CacheTBLTPStats, CacheTBLTPThresholds, CacheTBLTPFilters, CacheSessionCostsTBL, CacheCDRsTBL,
-		CacheTBLTPRoutes, CacheTBLTPAttributes, CacheTBLTPChargers, CacheTBLTPDispatchers,
-		CacheTBLTPDispatcherHosts, CacheTBLTPRateProfiles})
+		CacheAuditRecordsTBL, CacheTBLTPRoutes, CacheTBLTPAttributes, CacheTBLTPChargers, CacheTBLTPDispatchers,
+		CacheTBLTPDispatcherHosts, CacheTBLTPRateProfiles, CacheTBLTPFxRates,
+		CacheTBLTPPortedNumbers})
 	CacheInstanceToPrefix = map[string]string{
 		CacheDestinations:              DESTINATION_PREFIX,
 		CacheReverseDestinations:       REVERSE_DESTINATION_PREFIX,
@@ -99,8 +101,15 @@ var (
 		CacheRateProfilesFilterIndexes: RateProfilesFilterIndexPrfx,
 		CacheLoadIDs:                   LoadIDPrefix,
 		CacheAccounts:                  ACCOUNT_PREFIX,
+		CacheProfileRevisions:          ProfileRevisionsPrefix,
+		CacheStoredSessions:            StoredSessionPrefix,
+		CacheConfigSections:            ConfigSectionPrefix,
 		CacheRateFilterIndexes:         RateFilterIndexPrfx,
 		CacheReverseFilterIndexes:      FilterIndexPrfx,
+		CacheTaxProfiles:               TaxProfilePrefix,
+		CacheTaxFilterIndexes:          TaxFilterIndexes,
+		CacheFxRates:                   FxRatesPrefix,
+		CachePortedNumbers:             PortedNumbersPrefix,
 	}
 	CachePrefixToInstance map[string]string    // will be built on init
 	CacheIndexesToPrefix  = map[string]string{ // used by match index to get all the ids when index selects is disabled and for compute indexes
@@ -113,6 +122,7 @@ var (
 		CacheDispatcherFilterIndexes:   DispatcherProfilePrefix,
 		CacheRateProfilesFilterIndexes: RateProfilePrefix,
 		CacheRateFilterIndexes:         RatePrefix,
+		CacheTaxFilterIndexes:          TaxProfilePrefix,
 	}
 
 	// NonMonetaryBalances are types of balances which are not handled as monetary
@@ -139,12 +149,15 @@ var (
 		TBLTPFilters:          CacheTBLTPFilters,
 		SessionCostsTBL:       CacheSessionCostsTBL,
 		CDRsTBL:               CacheCDRsTBL,
+		AuditRecordsTBL:       CacheAuditRecordsTBL,
 		TBLTPRoutes:           CacheTBLTPRoutes,
 		TBLTPAttributes:       CacheTBLTPAttributes,
 		TBLTPChargers:         CacheTBLTPChargers,
 		TBLTPDispatchers:      CacheTBLTPDispatchers,
 		TBLTPDispatcherHosts:  CacheTBLTPDispatcherHosts,
 		TBLTPRateProfiles:     CacheTBLTPRateProfiles,
+		TBLTPFxRates:          CacheTBLTPFxRates,
+		TBLTPPortedNumbers:    CacheTBLTPPortedNumbers,
 	}
 	// ProtectedSFlds are the fields that sessions should not alter
 	ProtectedSFlds = NewStringSet([]string{CGRID, OriginHost, OriginID, Usage})
@@ -282,6 +295,12 @@ const (
 	DispatcherProfilePrefix      = "dpp_"
 	RateProfilePrefix            = "rtp_"
 	DispatcherHostPrefix         = "dph_"
+	ProfileRevisionsPrefix       = "prv_"
+	StoredSessionPrefix          = "ssn_"
+	ConfigSectionPrefix          = "cfs_"
+	TaxProfilePrefix             = "txp_"
+	FxRatesPrefix                = "fxr_"
+	PortedNumbersPrefix          = "mnp_"
 	ThresholdProfilePrefix       = "thp_"
 	StatQueuePrefix              = "stq_"
 	LoadIDPrefix                 = "lid_"
@@ -304,6 +323,9 @@ const (
 	MetaSQL                      = "*sql"
 	MetaMySQL                    = "*mysql"
 	MetaS3jsonMap                = "*s3_json_map"
+	MetaFile                     = "*file"
+	MetaHTTP                     = "*http"
+	MetaS3                       = "*s3"
 	CONFIG_PATH                  = "/etc/cgrates/"
 	DISCONNECT_CAUSE             = "DisconnectCause"
 	MetaFlatstore                = "*flatstore"
@@ -350,6 +372,7 @@ const (
 	XML                         = "xml"
 	MetaGOB                     = "*gob"
 	MetaJSON                    = "*json"
+	MetaGRPC                    = "*grpc"
 	MetaMSGPACK                 = "*msgpack"
 	MetaDateTime                = "*datetime"
 	MetaMaskedDestination       = "*masked_destination"
@@ -393,6 +416,8 @@ const (
 	MetaGuardian                = "*guardians"
 	MetaEEs                     = "*ees"
 	MetaRateS                   = "*rates"
+	MetaTaxes                   = "*taxes"
+	MetaPubSubs                 = "*pubsubs"
 	MetaContinue                = "*continue"
 	Migrator                    = "migrator"
 	UnsupportedMigrationTask    = "unsupported migration task"
@@ -434,6 +459,8 @@ const (
 	DispatcherProfiles          = "DispatcherProfiles"
 	DispatcherHosts             = "DispatcherHosts"
 	RateProfiles                = "RateProfiles"
+	FxRates                     = "FxRates"
+	PortedNumbers               = "PortedNumbers"
 	MetaEveryMinute             = "*every_minute"
 	MetaHourly                  = "*hourly"
 	ID                          = "ID"
@@ -468,6 +495,8 @@ const (
 	ResourceID               = "ResourceID"
 	TotalUsage               = "TotalUsage"
 	StatID                   = "StatID"
+	ThresholdID              = "ThresholdID"
+	Hits                     = "Hits"
 	BalanceType              = "BalanceType"
 	BalanceID                = "BalanceID"
 	BalanceDestinationIds    = "BalanceDestinationIds"
@@ -484,6 +513,10 @@ const (
 	BalanceUpdate            = "BalanceUpdate"
 	StatUpdate               = "StatUpdate"
 	ResourceUpdate           = "ResourceUpdate"
+	SessionInitiate          = "SessionInitiate"
+	SessionTerminate         = "SessionTerminate"
+	CDRProcessed             = "CDRProcessed"
+	ThresholdHit             = "ThresholdHit"
 	CDR                      = "CDR"
 	CDRs                     = "CDRs"
 	ExpiryTime               = "ExpiryTime"
@@ -534,6 +567,22 @@ const (
 	Accounting               = "Accounting"
 	Rating                   = "Rating"
 	Charges                  = "Charges"
+	Taxes                    = "Taxes"
+	NetCost                  = "NetCost"
+	TaxAmount                = "TaxAmount"
+	TaxProfileID             = "TaxProfileID"
+	TaxID                    = "TaxID"
+	Rate                     = "Rate"
+	Inclusive                = "Inclusive"
+	Base                     = "Base"
+	Amount                   = "Amount"
+	Currency                 = "Currency"
+	FromCurrency             = "FromCurrency"
+	ToCurrency               = "ToCurrency"
+	FxRate                   = "FxRate"
+	Number                   = "Number"
+	LRN                      = "LRN"
+	Network                  = "Network"
 	CompressFactor           = "CompressFactor"
 	Increments               = "Increments"
 	Balance                  = "Balance"
@@ -620,6 +669,7 @@ const (
 	MetaDivide               = "*divide"
 	MetaUrl                  = "*url"
 	MetaXml                  = "*xml"
+	MetaForm                 = "*form"
 	MetaReq                  = "*req"
 	MetaVars                 = "*vars"
 	MetaRep                  = "*rep"
@@ -632,6 +682,17 @@ const (
 	MetaInitiate             = "*initiate"
 	MetaUpdate               = "*update"
 	MetaTerminate            = "*terminate"
+	MetaMSCC                 = "*mscc"
+	MetaPolicy               = "*policy"
+	MetaProxy                = "*proxy"
+	ChargingRuleInstall      = "ChargingRuleInstall"
+	ChargingRuleRemove       = "ChargingRuleRemove"
+	QoSClassIdentifier       = "QoSClassIdentifier"
+	MaxRequestedBandwidthUL  = "MaxRequestedBandwidthUL"
+	MaxRequestedBandwidthDL  = "MaxRequestedBandwidthDL"
+	MetaRedirect             = "*redirect"
+	RatingGroup              = "RatingGroup"
+	ServiceIdentifier        = "ServiceIdentifier"
 	MetaEvent                = "*event"
 	MetaMessage              = "*message"
 	MetaDryRun               = "*dryrun"
@@ -644,7 +705,10 @@ const (
 	MetaApp                  = "*app"
 	MetaAppID                = "*appid"
 	MetaCmd                  = "*cmd"
-	MetaEnv                  = "*env:" // use in config for describing enviormant variables
+	MetaEnv                  = "*env:"  // use in config for describing enviormant variables
+	MetaFileSecret           = "*file:" // use in config for secrets read out of files
+	MetaHTTPSecret           = "*http:" // use in config for secrets fetched over HTTP
+	MaskedSecret             = "******"
 	MetaTemplate             = "*template"
 	MetaCCA                  = "*cca"
 	MetaErr                  = "*err"
@@ -665,6 +729,7 @@ const (
 	MetaDuration             = "*duration"
 	MetaLibPhoneNumber       = "*libphonenumber"
 	MetaTimeString           = "*time_string"
+	MetaExpr                 = "*expr"
 	MetaIP2Hex               = "*ip2hex"
 	MetaSIPURIMethod         = "*sipuri_method"
 	MetaSIPURIHost           = "*sipuri_host"
@@ -697,6 +762,16 @@ const (
 	ApierV                   = "ApierV"
 	MetaApier                = "*apier"
 	MetaAnalyzer             = "*analyzer"
+	MetaAudits               = "*audits"
+	MetaUser                 = "*user"
+	MetaAPIKey               = "*api_key"
+	MetaCertificate          = "*certificate"
+	MetaUnknown              = "*unknown"
+	ItemType                 = "ItemType"
+	ItemID                   = "ItemID"
+	Method                   = "Method"
+	Caller                   = "Caller"
+	Changes                  = "Changes"
 	CGREventString           = "CGREvent"
 	MetaTextPlain            = "*text_plain"
 	MetaIgnoreErrors         = "*ignore_errors"
@@ -705,6 +780,7 @@ const (
 	MetaAuthorize            = "*authorize"
 	MetaSTIRAuthenticate     = "*stir_authenticate"
 	MetaSTIRInitiate         = "*stir_initiate"
+	MetaSTIRVerify           = "*stir_verify"
 	MetaInit                 = "*init"
 	MetaRatingPlanCost       = "*rating_plan_cost"
 	RatingPlanIDs            = "RatingPlanIDs"
@@ -764,6 +840,8 @@ const (
 	MetaYearly               = "*yearly"
 	MetaDaily                = "*daily"
 	MetaWeekly               = "*weekly"
+	MetaUnits                = "*units"
+	Tenants                  = "Tenants"
 	RateS                    = "RateS"
 	Underline                = "_"
 	MetaPartial              = "*partial"
@@ -832,6 +910,10 @@ const (
 	ChargerS    = "ChargerS"
 	CacheS      = "CacheS"
 	AnalyzerS   = "AnalyzerS"
+	AuditS      = "AuditS"
+	TaxS        = "TaxS"
+	PubSubS     = "PubSubS"
+	ConfigDBS   = "ConfigDBS"
 	CDRServer   = "CDRServer"
 	ResponderS  = "ResponderS"
 	GuardianS   = "GuardianS"
@@ -856,6 +938,7 @@ const (
 	ApierSLow      = "apiers"
 	EEsLow         = "ees"
 	RateSLow       = "rates"
+	AuditSLow      = "audits"
 )
 
 // Actions
@@ -990,6 +1073,7 @@ const (
 	MetaGreaterOrEqual = "*gte"
 	MetaResources      = "*resources"
 	MetaEqual          = "*eq"
+	MetaMNP            = "*mnp"
 
 	MetaNotString       = "*notstring"
 	MetaNotPrefix       = "*notprefix"
@@ -1002,6 +1086,7 @@ const (
 	MetaNotDestinations = "*notdestinations"
 	MetaNotResources    = "*notresources"
 	MetaNotEqual        = "*noteq"
+	MetaNotMNP          = "*notmnp"
 
 	MetaEC = "*ec"
 )
@@ -1254,7 +1339,15 @@ const (
 	APIerSv1GetSharedGroup              = "APIerSv1.GetSharedGroup"
 	APIerSv1RemoveActionTrigger         = "APIerSv1.RemoveActionTrigger"
 	APIerSv1GetAccount                  = "APIerSv1.GetAccount"
+	APIerSv1GetAccounts                 = "APIerSv1.GetAccounts"
 	APIerSv1GetAttributeProfileIDsCount = "APIerSv1.GetAttributeProfileIDsCount"
+	APIerSv1GetFxRate                   = "APIerSv1.GetFxRate"
+	APIerSv1SetFxRate                   = "APIerSv1.SetFxRate"
+	APIerSv1RemoveFxRate                = "APIerSv1.RemoveFxRate"
+	APIerSv1GetPortedNumber             = "APIerSv1.GetPortedNumber"
+	APIerSv1SetPortedNumber             = "APIerSv1.SetPortedNumber"
+	APIerSv1RemovePortedNumber          = "APIerSv1.RemovePortedNumber"
+	APIerSv1LookupPortedNumber          = "APIerSv1.LookupPortedNumber"
 )
 
 // APIerSv1 TP APIs
@@ -1263,6 +1356,12 @@ const (
 	APIerSv1GetTPTiming              = "APIerSv1.GetTPTiming"
 	APIerSv1RemoveTPTiming           = "APIerSv1.RemoveTPTiming"
 	APIerSv1GetTPTimingIds           = "APIerSv1.GetTPTimingIds"
+	APIerSv1SetTPFxRate              = "APIerSv1.SetTPFxRate"
+	APIerSv1GetTPFxRate              = "APIerSv1.GetTPFxRate"
+	APIerSv1RemoveTPFxRate           = "APIerSv1.RemoveTPFxRate"
+	APIerSv1SetTPPortedNumber        = "APIerSv1.SetTPPortedNumber"
+	APIerSv1GetTPPortedNumber        = "APIerSv1.GetTPPortedNumber"
+	APIerSv1RemoveTPPortedNumber     = "APIerSv1.RemoveTPPortedNumber"
 	APIerSv1LoadTariffPlanFromStorDb = "APIerSv1.LoadTariffPlanFromStorDb"
 	APIerSv1RemoveTPFromFolder       = "APIerSv1.RemoveTPFromFolder"
 )
@@ -1312,6 +1411,10 @@ const (
 	ConfigSv1GetJSONSection       = "ConfigSv1.GetJSONSection"
 	ConfigSv1ReloadConfigFromPath = "ConfigSv1.ReloadConfigFromPath"
 	ConfigSv1ReloadConfigFromJSON = "ConfigSv1.ReloadConfigFromJSON"
+	ConfigSv1GetDBSection         = "ConfigSv1.GetDBSection"
+	ConfigSv1GetDBSectionVersions = "ConfigSv1.GetDBSectionVersions"
+	ConfigSv1SetDBSection         = "ConfigSv1.SetDBSection"
+	ConfigSv1RemoveDBSection      = "ConfigSv1.RemoveDBSection"
 )
 
 const (
@@ -1327,11 +1430,12 @@ const (
 )
 
 const (
-	CoreS         = "CoreS"
-	CoreSv1       = "CoreSv1"
-	CoreSv1Status = "CoreSv1.Status"
-	CoreSv1Ping   = "CoreSv1.Ping"
-	CoreSv1Sleep  = "CoreSv1.Sleep"
+	CoreS                        = "CoreS"
+	CoreSv1                      = "CoreSv1"
+	CoreSv1Status                = "CoreSv1.Status"
+	CoreSv1Ping                  = "CoreSv1.Ping"
+	CoreSv1Sleep                 = "CoreSv1.Sleep"
+	CoreSv1RequestLimiterMetrics = "CoreSv1.RequestLimiterMetrics"
 )
 
 // RouteS APIs
@@ -1442,6 +1546,7 @@ const (
 	SessionSv1DisconnectWarning          = "SessionSv1.DisconnectWarning"
 	SessionSv1STIRAuthenticate           = "SessionSv1.STIRAuthenticate"
 	SessionSv1STIRIdentity               = "SessionSv1.STIRIdentity"
+	SessionSv1STIRVerify                 = "SessionSv1.STIRVerify"
 	SessionSv1Sleep                      = "SessionSv1.Sleep"
 )
 
@@ -1478,12 +1583,48 @@ const (
 	APIerSv1RemoveRateProfileRates = "APIerSv1.RemoveRateProfileRates"
 )
 
+// Profile revisions APIs
+const (
+	APIerSv1GetProfileRevisions     = "APIerSv1.GetProfileRevisions"
+	APIerSv1GetProfileRevisionsDiff = "APIerSv1.GetProfileRevisionsDiff"
+	APIerSv1RollbackProfile         = "APIerSv1.RollbackProfile"
+)
+
 // AnalyzerS APIs
 const (
 	AnalyzerSv1     = "AnalyzerSv1"
 	AnalyzerSv1Ping = "AnalyzerSv1.Ping"
 )
 
+// AuditS APIs
+const (
+	AuditSv1                = "AuditSv1"
+	AuditSv1Ping            = "AuditSv1.Ping"
+	AuditSv1GetAuditRecords = "AuditSv1.GetAuditRecords"
+)
+
+// PubSubS APIs
+const (
+	PubSubSv1            = "PubSubSv1"
+	PubSubSv1Ping        = "PubSubSv1.Ping"
+	PubSubSv1Publish     = "PubSubSv1.Publish"
+	PubSubSv1Subscribe   = "PubSubSv1.Subscribe"
+	PubSubSv1Unsubscribe = "PubSubSv1.Unsubscribe"
+	PubSubSv1PushEvent   = "PubSubSv1.PushEvent" // implemented by the subscribers
+)
+
+// TaxS APIs
+const (
+	TaxSv1                       = "TaxSv1"
+	TaxSv1Ping                   = "TaxSv1.Ping"
+	TaxSv1ComputeTaxes           = "TaxSv1.ComputeTaxes"
+	TaxSv1GetTaxProfilesForEvent = "TaxSv1.GetTaxProfilesForEvent"
+	APIerSv1GetTaxProfile        = "APIerSv1.GetTaxProfile"
+	APIerSv1GetTaxProfileIDs     = "APIerSv1.GetTaxProfileIDs"
+	APIerSv1SetTaxProfile        = "APIerSv1.SetTaxProfile"
+	APIerSv1RemoveTaxProfile     = "APIerSv1.RemoveTaxProfile"
+)
+
 // LoaderS APIs
 const (
 	LoaderSv1       = "LoaderSv1"
@@ -1545,6 +1686,13 @@ const (
 	SchedulerSv1ExecuteActionPlans = "SchedulerSv1.ExecuteActionPlans"
 )
 
+// DiameterAgent APIs
+const (
+	DiameterAgentV1            = "DiameterAgentV1"
+	DiameterAgentV1Ping        = "DiameterAgentV1.Ping"
+	DiameterAgentV1SendRequest = "DiameterAgentV1.SendRequest"
+)
+
 // EEs
 const (
 	EventExporterSv1             = "EventExporterSv1"
@@ -1592,6 +1740,8 @@ const (
 	DispatcherProfilesCsv = "DispatcherProfiles.csv"
 	DispatcherHostsCsv    = "DispatcherHosts.csv"
 	RateProfilesCsv       = "RateProfiles.csv"
+	FxRatesCsv            = "FxRates.csv"
+	PortedNumbersCsv      = "PortedNumbers.csv"
 )
 
 // Table Name
@@ -1613,6 +1763,7 @@ const (
 	TBLTPFilters          = "tp_filters"
 	SessionCostsTBL       = "session_costs"
 	CDRsTBL               = "cdrs"
+	AuditRecordsTBL       = "audit_records"
 	TBLTPRoutes           = "tp_routes"
 	TBLTPAttributes       = "tp_attributes"
 	TBLTPChargers         = "tp_chargers"
@@ -1621,6 +1772,8 @@ const (
 	TBLTPDispatchers      = "tp_dispatcher_profiles"
 	TBLTPDispatcherHosts  = "tp_dispatcher_hosts"
 	TBLTPRateProfiles     = "tp_rate_profiles"
+	TBLTPFxRates          = "tp_fx_rates"
+	TBLTPPortedNumbers    = "tp_ported_numbers"
 )
 
 // Cache Name
@@ -1648,6 +1801,9 @@ const (
 	CacheChargerProfiles           = "*charger_profiles"
 	CacheDispatcherProfiles        = "*dispatcher_profiles"
 	CacheDispatcherHosts           = "*dispatcher_hosts"
+	CacheProfileRevisions          = "*profile_revisions"
+	CacheStoredSessions            = "*stored_sessions"
+	CacheConfigSections            = "*config_sections"
 	CacheDispatchers               = "*dispatchers"
 	CacheDispatcherRoutes          = "*dispatcher_routes"
 	CacheDispatcherLoads           = "*dispatcher_loads"
@@ -1673,6 +1829,10 @@ const (
 	CacheUCH                       = "*uch"
 	CacheSTIR                      = "*stir"
 	CacheEventCharges              = "*event_charges"
+	CacheTaxProfiles               = "*tax_profiles"
+	CacheTaxFilterIndexes          = "*tax_filter_indexes"
+	CacheFxRates                   = "*fx_rates"
+	CachePortedNumbers             = "*ported_numbers"
 	CacheReverseFilterIndexes      = "*reverse_filter_indexes"
 	CacheAccounts                  = "*accounts"
 	CacheVersions                  = "*versions"
@@ -1695,12 +1855,15 @@ const (
 	CacheTBLTPFilters          = "*tp_filters"
 	CacheSessionCostsTBL       = "*session_costs"
 	CacheCDRsTBL               = "*cdrs"
+	CacheAuditRecordsTBL       = "*audit_records"
 	CacheTBLTPRoutes           = "*tp_routes"
 	CacheTBLTPAttributes       = "*tp_attributes"
 	CacheTBLTPChargers         = "*tp_chargers"
 	CacheTBLTPDispatchers      = "*tp_dispatcher_profiles"
 	CacheTBLTPDispatcherHosts  = "*tp_dispatcher_hosts"
 	CacheTBLTPRateProfiles     = "*tp_rate_profiles"
+	CacheTBLTPFxRates          = "*tp_fx_rates"
+	CacheTBLTPPortedNumbers    = "*tp_ported_numbers"
 )
 
 // Prefix for indexing
@@ -1716,6 +1879,7 @@ const (
 	RateProfilesFilterIndexPrfx = "rpi_"
 	RateFilterIndexPrfx         = "rri_"
 	FilterIndexPrfx             = "fii_"
+	TaxFilterIndexes            = "txi_"
 )
 
 // Agents
@@ -1813,6 +1977,7 @@ const (
 	DataDbSentinelNameCfg = "redis_sentinel"
 	RmtConnsCfg           = "remote_conns"
 	RplConnsCfg           = "replication_conns"
+	ProfileRevisionsCfg   = "profile_revisions"
 )
 
 // ItemOpt
@@ -1843,18 +2008,58 @@ const (
 	RPCJSONTLSListenCfg = "rpc_json_tls"
 	RPCGOBTLSListenCfg  = "rpc_gob_tls"
 	HTTPTLSListenCfg    = "http_tls"
+	RPCGRPCListenCfg    = "rpc_grpc"
+	RPCGRPCTLSListenCfg = "rpc_grpc_tls"
 )
 
 // HTTPCfg
 const (
 	HTTPJsonRPCURLCfg        = "json_rpc_url"
 	HTTPWSURLCfg             = "ws_url"
+	HTTPRESTURLCfg           = "rest_url"
 	HTTPFreeswitchCDRsURLCfg = "freeswitch_cdrs_url"
 	HTTPCDRsURLCfg           = "http_cdrs"
 	HTTPUseBasicAuthCfg      = "use_basic_auth"
 	HTTPAuthUsersCfg         = "auth_users"
 )
 
+// RPCAuthCfg
+const (
+	APIKeysCfg      = "api_keys"
+	UsersCfg        = "users"
+	CertificatesCfg = "certificates"
+	GuestRoleCfg    = "guest_role"
+	RolesCfg        = "roles"
+	MethodsCfg      = "methods"
+	TenantsCfg      = "tenants"
+)
+
+// AuditSCfg
+const (
+	EEsConnsCfg = "ees_conns"
+)
+
+// PubSubSCfg
+const (
+	BufferSizeCfg = "buffer_size"
+)
+
+// ConfigDBCfg
+const (
+	SyncIntervalCfg   = "sync_interval"
+	LocalOverridesCfg = "local_overrides"
+)
+
+// RequestLimitersCfg
+const (
+	PrioritiesCfg   = "priorities"
+	LimitersCfg     = "limiters"
+	PerTenantCfg    = "per_tenant"
+	RateLimitCfg    = "rate_limit"
+	RateIntervalCfg = "rate_interval"
+	QueueTimeoutCfg = "queue_timeout"
+)
+
 // FilterSCfg
 const (
 	StatSConnsCfg     = "stats_conns"
@@ -1888,6 +2093,8 @@ const (
 	ChargerSConnsCfg    = "chargers_conns"
 	AttributeSConnsCfg  = "attributes_conns"
 	OnlineCDRExportsCfg = "online_cdr_exports"
+	TaxSConnsCfg        = "taxs_conns"
+	PubSubSConnsCfg     = "pubsubs_conns"
 )
 
 // SessionSCfg
@@ -1911,6 +2118,7 @@ const (
 	SessionIndexesCfg      = "session_indexes"
 	ClientProtocolCfg      = "client_protocol"
 	ChannelSyncIntervalCfg = "channel_sync_interval"
+	RestoreGracePeriodCfg  = "restore_grace_period"
 	TerminateAttemptsCfg   = "terminate_attempts"
 	AlterableFieldsCfg     = "alterable_fields"
 	MinDurLowBalanceCfg    = "min_dur_low_balance"
@@ -1921,6 +2129,8 @@ const (
 	DefaultAttestCfg      = "default_attest"
 	PublicKeyPathCfg      = "publickey_path"
 	PrivateKeyPathCfg     = "privatekey_path"
+	TrustAnchorsCfg       = "trust_anchors"
+	CRLsCfg               = "crls"
 )
 
 // FsAgentCfg
@@ -1950,8 +2160,9 @@ const (
 	UserCf = "user"
 
 	// AsteriskAgentCfg
-	CreateCDRCfg     = "create_cdr"
-	AsteriskConnsCfg = "asterisk_conns"
+	CreateCDRCfg      = "create_cdr"
+	UpdateIntervalCfg = "update_interval"
+	AsteriskConnsCfg  = "asterisk_conns"
 
 	// DiameterAgentCfg
 	ListenNetCfg         = "listen_net"
@@ -1966,9 +2177,19 @@ const (
 	ASRTemplateCfg       = "asr_template"
 	RARTemplateCfg       = "rar_template"
 	ForcedDisconnectCfg  = "forced_disconnect"
+	MSCCValidityTimeCfg  = "mscc_validity_time"
+	FinalUnitActionCfg   = "final_unit_action"
+	RedirectServerCfg    = "redirect_server_address"
+	PeersCfg             = "peers"
 	TemplatesCfg         = "templates"
 	RequestProcessorsCfg = "request_processors"
 
+	// DiameterPeerCfg
+	AuthApplicationIDsCfg = "auth_application_ids"
+	WeightCfg             = "weight"
+	WatchdogIntervalCfg   = "watchdog_interval"
+	ReconnectIntervalCfg  = "reconnect_interval"
+
 	// RequestProcessor
 	RequestFieldsCfg = "request_fields"
 	ReplyFieldsCfg   = "reply_fields"
@@ -2004,12 +2225,14 @@ const (
 	AttributeIDsCfg      = "attribute_ids"
 
 	//LoaderSCfg
-	IdCfg           = "id"
-	DryRunCfg       = "dry_run"
-	LockFileNameCfg = "lock_filename"
-	TpInDirCfg      = "tp_in_dir"
-	TpOutDirCfg     = "tp_out_dir"
-	DataCfg         = "data"
+	IdCfg            = "id"
+	DryRunCfg        = "dry_run"
+	LockFileNameCfg  = "lock_filename"
+	TpInDirCfg       = "tp_in_dir"
+	TpOutDirCfg      = "tp_out_dir"
+	SourceTypeCfg    = "source_type"
+	SourceTimeoutCfg = "source_timeout"
+	DataCfg          = "data"
 
 	DefaultRatioCfg            = "default_ratio"
 	ReadersCfg                 = "readers"
@@ -2150,6 +2373,8 @@ const (
 	TlsCfg           = "tls"              // from JSON
 	CacheCfg         = "caches"           // from JSON
 	HttpCfg          = "http"             // from JSON
+	RPCAuthCfg       = "rpc_auth"         // from JSON
+	ReqLimitersCfg   = "request_limiters" // from JSON
 	FilterSCfg       = "filters"          // from JSON
 	RalsCfg          = "rals"             // from JSON
 	SchedulerCfg     = "schedulers"       // from JSON
@@ -2173,6 +2398,10 @@ const (
 	MigratorCgrCfg   = "migrator"         // from JSON
 	MailerCfg        = "mailer"           // from JSON
 	AnalyzerSCfg     = "analyzers"        // from JSON
+	AuditSCfg        = "audits"           // from JSON
+	TaxSCfg          = "taxs"             // from JSON
+	PubSubSCfg       = "pubsubs"          // from JSON
+	ConfigDBCfg      = "config_db"        // from JSON
 	Apier            = "apiers"           // from JSON
 	ErsCfg           = "ers"              // from JSON
 
@@ -2180,16 +2409,25 @@ const (
 
 // STIR/SHAKEN
 const (
-	STIRAlg = "ES256"
-	STIRPpt = "shaken"
-	STIRTyp = "passport"
+	STIRAlg    = "ES256"
+	STIRPpt    = "shaken"
+	STIRPptDiv = "div" // diversion extension(RFC 8946)
+	STIRPptRcd = "rcd" // rich call data extension
+	STIRTyp    = "passport"
+
+	STIRRcdiAlg = "sha256" // the algorithm of the rich call data integrity digests
 
 	STIRAlgField  = "alg"
 	STIRPptField  = "ppt"
 	STIRInfoField = "info"
 
 	STIRExtraInfoPrefix = ";info=<"
-	STIRExtraInfoSuffix = ">;alg=ES256;ppt=shaken"
+	STIRExtraInfoSuffix = ">;alg=ES256;ppt="
+
+	// verification status(verstat) values
+	STIRTNValidationPassed = "TN-Validation-Passed"
+	STIRTNValidationFailed = "TN-Validation-Failed"
+	STIRNoTNValidation     = "No-TN-Validation"
 )
 
 // Strip/Padding strategy
@@ -2223,6 +2461,14 @@ const (
 	STIRDestinationURI     = "STIRDestinationURI"
 	STIRPublicKeyPath      = "STIRPublicKeyPath"
 	STIRPrivateKeyPath     = "STIRPrivateKeyPath"
+	STIRVerification       = "STIRVerification"
+	Verstat                = "Verstat"
+	Attest                 = "Attest"
+	X5u                    = "X5u"
+	Reason                 = "Reason"
+	CallerName             = "CallerName"
+	CallerLogo             = "CallerLogo"
+	CallReason             = "CallReason"
 
 	DebitInterval = "DebitInterval"
 	Context       = "Context"
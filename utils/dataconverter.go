@@ -36,9 +36,20 @@ type DataConverters []DataConverter
 
 // ConvertString converts from and to string
 func (dcs DataConverters) ConvertString(in string) (out string, err error) {
+	return dcs.ConvertStringWithDataProvider(in, nil)
+}
+
+// ConvertStringWithDataProvider converts from and to string
+// passing the DataProvider to the converters reading other fields
+func (dcs DataConverters) ConvertStringWithDataProvider(in string, dP DataProvider) (out string, err error) {
 	outIface := interface{}(in)
 	for _, cnv := range dcs {
-		if outIface, err = cnv.Convert(outIface); err != nil {
+		if dpCnv, canDP := cnv.(DataProviderConverter); canDP && dP != nil {
+			outIface, err = dpCnv.ConvertDataProvider(outIface, dP)
+		} else {
+			outIface, err = cnv.Convert(outIface)
+		}
+		if err != nil {
 			return
 		}
 	}
@@ -50,6 +61,12 @@ type DataConverter interface {
 	Convert(interface{}) (interface{}, error)
 }
 
+// DataProviderConverter is a DataConverter needing the other fields of the event
+type DataProviderConverter interface {
+	DataConverter
+	ConvertDataProvider(in interface{}, dP DataProvider) (interface{}, error)
+}
+
 // NewDataConverter is a factory of converters
 func NewDataConverter(params string) (conv DataConverter, err error) {
 	switch {
@@ -82,6 +99,8 @@ func NewDataConverter(params string) (conv DataConverter, err error) {
 		return new(SIPURIUserConverter), nil
 	case params == MetaSIPURIMethod:
 		return new(SIPURIMethodConverter), nil
+	case params == MetaMNP:
+		return new(MNPConverter), nil
 	case strings.HasPrefix(params, MetaLibPhoneNumber):
 		if len(params) == len(MetaLibPhoneNumber) {
 			return NewPhoneNumberConverter("")
@@ -92,6 +111,8 @@ func NewDataConverter(params string) (conv DataConverter, err error) {
 			return NewTimeStringConverter(time.RFC3339)
 		}
 		return NewTimeStringConverter(params[len(MetaTimeString)+1:])
+	case strings.HasPrefix(params, MetaExpr+InInFieldSep):
+		return NewExprConverter(params[len(MetaExpr)+1:])
 	default:
 		return nil, fmt.Errorf("unsupported converter definition: <%s>", params)
 	}
@@ -340,6 +361,29 @@ func (*SIPURIMethodConverter) Convert(in interface{}) (out interface{}, err erro
 	return sipingo.MethodFrom(val), nil
 }
 
+// PortedNumberLookup returns the routing number (LRN) of the network the number was ported into
+// or ErrNotFound if the number is not ported, it is set by the engine which knows the ported numbers
+var PortedNumberLookup func(number string) (lrn string, err error)
+
+// MNPConverter replaces the number with its routing number, the not ported numbers are kept as they are
+type MNPConverter struct{}
+
+// Convert implements DataConverter interface
+func (*MNPConverter) Convert(in interface{}) (out interface{}, err error) {
+	number := IfaceAsString(in)
+	if PortedNumberLookup == nil {
+		return nil, ErrPrefixNotErrNotImplemented(MetaMNP)
+	}
+	var lrn string
+	if lrn, err = PortedNumberLookup(number); err != nil {
+		if err.Error() != ErrNotFound.Error() {
+			return nil, err
+		}
+		return number, nil
+	}
+	return lrn, nil
+}
+
 func NewTimeStringConverter(params string) (hdlr DataConverter, err error) {
 	tm := new(TimeStringConverter)
 	tm.Layout = params
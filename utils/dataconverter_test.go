@@ -790,3 +790,33 @@ func TestNewTimeStringConverter(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", exp, rcv)
 	}
 }
+
+func TestMNPConverter(t *testing.T) {
+	conv, err := NewDataConverter(MetaMNP)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func(lookup func(string) (string, error)) { PortedNumberLookup = lookup }(PortedNumberLookup)
+	PortedNumberLookup = nil
+	if _, err := conv.Convert("40721000123"); err == nil ||
+		err.Error() != "NOT_IMPLEMENTED:*mnp" {
+		t.Errorf("Expecting: NOT_IMPLEMENTED:*mnp, received: %v", err)
+	}
+	PortedNumberLookup = func(number string) (string, error) {
+		if number == "40721000123" {
+			return "D0140721000123", nil
+		}
+		return EmptyString, ErrNotFound
+	}
+	if rcv, err := conv.Convert("40721000123"); err != nil {
+		t.Error(err)
+	} else if rcv != "D0140721000123" {
+		t.Errorf("Expecting: D0140721000123, received: %+v", rcv)
+	}
+	// not ported numbers are kept as they are
+	if rcv, err := conv.Convert(40722000123); err != nil {
+		t.Error(err)
+	} else if rcv != "40722000123" {
+		t.Errorf("Expecting: 40722000123, received: %+v", rcv)
+	}
+}
@@ -55,6 +55,7 @@ var (
 	ErrMandatoryIeMissingNoCaps = errors.New("mandatory information missing")
 	ErrUnauthorizedApi          = errors.New("UNAUTHORIZED_API")
 	ErrUnknownApiKey            = errors.New("UNKNOWN_API_KEY")
+	ErrUnauthorizedTenant       = errors.New("UNAUTHORIZED_TENANT")
 	ErrReqUnsynchronized        = errors.New("REQ_UNSYNCHRONIZED")
 	ErrUnsupporteServiceMethod  = errors.New("UNSUPPORTED_SERVICE_METHOD")
 	ErrDisconnected             = errors.New("DISCONNECTED")
@@ -70,6 +71,8 @@ var (
 	ErrMaxIncrementsExceeded    = errors.New("MAX_INCREMENTS_EXCEEDED")
 	ErrIndexOutOfBounds         = errors.New("INDEX_OUT_OF_BOUNDS")
 	ErrWrongPath                = errors.New("WRONG_PATH")
+	ErrFxRateNotFound           = errors.New("FX_RATE_NOT_FOUND")
+	ErrMaxRequestsExceeded      = errors.New("MAX_REQUESTS_EXCEEDED")
 	ErrServiceAlreadyRunning    = fmt.Errorf("service already running")
 
 	ErrMap = map[string]error{
@@ -97,6 +100,7 @@ var (
 		ErrNotConvertibleNoCaps.Error():    ErrNotConvertibleNoCaps,
 		ErrUnauthorizedApi.Error():         ErrUnauthorizedApi,
 		ErrUnknownApiKey.Error():           ErrUnknownApiKey,
+		ErrUnauthorizedTenant.Error():      ErrUnauthorizedTenant,
 		ErrReqUnsynchronized.Error():       ErrReqUnsynchronized,
 		ErrUnsupporteServiceMethod.Error(): ErrUnsupporteServiceMethod,
 		ErrDisconnected.Error():            ErrDisconnected,
@@ -109,6 +113,8 @@ var (
 		ErrMaxIncrementsExceeded.Error():   ErrMaxIncrementsExceeded,
 		ErrIndexOutOfBounds.Error():        ErrIndexOutOfBounds,
 		ErrWrongPath.Error():               ErrWrongPath,
+		ErrFxRateNotFound.Error():          ErrFxRateNotFound,
+		ErrMaxRequestsExceeded.Error():     ErrMaxRequestsExceeded,
 	}
 )
 
@@ -233,6 +239,10 @@ func ErrEnvNotFound(key string) error {
 	return ErrPrefix(ErrNotFound, "ENV_VAR:"+key)
 }
 
+func ErrSecretNotFound(ref string) error {
+	return ErrPrefix(ErrNotFound, "SECRET:"+ref)
+}
+
 // IsNetworkError will decide if an error is network generated or RPC one
 // used by Dispatcher to figure out whether it should try another connection
 func IsNetworkError(err error) bool {
@@ -0,0 +1,1092 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	exprMaxLen   = 4096 // longest expression accepted
+	exprMaxDepth = 64   // deepest nesting accepted
+)
+
+// NewExpression compiles the rule into an Expression
+// eg: if(~*req.Usage > 60s, ~*req.Cost*1.2, ~*req.Cost)
+func NewExpression(rule string) (expr *Expression, err error) {
+	if len(rule) > exprMaxLen {
+		return nil, fmt.Errorf("expression longer than %d characters", exprMaxLen)
+	}
+	prsr := &exprParser{lxr: &exprLexer{src: rule}}
+	if err = prsr.next(); err != nil {
+		return
+	}
+	expr = &Expression{rule: rule}
+	if expr.root, err = prsr.parseExpr(0, 0); err != nil {
+		return nil, err
+	}
+	if prsr.tkn.typ != exprTknEOF {
+		return nil, fmt.Errorf("unexpected <%s> at position %d", prsr.tkn.val, prsr.tkn.pos)
+	}
+	return
+}
+
+// Expression is a compiled expression evaluated over a DataProvider
+// the language has no loops, variables or side effects, only operators and the functions in exprFuncs
+type Expression struct {
+	rule string
+	root exprNode
+}
+
+// String returns the rule of the expression
+func (expr *Expression) String() string {
+	return expr.rule
+}
+
+// Evaluate returns the value of the expression for the fields in the DataProvider
+// the result is one of: nil, bool, float64, string, time.Duration or time.Time
+func (expr *Expression) Evaluate(dP DataProvider) (interface{}, error) {
+	if dP == nil {
+		dP = MapStorage{}
+	}
+	return expr.root.eval(dP)
+}
+
+// ExprConverter evaluates the expression ignoring the value of the rule
+type ExprConverter struct {
+	expr *Expression
+}
+
+// NewExprConverter compiles the expression of the converter
+func NewExprConverter(params string) (hdlr DataConverter, err error) {
+	var expr *Expression
+	if expr, err = NewExpression(params); err != nil {
+		return
+	}
+	return &ExprConverter{expr: expr}, nil
+}
+
+// Convert evaluates the expression without the fields of the event
+func (ec *ExprConverter) Convert(in interface{}) (interface{}, error) {
+	return ec.expr.Evaluate(nil)
+}
+
+// ConvertDataProvider evaluates the expression over the DataProvider
+func (ec *ExprConverter) ConvertDataProvider(in interface{}, dP DataProvider) (interface{}, error) {
+	return ec.expr.Evaluate(dP)
+}
+
+type exprTknType byte
+
+const (
+	exprTknEOF exprTknType = iota
+	exprTknNumber
+	exprTknDuration
+	exprTknString
+	exprTknField
+	exprTknIdent
+	exprTknOperator
+)
+
+type exprToken struct {
+	typ exprTknType
+	val string
+	pos int
+}
+
+// exprLexer splits the rule into tokens
+type exprLexer struct {
+	src string
+	pos int
+}
+
+func (lx *exprLexer) nextToken() (tkn exprToken, err error) {
+	for lx.pos < len(lx.src) && unicode.IsSpace(rune(lx.src[lx.pos])) {
+		lx.pos++
+	}
+	tkn.pos = lx.pos
+	if lx.pos >= len(lx.src) {
+		return
+	}
+	start := lx.pos
+	c := lx.src[lx.pos]
+	switch {
+	case c >= '0' && c <= '9' || c == '.' && lx.pos+1 < len(lx.src) && isExprDigit(lx.src[lx.pos+1]):
+		tkn.typ = exprTknNumber
+		for lx.pos < len(lx.src) && (isExprDigit(lx.src[lx.pos]) || lx.src[lx.pos] == '.') {
+			lx.pos++
+		}
+		if exp := lx.exponentLen(); exp != 0 { // exponent as 1e10, no duration unit starts with e
+			lx.pos += exp
+			for lx.pos < len(lx.src) && isExprDigit(lx.src[lx.pos]) {
+				lx.pos++
+			}
+		} else if lx.pos < len(lx.src) && isExprLetter(lx.src[lx.pos]) { // duration as 1m30s
+			tkn.typ = exprTknDuration
+			for lx.pos < len(lx.src) && (isExprLetter(lx.src[lx.pos]) ||
+				isExprDigit(lx.src[lx.pos]) || lx.src[lx.pos] == '.') {
+				lx.pos++
+			}
+		}
+		tkn.val = lx.src[start:lx.pos]
+	case c == '"' || c == '\'':
+		tkn.typ = exprTknString
+		lx.pos++
+		var sb strings.Builder
+		for {
+			if lx.pos >= len(lx.src) {
+				return tkn, fmt.Errorf("unterminated string at position %d", start)
+			}
+			ch := lx.src[lx.pos]
+			lx.pos++
+			if ch == c {
+				break
+			}
+			if ch == '\\' && lx.pos < len(lx.src) {
+				ch = lx.src[lx.pos]
+				lx.pos++
+				switch ch {
+				case 'n':
+					ch = '\n'
+				case 't':
+					ch = '\t'
+				}
+			}
+			sb.WriteByte(ch)
+		}
+		tkn.val = sb.String()
+	case c == '~':
+		tkn.typ = exprTknField
+		lx.pos++
+		if err = lx.scanField(); err != nil {
+			return
+		}
+		tkn.val = lx.src[start:lx.pos]
+	case isExprLetter(c):
+		tkn.typ = exprTknIdent
+		for lx.pos < len(lx.src) && (isExprLetter(lx.src[lx.pos]) || isExprDigit(lx.src[lx.pos])) {
+			lx.pos++
+		}
+		tkn.val = lx.src[start:lx.pos]
+	default:
+		tkn.typ = exprTknOperator
+		if lx.pos+1 < len(lx.src) {
+			switch op := lx.src[lx.pos : lx.pos+2]; op {
+			case "==", "!=", "<=", ">=", "&&", "||":
+				lx.pos += 2
+				tkn.val = op
+				return
+			}
+		}
+		switch c {
+		case '+', '-', '*', '/', '%', '<', '>', '!', '(', ')', ',':
+			lx.pos++
+			tkn.val = string(c)
+		default:
+			return tkn, fmt.Errorf("unexpected character <%c> at position %d", c, start)
+		}
+	}
+	return
+}
+
+// exponentLen returns the length of the exponent marker (e, e+ or e-) if followed by a digit, 0 otherwise
+func (lx *exprLexer) exponentLen() int {
+	if lx.pos >= len(lx.src) || lx.src[lx.pos] != 'e' && lx.src[lx.pos] != 'E' {
+		return 0
+	}
+	i := lx.pos + 1
+	if i < len(lx.src) && (lx.src[i] == '+' || lx.src[i] == '-') {
+		i++
+	}
+	if i >= len(lx.src) || !isExprDigit(lx.src[i]) {
+		return 0
+	}
+	return i - lx.pos
+}
+
+// scanField consumes the path of a field, eg: ~*req.Account, ~*req<File2.csv>.1 or ~*req.Fields[1]
+// the * is part of the path only at the beginning of a path item so ~*req.Cost*1.2 is a multiplication
+func (lx *exprLexer) scanField() (err error) {
+	for {
+		itmStart := lx.pos
+		if lx.pos < len(lx.src) && lx.src[lx.pos] == '*' {
+			lx.pos++
+		}
+		for lx.pos < len(lx.src) && (isExprLetter(lx.src[lx.pos]) || isExprDigit(lx.src[lx.pos])) {
+			lx.pos++
+		}
+		if lx.pos == itmStart || lx.pos == itmStart+1 && lx.src[itmStart] == '*' {
+			return fmt.Errorf("invalid field path at position %d", itmStart)
+		}
+		if lx.src[itmStart] == '*' && lx.pos < len(lx.src) && lx.src[lx.pos] == '<' { // file name for loaders
+			end := strings.IndexByte(lx.src[lx.pos:], '>')
+			if end == -1 || strings.ContainsAny(lx.src[lx.pos:lx.pos+end], " \t") {
+				return fmt.Errorf("invalid field path at position %d", itmStart)
+			}
+			lx.pos += end + 1
+		}
+		for lx.pos < len(lx.src) && lx.src[lx.pos] == '[' { // indexes
+			end := strings.IndexByte(lx.src[lx.pos:], ']')
+			if end == -1 {
+				return fmt.Errorf("unclosed index at position %d", lx.pos)
+			}
+			lx.pos += end + 1
+		}
+		if lx.pos+1 >= len(lx.src) || lx.src[lx.pos] != '.' ||
+			!(isExprLetter(lx.src[lx.pos+1]) || isExprDigit(lx.src[lx.pos+1]) || lx.src[lx.pos+1] == '*') {
+			return
+		}
+		lx.pos++ // the dot
+	}
+}
+
+// exprSplitPath splits the path of the field keeping together the file names, eg: *req<File2.csv>.1
+func exprSplitPath(path string) (spath []string) {
+	var inFile bool
+	var start int
+	for i := 0; i < len(path); i++ {
+		switch path[i] {
+		case '<':
+			inFile = true
+		case '>':
+			inFile = false
+		case '.':
+			if !inFile {
+				spath = append(spath, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(spath, path[start:])
+}
+
+func isExprDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isExprLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+// exprParser builds the tree of nodes out of tokens, using precedence climbing
+type exprParser struct {
+	lxr *exprLexer
+	tkn exprToken
+}
+
+func (prsr *exprParser) next() (err error) {
+	prsr.tkn, err = prsr.lxr.nextToken()
+	return
+}
+
+// exprBinaryPrec is the precedence of the binary operators, higher binds stronger
+var exprBinaryPrec = map[string]int{
+	"||": 1,
+	"&&": 2,
+	"==": 3, "!=": 3,
+	"<": 4, "<=": 4, ">": 4, ">=": 4,
+	"+": 5, "-": 5,
+	"*": 6, "/": 6, "%": 6,
+}
+
+func (prsr *exprParser) parseExpr(minPrec, depth int) (node exprNode, err error) {
+	if depth > exprMaxDepth {
+		return nil, fmt.Errorf("expression nested deeper than %d", exprMaxDepth)
+	}
+	if node, err = prsr.parseUnary(depth); err != nil {
+		return
+	}
+	for prsr.tkn.typ == exprTknOperator {
+		op := prsr.tkn.val
+		prec, isBinary := exprBinaryPrec[op]
+		if !isBinary || prec <= minPrec {
+			return
+		}
+		if err = prsr.next(); err != nil {
+			return
+		}
+		var right exprNode
+		if right, err = prsr.parseExpr(prec, depth+1); err != nil {
+			return
+		}
+		node = &exprBinary{op: op, left: node, right: right}
+	}
+	return
+}
+
+func (prsr *exprParser) parseUnary(depth int) (node exprNode, err error) {
+	if prsr.tkn.typ == exprTknOperator &&
+		(prsr.tkn.val == "!" || prsr.tkn.val == "-") {
+		op := prsr.tkn.val
+		if err = prsr.next(); err != nil {
+			return
+		}
+		var operand exprNode
+		if operand, err = prsr.parseUnary(depth + 1); err != nil {
+			return
+		}
+		return &exprUnary{op: op, operand: operand}, nil
+	}
+	return prsr.parsePrimary(depth)
+}
+
+func (prsr *exprParser) parsePrimary(depth int) (node exprNode, err error) {
+	if depth > exprMaxDepth {
+		return nil, fmt.Errorf("expression nested deeper than %d", exprMaxDepth)
+	}
+	tkn := prsr.tkn
+	switch tkn.typ {
+	case exprTknEOF:
+		return nil, fmt.Errorf("unexpected end of expression")
+	case exprTknNumber:
+		var f float64
+		if f, err = strconv.ParseFloat(tkn.val, 64); err != nil {
+			return nil, fmt.Errorf("invalid number <%s> at position %d", tkn.val, tkn.pos)
+		}
+		node = &exprConst{val: f}
+	case exprTknDuration:
+		var d time.Duration
+		if d, err = time.ParseDuration(tkn.val); err != nil {
+			return nil, fmt.Errorf("invalid duration <%s> at position %d", tkn.val, tkn.pos)
+		}
+		node = &exprConst{val: d}
+	case exprTknString:
+		node = &exprConst{val: tkn.val}
+	case exprTknField:
+		node = &exprField{path: exprSplitPath(tkn.val[1:])}
+	case exprTknIdent:
+		switch tkn.val {
+		case "true":
+			node = &exprConst{val: true}
+		case "false":
+			node = &exprConst{val: false}
+		case "nil":
+			node = &exprConst{}
+		default:
+			return prsr.parseCall(depth)
+		}
+	case exprTknOperator:
+		if tkn.val != "(" {
+			return nil, fmt.Errorf("unexpected <%s> at position %d", tkn.val, tkn.pos)
+		}
+		if err = prsr.next(); err != nil {
+			return
+		}
+		if node, err = prsr.parseExpr(0, depth+1); err != nil {
+			return
+		}
+		if prsr.tkn.typ != exprTknOperator || prsr.tkn.val != ")" {
+			return nil, fmt.Errorf("missing <)> at position %d", prsr.tkn.pos)
+		}
+	}
+	err = prsr.next()
+	return
+}
+
+// parseCall parses the function call, the current token is the function name
+func (prsr *exprParser) parseCall(depth int) (node exprNode, err error) {
+	name, pos := prsr.tkn.val, prsr.tkn.pos
+	fn, has := exprFuncs[name]
+	if !has {
+		return nil, fmt.Errorf("unknown function <%s> at position %d", name, pos)
+	}
+	if err = prsr.next(); err != nil {
+		return
+	}
+	if prsr.tkn.typ != exprTknOperator || prsr.tkn.val != "(" {
+		return nil, fmt.Errorf("missing <(> after <%s> at position %d", name, pos)
+	}
+	if err = prsr.next(); err != nil {
+		return
+	}
+	call := &exprCall{name: name, fn: fn}
+	for !(prsr.tkn.typ == exprTknOperator && prsr.tkn.val == ")") {
+		if len(call.args) != 0 {
+			if prsr.tkn.typ != exprTknOperator || prsr.tkn.val != "," {
+				return nil, fmt.Errorf("missing <,> at position %d", prsr.tkn.pos)
+			}
+			if err = prsr.next(); err != nil {
+				return
+			}
+		}
+		var arg exprNode
+		if arg, err = prsr.parseExpr(0, depth+1); err != nil {
+			return
+		}
+		call.args = append(call.args, arg)
+	}
+	if len(call.args) < fn.minArgs ||
+		fn.maxArgs != -1 && len(call.args) > fn.maxArgs {
+		return nil, fmt.Errorf("wrong number of arguments for <%s> at position %d", name, pos)
+	}
+	if name == "match" || name == "regexReplace" { // compile the constant regexps once
+		if cnst, isConst := call.args[1].(*exprConst); isConst {
+			var re *regexp.Regexp
+			if re, err = regexp.Compile(IfaceAsString(cnst.val)); err != nil {
+				return nil, fmt.Errorf("invalid regexp for <%s> at position %d: %s", name, pos, err.Error())
+			}
+			call.args[1] = &exprConst{val: re}
+		}
+	}
+	err = prsr.next()
+	return call, err
+}
+
+// exprNode is one node of the compiled expression
+type exprNode interface {
+	eval(dP DataProvider) (interface{}, error)
+}
+
+type exprConst struct {
+	val interface{}
+}
+
+func (ec *exprConst) eval(DataProvider) (interface{}, error) {
+	return ec.val, nil
+}
+
+// exprField reads the field out of DataProvider, missing fields are nil
+type exprField struct {
+	path []string
+}
+
+func (ef *exprField) eval(dP DataProvider) (val interface{}, err error) {
+	if val, err = dP.FieldAsInterface(ef.path); err != nil {
+		if err == ErrNotFound {
+			return nil, nil
+		}
+		return
+	}
+	return exprNormalize(val), nil
+}
+
+type exprUnary struct {
+	op      string
+	operand exprNode
+}
+
+func (eu *exprUnary) eval(dP DataProvider) (val interface{}, err error) {
+	if val, err = eu.operand.eval(dP); err != nil {
+		return
+	}
+	if eu.op == "!" {
+		var b bool
+		if b, err = exprAsBool(val); err != nil {
+			return
+		}
+		return !b, nil
+	}
+	switch v := val.(type) {
+	case time.Duration:
+		return -v, nil
+	default:
+		var f float64
+		if f, err = exprAsFloat(val); err != nil {
+			return
+		}
+		return -f, nil
+	}
+}
+
+type exprBinary struct {
+	op          string
+	left, right exprNode
+}
+
+func (eb *exprBinary) eval(dP DataProvider) (val interface{}, err error) {
+	var left, right interface{}
+	if left, err = eb.left.eval(dP); err != nil {
+		return
+	}
+	switch eb.op { // short circuit for the logical operators
+	case "&&", "||":
+		var lb, rb bool
+		if lb, err = exprAsBool(left); err != nil {
+			return
+		}
+		if lb == (eb.op == "||") {
+			return lb, nil
+		}
+		if right, err = eb.right.eval(dP); err != nil {
+			return
+		}
+		if rb, err = exprAsBool(right); err != nil {
+			return
+		}
+		return rb, nil
+	}
+	if right, err = eb.right.eval(dP); err != nil {
+		return
+	}
+	switch eb.op {
+	case "==", "!=", "<", "<=", ">", ">=":
+		return exprCompare(eb.op, left, right)
+	}
+	return exprArithmetic(eb.op, left, right)
+}
+
+type exprCall struct {
+	name string
+	fn   *exprFunc
+	args []exprNode
+}
+
+func (ec *exprCall) eval(dP DataProvider) (val interface{}, err error) {
+	if ec.name == "if" { // evaluate only the branch needed
+		var cond interface{}
+		if cond, err = ec.args[0].eval(dP); err != nil {
+			return
+		}
+		var b bool
+		if b, err = exprAsBool(cond); err != nil {
+			return
+		}
+		if b {
+			return ec.args[1].eval(dP)
+		}
+		return ec.args[2].eval(dP)
+	}
+	args := make([]interface{}, len(ec.args))
+	for i, arg := range ec.args {
+		if args[i], err = arg.eval(dP); err != nil {
+			return
+		}
+	}
+	if val, err = ec.fn.call(args); err != nil {
+		return nil, fmt.Errorf("%s: %s", ec.name, err.Error())
+	}
+	return
+}
+
+// exprNormalize brings the values of the fields to the types known by the expression
+func exprNormalize(val interface{}) interface{} {
+	switch v := val.(type) {
+	case nil, bool, float64, string, time.Duration, time.Time:
+		return v
+	case int:
+		return float64(v)
+	case int32:
+		return float64(v)
+	case int64:
+		return float64(v)
+	case uint64:
+		return float64(v)
+	case float32:
+		return float64(v)
+	default:
+		return IfaceAsString(v)
+	}
+}
+
+func exprAsFloat(val interface{}) (f float64, err error) {
+	switch v := val.(type) {
+	case nil:
+		return
+	case bool:
+		if v {
+			f = 1
+		}
+		return
+	case string:
+		if f, err = strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil {
+			return 0, fmt.Errorf("cannot convert <%s> to number", v)
+		}
+		return
+	case time.Time:
+		return 0, fmt.Errorf("cannot convert time <%s> to number", v)
+	}
+	return IfaceAsFloat64(val)
+}
+
+func exprAsBool(val interface{}) (b bool, err error) {
+	switch v := val.(type) {
+	case nil:
+		return
+	case bool:
+		return v, nil
+	case string:
+		if v == EmptyString {
+			return
+		}
+		if b, err = strconv.ParseBool(v); err != nil {
+			return false, fmt.Errorf("cannot convert <%s> to bool", v)
+		}
+		return
+	case float64:
+		return v != 0, nil
+	case time.Duration:
+		return v != 0, nil
+	}
+	return false, fmt.Errorf("cannot convert <%v> to bool", val)
+}
+
+func exprAsDuration(val interface{}) (d time.Duration, err error) {
+	switch v := val.(type) {
+	case nil:
+		return
+	case string:
+		return ParseDurationWithNanosecs(strings.TrimSpace(v))
+	case time.Time:
+		return 0, fmt.Errorf("cannot convert time <%s> to duration", v)
+	}
+	return IfaceAsDuration(val)
+}
+
+func exprAsTime(val interface{}) (t time.Time, err error) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, nil
+	case string:
+		return ParseTimeDetectLayout(v, EmptyString)
+	case float64: // unix timestamp
+		return time.Unix(int64(v), 0), nil
+	}
+	return t, fmt.Errorf("cannot convert <%v> to time", val)
+}
+
+// exprIsNumber checks if the value can be used as number, the numeric strings included
+func exprIsNumber(val interface{}) bool {
+	switch v := val.(type) {
+	case float64:
+		return true
+	case string:
+		_, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return err == nil
+	}
+	return false
+}
+
+// exprPromote converts the strings holding durations(ie: 2m) into durations
+func exprPromote(val interface{}) interface{} {
+	if str, isStr := val.(string); isStr && !exprIsNumber(str) {
+		if d, err := time.ParseDuration(strings.TrimSpace(str)); err == nil {
+			return d
+		}
+	}
+	return val
+}
+
+// exprIsText checks if the value is a string not holding a number or a duration
+func exprIsText(val interface{}) bool {
+	_, isStr := val.(string)
+	return isStr && !exprIsNumber(val)
+}
+
+func exprCompare(op string, left, right interface{}) (b bool, err error) {
+	var cmp int
+	left, right = exprPromote(left), exprPromote(right)
+	_, lIsTime := left.(time.Time)
+	_, rIsTime := right.(time.Time)
+	_, lIsDur := left.(time.Duration)
+	_, rIsDur := right.(time.Duration)
+	_, lIsBool := left.(bool)
+	_, rIsBool := right.(bool)
+	switch {
+	case lIsTime || rIsTime:
+		var lt, rt time.Time
+		if lt, err = exprAsTime(left); err != nil {
+			return
+		}
+		if rt, err = exprAsTime(right); err != nil {
+			return
+		}
+		switch {
+		case lt.Before(rt):
+			cmp = -1
+		case lt.After(rt):
+			cmp = 1
+		}
+	case lIsDur || rIsDur:
+		var ld, rd time.Duration
+		if ld, err = exprAsDuration(left); err != nil {
+			return
+		}
+		if rd, err = exprAsDuration(right); err != nil {
+			return
+		}
+		switch {
+		case ld < rd:
+			cmp = -1
+		case ld > rd:
+			cmp = 1
+		}
+	case lIsBool || rIsBool:
+		if op != "==" && op != "!=" {
+			return false, fmt.Errorf("operator <%s> not supported for bool", op)
+		}
+		var lb, rb bool
+		if lb, err = exprAsBool(left); err != nil {
+			return
+		}
+		if rb, err = exprAsBool(right); err != nil {
+			return
+		}
+		if lb != rb {
+			cmp = 1
+		}
+	case exprIsNumber(left) && exprIsNumber(right):
+		lf, _ := exprAsFloat(left)
+		rf, _ := exprAsFloat(right)
+		switch {
+		case lf < rf:
+			cmp = -1
+		case lf > rf:
+			cmp = 1
+		}
+	default:
+		cmp = strings.Compare(IfaceAsString(left), IfaceAsString(right))
+	}
+	switch op {
+	case "==":
+		return cmp == 0, nil
+	case "!=":
+		return cmp != 0, nil
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+func exprArithmetic(op string, left, right interface{}) (val interface{}, err error) {
+	if op == "+" && (exprIsText(exprPromote(left)) || exprIsText(exprPromote(right))) {
+		return IfaceAsString(left) + IfaceAsString(right), nil
+	}
+	left, right = exprPromote(left), exprPromote(right)
+	lt, lIsTime := left.(time.Time)
+	_, rIsTime := right.(time.Time)
+	_, lIsDur := left.(time.Duration)
+	_, rIsDur := right.(time.Duration)
+	switch {
+	case lIsTime && rIsTime && op == "-":
+		return lt.Sub(right.(time.Time)), nil
+	case lIsTime && (op == "+" || op == "-"):
+		var d time.Duration
+		if d, err = exprAsDuration(right); err != nil {
+			return
+		}
+		if op == "-" {
+			d = -d
+		}
+		return lt.Add(d), nil
+	case lIsTime || rIsTime:
+		return nil, fmt.Errorf("operator <%s> not supported for time", op)
+	case lIsDur || rIsDur:
+		return exprDurationArithmetic(op, left, right, lIsDur, rIsDur)
+	}
+	var lf, rf float64
+	if lf, err = exprAsFloat(left); err != nil {
+		return
+	}
+	if rf, err = exprAsFloat(right); err != nil {
+		return
+	}
+	switch op {
+	case "+":
+		return lf + rf, nil
+	case "-":
+		return lf - rf, nil
+	case "*":
+		return lf * rf, nil
+	}
+	if rf == 0 {
+		return nil, fmt.Errorf("division by zero")
+	}
+	if op == "/" {
+		return lf / rf, nil
+	}
+	return math.Mod(lf, rf), nil
+}
+
+// exprDurationArithmetic handles the operations with at least one duration
+// durations are added to durations and multiplied or divided by numbers
+func exprDurationArithmetic(op string, left, right interface{}, lIsDur, rIsDur bool) (val interface{}, err error) {
+	switch op {
+	case "+", "-":
+		var ld, rd time.Duration
+		if ld, err = exprAsDuration(left); err != nil {
+			return
+		}
+		if rd, err = exprAsDuration(right); err != nil {
+			return
+		}
+		if op == "-" {
+			return ld - rd, nil
+		}
+		return ld + rd, nil
+	case "*":
+		if lIsDur && rIsDur {
+			return nil, fmt.Errorf("cannot multiply durations")
+		}
+		d, n := left, right
+		if rIsDur {
+			d, n = right, left
+		}
+		var f float64
+		if f, err = exprAsFloat(n); err != nil {
+			return
+		}
+		return time.Duration(float64(d.(time.Duration)) * f), nil
+	case "/":
+		if !lIsDur {
+			return nil, fmt.Errorf("cannot divide by duration")
+		}
+		if rIsDur { // ratio of durations
+			if right.(time.Duration) == 0 {
+				return nil, fmt.Errorf("division by zero")
+			}
+			return float64(left.(time.Duration)) / float64(right.(time.Duration)), nil
+		}
+		var f float64
+		if f, err = exprAsFloat(right); err != nil {
+			return
+		}
+		if f == 0 {
+			return nil, fmt.Errorf("division by zero")
+		}
+		return time.Duration(float64(left.(time.Duration)) / f), nil
+	}
+	return nil, fmt.Errorf("operator <%s> not supported for duration", op)
+}
+
+// exprFunc is a function available to expressions
+type exprFunc struct {
+	minArgs int
+	maxArgs int // -1 for unlimited
+	call    func(args []interface{}) (interface{}, error)
+}
+
+// exprFuncs are the only functions an expression can call
+var exprFuncs map[string]*exprFunc
+
+func init() {
+	exprFuncs = map[string]*exprFunc{
+		"if": {minArgs: 3, maxArgs: 3}, // evaluated lazily by exprCall
+		// math
+		"abs":   exprFloatFunc(math.Abs),
+		"ceil":  exprFloatFunc(math.Ceil),
+		"floor": exprFloatFunc(math.Floor),
+		"sqrt":  exprFloatFunc(math.Sqrt),
+		"round": {minArgs: 1, maxArgs: 3, call: exprRound},
+		"pow": {minArgs: 2, maxArgs: 2, call: func(args []interface{}) (interface{}, error) {
+			x, err := exprAsFloat(args[0])
+			if err != nil {
+				return nil, err
+			}
+			y, err := exprAsFloat(args[1])
+			if err != nil {
+				return nil, err
+			}
+			return math.Pow(x, y), nil
+		}},
+		"min": {minArgs: 1, maxArgs: -1, call: func(args []interface{}) (interface{}, error) {
+			return exprMinMax(args, "<")
+		}},
+		"max": {minArgs: 1, maxArgs: -1, call: func(args []interface{}) (interface{}, error) {
+			return exprMinMax(args, ">")
+		}},
+		"number": {minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+			return exprAsFloat(args[0])
+		}},
+		// strings
+		"string": {minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+			return IfaceAsString(args[0]), nil
+		}},
+		"len": {minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+			return float64(len([]rune(IfaceAsString(args[0])))), nil
+		}},
+		"lower": exprStringFunc(strings.ToLower),
+		"upper": exprStringFunc(strings.ToUpper),
+		"trim":  exprStringFunc(strings.TrimSpace),
+		"concat": {minArgs: 1, maxArgs: -1, call: func(args []interface{}) (interface{}, error) {
+			var sb strings.Builder
+			for _, arg := range args {
+				sb.WriteString(IfaceAsString(arg))
+			}
+			return sb.String(), nil
+		}},
+		"substr":    {minArgs: 2, maxArgs: 3, call: exprSubstr},
+		"contains":  exprStringPredicate(strings.Contains),
+		"hasPrefix": exprStringPredicate(strings.HasPrefix),
+		"hasSuffix": exprStringPredicate(strings.HasSuffix),
+		"replace": {minArgs: 3, maxArgs: 3, call: func(args []interface{}) (interface{}, error) {
+			return strings.Replace(IfaceAsString(args[0]),
+				IfaceAsString(args[1]), IfaceAsString(args[2]), -1), nil
+		}},
+		// regexps
+		"match": {minArgs: 2, maxArgs: 2, call: func(args []interface{}) (interface{}, error) {
+			re, err := exprAsRegexp(args[1])
+			if err != nil {
+				return nil, err
+			}
+			return re.MatchString(IfaceAsString(args[0])), nil
+		}},
+		"regexReplace": {minArgs: 3, maxArgs: 3, call: func(args []interface{}) (interface{}, error) {
+			re, err := exprAsRegexp(args[1])
+			if err != nil {
+				return nil, err
+			}
+			return re.ReplaceAllString(IfaceAsString(args[0]), IfaceAsString(args[2])), nil
+		}},
+		// time
+		"now": {maxArgs: 0, call: func([]interface{}) (interface{}, error) {
+			return time.Now(), nil
+		}},
+		"time": {minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+			return exprAsTime(args[0])
+		}},
+		"duration": {minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+			return exprAsDuration(args[0])
+		}},
+		"seconds": {minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+			d, err := exprAsDuration(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return d.Seconds(), nil
+		}},
+		"unix": {minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+			t, err := exprAsTime(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return float64(t.Unix()), nil
+		}},
+		"format": {minArgs: 2, maxArgs: 2, call: func(args []interface{}) (interface{}, error) {
+			t, err := exprAsTime(args[0])
+			if err != nil {
+				return nil, err
+			}
+			return t.Format(IfaceAsString(args[1])), nil
+		}},
+		"hour": exprTimeFunc(func(t time.Time) float64 { return float64(t.Hour()) }),
+		"weekday": exprTimeFunc(func(t time.Time) float64 {
+			return float64(t.Weekday())
+		}),
+	}
+}
+
+func exprFloatFunc(f func(float64) float64) *exprFunc {
+	return &exprFunc{minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+		x, err := exprAsFloat(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return f(x), nil
+	}}
+}
+
+func exprStringFunc(f func(string) string) *exprFunc {
+	return &exprFunc{minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+		return f(IfaceAsString(args[0])), nil
+	}}
+}
+
+func exprStringPredicate(f func(string, string) bool) *exprFunc {
+	return &exprFunc{minArgs: 2, maxArgs: 2, call: func(args []interface{}) (interface{}, error) {
+		return f(IfaceAsString(args[0]), IfaceAsString(args[1])), nil
+	}}
+}
+
+func exprTimeFunc(f func(time.Time) float64) *exprFunc {
+	return &exprFunc{minArgs: 1, maxArgs: 1, call: func(args []interface{}) (interface{}, error) {
+		t, err := exprAsTime(args[0])
+		if err != nil {
+			return nil, err
+		}
+		return f(t), nil
+	}}
+}
+
+// exprRound rounds the number, round(x[, decimals[, method]]) with method as *up, *down or *middle
+func exprRound(args []interface{}) (interface{}, error) {
+	x, err := exprAsFloat(args[0])
+	if err != nil {
+		return nil, err
+	}
+	var decimals float64
+	if len(args) > 1 {
+		if decimals, err = exprAsFloat(args[1]); err != nil {
+			return nil, err
+		}
+	}
+	method := ROUNDING_MIDDLE
+	if len(args) > 2 {
+		method = IfaceAsString(args[2])
+	}
+	return Round(x, int(decimals), method), nil
+}
+
+func exprMinMax(args []interface{}, op string) (val interface{}, err error) {
+	val = args[0]
+	for _, arg := range args[1:] {
+		var better bool
+		if better, err = exprCompare(op, arg, val); err != nil {
+			return
+		}
+		if better {
+			val = arg
+		}
+	}
+	return
+}
+
+// exprSubstr returns the characters of the string, substr(s, start[, length])
+func exprSubstr(args []interface{}) (interface{}, error) {
+	runes := []rune(IfaceAsString(args[0]))
+	start, err := exprAsFloat(args[1])
+	if err != nil {
+		return nil, err
+	}
+	from := int(start)
+	if from < 0 {
+		from = 0
+	}
+	if from > len(runes) {
+		from = len(runes)
+	}
+	to := len(runes)
+	if len(args) > 2 {
+		var length float64
+		if length, err = exprAsFloat(args[2]); err != nil {
+			return nil, err
+		}
+		if int(length) >= 0 && from+int(length) < to {
+			to = from + int(length)
+		}
+	}
+	return string(runes[from:to]), nil
+}
+
+func exprAsRegexp(val interface{}) (*regexp.Regexp, error) {
+	if re, isRegexp := val.(*regexp.Regexp); isRegexp {
+		return re, nil
+	}
+	return regexp.Compile(IfaceAsString(val))
+}
@@ -0,0 +1,174 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package utils
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestExpressionEvaluate(t *testing.T) {
+	dP := MapStorage{
+		MetaReq: MapStorage{
+			Account:    "1001",
+			Usage:      "2m",
+			Cost:       10,
+			AnswerTime: time.Date(2020, 4, 18, 14, 30, 0, 0, time.UTC),
+			"Fields":   []string{"a", "b"},
+		},
+		"*req<File2.csv>": MapStorage{"1": "secondFile"},
+	}
+	tests := []struct {
+		rule string
+		exp  interface{}
+	}{
+		{"if(~*req.Usage > 60s, ~*req.Cost*1.2, ~*req.Cost)", 12.0},
+		{"if(~*req.Usage > 5m, ~*req.Cost*1.2, ~*req.Cost)", 10.0},
+		{"1 + 2 * 3 - 4 / 2", 5.0},
+		{"(1 + 2) * 3 % 4", 1.0},
+		{"1e10 / 1E+9 + 2.5e-1 * 4", 11.0},
+		{"~*req.Usage + 1m30s", 210 * time.Second},
+		{"-~*req.Cost + 1", -9.0},
+		{"!(~*req.Cost > 5) || false", false},
+		{"~*req.Missing == nil && true", true},
+		{"~*req.Account + '_' + \"x\"", "1001_x"},
+		{"~*req.Account == 1001", true},
+		{"~*req.Usage + 30s", 150 * time.Second},
+		{"~*req.Usage * 2", 4 * time.Minute},
+		{"~*req.Usage / 30s", 4.0},
+		{"~*req.AnswerTime + 1h > time('2020-04-18T15:00:00Z')", true},
+		{"hour(~*req.AnswerTime)", 14.0},
+		{"weekday(~*req.AnswerTime)", 6.0},
+		{"unix(~*req.AnswerTime)", 1587220200.0},
+		{"format(~*req.AnswerTime, '2006-01-02')", "2020-04-18"},
+		{"seconds(~*req.Usage)", 120.0},
+		{"round(10 / 3, 2)", 3.33},
+		{"round(2.5)", 3.0},
+		{"abs(-2) + ceil(1.2) + floor(1.8) + sqrt(16) + pow(2, 3)", 17.0},
+		{"max(1, ~*req.Cost, 3) + min(4, 2)", 12.0},
+		{"upper(lower('AbC')) + trim('  d ')", "ABCd"},
+		{"len(~*req.Account) + number('1.5')", 5.5},
+		{"substr(~*req.Account, 1, 2)", "00"},
+		{"concat(~*req.Account, '@', 'cgrates.org')", "1001@cgrates.org"},
+		{"contains('cgrates', 'rat') && hasPrefix('cgrates', 'cg') && hasSuffix('cgrates', 'es')", true},
+		{"replace('a.b.c', '.', '/')", "a/b/c"},
+		{"match(~*req.Account, '^10')", true},
+		{"regexReplace(~*req.Account, '0+', '-')", "1-1"},
+		{"string(~*req.Cost) + 'a'", "10a"},
+		{"~*req.Account + ~*req.Cost", 1011.0},
+		{"duration('1m') > 30s", true},
+		{"~*req<File2.csv>.1", "secondFile"},
+		{"~*req.Fields[1]", "b"},
+	}
+	for _, tst := range tests {
+		expr, err := NewExpression(tst.rule)
+		if err != nil {
+			t.Errorf("compiling <%s>: %v", tst.rule, err)
+			continue
+		}
+		if rcv, err := expr.Evaluate(dP); err != nil {
+			t.Errorf("evaluating <%s>: %v", tst.rule, err)
+		} else if !reflect.DeepEqual(tst.exp, rcv) {
+			t.Errorf("evaluating <%s> expected: %v(%T) received: %v(%T)",
+				tst.rule, tst.exp, tst.exp, rcv, rcv)
+		}
+	}
+}
+
+func TestExpressionLazyEvaluation(t *testing.T) {
+	expr, err := NewExpression("if(~*req.Cost == nil, 0, 10 / ~*req.Cost) + (false && 1 / 0) + (true || 1 / 0)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rcv, err := expr.Evaluate(MapStorage{}); err != nil {
+		t.Error(err)
+	} else if rcv != 1.0 { // bools are added as 0 and 1
+		t.Errorf("received: %v", rcv)
+	}
+}
+
+func TestExpressionErrors(t *testing.T) {
+	for _, rule := range []string{
+		"",
+		"1 +",
+		"(1 + 2",
+		"unknown(1)",
+		"if(1, 2)",
+		"abs(1, 2)",
+		"match('a', '(')",
+		"'unterminated",
+		"1 $ 2",
+		"~",
+		"~*req.",
+		"1 2",
+		"upper",
+	} {
+		if _, err := NewExpression(rule); err == nil {
+			t.Errorf("expected error compiling <%s>", rule)
+		}
+	}
+	for _, rule := range []string{
+		"1 / 0",
+		"'a' - 1",
+		"30s * 30s",
+		"time('x')",
+		"true < false",
+	} {
+		expr, err := NewExpression(rule)
+		if err != nil {
+			t.Errorf("compiling <%s>: %v", rule, err)
+			continue
+		}
+		if _, err := expr.Evaluate(nil); err == nil {
+			t.Errorf("expected error evaluating <%s>", rule)
+		}
+	}
+}
+
+func TestExpressionMaxDepth(t *testing.T) {
+	rule := ""
+	for i := 0; i < exprMaxDepth+1; i++ {
+		rule += "("
+	}
+	rule += "1"
+	for i := 0; i < exprMaxDepth+1; i++ {
+		rule += ")"
+	}
+	if _, err := NewExpression(rule); err == nil {
+		t.Error("expected error for nesting")
+	}
+}
+
+func TestExprConverter(t *testing.T) {
+	dcs := DataConverters{NewDataConverterMustCompile("*expr:~*req.Cost * 2")}
+	dP := MapStorage{MetaReq: MapStorage{Cost: 1.5}}
+	if rcv, err := dcs.ConvertStringWithDataProvider("ignored", dP); err != nil {
+		t.Error(err)
+	} else if rcv != "3" {
+		t.Errorf("expecting: 3 received: %q", rcv)
+	}
+	if rcv, err := dcs.ConvertString("ignored"); err != nil { // without fields
+		t.Error(err)
+	} else if rcv != "0" {
+		t.Errorf("expecting: 0 received: %q", rcv)
+	}
+	if _, err := NewDataConverter("*expr:1 +"); err == nil {
+		t.Error("expecting error")
+	}
+}
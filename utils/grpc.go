@@ -0,0 +1,431 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cgrates/rpcclient"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/status"
+)
+
+// gRPC service and method names as defined in data/grpc/cgrates.proto
+const (
+	GRPCServiceName = "cgrates.RPC"
+	GRPCCallMethod  = "/" + GRPCServiceName + "/Call"
+)
+
+// GRPCRequest is the envelope of a RPC call over gRPC
+// Params holds the JSON encoded arguments of the method
+type GRPCRequest struct {
+	Method string
+	Params []byte
+	ID     uint64 // used to match the replies on the stream
+}
+
+// GRPCReply is the envelope of a RPC reply over gRPC
+// Result holds the JSON encoded reply of the method
+type GRPCReply struct {
+	Result []byte
+	Error  string
+	ID     uint64
+}
+
+// Reset implements proto.Message
+func (req *GRPCRequest) Reset() { *req = GRPCRequest{} }
+
+// String implements proto.Message
+func (req *GRPCRequest) String() string { return ToJSON(req) }
+
+// ProtoMessage implements proto.Message
+func (*GRPCRequest) ProtoMessage() {}
+
+// Marshal encodes the request in protobuf wire format
+func (req *GRPCRequest) Marshal() ([]byte, error) {
+	b := protoAppendBytes(nil, 1, []byte(req.Method))
+	b = protoAppendBytes(b, 2, req.Params)
+	return protoAppendVarint(b, 3, req.ID), nil
+}
+
+// Unmarshal decodes the request from protobuf wire format
+func (req *GRPCRequest) Unmarshal(b []byte) error {
+	return protoUnmarshal(b, func(fld int, val []byte, varint uint64) {
+		switch fld {
+		case 1:
+			req.Method = string(val)
+		case 2:
+			req.Params = append([]byte(nil), val...)
+		case 3:
+			req.ID = varint
+		}
+	})
+}
+
+// Reset implements proto.Message
+func (rply *GRPCReply) Reset() { *rply = GRPCReply{} }
+
+// String implements proto.Message
+func (rply *GRPCReply) String() string { return ToJSON(rply) }
+
+// ProtoMessage implements proto.Message
+func (*GRPCReply) ProtoMessage() {}
+
+// Marshal encodes the reply in protobuf wire format
+func (rply *GRPCReply) Marshal() ([]byte, error) {
+	b := protoAppendBytes(nil, 1, rply.Result)
+	b = protoAppendBytes(b, 2, []byte(rply.Error))
+	return protoAppendVarint(b, 3, rply.ID), nil
+}
+
+// Unmarshal decodes the reply from protobuf wire format
+func (rply *GRPCReply) Unmarshal(b []byte) error {
+	return protoUnmarshal(b, func(fld int, val []byte, varint uint64) {
+		switch fld {
+		case 1:
+			rply.Result = append([]byte(nil), val...)
+		case 2:
+			rply.Error = string(val)
+		case 3:
+			rply.ID = varint
+		}
+	})
+}
+
+// protobuf wire types used by the envelopes
+const (
+	protoWireVarint  = 0
+	protoWireFixed64 = 1
+	protoWireBytes   = 2
+	protoWireFixed32 = 5
+)
+
+func protoAppendVarint(b []byte, fld int, val uint64) []byte {
+	if val == 0 { // proto3 omits default values
+		return b
+	}
+	b = appendUvarint(b, uint64(fld<<3|protoWireVarint))
+	return appendUvarint(b, val)
+}
+
+func protoAppendBytes(b []byte, fld int, val []byte) []byte {
+	if len(val) == 0 {
+		return b
+	}
+	b = appendUvarint(b, uint64(fld<<3|protoWireBytes))
+	b = appendUvarint(b, uint64(len(val)))
+	return append(b, val...)
+}
+
+func appendUvarint(b []byte, val uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(b, buf[:binary.PutUvarint(buf[:], val)]...)
+}
+
+var errProtoMalformed = errors.New("malformed protobuf message")
+
+// protoUnmarshal iterates over the fields of a protobuf message, skipping the unknown wire types
+func protoUnmarshal(b []byte, setField func(fld int, val []byte, varint uint64)) error {
+	for len(b) != 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errProtoMalformed
+		}
+		b = b[n:]
+		fld := int(key >> 3)
+		switch key & 7 {
+		case protoWireVarint:
+			val, n := binary.Uvarint(b)
+			if n <= 0 {
+				return errProtoMalformed
+			}
+			b = b[n:]
+			setField(fld, nil, val)
+		case protoWireBytes:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return errProtoMalformed
+			}
+			setField(fld, b[n:n+int(l)], 0)
+			b = b[n+int(l):]
+		case protoWireFixed64:
+			if len(b) < 8 {
+				return errProtoMalformed
+			}
+			b = b[8:]
+		case protoWireFixed32:
+			if len(b) < 4 {
+				return errProtoMalformed
+			}
+			b = b[4:]
+		default:
+			return errProtoMalformed
+		}
+	}
+	return nil
+}
+
+// grpcRPCServer is the handler type of the gRPC service
+type grpcRPCServer interface {
+	call(ctx context.Context, req *GRPCRequest) *GRPCReply
+}
+
+// grpcRPCService dispatches the gRPC requests to the registered RPC methods
+type grpcRPCService struct {
+	srv *Server
+}
+
+// call executes the request, returning early if the deadline of the context is reached
+func (gs *grpcRPCService) call(ctx context.Context, req *GRPCRequest) (rply *GRPCReply) {
+	rplyChan := make(chan *GRPCReply, 1)
+	go func() {
+		rply := &GRPCReply{ID: req.ID}
+		result, err := gs.srv.callJSONRPC(req.Method, req.Params, NewGRPCRPCCaller(ctx))
+		if err != nil {
+			rply.Error = err.Error()
+		} else {
+			rply.Result = result
+		}
+		rplyChan <- rply
+	}()
+	select {
+	case rply = <-rplyChan:
+	case <-ctx.Done():
+		rply = &GRPCReply{ID: req.ID, Error: rpcclient.ErrReplyTimeout.Error()}
+	}
+	return
+}
+
+func grpcCallHandler(srv interface{}, ctx context.Context,
+	dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	req := new(GRPCRequest)
+	if err := dec(req); err != nil {
+		return nil, err
+	}
+	if ctx.Err() != nil {
+		return nil, status.Error(codes.DeadlineExceeded, ctx.Err().Error())
+	}
+	return srv.(grpcRPCServer).call(ctx, req), nil
+}
+
+// grpcStreamHandler serves the requests received on a bidirectional stream concurrently
+// the replies are sent back as soon as they are ready, matched by the ID of the request
+func grpcStreamHandler(srv interface{}, stream grpc.ServerStream) (err error) {
+	var sendMux sync.Mutex
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		req := new(GRPCRequest)
+		if err = stream.RecvMsg(req); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return
+		}
+		wg.Add(1)
+		go func(req *GRPCRequest) {
+			rply := srv.(grpcRPCServer).call(stream.Context(), req)
+			sendMux.Lock()
+			if err := stream.SendMsg(rply); err != nil {
+				Logger.Warning(fmt.Sprintf("<CGRServer> gRPC stream send error: <%s>", err.Error()))
+			}
+			sendMux.Unlock()
+			wg.Done()
+		}(req)
+	}
+}
+
+var grpcServiceDesc = grpc.ServiceDesc{
+	ServiceName: GRPCServiceName,
+	HandlerType: (*grpcRPCServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Call",
+			Handler:    grpcCallHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       grpcStreamHandler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "cgrates.proto",
+}
+
+func (s *Server) serveGRPC(lis net.Listener, opts ...grpc.ServerOption) error {
+	grpcSrv := grpc.NewServer(opts...)
+	grpcSrv.RegisterService(&grpcServiceDesc, &grpcRPCService{srv: s})
+	return grpcSrv.Serve(lis)
+}
+
+// ServeGRPC exposes the registered RPC methods over gRPC
+func (s *Server) ServeGRPC(addr string, exitChan chan bool) {
+	s.RLock()
+	enabled := s.rpcEnabled
+	s.RUnlock()
+	if !enabled {
+		return
+	}
+	lGRPC, err := net.Listen(TCP, addr)
+	if err != nil {
+		log.Println("ServeGRPC listen error:", err)
+		exitChan <- true
+		return
+	}
+	Logger.Info(fmt.Sprintf("Starting CGRateS gRPC server at <%s>.", addr))
+	if err = s.serveGRPC(lGRPC); err != nil {
+		log.Println(fmt.Sprintf("<CGRServer> gRPC serve error: %s", err))
+	}
+	exitChan <- true
+}
+
+// ServeGRPCTLS exposes the registered RPC methods over gRPC with TLS
+func (s *Server) ServeGRPCTLS(addr, serverCrt, serverKey, caCert string,
+	serverPolicy int, serverName string, exitChan chan bool) {
+	s.RLock()
+	enabled := s.rpcEnabled
+	s.RUnlock()
+	if !enabled {
+		return
+	}
+	config, err := loadTLSConfig(serverCrt, serverKey, caCert, serverPolicy, serverName)
+	if err != nil {
+		return
+	}
+	lGRPC, err := net.Listen(TCP, addr)
+	if err != nil {
+		log.Println(fmt.Sprintf("Error: %s when listening", err))
+		exitChan <- true
+		return
+	}
+	Logger.Info(fmt.Sprintf("Starting CGRateS gRPC TLS server at <%s>.", addr))
+	if err = s.serveGRPC(lGRPC, grpc.Creds(credentials.NewTLS(&config))); err != nil {
+		log.Println(fmt.Sprintf("<CGRServer> gRPC TLS serve error: %s", err))
+	}
+	exitChan <- true
+}
+
+// NewGRPCClient returns a rpcclient.ClientConnector calling the remote methods over gRPC
+func NewGRPCClient(addr string, useTLS bool, keyPath, certPath, caPath string,
+	connectTimeout, replyTimeout time.Duration, lazyConnect bool) (client *GRPCClient, err error) {
+	client = &GRPCClient{replyTimeout: replyTimeout}
+	dialOpts := []grpc.DialOption{grpc.WithInsecure()}
+	if useTLS {
+		var config *tls.Config
+		if config, err = loadClientTLSConfig(certPath, keyPath, caPath); err != nil {
+			return
+		}
+		dialOpts = []grpc.DialOption{grpc.WithTransportCredentials(credentials.NewTLS(config))}
+	}
+	if !lazyConnect {
+		ctx, cancel := context.WithTimeout(context.Background(), connectTimeout)
+		client.conn, err = grpc.DialContext(ctx, addr, append(dialOpts, grpc.WithBlock())...)
+		cancel()
+		if err == nil {
+			return
+		}
+	}
+	// not connected yet, the connection will keep retrying in the background
+	var errDial error
+	if client.conn, errDial = grpc.Dial(addr, dialOpts...); errDial != nil {
+		return nil, errDial
+	}
+	return
+}
+
+// GRPCClient is a rpcclient.ClientConnector over gRPC
+// the reconnects are handled by the gRPC connection itself
+type GRPCClient struct {
+	conn         *grpc.ClientConn
+	replyTimeout time.Duration
+}
+
+// Call implements rpcclient.ClientConnector
+func (client *GRPCClient) Call(serviceMethod string, args interface{}, reply interface{}) (err error) {
+	req := &GRPCRequest{Method: serviceMethod}
+	if req.Params, err = json.Marshal(args); err != nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), client.replyTimeout)
+	defer cancel()
+	rply := new(GRPCReply)
+	if err = client.conn.Invoke(ctx, GRPCCallMethod, req, rply); err != nil {
+		switch status.Code(err) {
+		case codes.DeadlineExceeded:
+			return rpcclient.ErrReplyTimeout
+		case codes.Unavailable:
+			return rpcclient.ErrDisconnected
+		}
+		return
+	}
+	if rply.Error != EmptyString {
+		return errors.New(rply.Error)
+	}
+	return json.Unmarshal(rply.Result, reply)
+}
+
+// Close closes the gRPC connection
+func (client *GRPCClient) Close() error {
+	return client.conn.Close()
+}
+
+// loadClientTLSConfig builds the TLS configuration used by the clients
+func loadClientTLSConfig(clientCrt, clientKey, caPath string) (config *tls.Config, err error) {
+	config = new(tls.Config)
+	if clientCrt != EmptyString && clientKey != EmptyString {
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(clientCrt, clientKey); err != nil {
+			return
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	if config.RootCAs, err = x509.SystemCertPool(); err != nil {
+		return
+	}
+	if config.RootCAs == nil {
+		config.RootCAs = x509.NewCertPool()
+	}
+	if caPath != EmptyString {
+		var ca []byte
+		if ca, err = ioutil.ReadFile(caPath); err != nil {
+			return
+		}
+		if !config.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("cannot append certificate authority from <%s>", caPath)
+		}
+	}
+	return
+}
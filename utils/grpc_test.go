@@ -0,0 +1,124 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"context"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestGRPCEnvelopeMarshal(t *testing.T) {
+	req := &GRPCRequest{
+		Method: "SessionSv1.AuthorizeEvent",
+		Params: []byte(`{"Tenant":"cgrates.org"}`),
+		ID:     300,
+	}
+	b, err := req.Marshal()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rcvReq := new(GRPCRequest)
+	if err = rcvReq.Unmarshal(b); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(req, rcvReq) {
+		t.Errorf("Expected %s, received %s", req, rcvReq)
+	}
+	rply := &GRPCReply{Error: "NOT_FOUND", ID: 300}
+	if b, err = rply.Marshal(); err != nil {
+		t.Fatal(err)
+	}
+	b = append(b, 0x25, 1, 2, 3, 4) // unknown fixed32 field 4
+	rcvRply := new(GRPCReply)
+	if err = rcvRply.Unmarshal(b); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(rply, rcvRply) {
+		t.Errorf("Expected %s, received %s", rply, rcvRply)
+	}
+	if err = rcvRply.Unmarshal([]byte{0x0a, 5, 1}); err != errProtoMalformed {
+		t.Errorf("Expected %v, received %v", errProtoMalformed, err)
+	}
+}
+
+type grpcTestService struct{}
+
+func (grpcTestService) Ping(args *TenantID, reply *string) error {
+	if args.ID == EmptyString {
+		return NewErrMandatoryIeMissing(ID)
+	}
+	*reply = ConcatenatedKey(args.Tenant, args.ID)
+	return nil
+}
+
+func TestGRPCClientCall(t *testing.T) {
+	ConReqs = NewConReqs(0, EmptyString)
+	srv := NewServer()
+	srv.RpcRegisterName("GRPCTestV1", grpcTestService{})
+	l, err := net.Listen(TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	go srv.serveGRPC(l)
+	clnt, err := NewGRPCClient(l.Addr().String(), false, EmptyString, EmptyString, EmptyString,
+		time.Second, time.Second, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clnt.Close()
+	var reply string
+	if err = clnt.Call("GRPCTestV1.Ping", &TenantID{Tenant: "cgrates.org", ID: "1001"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "cgrates.org:1001" {
+		t.Errorf("Expected %q, received %q", "cgrates.org:1001", reply)
+	}
+	expErr := "MANDATORY_IE_MISSING: [ID]"
+	if err = clnt.Call("GRPCTestV1.Ping", &TenantID{Tenant: "cgrates.org"}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received %v", expErr, err)
+	}
+
+	stream, err := clnt.conn.NewStream(context.Background(), &grpcServiceDesc.Streams[0], "/"+GRPCServiceName+"/Stream")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := uint64(1); i <= 2; i++ {
+		if err = stream.SendMsg(&GRPCRequest{Method: "GRPCTestV1.Ping",
+			Params: []byte(`{"Tenant":"cgrates.org","ID":"1001"}`), ID: i}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = stream.CloseSend(); err != nil {
+		t.Fatal(err)
+	}
+	rcvIDs := make(map[uint64]bool)
+	for i := 0; i < 2; i++ {
+		rply := new(GRPCReply)
+		if err = stream.RecvMsg(rply); err != nil {
+			t.Fatal(err)
+		}
+		if string(rply.Result) != `"cgrates.org:1001"` {
+			t.Errorf("Unexpected reply: %s", rply)
+		}
+		rcvIDs[rply.ID] = true
+	}
+	if !reflect.DeepEqual(map[uint64]bool{1: true, 2: true}, rcvIDs) {
+		t.Errorf("Unexpected reply IDs: %v", rcvIDs)
+	}
+}
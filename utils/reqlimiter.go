@@ -0,0 +1,309 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"container/heap"
+	"path"
+	"sync"
+	"time"
+)
+
+// ReqLimiters holds the state of the request limiters configured in request_limiters section
+var ReqLimiters = NewRequestLimiters()
+
+// RPCLimiter limits the RPC calls before their execution
+// the returned function, if not nil, releases the allocation once the call is answered
+type RPCLimiter interface {
+	LimitRPC(serviceMethod string, args interface{}) (release func(), err error)
+}
+
+// RequestLimiterProfile defines the limits applied to the matching requests
+type RequestLimiterProfile struct {
+	Methods            []string      // method patterns, eg: APIerSv1.*, empty for all
+	Tenants            []string      // limited tenants, empty or *any for all
+	PerTenant          bool          // each tenant gets its own limits
+	ConcurrentRequests int           // maximum active requests, 0 for unlimited
+	RateLimit          int           // maximum requests started within RateInterval, 0 for unlimited
+	RateInterval       time.Duration // interval of the RateLimit
+	Strategy           string        // <*busy|*queue> when the limits are reached
+	QueueTimeout       time.Duration // maximum wait in queue, 0 for unlimited
+}
+
+// MatchesMethod checks if the method matches one of the patterns of the limiter
+func (lp *RequestLimiterProfile) MatchesMethod(serviceMethod string) bool {
+	if len(lp.Methods) == 0 {
+		return true
+	}
+	for _, pattern := range lp.Methods {
+		if matched, err := path.Match(pattern, serviceMethod); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesTenant checks if the tenant is limited by the profile
+func (lp *RequestLimiterProfile) MatchesTenant(tnt string) bool {
+	if len(lp.Tenants) == 0 {
+		return true
+	}
+	for _, limited := range lp.Tenants {
+		if limited == META_ANY || limited == tnt {
+			return true
+		}
+	}
+	return false
+}
+
+// RequestLimiterMetrics are the counters of one request limiter
+type RequestLimiterMetrics struct {
+	Active   int    // requests under processing
+	Queued   int    // requests waiting for a free slot
+	Allowed  uint64 // requests allowed since start
+	Rejected uint64 // requests rejected since start, including the ones timed out in queue
+}
+
+// reqWaiter is a request queued in a limiter
+type reqWaiter struct {
+	priority int
+	seq      uint64 // preserves the arrival order within the same priority
+	granted  bool
+	ready    chan struct{}
+	index    int
+}
+
+// reqWaiters is a priority queue of the waiting requests, implementing heap.Interface
+type reqWaiters []*reqWaiter
+
+func (rw reqWaiters) Len() int { return len(rw) }
+
+func (rw reqWaiters) Less(i, j int) bool {
+	if rw[i].priority != rw[j].priority {
+		return rw[i].priority > rw[j].priority
+	}
+	return rw[i].seq < rw[j].seq
+}
+
+func (rw reqWaiters) Swap(i, j int) {
+	rw[i], rw[j] = rw[j], rw[i]
+	rw[i].index = i
+	rw[j].index = j
+}
+
+func (rw *reqWaiters) Push(x interface{}) {
+	w := x.(*reqWaiter)
+	w.index = len(*rw)
+	*rw = append(*rw, w)
+}
+
+func (rw *reqWaiters) Pop() interface{} {
+	old := *rw
+	w := old[len(old)-1]
+	old[len(old)-1] = nil
+	w.index = -1
+	*rw = old[:len(old)-1]
+	return w
+}
+
+// requestLimiter applies the limits of one profile
+type requestLimiter struct {
+	sync.Mutex
+	prf      *RequestLimiterProfile
+	tokens   float64 // requests which can still be started within the rate
+	lastFill time.Time
+	waiting  reqWaiters
+	seq      uint64
+	timer    *time.Timer // wakes up the queue when the rate allows new requests
+	metrics  RequestLimiterMetrics
+}
+
+func newRequestLimiter(prf *RequestLimiterProfile) *requestLimiter {
+	return &requestLimiter{
+		prf:      prf,
+		tokens:   float64(prf.RateLimit),
+		lastFill: time.Now(),
+	}
+}
+
+// refill adds the tokens gained since the last refill
+func (rl *requestLimiter) refill() {
+	if rl.prf.RateLimit <= 0 || rl.prf.RateInterval <= 0 {
+		return
+	}
+	now := time.Now()
+	rl.tokens += float64(now.Sub(rl.lastFill)) / float64(rl.prf.RateInterval) * float64(rl.prf.RateLimit)
+	if rl.tokens > float64(rl.prf.RateLimit) {
+		rl.tokens = float64(rl.prf.RateLimit)
+	}
+	rl.lastFill = now
+}
+
+// canStart checks if a new request fits in the limits
+func (rl *requestLimiter) canStart() bool {
+	return (rl.prf.ConcurrentRequests <= 0 || rl.metrics.Active < rl.prf.ConcurrentRequests) &&
+		(rl.prf.RateLimit <= 0 || rl.tokens >= 1)
+}
+
+func (rl *requestLimiter) start() {
+	rl.metrics.Active++
+	rl.metrics.Allowed++
+	if rl.prf.RateLimit > 0 {
+		rl.tokens--
+	}
+}
+
+// dispatch starts the waiting requests, highest priority first
+// if only the rate blocks the queue, it is checked again when the next token is available
+func (rl *requestLimiter) dispatch() {
+	rl.refill()
+	for len(rl.waiting) != 0 && rl.canStart() {
+		w := heap.Pop(&rl.waiting).(*reqWaiter)
+		rl.metrics.Queued--
+		rl.start()
+		w.granted = true
+		close(w.ready)
+	}
+	if len(rl.waiting) == 0 || rl.timer != nil ||
+		rl.prf.RateLimit <= 0 || rl.tokens >= 1 { // waiting for a slot to be released
+		return
+	}
+	wait := time.Duration((1 - rl.tokens) / float64(rl.prf.RateLimit) * float64(rl.prf.RateInterval))
+	rl.timer = time.AfterFunc(wait, func() {
+		rl.Lock()
+		rl.timer = nil
+		rl.dispatch()
+		rl.Unlock()
+	})
+}
+
+// allocate reserves a slot for the request, waiting for it when the strategy is *queue
+func (rl *requestLimiter) allocate(priority int) (err error) {
+	rl.Lock()
+	rl.refill()
+	if len(rl.waiting) == 0 && rl.canStart() {
+		rl.start()
+		rl.Unlock()
+		return
+	}
+	if rl.prf.Strategy != MetaQueue {
+		rl.metrics.Rejected++
+		rl.Unlock()
+		return ErrMaxRequestsExceeded
+	}
+	rl.seq++
+	w := &reqWaiter{priority: priority, seq: rl.seq, ready: make(chan struct{})}
+	heap.Push(&rl.waiting, w)
+	rl.metrics.Queued++
+	rl.dispatch()
+	rl.Unlock()
+	if rl.prf.QueueTimeout <= 0 {
+		<-w.ready
+		return
+	}
+	tmr := time.NewTimer(rl.prf.QueueTimeout)
+	defer tmr.Stop()
+	select {
+	case <-w.ready:
+		return
+	case <-tmr.C:
+	}
+	rl.Lock()
+	defer rl.Unlock()
+	if w.granted { // started in the meantime
+		return
+	}
+	heap.Remove(&rl.waiting, w.index)
+	rl.metrics.Queued--
+	rl.metrics.Rejected++
+	return ErrMaxRequestsExceeded
+}
+
+// release frees the slot of a finished request
+func (rl *requestLimiter) release() {
+	rl.Lock()
+	rl.metrics.Active--
+	rl.dispatch()
+	rl.Unlock()
+}
+
+// NewRequestLimiters returns an empty set of request limiters
+func NewRequestLimiters() *RequestLimiters {
+	return &RequestLimiters{limiters: make(map[string]*requestLimiter)}
+}
+
+// RequestLimiters holds the request limiters, created on first use
+type RequestLimiters struct {
+	sync.RWMutex
+	limiters map[string]*requestLimiter
+}
+
+// limiter returns the limiter for the key, recreating it if the profile has changed
+func (rls *RequestLimiters) limiter(key string, prf *RequestLimiterProfile) (rl *requestLimiter) {
+	rls.RLock()
+	rl, has := rls.limiters[key]
+	rls.RUnlock()
+	if has && rl.prf == prf {
+		return
+	}
+	rls.Lock()
+	defer rls.Unlock()
+	if rl, has = rls.limiters[key]; has && rl.prf == prf {
+		return
+	}
+	rl = newRequestLimiter(prf) // the requests allocated on the old limiter will release on it
+	rls.limiters[key] = rl
+	return
+}
+
+// Allocate reserves a slot in the limiter identified by key
+// the returned function needs to be called once the request is finished
+func (rls *RequestLimiters) Allocate(key string, prf *RequestLimiterProfile,
+	priority int) (release func(), err error) {
+	rl := rls.limiter(key, prf)
+	if err = rl.allocate(priority); err != nil {
+		return
+	}
+	return rl.release, nil
+}
+
+// Prune removes the limiters not matching any of the keys
+func (rls *RequestLimiters) Prune(keep func(key string) bool) {
+	rls.Lock()
+	for key := range rls.limiters {
+		if !keep(key) {
+			delete(rls.limiters, key)
+		}
+	}
+	rls.Unlock()
+}
+
+// Metrics returns a snapshot of the counters of each limiter
+func (rls *RequestLimiters) Metrics() (mtrcs map[string]*RequestLimiterMetrics) {
+	rls.RLock()
+	defer rls.RUnlock()
+	mtrcs = make(map[string]*RequestLimiterMetrics, len(rls.limiters))
+	for key, rl := range rls.limiters {
+		rl.Lock()
+		m := rl.metrics
+		rl.Unlock()
+		mtrcs[key] = &m
+	}
+	return
+}
@@ -0,0 +1,139 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRequestLimiterBusy(t *testing.T) {
+	rls := NewRequestLimiters()
+	prf := &RequestLimiterProfile{ConcurrentRequests: 1, Strategy: MetaBusy}
+	release, err := rls.Allocate("lmt1", prf, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rls.Allocate("lmt1", prf, 0); err != ErrMaxRequestsExceeded {
+		t.Errorf("Expected %v, received: %v", ErrMaxRequestsExceeded, err)
+	}
+	release()
+	if release, err = rls.Allocate("lmt1", prf, 0); err != nil {
+		t.Error(err)
+	} else {
+		release()
+	}
+	exp := &RequestLimiterMetrics{Allowed: 2, Rejected: 1}
+	if rcv := rls.Metrics()["lmt1"]; *rcv != *exp {
+		t.Errorf("Expected %+v, received: %+v", exp, rcv)
+	}
+}
+
+func TestRequestLimiterQueuePriority(t *testing.T) {
+	rls := NewRequestLimiters()
+	prf := &RequestLimiterProfile{ConcurrentRequests: 1, Strategy: MetaQueue}
+	release, err := rls.Allocate("lmt1", prf, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	order := make(chan int, 3)
+	for i, prio := range []int{0, 10, 5} {
+		go func(prio int) {
+			rls, err := rls.Allocate("lmt1", prf, prio)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			order <- prio
+			rls()
+		}(prio)
+		for rls.Metrics()["lmt1"].Queued != i+1 { // keep the arrival order
+			time.Sleep(time.Millisecond)
+		}
+	}
+	release()
+	for _, exp := range []int{10, 5, 0} {
+		if rcv := <-order; rcv != exp {
+			t.Errorf("Expected priority %d, received: %d", exp, rcv)
+		}
+	}
+}
+
+func TestRequestLimiterQueueTimeout(t *testing.T) {
+	rls := NewRequestLimiters()
+	prf := &RequestLimiterProfile{ConcurrentRequests: 1, Strategy: MetaQueue,
+		QueueTimeout: 10 * time.Millisecond}
+	release, err := rls.Allocate("lmt1", prf, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer release()
+	if _, err := rls.Allocate("lmt1", prf, 0); err != ErrMaxRequestsExceeded {
+		t.Errorf("Expected %v, received: %v", ErrMaxRequestsExceeded, err)
+	}
+	exp := &RequestLimiterMetrics{Active: 1, Allowed: 1, Rejected: 1}
+	if rcv := rls.Metrics()["lmt1"]; *rcv != *exp {
+		t.Errorf("Expected %+v, received: %+v", exp, rcv)
+	}
+}
+
+func TestRequestLimiterRate(t *testing.T) {
+	rls := NewRequestLimiters()
+	prf := &RequestLimiterProfile{RateLimit: 2, RateInterval: 50 * time.Millisecond, Strategy: MetaBusy}
+	for i := 0; i < 2; i++ {
+		if release, err := rls.Allocate("lmt1", prf, 0); err != nil {
+			t.Fatal(err)
+		} else {
+			release()
+		}
+	}
+	if _, err := rls.Allocate("lmt1", prf, 0); err != ErrMaxRequestsExceeded {
+		t.Errorf("Expected %v, received: %v", ErrMaxRequestsExceeded, err)
+	}
+	prf = &RequestLimiterProfile{RateLimit: 1, RateInterval: 20 * time.Millisecond, Strategy: MetaQueue}
+	for i := 0; i < 2; i++ { // the second request waits for the rate
+		if release, err := rls.Allocate("lmt2", prf, 0); err != nil {
+			t.Fatal(err)
+		} else {
+			release()
+		}
+	}
+	exp := &RequestLimiterMetrics{Allowed: 2}
+	if rcv := rls.Metrics()["lmt2"]; *rcv != *exp {
+		t.Errorf("Expected %+v, received: %+v", exp, rcv)
+	}
+}
+
+func TestRequestLimitersProfileChange(t *testing.T) {
+	rls := NewRequestLimiters()
+	prf := &RequestLimiterProfile{ConcurrentRequests: 1, Strategy: MetaBusy}
+	release, err := rls.Allocate("lmt1", prf, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prf = &RequestLimiterProfile{ConcurrentRequests: 1, Strategy: MetaBusy}
+	if _, err := rls.Allocate("lmt1", prf, 0); err != nil { // new limits on profile change
+		t.Error(err)
+	}
+	release()
+	rls.Prune(func(key string) bool { return key != "lmt1" })
+	if len(rls.Metrics()) != 0 {
+		t.Errorf("Expected no limiters, received: %s", ToJSON(rls.Metrics()))
+	}
+}
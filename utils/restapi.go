@@ -0,0 +1,540 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OpenAPIPath is the path, relative to the REST URL, serving the OpenAPI document
+const OpenAPIPath = "/openapi.json"
+
+// RESTRoute maps a HTTP method on a REST path to a RPC method
+// path segments in curly braces are parameters passed as fields of the RPC arguments
+type RESTRoute struct {
+	Method    string // HTTP method
+	Path      string // path relative to the REST URL, eg: /accounts/{Tenant}/{Account}
+	RPCMethod string // RPC method called, eg: APIerSv1.GetAccount
+	Summary   string // short description used in the OpenAPI document
+}
+
+// RESTRoutes are the resources exposed over the REST API
+var RESTRoutes = []*RESTRoute{
+	{Method: http.MethodGet, Path: "/accounts/{Tenant}", RPCMethod: APIerSv1GetAccounts, Summary: "List the accounts of a tenant"},
+	{Method: http.MethodGet, Path: "/accounts/{Tenant}/{Account}", RPCMethod: APIerSv1GetAccount, Summary: "Get an account"},
+	{Method: http.MethodPut, Path: "/accounts/{Tenant}/{Account}", RPCMethod: APIerSv1SetAccount, Summary: "Create or update an account"},
+	{Method: http.MethodDelete, Path: "/accounts/{Tenant}/{Account}", RPCMethod: APIerSv1RemoveAccount, Summary: "Remove an account"},
+
+	{Method: http.MethodGet, Path: "/attribute_profiles/{Tenant}", RPCMethod: APIerSv1GetAttributeProfileIDs, Summary: "List the attribute profile IDs of a tenant"},
+	{Method: http.MethodGet, Path: "/attribute_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1GetAttributeProfile, Summary: "Get an attribute profile"},
+	{Method: http.MethodPut, Path: "/attribute_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1SetAttributeProfile, Summary: "Create or update an attribute profile"},
+	{Method: http.MethodDelete, Path: "/attribute_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1RemoveAttributeProfile, Summary: "Remove an attribute profile"},
+
+	{Method: http.MethodGet, Path: "/route_profiles/{Tenant}", RPCMethod: APIerSv1GetRouteProfileIDs, Summary: "List the route profile IDs of a tenant"},
+	{Method: http.MethodGet, Path: "/route_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1GetRouteProfile, Summary: "Get a route profile"},
+	{Method: http.MethodPut, Path: "/route_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1SetRouteProfile, Summary: "Create or update a route profile"},
+	{Method: http.MethodDelete, Path: "/route_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1RemoveRouteProfile, Summary: "Remove a route profile"},
+
+	{Method: http.MethodGet, Path: "/charger_profiles/{Tenant}", RPCMethod: APIerSv1GetChargerProfileIDs, Summary: "List the charger profile IDs of a tenant"},
+	{Method: http.MethodGet, Path: "/charger_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1GetChargerProfile, Summary: "Get a charger profile"},
+	{Method: http.MethodPut, Path: "/charger_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1SetChargerProfile, Summary: "Create or update a charger profile"},
+	{Method: http.MethodDelete, Path: "/charger_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1RemoveChargerProfile, Summary: "Remove a charger profile"},
+
+	{Method: http.MethodGet, Path: "/resource_profiles/{Tenant}", RPCMethod: APIerSv1GetResourceProfileIDs, Summary: "List the resource profile IDs of a tenant"},
+	{Method: http.MethodGet, Path: "/resource_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1GetResourceProfile, Summary: "Get a resource profile"},
+	{Method: http.MethodPut, Path: "/resource_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1SetResourceProfile, Summary: "Create or update a resource profile"},
+	{Method: http.MethodDelete, Path: "/resource_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1RemoveResourceProfile, Summary: "Remove a resource profile"},
+
+	{Method: http.MethodGet, Path: "/stat_profiles/{Tenant}", RPCMethod: APIerSv1GetStatQueueProfileIDs, Summary: "List the stat queue profile IDs of a tenant"},
+	{Method: http.MethodGet, Path: "/stat_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1GetStatQueueProfile, Summary: "Get a stat queue profile"},
+	{Method: http.MethodPut, Path: "/stat_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1SetStatQueueProfile, Summary: "Create or update a stat queue profile"},
+	{Method: http.MethodDelete, Path: "/stat_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1RemoveStatQueueProfile, Summary: "Remove a stat queue profile"},
+
+	{Method: http.MethodGet, Path: "/threshold_profiles/{Tenant}", RPCMethod: APIerSv1GetThresholdProfileIDs, Summary: "List the threshold profile IDs of a tenant"},
+	{Method: http.MethodGet, Path: "/threshold_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1GetThresholdProfile, Summary: "Get a threshold profile"},
+	{Method: http.MethodPut, Path: "/threshold_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1SetThresholdProfile, Summary: "Create or update a threshold profile"},
+	{Method: http.MethodDelete, Path: "/threshold_profiles/{Tenant}/{ID}", RPCMethod: APIerSv1RemoveThresholdProfile, Summary: "Remove a threshold profile"},
+
+	{Method: http.MethodGet, Path: "/filters/{Tenant}", RPCMethod: APIerSv1GetFilterIDs, Summary: "List the filter IDs of a tenant"},
+	{Method: http.MethodGet, Path: "/filters/{Tenant}/{ID}", RPCMethod: APIerSv1GetFilter, Summary: "Get a filter"},
+	{Method: http.MethodPut, Path: "/filters/{Tenant}/{ID}", RPCMethod: APIerSv1SetFilter, Summary: "Create or update a filter"},
+	{Method: http.MethodDelete, Path: "/filters/{Tenant}/{ID}", RPCMethod: APIerSv1RemoveFilter, Summary: "Remove a filter"},
+
+	{Method: http.MethodGet, Path: "/cdrs", RPCMethod: CDRsV1GetCDRs, Summary: "Query CDRs"},
+	{Method: http.MethodGet, Path: "/cdrs/count", RPCMethod: CDRsV1GetCDRsCount, Summary: "Count CDRs"},
+	{Method: http.MethodPost, Path: "/cdrs", RPCMethod: CDRsV1ProcessEvent, Summary: "Process an event as CDR"},
+
+	{Method: http.MethodGet, Path: "/sessions", RPCMethod: SessionSv1GetActiveSessions, Summary: "Query active sessions"},
+	{Method: http.MethodGet, Path: "/sessions/count", RPCMethod: SessionSv1GetActiveSessionsCount, Summary: "Count active sessions"},
+	{Method: http.MethodDelete, Path: "/sessions", RPCMethod: SessionSv1ForceDisconnect, Summary: "Disconnect the matching active sessions"},
+}
+
+// match checks if the route matches the path segments, returning the path parameters
+func (rt *RESTRoute) match(segments []string) (params map[string]string, matched bool) {
+	rtSegments := strings.Split(strings.Trim(rt.Path, "/"), "/")
+	if len(rtSegments) != len(segments) {
+		return
+	}
+	params = make(map[string]string)
+	for i, rtSeg := range rtSegments {
+		if strings.HasPrefix(rtSeg, "{") && strings.HasSuffix(rtSeg, "}") {
+			if segments[i] == EmptyString {
+				return nil, false
+			}
+			params[rtSeg[1:len(rtSeg)-1]] = segments[i]
+			continue
+		}
+		if rtSeg != segments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
+
+// pathParams returns the names of the parameters in route path
+func (rt *RESTRoute) pathParams() (params []string) {
+	for _, rtSeg := range strings.Split(strings.Trim(rt.Path, "/"), "/") {
+		if strings.HasPrefix(rtSeg, "{") && strings.HasSuffix(rtSeg, "}") {
+			params = append(params, rtSeg[1:len(rtSeg)-1])
+		}
+	}
+	return
+}
+
+// registerRESTHandler registers the REST API and the OpenAPI document on the mux
+func (s *Server) registerRESTHandler(mux *http.ServeMux, restURL string,
+	useBasicAuth bool, userList map[string]string) {
+	restURL = strings.TrimSuffix(restURL, "/")
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		s.handleRESTRequest(w, r, restURL, NewHTTPRPCCaller(r, useBasicAuth))
+	}
+	if useBasicAuth {
+		handler = use(handler, basicAuth(userList))
+	}
+	mux.HandleFunc(restURL+"/", handler)
+}
+
+// handleRESTRequest translates the REST request into a JSON-RPC call
+func (s *Server) handleRESTRequest(w http.ResponseWriter, r *http.Request, restURL string, caller *RPCCaller) {
+	defer r.Body.Close()
+	w.Header().Set("Content-Type", "application/json")
+	path := strings.TrimPrefix(r.URL.Path, restURL)
+	if path == OpenAPIPath {
+		if r.Method != http.MethodGet {
+			writeRESTError(w, http.StatusMethodNotAllowed, ErrUnsupporteServiceMethod.Error())
+			return
+		}
+		json.NewEncoder(w).Encode(s.OpenAPIDocument(restURL))
+		return
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	var pathMatched bool
+	for _, rt := range RESTRoutes {
+		params, matched := rt.match(segments)
+		if !matched {
+			continue
+		}
+		pathMatched = true
+		if rt.Method != r.Method {
+			continue
+		}
+		s.callRESTRoute(w, r, rt, params, caller)
+		return
+	}
+	if pathMatched {
+		writeRESTError(w, http.StatusMethodNotAllowed, ErrUnsupporteServiceMethod.Error())
+		return
+	}
+	writeRESTError(w, http.StatusNotFound, ErrNotFound.Error())
+}
+
+// callRESTRoute builds the RPC arguments out of the request and executes the RPC method of the route
+func (s *Server) callRESTRoute(w http.ResponseWriter, r *http.Request, rt *RESTRoute,
+	params map[string]string, caller *RPCCaller) {
+	method, has := s.getRPCMethod(rt.RPCMethod)
+	if !has {
+		writeRESTError(w, http.StatusNotImplemented, ErrNotImplemented.Error())
+		return
+	}
+	args := make(map[string]interface{})
+	for name, vals := range r.URL.Query() {
+		val, err := restParamValue(method.argType, name, vals)
+		if err != nil {
+			writeRESTError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		args[name] = val
+	}
+	if r.Method == http.MethodPut || r.Method == http.MethodPost {
+		body := make(map[string]interface{})
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil && err != io.EOF {
+			writeRESTError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		for name, val := range body {
+			args[name] = val
+		}
+	}
+	for name, param := range params {
+		val, err := restParamValue(method.argType, name, []string{param})
+		if err != nil {
+			writeRESTError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		args[name] = val
+	}
+	jsnArgs, err := json.Marshal(args)
+	if err != nil {
+		writeRESTError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	result, err := s.callJSONRPC(rt.RPCMethod, jsnArgs, caller)
+	if err != nil {
+		writeRESTError(w, restErrorStatus(err.Error()), err.Error())
+		return
+	}
+	w.Write(result)
+}
+
+// restErrorStatus returns the HTTP status code matching the RPC error
+func restErrorStatus(errStr string) int {
+	switch {
+	case errStr == ErrNotFound.Error(),
+		strings.HasSuffix(errStr, NotFoundCaps):
+		return http.StatusNotFound
+	case strings.HasPrefix(errStr, MandatoryIEMissingCaps),
+		strings.HasPrefix(errStr, ErrMandatoryIeMissingNoCaps.Error()):
+		return http.StatusBadRequest
+	case errStr == ErrExists.Error():
+		return http.StatusConflict
+	case errStr == ErrNotImplemented.Error():
+		return http.StatusNotImplemented
+	case errStr == ErrUnknownApiKey.Error():
+		return http.StatusUnauthorized
+	case errStr == ErrUnauthorizedApi.Error(),
+		errStr == ErrUnauthorizedTenant.Error():
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+func writeRESTError(w http.ResponseWriter, status int, errStr string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": errStr})
+}
+
+// restParamValue converts the string values of a HTTP parameter to the type of the argument field with the same name
+// unknown fields are passed as strings
+func restParamValue(argType reflect.Type, name string, vals []string) (val interface{}, err error) {
+	fldType, has := jsonFieldType(argType, name)
+	if !has {
+		if len(vals) == 1 {
+			return vals[0], nil
+		}
+		return vals, nil
+	}
+	if fldType.Kind() == reflect.Slice && fldType.Elem().Kind() != reflect.Uint8 {
+		out := make([]interface{}, len(vals))
+		for i, v := range vals {
+			if out[i], err = restConvertValue(fldType.Elem(), v); err != nil {
+				return nil, fmt.Errorf("invalid value for parameter <%s>: %s", name, err.Error())
+			}
+		}
+		return out, nil
+	}
+	if val, err = restConvertValue(fldType, vals[len(vals)-1]); err != nil {
+		return nil, fmt.Errorf("invalid value for parameter <%s>: %s", name, err.Error())
+	}
+	return
+}
+
+// restConvertValue converts a string to the JSON representation of the type
+func restConvertValue(t reflect.Type, val string) (interface{}, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch {
+	case t == reflect.TypeOf(time.Duration(0)):
+		return ParseDurationWithNanosecs(val)
+	case t == reflect.TypeOf(time.Time{}):
+		return ParseTimeDetectLayout(val, EmptyString)
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return val, nil
+	case reflect.Bool:
+		return strconv.ParseBool(val)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(val, 10, 64)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(val, 10, 64)
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(val, 64)
+	}
+	var out interface{}
+	if err := json.Unmarshal([]byte(val), &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// jsonField describes a field as seen by encoding/json
+type jsonField struct {
+	name      string
+	typ       reflect.Type
+	omitEmpty bool
+}
+
+// jsonFields returns the fields of the struct type as encoded by encoding/json, flattening the embedded structs
+func jsonFields(t reflect.Type) (flds []*jsonField) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return
+	}
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag := sf.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		tagName, tagOpts := tag, EmptyString
+		if idx := strings.Index(tag, ","); idx != -1 {
+			tagName, tagOpts = tag[:idx], tag[idx+1:]
+		}
+		if sf.Anonymous && tagName == EmptyString {
+			ft := sf.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				flds = append(flds, jsonFields(ft)...)
+				continue
+			}
+		}
+		if sf.PkgPath != EmptyString { // unexported
+			continue
+		}
+		if tagName == EmptyString {
+			tagName = sf.Name
+		}
+		flds = append(flds, &jsonField{
+			name:      tagName,
+			typ:       sf.Type,
+			omitEmpty: strings.Contains(tagOpts, "omitempty"),
+		})
+	}
+	return
+}
+
+// jsonFieldType returns the type of the field encoded under name
+func jsonFieldType(t reflect.Type, name string) (reflect.Type, bool) {
+	for _, fld := range jsonFields(t) {
+		if fld.name == name {
+			ft := fld.typ
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			return ft, true
+		}
+	}
+	return nil, false
+}
+
+// OpenAPIDocument generates the OpenAPI document of the REST routes with registered RPC methods
+func (s *Server) OpenAPIDocument(restURL string) map[string]interface{} {
+	bldr := &openAPIBuilder{schemas: make(map[string]interface{})}
+	paths := make(map[string]interface{})
+	for _, rt := range RESTRoutes {
+		method, has := s.getRPCMethod(rt.RPCMethod)
+		if !has {
+			continue
+		}
+		pathParams := rt.pathParams()
+		params := make([]interface{}, 0, len(pathParams))
+		for _, name := range pathParams {
+			params = append(params, map[string]interface{}{
+				"name":     name,
+				"in":       "path",
+				"required": true,
+				"schema":   map[string]interface{}{"type": "string"},
+			})
+		}
+		op := map[string]interface{}{
+			"operationId": rt.RPCMethod,
+			"summary":     rt.Summary,
+			"tags":        []string{strings.Split(strings.Trim(rt.Path, "/"), "/")[0]},
+			"responses": map[string]interface{}{
+				"200": map[string]interface{}{
+					"description": "successful operation",
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": bldr.schema(method.replyType),
+						},
+					},
+				},
+				"default": map[string]interface{}{
+					"description": "error returned by the RPC method",
+					"content": map[string]interface{}{
+						"application/json": map[string]interface{}{
+							"schema": map[string]interface{}{"$ref": "#/components/schemas/Error"},
+						},
+					},
+				},
+			},
+		}
+		if rt.Method == http.MethodPut || rt.Method == http.MethodPost {
+			op["requestBody"] = map[string]interface{}{
+				"content": map[string]interface{}{
+					"application/json": map[string]interface{}{
+						"schema": bldr.schema(method.argType),
+					},
+				},
+			}
+		} else {
+			for _, fld := range jsonFields(method.argType) {
+				if IsSliceMember(pathParams, fld.name) {
+					continue
+				}
+				params = append(params, map[string]interface{}{
+					"name":   fld.name,
+					"in":     "query",
+					"schema": bldr.schema(fld.typ),
+				})
+			}
+		}
+		if len(params) != 0 {
+			op["parameters"] = params
+		}
+		oaPath := restURL + rt.Path
+		if _, has := paths[oaPath]; !has {
+			paths[oaPath] = make(map[string]interface{})
+		}
+		paths[oaPath].(map[string]interface{})[strings.ToLower(rt.Method)] = op
+	}
+	bldr.schemas["Error"] = map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"error": map[string]interface{}{"type": "string"},
+		},
+	}
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":   "CGRateS REST API",
+			"version": VERSION,
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": bldr.schemas,
+		},
+	}
+}
+
+var (
+	typeOfTime        = reflect.TypeOf(time.Time{})
+	typeOfDuration    = reflect.TypeOf(time.Duration(0))
+	typeOfJSONMarshal = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// openAPIBuilder generates the JSON schemas out of Go types
+type openAPIBuilder struct {
+	schemas map[string]interface{} // named schemas of the struct types
+}
+
+// schemaName returns the name under components of a named type
+func (bldr *openAPIBuilder) schemaName(t reflect.Type) string {
+	pkg := t.PkgPath()
+	if idx := strings.LastIndex(pkg, "/"); idx != -1 {
+		pkg = pkg[idx+1:]
+	}
+	if pkg == EmptyString {
+		return t.Name()
+	}
+	return pkg + NestingSep + t.Name()
+}
+
+// schema returns the JSON schema of the type
+func (bldr *openAPIBuilder) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t {
+	case typeOfTime:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case typeOfDuration:
+		return map[string]interface{}{"type": "integer", "format": "int64", "description": "duration in nanoseconds"}
+	}
+	if t.Implements(typeOfJSONMarshal) || reflect.PtrTo(t).Implements(typeOfJSONMarshal) {
+		return map[string]interface{}{} // custom encoding, any value
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer", "format": "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32:
+		return map[string]interface{}{"type": "number", "format": "float"}
+	case reflect.Float64:
+		return map[string]interface{}{"type": "number", "format": "double"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": bldr.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": bldr.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == EmptyString {
+			return bldr.structSchema(t)
+		}
+		name := bldr.schemaName(t)
+		if _, has := bldr.schemas[name]; !has {
+			bldr.schemas[name] = nil // placeholder for recursive types
+			bldr.schemas[name] = bldr.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + name}
+	}
+	return map[string]interface{}{} // interface{} or not encodable, any value
+}
+
+// structSchema returns the object schema describing the fields of the struct
+func (bldr *openAPIBuilder) structSchema(t reflect.Type) map[string]interface{} {
+	props := make(map[string]interface{})
+	for _, fld := range jsonFields(t) {
+		props[fld.name] = bldr.schema(fld.typ)
+	}
+	return map[string]interface{}{
+		"type":       "object",
+		"properties": props,
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type restTestAPIer struct{}
+
+func (restTestAPIer) GetAccount(args *AttrGetAccount, reply *interface{}) error {
+	if args.Account != "1001" {
+		return ErrNotFound
+	}
+	*reply = map[string]string{"ID": ConcatenatedKey(args.Tenant, args.Account)}
+	return nil
+}
+
+func (restTestAPIer) GetFilterIDs(args *TenantArgWithPaginator, reply *[]string) error {
+	*reply = []string{args.Tenant}
+	if args.Limit != nil {
+		*reply = append(*reply, "limit")
+	}
+	return nil
+}
+
+func (restTestAPIer) SetFilter(args *TenantIDWithCache, reply *string) error {
+	if args.ID != "FLTR_1" || args.Cache == nil {
+		return NewErrMandatoryIeMissing("Cache")
+	}
+	*reply = OK
+	return nil
+}
+
+func (restTestAPIer) unexported(args *TenantID, reply *string) error {
+	return nil
+}
+
+func TestRESTRequest(t *testing.T) {
+	ConReqs = NewConReqs(0, EmptyString)
+	srv := NewServer()
+	srv.RpcRegisterName(APIerSv1, restTestAPIer{})
+	mux := http.NewServeMux()
+	srv.registerRESTHandler(mux, "/rest/", false, nil)
+
+	testCases := []struct {
+		method string
+		url    string
+		body   string
+		code   int
+		rply   string
+	}{
+		{http.MethodGet, "/rest/accounts/cgrates.org/1001", "", http.StatusOK, `{"ID":"cgrates.org:1001"}`},
+		{http.MethodGet, "/rest/accounts/cgrates.org/1002", "", http.StatusNotFound, `{"error":"NOT_FOUND"}`},
+		{http.MethodGet, "/rest/filters/cgrates.org?Limit=2", "", http.StatusOK, `["cgrates.org","limit"]`},
+		{http.MethodGet, "/rest/filters/cgrates.org?Limit=a", "", http.StatusBadRequest, ""},
+		{http.MethodPut, "/rest/filters/cgrates.org/FLTR_1", `{"Cache":"*reload"}`, http.StatusOK, `"OK"`},
+		{http.MethodPut, "/rest/filters/cgrates.org/FLTR_1", "", http.StatusBadRequest, ""},
+		{http.MethodPost, "/rest/filters/cgrates.org/FLTR_1", "", http.StatusMethodNotAllowed, ""},
+		{http.MethodGet, "/rest/cdrs", "", http.StatusNotImplemented, `{"error":"NOT_IMPLEMENTED"}`},
+		{http.MethodGet, "/rest/unknown", "", http.StatusNotFound, `{"error":"NOT_FOUND"}`},
+	}
+	for _, tc := range testCases {
+		req := httptest.NewRequest(tc.method, tc.url, strings.NewReader(tc.body))
+		rr := httptest.NewRecorder()
+		mux.ServeHTTP(rr, req)
+		if rr.Code != tc.code {
+			t.Errorf("%s %s: expected code %d, received %d: %s", tc.method, tc.url, tc.code, rr.Code, rr.Body.String())
+		} else if rply := strings.TrimSpace(rr.Body.String()); tc.rply != "" && rply != tc.rply {
+			t.Errorf("%s %s: expected %s, received %s", tc.method, tc.url, tc.rply, rply)
+		}
+	}
+}
+
+func TestRESTParamValue(t *testing.T) {
+	argType := reflect.TypeOf(new(SessionFilter))
+	if rcv, err := restParamValue(argType, "Limit", []string{"10"}); err != nil {
+		t.Error(err)
+	} else if rcv != int64(10) {
+		t.Errorf("Expected %v, received %v", 10, rcv)
+	}
+	exp := []interface{}{"*string:~*req.Account:1001", "*prefix:~*req.Destination:+49"}
+	if rcv, err := restParamValue(argType, "Filters", []string{"*string:~*req.Account:1001", "*prefix:~*req.Destination:+49"}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %v, received %v", exp, rcv)
+	}
+	if rcv, err := restParamValue(argType, "Unknown", []string{"value"}); err != nil {
+		t.Error(err)
+	} else if rcv != "value" {
+		t.Errorf("Expected %v, received %v", "value", rcv)
+	}
+	if _, err := restParamValue(argType, "Limit", []string{"ten"}); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	srv := NewServer()
+	srv.registerRPCMethods(APIerSv1, restTestAPIer{})
+	if _, has := srv.getRPCMethod("APIerSv1.unexported"); has {
+		t.Error("Unexported method registered")
+	}
+	doc := srv.OpenAPIDocument("/rest")
+	paths := doc["paths"].(map[string]interface{})
+	if len(paths) != 3 {
+		t.Errorf("Expected 3 paths, received: %s", ToJSON(paths))
+	}
+	ops := paths["/rest/filters/{Tenant}/{ID}"].(map[string]interface{})
+	if _, has := ops["put"]; !has {
+		t.Errorf("Expected put operation, received: %s", ToJSON(ops))
+	}
+	if _, has := ops["get"]; has {
+		t.Errorf("Unexpected get operation, received: %s", ToJSON(ops))
+	}
+	schemas := doc["components"].(map[string]interface{})["schemas"].(map[string]interface{})
+	eSchema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"Tenant": map[string]interface{}{"type": "string"},
+			"ID":     map[string]interface{}{"type": "string"},
+			"Cache":  map[string]interface{}{"type": "string"},
+		},
+	}
+	if !reflect.DeepEqual(eSchema, schemas["utils.TenantIDWithCache"]) {
+		t.Errorf("Expected %s, received %s", ToJSON(eSchema), ToJSON(schemas["utils.TenantIDWithCache"]))
+	}
+	if _, err := json.Marshal(doc); err != nil {
+		t.Error(err)
+	}
+}
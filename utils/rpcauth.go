@@ -0,0 +1,420 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"net"
+	"net/http"
+	"net/rpc"
+	"path"
+	"reflect"
+	"sync"
+
+	"github.com/cenkalti/rpc2"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// APIKeyHeader is the HTTP header, or gRPC metadata key, carrying the API key of the caller
+const APIKeyHeader = "X-API-Key"
+
+// RPCCaller holds the identity of the RPC caller as detected on the connection
+type RPCCaller struct {
+	APIKey     string // API key sent in headers
+	User       string // authenticated HTTP basic auth user
+	CertCN     string // common name of the verified TLS client certificate
+	RemoteAddr string // address of the remote peer
+}
+
+// Identity returns the identity of the caller, without exposing the API key
+func (c *RPCCaller) Identity() string {
+	switch {
+	case c == nil:
+		return MetaInternal
+	case c.CertCN != EmptyString:
+		return ConcatenatedKey(MetaCertificate, c.CertCN)
+	case c.User != EmptyString:
+		return ConcatenatedKey(MetaUser, c.User)
+	case c.APIKey != EmptyString:
+		return ConcatenatedKey(MetaAPIKey, Sha1(c.APIKey)[:8])
+	case c.RemoteAddr != EmptyString:
+		return c.RemoteAddr
+	}
+	return MetaUnknown
+}
+
+// RPCAuthorizer authorizes the RPC calls based on the caller and the arguments of the method
+type RPCAuthorizer interface {
+	AuthorizeRPC(caller *RPCCaller, serviceMethod string, args interface{}) error
+}
+
+// RPCAuditor is notified about the RPC calls before their execution
+// the returned function, if not nil, is called with the result of the call
+type RPCAuditor interface {
+	AuditRPC(caller *RPCCaller, serviceMethod string, args interface{}) func(err error)
+}
+
+// RPCRole defines the methods and the tenants allowed to the callers
+type RPCRole struct {
+	Methods []string // method patterns, eg: APIerSv1.Get*, * for all
+	Tenants []string // allowed tenants, *any for all
+}
+
+// AllowsMethod checks if the method matches one of the patterns of the role
+func (rl *RPCRole) AllowsMethod(serviceMethod string) bool {
+	for _, pattern := range rl.Methods {
+		if matched, err := path.Match(pattern, serviceMethod); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsAnyTenant checks if the role has access to all the tenants
+func (rl *RPCRole) AllowsAnyTenant() bool {
+	for _, allowed := range rl.Tenants {
+		if allowed == META_ANY {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowsTenant checks if the role has access to the tenant
+func (rl *RPCRole) AllowsTenant(tnt string) bool {
+	for _, allowed := range rl.Tenants {
+		if allowed == META_ANY || allowed == tnt {
+			return true
+		}
+	}
+	return false
+}
+
+// NewHTTPRPCCaller returns the caller of a HTTP request
+// the basic auth user is only considered if it was verified before
+func NewHTTPRPCCaller(r *http.Request, verifiedUser bool) (caller *RPCCaller) {
+	caller = &RPCCaller{APIKey: r.Header.Get(APIKeyHeader), RemoteAddr: r.RemoteAddr}
+	if verifiedUser {
+		caller.User, _, _ = r.BasicAuth()
+	}
+	if r.TLS != nil && len(r.TLS.VerifiedChains) != 0 {
+		caller.CertCN = r.TLS.PeerCertificates[0].Subject.CommonName
+	}
+	return
+}
+
+// NewConnRPCCaller returns the caller of a connection, completing the TLS handshake if needed
+func NewConnRPCCaller(conn net.Conn) (caller *RPCCaller) {
+	caller = &RPCCaller{RemoteAddr: conn.RemoteAddr().String()}
+	tlsConn, isTLS := conn.(*tls.Conn)
+	if !isTLS {
+		return
+	}
+	if err := tlsConn.Handshake(); err != nil {
+		return
+	}
+	if state := tlsConn.ConnectionState(); len(state.VerifiedChains) != 0 {
+		caller.CertCN = state.PeerCertificates[0].Subject.CommonName
+	}
+	return
+}
+
+// NewGRPCRPCCaller returns the caller of a gRPC request
+func NewGRPCRPCCaller(ctx context.Context) (caller *RPCCaller) {
+	caller = new(RPCCaller)
+	if md, has := metadata.FromIncomingContext(ctx); has {
+		if keys := md.Get(APIKeyHeader); len(keys) != 0 {
+			caller.APIKey = keys[0]
+		}
+	}
+	if p, has := peer.FromContext(ctx); has {
+		caller.RemoteAddr = p.Addr.String()
+		if tlsInfo, isTLS := p.AuthInfo.(credentials.TLSInfo); isTLS &&
+			len(tlsInfo.State.VerifiedChains) != 0 {
+			caller.CertCN = tlsInfo.State.PeerCertificates[0].Subject.CommonName
+		}
+	}
+	return
+}
+
+// RPCArgsTenant returns the Tenant field of the RPC arguments
+// has is false for the methods without tenant in arguments
+func RPCArgsTenant(args interface{}) (tnt string, has bool) {
+	return RPCArgsString(args, Tenant)
+}
+
+// rpcTenantUnscopedMethods have a Tenant in arguments which does not restrict the reply to that tenant
+var rpcTenantUnscopedMethods = NewStringSet([]string{
+	SessionSv1GetActiveSessions,
+	SessionSv1GetActiveSessionsCount,
+	SessionSv1GetPassiveSessions,
+	SessionSv1GetPassiveSessionsCount,
+	SessionSv1ForceDisconnect,
+})
+
+// RPCArgsTenants returns the tenants the RPC call is restricted to, out of the
+// Tenant and Tenants fields of the arguments, an empty tenant meaning the default one
+// has is false if the call cannot be restricted to a list of tenants
+func RPCArgsTenants(serviceMethod string, args interface{}) (tnts []string, has bool) {
+	if rpcTenantUnscopedMethods.Has(serviceMethod) {
+		return
+	}
+	if fld, hasFld := rpcArgsField(reflect.ValueOf(args), Tenants); hasFld {
+		lst, canList := fld.Interface().([]string)
+		if !canList || len(lst) == 0 { // no list means all tenants
+			return nil, false
+		}
+		tnts = append(tnts, lst...)
+		if tnt, hasTnt := RPCArgsTenant(args); hasTnt && tnt != EmptyString {
+			tnts = append(tnts, tnt)
+		}
+		return tnts, true
+	}
+	tnt, has := RPCArgsTenant(args)
+	if !has {
+		return
+	}
+	return []string{tnt}, true
+}
+
+// RPCArgsString returns the value of a string field out of the RPC arguments
+func RPCArgsString(args interface{}, fldName string) (val string, has bool) {
+	fld, has := rpcArgsField(reflect.ValueOf(args), fldName)
+	if !has || fld.Kind() != reflect.String {
+		return EmptyString, false
+	}
+	return fld.String(), true
+}
+
+// RPCArgsAPIKey returns the APIKey of the ArgDispatcher in RPC arguments
+func RPCArgsAPIKey(args interface{}) string {
+	fld, has := rpcArgsField(reflect.ValueOf(args), APIKey)
+	if !has {
+		return EmptyString
+	}
+	if fld.Kind() == reflect.Ptr {
+		if fld.IsNil() {
+			return EmptyString
+		}
+		fld = fld.Elem()
+	}
+	if fld.Kind() != reflect.String {
+		return EmptyString
+	}
+	return fld.String()
+}
+
+// rpcArgsField searches the field by name, including the fields of the embedded structs
+func rpcArgsField(v reflect.Value, fldName string) (fld reflect.Value, has bool) {
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+	if sf, found := v.Type().FieldByName(fldName); found && len(sf.Index) == 1 { // direct field
+		return v.Field(sf.Index[0]), true
+	}
+	for i := 0; i < v.NumField(); i++ {
+		if !v.Type().Field(i).Anonymous {
+			continue
+		}
+		if fld, has = rpcArgsField(v.Field(i), fldName); has {
+			return
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// rpcCallHooks authorizes, limits and audits the call before its execution
+// finish, if not nil, must be called with the result of the call
+func rpcCallHooks(hooks func() (RPCAuthorizer, RPCLimiter, RPCAuditor), caller *RPCCaller,
+	serviceMethod string, args interface{}) (finish func(error), err error) {
+	authorizer, limiter, auditor := hooks()
+	if authorizer != nil {
+		if err = authorizer.AuthorizeRPC(caller, serviceMethod, args); err != nil {
+			return
+		}
+	}
+	var release func()
+	if limiter != nil {
+		if release, err = limiter.LimitRPC(serviceMethod, args); err != nil {
+			return
+		}
+	}
+	var audit func(error)
+	if auditor != nil {
+		audit = auditor.AuditRPC(caller, serviceMethod, args)
+	}
+	if release == nil && audit == nil {
+		return
+	}
+	return func(err error) {
+		if release != nil {
+			release()
+		}
+		if audit != nil {
+			audit(err)
+		}
+	}, nil
+}
+
+// authServerCodec authorizes, limits and audits one request read out of the wrapped codec
+// the hooks run after the body is read so the request can wait in the limiters
+// without blocking the reading of the next requests on the same connection
+type authServerCodec struct {
+	codec         rpc.ServerCodec
+	caller        *RPCCaller
+	hooks         func() (RPCAuthorizer, RPCLimiter, RPCAuditor) // read on each request since they can change at runtime
+	writeMux      *sync.Mutex                                    // serializes the responses written on the connection
+	serviceMethod string
+	finish        func(error) // pending release and audit
+
+	readOnce sync.Once
+	read     chan bool // signals if the request was read out of the connection, false if nothing can be read anymore
+}
+
+// setRead signals the read loop once the request is read out of the connection
+func (c *authServerCodec) setRead(ok bool) {
+	c.readOnce.Do(func() { c.read <- ok })
+}
+
+func (c *authServerCodec) ReadRequestHeader(r *rpc.Request) (err error) {
+	if err = c.codec.ReadRequestHeader(r); err != nil {
+		c.setRead(false)
+		return
+	}
+	c.serviceMethod = r.ServiceMethod
+	return
+}
+
+func (c *authServerCodec) ReadRequestBody(x interface{}) (err error) {
+	err = c.codec.ReadRequestBody(x)
+	c.setRead(true) // the next request can be read while this one is processed
+	if err != nil ||
+		x == nil { // discarded body of unknown method
+		return
+	}
+	c.finish, err = rpcCallHooks(c.hooks, c.caller, c.serviceMethod, x)
+	return
+}
+
+func (c *authServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
+	if c.finish != nil {
+		var err error
+		if r.Error != EmptyString {
+			err = errors.New(r.Error)
+		}
+		c.finish(err)
+	}
+	c.writeMux.Lock()
+	defer c.writeMux.Unlock()
+	return c.codec.WriteResponse(r, x)
+}
+
+// Close is part of rpc.ServerCodec interface, the wrapped codec is closed by serveAuthCodec
+func (c *authServerCodec) Close() error {
+	return nil
+}
+
+// serveAuthCodec serves the requests of the codec, authorizing, limiting and auditing each of them
+// in its own goroutine, so the ones waiting in the limiters do not delay the ones read after them
+func serveAuthCodec(srv *rpc.Server, codec rpc.ServerCodec, caller *RPCCaller,
+	hooks func() (RPCAuthorizer, RPCLimiter, RPCAuditor)) {
+	var wg sync.WaitGroup
+	writeMux := new(sync.Mutex)
+	for {
+		req := &authServerCodec{
+			codec:    codec,
+			caller:   caller,
+			hooks:    hooks,
+			writeMux: writeMux,
+			read:     make(chan bool, 1),
+		}
+		wg.Add(1)
+		go func() {
+			srv.ServeRequest(req)
+			req.setRead(false) // in case the request could not be read
+			wg.Done()
+		}()
+		if !<-req.read {
+			break
+		}
+	}
+	wg.Wait()
+	codec.Close()
+}
+
+// biRPCCallerKey is the key of the RPCCaller within the state of the rpc2.Client
+const biRPCCallerKey = "RPCCaller"
+
+// ServeBiRPCCodec serves the BiRPC connection keeping the identity of the caller for the handlers
+func ServeBiRPCCodec(srv *rpc2.Server, codec rpc2.Codec, caller *RPCCaller) {
+	state := rpc2.NewState()
+	state.Set(biRPCCallerKey, caller)
+	srv.ServeCodecWithState(codec, state)
+}
+
+// BiRPCCaller returns the caller of the BiRPC client, nil for the internal ones
+func BiRPCCaller(clnt *rpc2.Client) (caller *RPCCaller) {
+	if clnt == nil || clnt.State == nil {
+		return
+	}
+	if c, has := clnt.State.Get(biRPCCallerKey); has {
+		caller, _ = c.(*RPCCaller)
+	}
+	return
+}
+
+var typeOfBiRPCClient = reflect.TypeOf((*rpc2.Client)(nil))
+
+// NewBiRPCHandler wraps the handler of a BiRPC method, func(*rpc2.Client, args, reply) error,
+// so the calls are authorized, limited and audited at dispatch
+func NewBiRPCHandler(hooks func() (RPCAuthorizer, RPCLimiter, RPCAuditor),
+	serviceMethod string, handlerFunc interface{}) interface{} {
+	fn := reflect.ValueOf(handlerFunc)
+	fnType := fn.Type()
+	if fnType.Kind() != reflect.Func || fnType.NumIn() != 3 ||
+		fnType.In(0) != typeOfBiRPCClient ||
+		fnType.NumOut() != 1 || fnType.Out(0) != typeOfError {
+		return handlerFunc // not a BiRPC handler, rpc2 will complain about it
+	}
+	return reflect.MakeFunc(fnType, func(in []reflect.Value) []reflect.Value {
+		clnt, _ := in[0].Interface().(*rpc2.Client)
+		finish, err := rpcCallHooks(hooks, BiRPCCaller(clnt), serviceMethod, in[1].Interface())
+		if err != nil {
+			errVal := reflect.New(typeOfError).Elem()
+			errVal.Set(reflect.ValueOf(err))
+			return []reflect.Value{errVal}
+		}
+		out := fn.Call(in)
+		if finish != nil {
+			err, _ = out[0].Interface().(error)
+			finish(err)
+		}
+		return out
+	}).Interface()
+}
@@ -0,0 +1,360 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/rpc2"
+	rpc2_jsonrpc "github.com/cenkalti/rpc2/jsonrpc"
+	"golang.org/x/net/websocket"
+)
+
+func TestRPCRole(t *testing.T) {
+	rl := &RPCRole{
+		Methods: []string{"APIerSv1.Get*", "CoreSv1.Status"},
+		Tenants: []string{"cgrates.org"},
+	}
+	if !rl.AllowsMethod(APIerSv1GetAccount) {
+		t.Errorf("Expected %s to be allowed", APIerSv1GetAccount)
+	}
+	if rl.AllowsMethod(APIerSv1RemoveAccount) {
+		t.Errorf("Expected %s not to be allowed", APIerSv1RemoveAccount)
+	}
+	if !rl.AllowsTenant("cgrates.org") || rl.AllowsTenant("itsyscom.com") {
+		t.Errorf("Unexpected tenants allowed: %+v", rl.Tenants)
+	}
+	rl.Tenants = []string{META_ANY}
+	if !rl.AllowsTenant("itsyscom.com") {
+		t.Errorf("Expected all tenants to be allowed")
+	}
+}
+
+func TestRPCArgsFields(t *testing.T) {
+	args := &TenantWithArgDispatcher{
+		TenantArg:     &TenantArg{Tenant: "cgrates.org"},
+		ArgDispatcher: &ArgDispatcher{APIKey: StringPointer("key1")},
+	}
+	if tnt, has := RPCArgsTenant(args); !has || tnt != "cgrates.org" {
+		t.Errorf("Expected tenant cgrates.org, received %q, %v", tnt, has)
+	}
+	if apiKey := RPCArgsAPIKey(args); apiKey != "key1" {
+		t.Errorf("Expected API key key1, received %q", apiKey)
+	}
+	args.ArgDispatcher = nil
+	if apiKey := RPCArgsAPIKey(args); apiKey != EmptyString {
+		t.Errorf("Expected no API key, received %q", apiKey)
+	}
+	if _, has := RPCArgsTenant(&TenantWithArgDispatcher{}); has {
+		t.Error("Expected no tenant for nil embedded struct")
+	}
+	if _, has := RPCArgsTenant(StringPointer("cgrates.org")); has {
+		t.Error("Expected no tenant for string arguments")
+	}
+}
+
+func TestRPCArgsTenants(t *testing.T) {
+	if tnts, has := RPCArgsTenants(CDRsV1GetCDRs, &RPCCDRsFilterWithArgDispatcher{
+		RPCCDRsFilter: &RPCCDRsFilter{Tenants: []string{"cgrates.org", "itsyscom.com"}},
+		TenantArg:     &TenantArg{},
+	}); !has || !reflect.DeepEqual(tnts, []string{"cgrates.org", "itsyscom.com"}) {
+		t.Errorf("Received %+v, %v", tnts, has)
+	}
+	if tnts, has := RPCArgsTenants(CDRsV1GetCDRs, &RPCCDRsFilterWithArgDispatcher{
+		RPCCDRsFilter: &RPCCDRsFilter{},
+		TenantArg:     &TenantArg{Tenant: "cgrates.org"},
+	}); has {
+		t.Errorf("Expected no tenants, received %+v", tnts)
+	}
+	if tnts, has := RPCArgsTenants(APIerSv1GetAccount, &AttrGetAccount{}); !has ||
+		!reflect.DeepEqual(tnts, []string{EmptyString}) {
+		t.Errorf("Received %+v, %v", tnts, has)
+	}
+	if tnts, has := RPCArgsTenants(SessionSv1GetActiveSessions, &SessionFilter{Tenant: "cgrates.org"}); has {
+		t.Errorf("Expected no tenants, received %+v", tnts)
+	}
+}
+
+func TestNewBiRPCHandler(t *testing.T) {
+	var audited []string
+	hooks := func() (RPCAuthorizer, RPCLimiter, RPCAuditor) {
+		return rpcAuthTestAuthorizer{}, nil, rpcAuthTestAuditor(func(err error) {
+			audited = append(audited, fmt.Sprint(err))
+		})
+	}
+	handler := NewBiRPCHandler(hooks, "RPCAuthTestV1.Ping",
+		func(clnt *rpc2.Client, args *TenantID, reply *string) error {
+			*reply = args.TenantID()
+			return nil
+		}).(func(*rpc2.Client, *TenantID, *string) error)
+	state := rpc2.NewState()
+	state.Set(biRPCCallerKey, &RPCCaller{RemoteAddr: "127.0.0.1:2014"})
+	clnt := &rpc2.Client{State: state}
+	var reply string
+	if err := handler(clnt, &TenantID{Tenant: "cgrates.org", ID: "1001"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "cgrates.org:1001" {
+		t.Errorf("Expected %q, received %q", "cgrates.org:1001", reply)
+	}
+	if err := handler(clnt, &TenantID{Tenant: "itsyscom.com", ID: "1001"}, &reply); err != ErrUnauthorizedTenant {
+		t.Errorf("Expected error %v, received %v", ErrUnauthorizedTenant, err)
+	}
+	if !reflect.DeepEqual(audited, []string{"<nil>"}) {
+		t.Errorf("Unexpected audits: %+v", audited)
+	}
+	if caller := BiRPCCaller(clnt); caller == nil || caller.RemoteAddr != "127.0.0.1:2014" {
+		t.Errorf("Unexpected caller: %+v", caller)
+	}
+}
+
+type rpcAuthTestAuditor func(error)
+
+func (a rpcAuthTestAuditor) AuditRPC(caller *RPCCaller, serviceMethod string, args interface{}) func(error) {
+	return a
+}
+
+type rpcAuthTestAuthorizer struct{}
+
+func (rpcAuthTestAuthorizer) AuthorizeRPC(caller *RPCCaller, serviceMethod string, args interface{}) error {
+	if caller.APIKey == "key1" {
+		return nil
+	}
+	if tnt, _ := RPCArgsTenant(args); tnt != "cgrates.org" {
+		return ErrUnauthorizedTenant
+	}
+	return nil
+}
+
+func TestRPCAuthServerCodec(t *testing.T) {
+	ConReqs = NewConReqs(0, EmptyString)
+	srv := NewServer()
+	srv.RpcRegisterName("RPCAuthTestV1", grpcTestService{})
+	srv.SetRPCAuthorizer(rpcAuthTestAuthorizer{})
+	l, err := net.Listen(TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go srv.serveConn(conn, srv.jsonServerCodec(conn))
+		}
+	}()
+	clnt, err := jsonrpc.Dial(TCP, l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clnt.Close()
+	var reply string
+	if err = clnt.Call("RPCAuthTestV1.Ping", &TenantID{Tenant: "cgrates.org", ID: "1001"}, &reply); err != nil {
+		t.Error(err)
+	}
+	if err = clnt.Call("RPCAuthTestV1.Ping", &TenantID{Tenant: "itsyscom.com", ID: "1001"}, &reply); err == nil ||
+		err.Error() != ErrUnauthorizedTenant.Error() {
+		t.Errorf("Expected error %v, received %v", ErrUnauthorizedTenant, err)
+	}
+	// connection still usable after rejection
+	if err = clnt.Call("RPCAuthTestV1.Ping", &TenantID{Tenant: "cgrates.org", ID: "1002"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "cgrates.org:1002" {
+		t.Errorf("Expected %q, received %q", "cgrates.org:1002", reply)
+	}
+
+	body := `{"method":"RPCAuthTestV1.Ping","params":[{"Tenant":"itsyscom.com","ID":"1001"}],"id":1}`
+	req := httptest.NewRequest(http.MethodPost, "/jsonrpc", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	srv.handleRequest(false)(rr, req)
+	if !strings.Contains(rr.Body.String(), ErrUnauthorizedTenant.Error()) {
+		t.Errorf("Expected %v, received %s", ErrUnauthorizedTenant, rr.Body.String())
+	}
+	req = httptest.NewRequest(http.MethodPost, "/jsonrpc", strings.NewReader(body))
+	req.Header.Set(APIKeyHeader, "key1")
+	rr = httptest.NewRecorder()
+	srv.handleRequest(false)(rr, req)
+	if !strings.Contains(rr.Body.String(), `"itsyscom.com:1001"`) {
+		t.Errorf("Unexpected reply: %s", rr.Body.String())
+	}
+}
+
+type rpcLimitTestService struct {
+	unblock chan struct{}
+	mux     sync.Mutex
+	called  []string
+}
+
+// rpcLimitTestSvc is registered only once within the default RPC server
+var rpcLimitTestSvc = new(rpcLimitTestService)
+
+func (s *rpcLimitTestService) call(method string) {
+	s.mux.Lock()
+	s.called = append(s.called, method)
+	s.mux.Unlock()
+}
+
+func (s *rpcLimitTestService) Block(args *TenantID, reply *string) error {
+	<-s.unblock
+	s.call("Block")
+	*reply = OK
+	return nil
+}
+
+func (s *rpcLimitTestService) Low(args *TenantID, reply *string) error {
+	s.call("Low")
+	*reply = OK
+	return nil
+}
+
+func (s *rpcLimitTestService) High(args *TenantID, reply *string) error {
+	s.call("High")
+	*reply = OK
+	return nil
+}
+
+type rpcLimitTestLimiter struct {
+	prf *RequestLimiterProfile
+}
+
+func (l rpcLimitTestLimiter) LimitRPC(serviceMethod string, args interface{}) (func(), error) {
+	var priority int
+	if serviceMethod == "RPCLimitTestV1.High" {
+		priority = 1
+	}
+	return ReqLimiters.Allocate("RPC_LIMIT_TEST", l.prf, priority)
+}
+
+func TestRPCAuthServerCodecPriority(t *testing.T) {
+	if ConReqs == nil { // not replaced since the responses of the previous tests can still use it
+		ConReqs = NewConReqs(0, EmptyString)
+	}
+	svc := rpcLimitTestSvc
+	svc.unblock = make(chan struct{})
+	svc.called = nil
+	srv := NewServer()
+	srv.RpcRegisterName("RPCLimitTestV1", svc)
+	srv.SetRPCLimiter(rpcLimitTestLimiter{prf: &RequestLimiterProfile{
+		ConcurrentRequests: 1,
+		Strategy:           MetaQueue,
+	}})
+	defer ReqLimiters.Prune(func(key string) bool { return key != "RPC_LIMIT_TEST" })
+	l, err := net.Listen(TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go srv.serveConn(conn, srv.jsonServerCodec(conn))
+		}
+	}()
+	clnt, err := jsonrpc.Dial(TCP, l.Addr().String())
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer clnt.Close()
+	waitQueued := func(queued int) {
+		for i := 0; i < 100; i++ {
+			if m, has := ReqLimiters.Metrics()["RPC_LIMIT_TEST"]; has && m.Queued == queued {
+				return
+			}
+			time.Sleep(10 * time.Millisecond)
+		}
+		close(svc.unblock)
+		t.Fatalf("expecting %d requests queued, received: %s", queued, ToJSON(ReqLimiters.Metrics()))
+	}
+	args := &TenantID{Tenant: "cgrates.org", ID: "1001"}
+	var blockRply, lowRply, highRply string
+	blockCall := clnt.Go("RPCLimitTestV1.Block", args, &blockRply, nil)
+	for i := 0; i < 100 && ReqLimiters.Metrics()["RPC_LIMIT_TEST"] == nil; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	lowCall := clnt.Go("RPCLimitTestV1.Low", args, &lowRply, nil)
+	waitQueued(1)
+	// the queued request does not stop the reading of the next ones on the same connection
+	highCall := clnt.Go("RPCLimitTestV1.High", args, &highRply, nil)
+	waitQueued(2)
+	close(svc.unblock)
+	for _, call := range []*rpc.Call{blockCall, lowCall, highCall} {
+		if rpl := <-call.Done; rpl.Error != nil {
+			t.Errorf("%s: %v", rpl.ServiceMethod, rpl.Error)
+		}
+	}
+	if exp := []string{"Block", "High", "Low"}; !reflect.DeepEqual(exp, svc.called) {
+		t.Errorf("expecting: %v, received: %v", exp, svc.called)
+	}
+}
+
+func TestBiRPCWSHandler(t *testing.T) {
+	hooks := func() (RPCAuthorizer, RPCLimiter, RPCAuditor) {
+		return rpcAuthTestAuthorizer{}, nil, nil
+	}
+	birpcSrv := rpc2.NewServer()
+	birpcSrv.Handle("RPCAuthTestV1.Caller", NewBiRPCHandler(hooks, "RPCAuthTestV1.Caller",
+		func(clnt *rpc2.Client, args *TenantID, reply *string) error {
+			*reply = BiRPCCaller(clnt).Identity()
+			return nil
+		}))
+	ts := httptest.NewServer(BiRPCWSHandler(func() *rpc2.Server { return birpcSrv },
+		true, map[string]string{"user1": base64.StdEncoding.EncodeToString([]byte("pass1"))}))
+	defer ts.Close()
+	wsURL := "ws" + strings.TrimPrefix(ts.URL, "http")
+	if _, err := websocket.Dial(wsURL, EmptyString, ts.URL); err == nil {
+		t.Fatal("Expected the connection without credentials to be refused")
+	}
+	wsCfg, err := websocket.NewConfig(wsURL, ts.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wsCfg.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("user1:pass1")))
+	ws, err := websocket.DialConfig(wsCfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	clnt := rpc2.NewClientWithCodec(rpc2_jsonrpc.NewJSONCodec(ws))
+	go clnt.Run()
+	defer clnt.Close()
+	var reply string
+	if err = clnt.Call("RPCAuthTestV1.Caller", &TenantID{Tenant: "cgrates.org", ID: "1001"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "*user:user1" {
+		t.Errorf("Expected %q, received %q", "*user:user1", reply)
+	}
+	if err = clnt.Call("RPCAuthTestV1.Caller", &TenantID{Tenant: "itsyscom.com", ID: "1001"}, &reply); err == nil ||
+		err.Error() != ErrUnauthorizedTenant.Error() {
+		t.Errorf("Expected error %v, received %v", ErrUnauthorizedTenant, err)
+	}
+}
@@ -23,6 +23,8 @@ import (
 	"crypto/tls"
 	"crypto/x509"
 	"encoding/gob"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
@@ -55,6 +57,7 @@ func NewServer() (s *Server) {
 	s.httpMux = http.NewServeMux()
 	s.httpsMux = http.NewServeMux()
 	s.stopbiRPCServer = make(chan struct{}, 1)
+	s.rpcMethods = make(map[string]*rpcMethod)
 	return s
 }
 
@@ -67,14 +70,106 @@ type Server struct {
 	httpsMux        *http.ServeMux
 	httpMux         *http.ServeMux
 	isDispatched    bool
+	rpcMethods      map[string]*rpcMethod // registered RPC methods, used to describe the REST API
+	authorizer      RPCAuthorizer         // authorizes the RPC calls, nil to allow all
+	limiter         RPCLimiter            // limits the RPC calls, nil to disable
+	auditor         RPCAuditor            // audits the RPC calls, nil to disable
+}
+
+// rpcMethod holds the argument and reply types of a registered RPC method
+type rpcMethod struct {
+	argType   reflect.Type
+	replyType reflect.Type
+}
+
+var typeOfError = reflect.TypeOf((*error)(nil)).Elem()
+
+// registerRPCMethods indexes the methods of rcvr which are suitable for net/rpc
+func (s *Server) registerRPCMethods(name string, rcvr interface{}) {
+	rcvType := reflect.TypeOf(rcvr)
+	if name == "" {
+		name = reflect.Indirect(reflect.ValueOf(rcvr)).Type().Name()
+	}
+	s.Lock()
+	for i := 0; i < rcvType.NumMethod(); i++ {
+		method := rcvType.Method(i)
+		mType := method.Type
+		if method.PkgPath != "" || // unexported
+			mType.NumIn() != 3 || mType.NumOut() != 1 ||
+			mType.In(2).Kind() != reflect.Ptr ||
+			mType.Out(0) != typeOfError {
+			continue
+		}
+		s.rpcMethods[name+NestingSep+method.Name] = &rpcMethod{
+			argType:   mType.In(1),
+			replyType: mType.In(2).Elem(),
+		}
+	}
+	s.Unlock()
+}
+
+// getRPCMethod returns the registered RPC method with the given name
+func (s *Server) getRPCMethod(serviceMethod string) (m *rpcMethod, has bool) {
+	s.RLock()
+	m, has = s.rpcMethods[serviceMethod]
+	s.RUnlock()
+	return
 }
 
 func (s *Server) SetDispatched() {
 	s.isDispatched = true
 }
 
+// SetRPCAuthorizer enables the authorization of the RPC calls received by the listeners
+func (s *Server) SetRPCAuthorizer(authorizer RPCAuthorizer) {
+	s.Lock()
+	s.authorizer = authorizer
+	s.Unlock()
+}
+
+// SetRPCAuditor enables the auditing of the RPC calls received by the listeners, nil to disable
+func (s *Server) SetRPCAuditor(auditor RPCAuditor) {
+	s.Lock()
+	s.auditor = auditor
+	s.Unlock()
+}
+
+// SetRPCLimiter enables the limiting of the RPC calls received by the listeners
+func (s *Server) SetRPCLimiter(limiter RPCLimiter) {
+	s.Lock()
+	s.limiter = limiter
+	s.Unlock()
+}
+
+// rpcHooks returns the authorizer, the limiter and the auditor of the RPC calls
+func (s *Server) rpcHooks() (authorizer RPCAuthorizer, limiter RPCLimiter, auditor RPCAuditor) {
+	s.RLock()
+	authorizer, limiter, auditor = s.authorizer, s.limiter, s.auditor
+	s.RUnlock()
+	return
+}
+
+// jsonServerCodec returns the JSON codec used by the listeners
+func (s *Server) jsonServerCodec(conn io.ReadWriteCloser) rpc.ServerCodec {
+	if s.isDispatched {
+		return NewCustomJSONServerCodec(conn)
+	}
+	return NewConcReqsServerCodec(conn)
+}
+
+// serveCodec serves the requests of the codec, authorizing, limiting and auditing them when configured
+func (s *Server) serveCodec(codec rpc.ServerCodec, caller *RPCCaller) {
+	serveAuthCodec(rpc.DefaultServer, codec, caller, s.rpcHooks)
+}
+
+// serveConn serves the requests received on a RPC connection
+func (s *Server) serveConn(conn net.Conn, codec rpc.ServerCodec) {
+	s.serveCodec(codec, NewConnRPCCaller(conn))
+}
+
 func (s *Server) RpcRegister(rcvr interface{}) {
 	rpc.Register(rcvr)
+	s.registerRPCMethods(EmptyString, rcvr)
 	s.Lock()
 	s.rpcEnabled = true
 	s.Unlock()
@@ -82,6 +177,7 @@ func (s *Server) RpcRegister(rcvr interface{}) {
 
 func (s *Server) RpcRegisterName(name string, rcvr interface{}) {
 	rpc.RegisterName(name, rcvr)
+	s.registerRPCMethods(name, rcvr)
 	s.Lock()
 	s.rpcEnabled = true
 	s.Unlock()
@@ -121,7 +217,13 @@ func (s *Server) BiRPCRegisterName(method string, handlerFunc interface{}) {
 		s.birpcSrv = rpc2.NewServer()
 		s.Unlock()
 	}
-	s.birpcSrv.Handle(method, handlerFunc)
+	s.birpcSrv.Handle(method, s.BiRPCHandler(method, handlerFunc))
+}
+
+// BiRPCHandler wraps the BiRPC handler so its calls are authorized, limited
+// and audited like the ones received by the other listeners
+func (s *Server) BiRPCHandler(method string, handlerFunc interface{}) interface{} {
+	return NewBiRPCHandler(s.rpcHooks, method, handlerFunc)
 }
 
 func (s *Server) BiRPCRegister(rcvr interface{}) {
@@ -174,11 +276,7 @@ func (s *Server) ServeJSON(addr string, exitChan chan bool) {
 			}
 			continue
 		}
-		if s.isDispatched {
-			go rpc.ServeCodec(NewCustomJSONServerCodec(conn))
-		} else {
-			go rpc.ServeCodec(NewConcReqsServerCodec(conn))
-		}
+		go s.serveConn(conn, s.jsonServerCodec(conn))
 	}
 
 }
@@ -214,15 +312,44 @@ func (s *Server) ServeGOB(addr string, exitChan chan bool) {
 			}
 			continue
 		}
-		go rpc.ServeCodec(NewConcReqsGobServerCodec(conn))
+		go s.serveConn(conn, NewConcReqsGobServerCodec(conn))
+	}
+}
+
+// handleRequest returns the handler of the JSON-RPC requests over HTTP
+// verifiedUser marks the basic auth user as authenticated
+func (s *Server) handleRequest(verifiedUser bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		defer r.Body.Close()
+		w.Header().Set("Content-Type", "application/json")
+		res := s.callRPCRequest(NewRPCRequest(r.Body), NewHTTPRPCCaller(r, verifiedUser))
+		io.Copy(w, res)
 	}
 }
 
-func handleRequest(w http.ResponseWriter, r *http.Request) {
-	defer r.Body.Close()
-	w.Header().Set("Content-Type", "application/json")
-	res := NewRPCRequest(r.Body).Call()
-	io.Copy(w, res)
+// wsHandler returns the handler of the WebSocket connections
+func (s *Server) wsHandler(verifiedUser bool) websocket.Handler {
+	return func(ws *websocket.Conn) {
+		s.serveCodec(s.jsonServerCodec(ws), NewHTTPRPCCaller(ws.Request(), verifiedUser))
+	}
+}
+
+// BiRPCWSHandler returns the handler serving a BiRPC server over WebSocket with the
+// caller identity and the basic auth of the other HTTP handlers
+// srv is called on each connection, the connection is refused if it returns nil
+func BiRPCWSHandler(srv func() *rpc2.Server, useBasicAuth bool, userList map[string]string) http.Handler {
+	wsHandler := websocket.Handler(func(ws *websocket.Conn) {
+		birpcSrv := srv()
+		if birpcSrv == nil {
+			ws.Close()
+			return
+		}
+		ServeBiRPCCodec(birpcSrv, rpc2_jsonrpc.NewJSONCodec(ws), NewHTTPRPCCaller(ws.Request(), useBasicAuth))
+	})
+	if !useBasicAuth {
+		return wsHandler
+	}
+	return use(wsHandler.ServeHTTP, basicAuth(userList))
 }
 
 func registerProfiler(addr string, mux *http.ServeMux) {
@@ -248,7 +375,7 @@ func (s *Server) RegisterProfiler(addr string) {
 }
 
 func (s *Server) ServeHTTP(addr string, jsonRPCURL string, wsRPCURL string,
-	useBasicAuth bool, userList map[string]string, exitChan chan bool) {
+	restURL string, useBasicAuth bool, userList map[string]string, exitChan chan bool) {
 	s.RLock()
 	enabled := s.rpcEnabled
 	s.RUnlock()
@@ -263,9 +390,9 @@ func (s *Server) ServeHTTP(addr string, jsonRPCURL string, wsRPCURL string,
 
 		Logger.Info("<HTTP> enabling handler for JSON-RPC")
 		if useBasicAuth {
-			s.httpMux.HandleFunc(jsonRPCURL, use(handleRequest, basicAuth(userList)))
+			s.httpMux.HandleFunc(jsonRPCURL, use(s.handleRequest(true), basicAuth(userList)))
 		} else {
-			s.httpMux.HandleFunc(jsonRPCURL, handleRequest)
+			s.httpMux.HandleFunc(jsonRPCURL, s.handleRequest(false))
 		}
 	}
 	if enabled && wsRPCURL != "" {
@@ -273,13 +400,7 @@ func (s *Server) ServeHTTP(addr string, jsonRPCURL string, wsRPCURL string,
 		s.httpEnabled = true
 		s.Unlock()
 		Logger.Info("<HTTP> enabling handler for WebSocket connections")
-		wsHandler := websocket.Handler(func(ws *websocket.Conn) {
-			if s.isDispatched {
-				rpc.ServeCodec(NewCustomJSONServerCodec(ws))
-			} else {
-				rpc.ServeCodec(NewConcReqsServerCodec(ws))
-			}
-		})
+		wsHandler := s.wsHandler(useBasicAuth)
 		if useBasicAuth {
 			s.httpMux.HandleFunc(wsRPCURL, use(func(w http.ResponseWriter, r *http.Request) {
 				wsHandler.ServeHTTP(w, r)
@@ -288,6 +409,13 @@ func (s *Server) ServeHTTP(addr string, jsonRPCURL string, wsRPCURL string,
 			s.httpMux.Handle(wsRPCURL, wsHandler)
 		}
 	}
+	if enabled && restURL != "" {
+		s.Lock()
+		s.httpEnabled = true
+		s.Unlock()
+		Logger.Info("<HTTP> enabling handler for REST API")
+		s.registerRESTHandler(s.httpMux, restURL, useBasicAuth, userList)
+	}
 	if !s.httpEnabled {
 		return
 	}
@@ -329,7 +457,9 @@ func (s *Server) ServeBiJSON(addr string, onConn func(*rpc2.Client), onDis func(
 				log.Fatal(err)
 				return // stop if we get Accept error
 			}
-			go s.birpcSrv.ServeCodec(rpc2_jsonrpc.NewJSONCodec(conn))
+			go func(conn net.Conn) {
+				ServeBiRPCCodec(s.birpcSrv, rpc2_jsonrpc.NewJSONCodec(conn), NewConnRPCCaller(conn))
+			}(conn)
 		}
 	}(lBiJSON)
 	<-s.stopbiRPCServer // wait until server is stoped to close the listener
@@ -379,6 +509,40 @@ func (r *rpcRequest) Call() io.Reader {
 	return r.rw
 }
 
+// callRPCRequest invokes the RPC request on behalf of the caller and returns the results
+func (s *Server) callRPCRequest(r *rpcRequest, caller *RPCCaller) io.Reader {
+	go s.serveCodec(NewConcReqsServerCodec(r), caller)
+	<-r.done
+	return r.rw
+}
+
+// callJSONRPC executes the RPC method over the JSON-RPC codec, returning the JSON encoded result
+func (s *Server) callJSONRPC(serviceMethod string, params json.RawMessage,
+	caller *RPCCaller) (result json.RawMessage, err error) {
+	if len(params) == 0 {
+		params = json.RawMessage("{}")
+	}
+	var req []byte
+	if req, err = json.Marshal(map[string]interface{}{
+		"method": serviceMethod,
+		"params": []json.RawMessage{params},
+		"id":     0,
+	}); err != nil {
+		return
+	}
+	var rply struct {
+		Result json.RawMessage
+		Error  *string
+	}
+	if err = json.NewDecoder(s.callRPCRequest(NewRPCRequest(bytes.NewBuffer(req)), caller)).Decode(&rply); err != nil {
+		return
+	}
+	if rply.Error != nil {
+		return nil, errors.New(*rply.Error)
+	}
+	return rply.Result, nil
+}
+
 func loadTLSConfig(serverCrt, serverKey, caCert string, serverPolicy int,
 	serverName string) (config tls.Config, err error) {
 	cert, err := tls.LoadX509KeyPair(serverCrt, serverKey)
@@ -456,7 +620,7 @@ func (s *Server) ServeGOBTLS(addr, serverCrt, serverKey, caCert string,
 			}
 			continue
 		}
-		go rpc.ServeCodec(NewConcReqsGobServerCodec(conn))
+		go s.serveConn(conn, NewConcReqsGobServerCodec(conn))
 	}
 }
 
@@ -497,16 +661,12 @@ func (s *Server) ServeJSONTLS(addr, serverCrt, serverKey, caCert string,
 			}
 			continue
 		}
-		if s.isDispatched {
-			go rpc.ServeCodec(NewCustomJSONServerCodec(conn))
-		} else {
-			go rpc.ServeCodec(NewConcReqsServerCodec(conn))
-		}
+		go s.serveConn(conn, s.jsonServerCodec(conn))
 	}
 }
 
 func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverPolicy int,
-	serverName string, jsonRPCURL string, wsRPCURL string,
+	serverName string, jsonRPCURL string, wsRPCURL string, restURL string,
 	useBasicAuth bool, userList map[string]string, exitChan chan bool) {
 	s.RLock()
 	enabled := s.rpcEnabled
@@ -521,9 +681,9 @@ func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverP
 		s.Unlock()
 		Logger.Info("<HTTPS> enabling handler for JSON-RPC")
 		if useBasicAuth {
-			s.httpsMux.HandleFunc(jsonRPCURL, use(handleRequest, basicAuth(userList)))
+			s.httpsMux.HandleFunc(jsonRPCURL, use(s.handleRequest(true), basicAuth(userList)))
 		} else {
-			s.httpsMux.HandleFunc(jsonRPCURL, handleRequest)
+			s.httpsMux.HandleFunc(jsonRPCURL, s.handleRequest(false))
 		}
 	}
 	if enabled && wsRPCURL != "" {
@@ -531,13 +691,7 @@ func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverP
 		s.httpEnabled = true
 		s.Unlock()
 		Logger.Info("<HTTPS> enabling handler for WebSocket connections")
-		wsHandler := websocket.Handler(func(ws *websocket.Conn) {
-			if s.isDispatched {
-				rpc.ServeCodec(NewCustomJSONServerCodec(ws))
-			} else {
-				rpc.ServeCodec(NewConcReqsServerCodec(ws))
-			}
-		})
+		wsHandler := s.wsHandler(useBasicAuth)
 		if useBasicAuth {
 			s.httpsMux.HandleFunc(wsRPCURL, use(func(w http.ResponseWriter, r *http.Request) {
 				wsHandler.ServeHTTP(w, r)
@@ -546,6 +700,13 @@ func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverP
 			s.httpsMux.Handle(wsRPCURL, wsHandler)
 		}
 	}
+	if enabled && restURL != "" {
+		s.Lock()
+		s.httpEnabled = true
+		s.Unlock()
+		Logger.Info("<HTTPS> enabling handler for REST API")
+		s.registerRESTHandler(s.httpsMux, restURL, useBasicAuth, userList)
+	}
 	if !s.httpEnabled {
 		return
 	}
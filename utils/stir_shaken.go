@@ -25,9 +25,15 @@ import (
 // NewPASSporTHeader returns a new PASSporT headder with:
 // extension shaken, ES256 algorithm and the given x5u
 func NewPASSporTHeader(x5uVal string) *PASSporTHeader {
+	return NewPASSporTExtensionHeader(x5uVal, STIRPpt)
+}
+
+// NewPASSporTExtensionHeader returns a new PASSporT headder with:
+// the given extension, ES256 algorithm and the given x5u
+func NewPASSporTExtensionHeader(x5uVal, ppt string) *PASSporTHeader {
 	return &PASSporTHeader{
 		Alg: STIRAlg,
-		Ppt: STIRPpt,
+		Ppt: ppt,
 		Typ: STIRTyp,
 		X5u: x5uVal,
 	}
@@ -69,6 +75,35 @@ type PASSporTOriginsIdentity struct {
 	URI string `json:"uri,omitempty"` // the identity in URI form
 }
 
+// NewPASSporTDiversionIdentity returns a new PASSporTDiversionIdentity with the given id
+func NewPASSporTDiversionIdentity(tn, uri string) *PASSporTDiversionIdentity {
+	return &PASSporTDiversionIdentity{
+		Tn:  tn,
+		URI: uri,
+	}
+}
+
+// PASSporTDiversionIdentity is the identity the call was diverted from
+type PASSporTDiversionIdentity struct {
+	Tn  string `json:"tn,omitempty"`  // the telephone number
+	URI string `json:"uri,omitempty"` // the identity in URI form
+}
+
+// PASSporTRcd is the rich call data of the caller
+type PASSporTRcd struct {
+	Nam string `json:"nam"`           // the display name of the caller
+	Icn string `json:"icn,omitempty"` // the URI of the caller logo
+}
+
+// Resources returns the URIs referred by the rich call data indexed by their JSON pointer
+func (rcd *PASSporTRcd) Resources() (res map[string]string) {
+	res = make(map[string]string)
+	if rcd.Icn != EmptyString {
+		res["/icn"] = rcd.Icn
+	}
+	return
+}
+
 // NewPASSporTPayload returns an new PASSporTPayload with the given origin and destination
 func NewPASSporTPayload(attest, originID string, dest PASSporTDestinationsIdentity, orig PASSporTOriginsIdentity) *PASSporTPayload {
 	return &PASSporTPayload{
@@ -87,4 +122,9 @@ type PASSporTPayload struct {
 	IAT    int64                        `json:"iat"`    // is the date and time of issuance of the JWT
 	Orig   PASSporTOriginsIdentity      `json:"orig"`   // the originator identity
 	OrigID string                       `json:"origid"` // is an opaque unique identifier representing an element on the path of a given SIP request. Not used for verification
+
+	Div  *PASSporTDiversionIdentity `json:"div,omitempty"`  // the identity the call was diverted from, mandatory for the div extension
+	Rcd  *PASSporTRcd               `json:"rcd,omitempty"`  // the rich call data, mandatory for the rcd extension
+	Rcdi map[string]string          `json:"rcdi,omitempty"` // the integrity digests of the rich call data resources indexed by their JSON pointer
+	Crn  string                     `json:"crn,omitempty"`  // the call reason
 }
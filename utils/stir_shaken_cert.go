@@ -0,0 +1,216 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"io"
+	"io/ioutil"
+	"strconv"
+	"time"
+)
+
+// OIDTNAuthList is the object identifier of the TNAuthList certificate extension(RFC 8226)
+var OIDTNAuthList = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 26}
+
+// NewX509CertificatesFromReader returns the certificates from io.Reader
+// the certificates are expected PEM encoded, the first one being the leaf of the chain
+func NewX509CertificatesFromReader(reader io.Reader) (certs []*x509.Certificate, err error) {
+	var certBuf []byte
+	if certBuf, err = ioutil.ReadAll(reader); err != nil {
+		return
+	}
+	for {
+		var block *pem.Block
+		if block, certBuf = pem.Decode(certBuf); block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			continue
+		}
+		var cert *x509.Certificate
+		if cert, err = x509.ParseCertificate(block.Bytes); err != nil {
+			return
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		err = errors.New("no certificate found")
+	}
+	return
+}
+
+// NewX509Certificates returns the certificates from the path
+func NewX509Certificates(certPath string, timeout time.Duration) (certs []*x509.Certificate, err error) {
+	var certBuf io.ReadCloser
+	if certBuf, err = GetReaderFromPath(certPath, timeout); err != nil {
+		return
+	}
+	certs, err = NewX509CertificatesFromReader(certBuf)
+	certBuf.Close()
+	return
+}
+
+// NewX509CRL returns the certificate revocation list from the path
+// the list can be either PEM or DER encoded
+func NewX509CRL(crlPath string, timeout time.Duration) (crl *pkix.CertificateList, err error) {
+	var crlBuf io.ReadCloser
+	if crlBuf, err = GetReaderFromPath(crlPath, timeout); err != nil {
+		return
+	}
+	defer crlBuf.Close()
+	var b []byte
+	if b, err = ioutil.ReadAll(crlBuf); err != nil {
+		return
+	}
+	return x509.ParseCRL(b)
+}
+
+// X509CertificateRevoked returns true if the certificate is revoked by one of the lists signed by its issuer
+func X509CertificateRevoked(cert, issuer *x509.Certificate, crls []*pkix.CertificateList, now time.Time) (revoked bool, err error) {
+	for _, crl := range crls {
+		if issuer.CheckCRLSignature(crl) != nil { // not issued by this CA
+			continue
+		}
+		if crl.HasExpired(now) {
+			return false, errors.New("expired CRL")
+		}
+		for _, rc := range crl.TBSCertList.RevokedCertificates {
+			if rc.SerialNumber.Cmp(cert.SerialNumber) == 0 {
+				return true, nil
+			}
+		}
+	}
+	return
+}
+
+// TNRange is a range of telephone numbers from TNAuthList
+type TNRange struct {
+	Start string
+	Count int
+}
+
+// TNAuthList is the list of the service provider codes and
+// telephone numbers the certificate holder is authorized for
+type TNAuthList struct {
+	SPCs     []string
+	TNs      []string
+	TNRanges []*TNRange
+}
+
+// HasTn returns true if the telephone number is covered by the list
+// lists containing only service provider codes do not restrict the number
+func (tnl *TNAuthList) HasTn(tn string) bool {
+	if len(tnl.TNs) == 0 && len(tnl.TNRanges) == 0 {
+		return true
+	}
+	if SliceHasMember(tnl.TNs, tn) {
+		return true
+	}
+	tnNr, err := strconv.ParseUint(tn, 10, 64)
+	if err != nil {
+		return false
+	}
+	for _, rng := range tnl.TNRanges {
+		if len(rng.Start) != len(tn) {
+			continue
+		}
+		startNr, err := strconv.ParseUint(rng.Start, 10, 64)
+		if err != nil {
+			continue
+		}
+		if tnNr >= startNr && tnNr < startNr+uint64(rng.Count) {
+			return true
+		}
+	}
+	return false
+}
+
+// NewTNAuthList decodes the TNAuthList extension of the certificate
+// returns ErrNotFound if the certificate has no such extension
+func NewTNAuthList(cert *x509.Certificate) (tnl *TNAuthList, err error) {
+	for _, ext := range cert.Extensions {
+		if ext.Id.Equal(OIDTNAuthList) {
+			return DecodeTNAuthList(ext.Value)
+		}
+	}
+	return nil, ErrNotFound
+}
+
+// DecodeTNAuthList decodes the DER encoded TNAuthorizationList
+// each TNEntry is an explicitly tagged choice of:
+// spc [0] ServiceProviderCode, range [1] TelephoneNumberRange or one [2] TelephoneNumber
+func DecodeTNAuthList(der []byte) (tnl *TNAuthList, err error) {
+	var entries []asn1.RawValue
+	var rest []byte
+	if rest, err = asn1.Unmarshal(der, &entries); err != nil {
+		return
+	}
+	if len(rest) != 0 {
+		return nil, errors.New("trailing data after TNAuthList")
+	}
+	tnl = new(TNAuthList)
+	for _, entry := range entries {
+		if entry.Class != asn1.ClassContextSpecific {
+			return nil, errors.New("invalid TNAuthList entry")
+		}
+		switch entry.Tag {
+		case 0:
+			var spc string
+			if err = unmarshalTNEntry(entry.Bytes, &spc, "ia5"); err != nil {
+				return nil, err
+			}
+			tnl.SPCs = append(tnl.SPCs, spc)
+		case 1:
+			var rng struct {
+				Start string `asn1:"ia5"`
+				Count int
+			}
+			if err = unmarshalTNEntry(entry.Bytes, &rng, EmptyString); err != nil {
+				return nil, err
+			}
+			tnl.TNRanges = append(tnl.TNRanges, &TNRange{Start: rng.Start, Count: rng.Count})
+		case 2:
+			var tn string
+			if err = unmarshalTNEntry(entry.Bytes, &tn, "ia5"); err != nil {
+				return nil, err
+			}
+			tnl.TNs = append(tnl.TNs, tn)
+		default:
+			return nil, errors.New("invalid TNAuthList entry")
+		}
+	}
+	return
+}
+
+// unmarshalTNEntry decodes the explicitly tagged value of a TNEntry
+func unmarshalTNEntry(b []byte, val interface{}, params string) (err error) {
+	var rest []byte
+	if rest, err = asn1.UnmarshalWithParams(b, val, params); err != nil {
+		return
+	}
+	if len(rest) != 0 {
+		return errors.New("trailing data in TNAuthList entry")
+	}
+	return
+}
@@ -0,0 +1,184 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package utils
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func newTestTNAuthList(t *testing.T) []byte {
+	spc, err := asn1.MarshalWithParams("1234", "ia5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rng, err := asn1.Marshal(struct {
+		Start string `asn1:"ia5"`
+		Count int
+	}{"4915550000", 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tn, err := asn1.MarshalWithParams("4915551000", "ia5")
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := asn1.Marshal([]asn1.RawValue{
+		{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: spc},
+		{Class: asn1.ClassContextSpecific, Tag: 1, IsCompound: true, Bytes: rng},
+		{Class: asn1.ClassContextSpecific, Tag: 2, IsCompound: true, Bytes: tn},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestDecodeTNAuthList(t *testing.T) {
+	exp := &TNAuthList{
+		SPCs:     []string{"1234"},
+		TNs:      []string{"4915551000"},
+		TNRanges: []*TNRange{{Start: "4915550000", Count: 100}},
+	}
+	if rcv, err := DecodeTNAuthList(newTestTNAuthList(t)); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s, received: %s", ToJSON(exp), ToJSON(rcv))
+	}
+	if _, err := DecodeTNAuthList([]byte("notASN1")); err == nil {
+		t.Error("Expected error")
+	}
+}
+
+func TestTNAuthListHasTn(t *testing.T) {
+	tnl := &TNAuthList{
+		TNs:      []string{"4915551000"},
+		TNRanges: []*TNRange{{Start: "4915550000", Count: 100}},
+	}
+	for tn, exp := range map[string]bool{
+		"4915551000":  true,
+		"4915550000":  true,
+		"4915550099":  true,
+		"4915550100":  false,
+		"49155500001": false,
+		"1001":        false,
+	} {
+		if rcv := tnl.HasTn(tn); rcv != exp {
+			t.Errorf("Expected %v for %q, received: %v", exp, tn, rcv)
+		}
+	}
+	tnl = &TNAuthList{SPCs: []string{"1234"}}
+	if !tnl.HasTn("1001") {
+		t.Error("Expected the service provider code to authorize any number")
+	}
+}
+
+func TestX509CertificatesAndCRL(t *testing.T) {
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	caTmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "STI-CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTmpl, caTmpl, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTmpl := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		Subject:         pkix.Name{CommonName: "SHAKEN 1234"},
+		NotBefore:       now.Add(-time.Hour),
+		NotAfter:        now.Add(time.Hour),
+		ExtraExtensions: []pkix.Extension{{Id: OIDTNAuthList, Value: newTestTNAuthList(t)}},
+	}
+	caCert, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafDER, err := x509.CreateCertificate(rand.Reader, leafTmpl, caCert, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var pemBuf bytes.Buffer
+	pem.Encode(&pemBuf, &pem.Block{Type: "CERTIFICATE", Bytes: leafDER})
+	pem.Encode(&pemBuf, &pem.Block{Type: "PUBLIC KEY", Bytes: []byte("ignored")})
+	pem.Encode(&pemBuf, &pem.Block{Type: "CERTIFICATE", Bytes: caDER})
+	certs, err := NewX509CertificatesFromReader(&pemBuf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(certs) != 2 ||
+		certs[0].SerialNumber.Int64() != 2 ||
+		certs[1].SerialNumber.Int64() != 1 {
+		t.Fatalf("Unexpected certificates: %+v", certs)
+	}
+	if _, err := NewX509CertificatesFromReader(bytes.NewBufferString("no certificate")); err == nil {
+		t.Error("Expected error")
+	}
+	if tnl, err := NewTNAuthList(certs[0]); err != nil {
+		t.Error(err)
+	} else if !tnl.HasTn("4915550001") {
+		t.Errorf("Expected number to be authorized by: %s", ToJSON(tnl))
+	}
+	if _, err := NewTNAuthList(certs[1]); err != ErrNotFound {
+		t.Errorf("Expected %v, received: %v", ErrNotFound, err)
+	}
+
+	crlDER, err := caCert.CreateCRL(rand.Reader, caKey, []pkix.RevokedCertificate{
+		{SerialNumber: big.NewInt(2), RevocationTime: now}}, now, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	crl, err := x509.ParseCRL(crlDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revoked, err := X509CertificateRevoked(certs[0], certs[1], []*pkix.CertificateList{crl}, now); err != nil {
+		t.Error(err)
+	} else if !revoked {
+		t.Error("Expected revoked certificate")
+	}
+	if revoked, err := X509CertificateRevoked(certs[1], certs[0], []*pkix.CertificateList{crl}, now); err != nil {
+		t.Error(err)
+	} else if revoked {
+		t.Error("Expected the CRL to be ignored for other issuers")
+	}
+	if _, err := X509CertificateRevoked(certs[0], certs[1], []*pkix.CertificateList{crl},
+		now.Add(2*time.Hour)); err == nil || err.Error() != "expired CRL" {
+		t.Errorf("Expected expired CRL, received: %v", err)
+	}
+}
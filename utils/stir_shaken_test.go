@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package utils
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -35,6 +36,30 @@ func TestNewPASSporTHeader(t *testing.T) {
 	}
 }
 
+func TestNewPASSporTExtensionHeader(t *testing.T) {
+	expected := &PASSporTHeader{
+		Alg: STIRAlg,
+		Ppt: STIRPptDiv,
+		Typ: STIRTyp,
+		X5u: "path/to/certificate",
+	}
+	if rply := NewPASSporTExtensionHeader("path/to/certificate", STIRPptDiv); !reflect.DeepEqual(expected, rply) {
+		t.Errorf("Expected: %s,received: %s", ToJSON(expected), ToJSON(rply))
+	}
+}
+
+func TestPASSporTRcdResources(t *testing.T) {
+	rcd := &PASSporTRcd{Nam: "ITsysCOM"}
+	if rply := rcd.Resources(); len(rply) != 0 {
+		t.Errorf("Expected no resources, received: %s", ToJSON(rply))
+	}
+	rcd.Icn = "https://www.example.org/logo.png"
+	expected := map[string]string{"/icn": "https://www.example.org/logo.png"}
+	if rply := rcd.Resources(); !reflect.DeepEqual(expected, rply) {
+		t.Errorf("Expected: %s,received: %s", ToJSON(expected), ToJSON(rply))
+	}
+}
+
 func TestNewPASSporTDestinationsIdentity(t *testing.T) {
 	expected := &PASSporTDestinationsIdentity{
 		Tn:  []string{"1001"},
@@ -70,3 +95,15 @@ func TestNewPASSporTPayload(t *testing.T) {
 		t.Errorf("Expected: %s,received: %s", ToJSON(expected), ToJSON(rply))
 	}
 }
+
+func TestPASSporTPayloadShakenClaims(t *testing.T) {
+	payload := NewPASSporTPayload(EmptyString, EmptyString,
+		*NewPASSporTDestinationsIdentity([]string{"1002"}, nil), *NewPASSporTOriginsIdentity("1001", EmptyString))
+	payload.IAT = 1587019822
+	exp := `{"attest":"","dest":{"tn":["1002"]},"iat":1587019822,"orig":{"tn":"1001"},"origid":""}`
+	if rcv, err := json.Marshal(payload); err != nil {
+		t.Fatal(err)
+	} else if string(rcv) != exp {
+		t.Errorf("Expected: %s, received: %s", exp, rcv)
+	}
+}
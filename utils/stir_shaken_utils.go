@@ -20,6 +20,8 @@ package utils
 
 import (
 	"crypto/ecdsa"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -72,6 +74,20 @@ func NewECDSAPubKey(pubKeyPath string, timeout time.Duration) (pubKey *ecdsa.Pub
 	return
 }
 
+// NewRcdIntegrity returns the integrity digest(sha256-<base64>) of the resource at the path
+func NewRcdIntegrity(path string, timeout time.Duration) (digest string, err error) {
+	var rsrBuf io.ReadCloser
+	if rsrBuf, err = GetReaderFromPath(path, timeout); err != nil {
+		return
+	}
+	defer rsrBuf.Close()
+	hash := sha256.New()
+	if _, err = io.Copy(hash, rsrBuf); err != nil {
+		return
+	}
+	return STIRRcdiAlg + "-" + base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
+}
+
 // GetReaderFromPath returns the reader at the given path
 func GetReaderFromPath(path string, timeout time.Duration) (r io.ReadCloser, err error) {
 	if !IsURL(path) {